@@ -10,9 +10,11 @@ import (
 	"time"
 
 	"github.com/vsssp/birthday-app/backend/internal/adapter/handler"
+	"github.com/vsssp/birthday-app/backend/internal/adapter/notify"
 	"github.com/vsssp/birthday-app/backend/internal/adapter/repository/postgres"
 	"github.com/vsssp/birthday-app/backend/internal/adapter/social"
 	"github.com/vsssp/birthday-app/backend/internal/config"
+	"github.com/vsssp/birthday-app/backend/internal/job"
 	jwtpkg "github.com/vsssp/birthday-app/backend/internal/pkg/jwt"
 	"github.com/vsssp/birthday-app/backend/internal/usecase"
 )
@@ -35,6 +37,23 @@ func main() {
 	providerRepo := postgres.NewAuthProviderRepository(pool)
 	tokenRepo := postgres.NewRefreshTokenRepository(pool)
 	recipientRepo := postgres.NewRecipientRepository(pool)
+	prefsRepo := postgres.NewPreferencesRepository(pool)
+	historyRepo := postgres.NewRecipientHistoryRepository(pool)
+	keywordRepo := postgres.NewKeywordRepository(pool)
+	groupRepo := postgres.NewGroupRepository(pool)
+	occasionRepo := postgres.NewOccasionRepository(pool)
+	holidayRepo := postgres.NewHolidaySubscriptionRepository(pool)
+	giftRepo := postgres.NewGiftRepository(pool)
+	ideaRepo := postgres.NewIdeaRepository(pool)
+	groupGiftRepo := postgres.NewGroupGiftRepository(pool)
+	exchangeRepo := postgres.NewExchangeRepository(pool)
+	wishlistRepo := postgres.NewWishlistRepository(pool)
+	householdRepo := postgres.NewHouseholdRepository(pool)
+	budgetRepo := postgres.NewBudgetRepository(pool)
+	rateRepo := postgres.NewExchangeRateRepository(pool)
+	calendarRepo := postgres.NewCalendarFeedRepository(pool)
+	importRepo := postgres.NewImportRepository(pool)
+	txManager := postgres.NewTxManager(pool)
 
 	// Services
 	jwtService := jwtpkg.NewService(
@@ -47,14 +66,54 @@ func main() {
 	googleVerifier := social.NewGoogleVerifier(cfg.Google.ClientID)
 	appleVerifier := social.NewAppleVerifier(cfg.Apple.ClientID)
 	socialVerifier := social.NewCompositeVerifier(googleVerifier, appleVerifier)
+	notifier := notify.NewLogNotifier()
 
 	// Use cases
 	authUseCase := usecase.NewAuthUseCase(userRepo, providerRepo, tokenRepo, jwtService, socialVerifier)
 	userUseCase := usecase.NewUserUseCase(userRepo)
-	recipientUseCase := usecase.NewRecipientUseCase(recipientRepo)
+	prefsUseCase := usecase.NewPreferencesUseCase(prefsRepo)
+	keywordUseCase := usecase.NewKeywordUseCase(keywordRepo, prefsUseCase)
+	permissionUseCase := usecase.NewPermissionUseCase(recipientRepo, householdRepo)
+	householdUseCase := usecase.NewHouseholdUseCase(householdRepo, userUseCase, notifier, txManager)
+	rateUseCase := usecase.NewExchangeRateUseCase(rateRepo, txManager)
+	recipientUseCase := usecase.NewRecipientUseCase(recipientRepo, historyRepo, txManager, prefsUseCase, keywordUseCase, permissionUseCase, rateUseCase)
+	duplicateUseCase := usecase.NewDuplicateUseCase(recipientRepo, recipientUseCase, occasionRepo, holidayRepo, groupRepo, giftRepo, ideaRepo, groupGiftRepo, wishlistRepo, txManager, permissionUseCase)
+	groupUseCase := usecase.NewGroupUseCase(groupRepo, permissionUseCase)
+	occasionUseCase := usecase.NewOccasionUseCase(occasionRepo, permissionUseCase)
+	giftUseCase := usecase.NewGiftUseCase(giftRepo, permissionUseCase, occasionRepo, keywordUseCase, prefsUseCase)
+	ideaUseCase := usecase.NewIdeaUseCase(ideaRepo, permissionUseCase, giftUseCase, keywordUseCase, prefsUseCase, txManager)
+	groupGiftUseCase := usecase.NewGroupGiftUseCase(groupGiftRepo, permissionUseCase, occasionRepo, userUseCase, notifier)
+	exchangeUseCase := usecase.NewExchangeUseCase(exchangeRepo, userUseCase, prefsUseCase, notifier, txManager)
+	wishlistUseCase := usecase.NewWishlistUseCase(wishlistRepo, recipientRepo, permissionUseCase, ideaRepo, txManager)
+	suggestionUseCase := usecase.NewSuggestionUseCase(recipientUseCase, giftRepo, prefsUseCase, rateUseCase)
+	budgetUseCase := usecase.NewBudgetUseCase(budgetRepo, prefsUseCase)
+	reportUseCase := usecase.NewReportUseCase(giftRepo, recipientRepo, occasionRepo, groupRepo, budgetRepo, prefsUseCase, rateUseCase, permissionUseCase)
+	holidayUseCase := usecase.NewHolidayUseCase(holidayRepo, permissionUseCase, prefsUseCase)
+	upcomingUseCase := usecase.NewUpcomingUseCase(recipientRepo, occasionRepo, holidayRepo, prefsUseCase, permissionUseCase)
+	calendarUseCase := usecase.NewCalendarUseCase(calendarRepo, recipientRepo, occasionRepo, holidayRepo, prefsUseCase, permissionUseCase)
+	importUseCase := usecase.NewImportUseCase(importRepo, recipientRepo, recipientUseCase, occasionUseCase, keywordUseCase, txManager, prefsUseCase, permissionUseCase)
+	exportUseCase := usecase.NewExportUseCase(recipientRepo, occasionRepo, permissionUseCase)
+
+	// Background jobs
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	trashRetention := time.Duration(cfg.Jobs.TrashRetentionDays) * 24 * time.Hour
+	go job.Every(jobCtx, "purge-recipient-trash", cfg.Jobs.TrashPurgeInterval, func(ctx context.Context) error {
+		purged, err := recipientUseCase.PurgeTrash(ctx, trashRetention)
+		if purged > 0 {
+			log.Printf("purged %d recipients from trash", purged)
+		}
+		return err
+	})
+	go job.Every(jobCtx, "purge-import-sessions", cfg.Jobs.ImportPurgeInterval, func(ctx context.Context) error {
+		_, err := importUseCase.PurgeExpired(ctx)
+		return err
+	})
 
 	// Router
-	router := handler.NewRouter(authUseCase, userUseCase, recipientUseCase, jwtService)
+	publicLimiter := handler.NewRateLimiter(cfg.Server.PublicRateLimit, time.Minute)
+	router := handler.NewRouter(authUseCase, userUseCase, recipientUseCase, duplicateUseCase, prefsUseCase, keywordUseCase, groupUseCase, occasionUseCase, giftUseCase, ideaUseCase, groupGiftUseCase, exchangeUseCase, wishlistUseCase, householdUseCase, suggestionUseCase, budgetUseCase, reportUseCase, holidayUseCase, calendarUseCase, importUseCase, exportUseCase, upcomingUseCase, jwtService, publicLimiter, cfg.Server.TrustedProxies)
 
 	// Server
 	srv := &http.Server{
@@ -78,6 +137,7 @@ func main() {
 	<-quit
 
 	log.Println("shutting down server...")
+	stopJobs()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
@@ -0,0 +1,47 @@
+// Command rates replaces the exchange-rate table with the rates in a CSV
+// file of base,quote,rate rows:
+//
+//	go run ./cmd/rates rates.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/vsssp/birthday-app/backend/internal/adapter/repository/postgres"
+	"github.com/vsssp/birthday-app/backend/internal/usecase"
+)
+
+func main() {
+	flag.Parse()
+	path := flag.Arg(0)
+	if path == "" {
+		log.Fatal("usage: rates <file.csv>")
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL environment variable is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read rates: %v", err)
+	}
+
+	ctx := context.Background()
+	pool, err := postgres.NewPool(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	rates := usecase.NewExchangeRateUseCase(postgres.NewExchangeRateRepository(pool), postgres.NewTxManager(pool))
+	n, err := rates.Load(ctx, data)
+	if err != nil {
+		log.Fatalf("failed to load rates: %v", err)
+	}
+	log.Printf("loaded %d exchange rates from %s", n, path)
+}
@@ -67,8 +67,9 @@ func main() {
 			Name:      "Maria Silva",
 			Age:       65,
 			Gender:    "female",
-			MinBudget: 50,
-			MaxBudget: 200,
+			MinBudget: domain.MoneyFromUnits(50),
+			MaxBudget: domain.MoneyFromUnits(200),
+			Currency:  domain.DefaultCurrency,
 			Keywords:  []string{"cooking", "reading", "gardening"},
 			CreatedAt: now,
 			UpdatedAt: now,
@@ -79,8 +80,9 @@ func main() {
 			Name:      "Pedro Santos",
 			Age:       12,
 			Gender:    "male",
-			MinBudget: 30,
-			MaxBudget: 100,
+			MinBudget: domain.MoneyFromUnits(30),
+			MaxBudget: domain.MoneyFromUnits(100),
+			Currency:  domain.DefaultCurrency,
 			Keywords:  []string{"gaming", "nerd", "tech"},
 			CreatedAt: now,
 			UpdatedAt: now,
@@ -91,8 +93,9 @@ func main() {
 			Name:      "Ana Costa",
 			Age:       30,
 			Gender:    "female",
-			MinBudget: 100,
-			MaxBudget: 500,
+			MinBudget: domain.MoneyFromUnits(100),
+			MaxBudget: domain.MoneyFromUnits(500),
+			Currency:  domain.DefaultCurrency,
 			Keywords:  []string{"fashion", "travel", "fitness"},
 			CreatedAt: now,
 			UpdatedAt: now,
@@ -2,13 +2,11 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/vsssp/birthday-app/backend/internal/domain"
 	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
 	"github.com/vsssp/birthday-app/backend/internal/port"
-	"github.com/vsssp/birthday-app/backend/internal/usecase"
 )
 
 // AuthHandler handles authentication HTTP requests.
@@ -25,22 +23,22 @@ func NewAuthHandler(authService port.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req domain.RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	if req.Email == "" || req.Password == "" {
-		response.Error(w, http.StatusBadRequest, "email and password are required")
+		badRequest(w, r, "email and password are required")
 		return
 	}
 	if len(req.Password) < 8 {
-		response.Error(w, http.StatusBadRequest, "password must be at least 8 characters")
+		badRequest(w, r, "password must be at least 8 characters")
 		return
 	}
 
 	tokens, err := h.authService.Register(r.Context(), req)
 	if err != nil {
-		handleAuthError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusCreated, tokens)
@@ -50,18 +48,18 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req domain.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	if req.Email == "" || req.Password == "" {
-		response.Error(w, http.StatusBadRequest, "email and password are required")
+		badRequest(w, r, "email and password are required")
 		return
 	}
 
 	tokens, err := h.authService.Login(r.Context(), req)
 	if err != nil {
-		handleAuthError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, tokens)
@@ -71,18 +69,18 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
 	var req domain.SocialLoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	if req.IDToken == "" {
-		response.Error(w, http.StatusBadRequest, "id_token is required")
+		badRequest(w, r, "id_token is required")
 		return
 	}
 
 	tokens, err := h.authService.GoogleLogin(r.Context(), req.IDToken)
 	if err != nil {
-		handleAuthError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, tokens)
@@ -92,18 +90,18 @@ func (h *AuthHandler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) AppleLogin(w http.ResponseWriter, r *http.Request) {
 	var req domain.SocialLoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	if req.IDToken == "" {
-		response.Error(w, http.StatusBadRequest, "id_token is required")
+		badRequest(w, r, "id_token is required")
 		return
 	}
 
 	tokens, err := h.authService.AppleLogin(r.Context(), req.IDToken)
 	if err != nil {
-		handleAuthError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, tokens)
@@ -113,32 +111,19 @@ func (h *AuthHandler) AppleLogin(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req domain.RefreshRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	if req.RefreshToken == "" {
-		response.Error(w, http.StatusBadRequest, "refresh_token is required")
+		badRequest(w, r, "refresh_token is required")
 		return
 	}
 
 	tokens, err := h.authService.RefreshToken(r.Context(), req.RefreshToken)
 	if err != nil {
-		handleAuthError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, tokens)
 }
-
-func handleAuthError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, usecase.ErrEmailAlreadyExists):
-		response.Error(w, http.StatusConflict, err.Error())
-	case errors.Is(err, usecase.ErrInvalidCredentials):
-		response.Error(w, http.StatusUnauthorized, err.Error())
-	case errors.Is(err, usecase.ErrInvalidToken):
-		response.Error(w, http.StatusUnauthorized, err.Error())
-	default:
-		response.Error(w, http.StatusInternalServerError, "internal server error")
-	}
-}
@@ -2,9 +2,11 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"testing"
 	"time"
 
@@ -15,13 +17,47 @@ import (
 	"github.com/vsssp/birthday-app/backend/internal/usecase"
 )
 
+// testExchangeRates is the rate table loaded for every test router.
+const testExchangeRates = "base,quote,rate\nUSD,BRL,5\n"
+
+// testPublicRateLimit is the per-minute limit on public routes in every test router.
+const testPublicRateLimit = 20
+
+// testTrustedProxy is the network whose forwarding headers every test router believes.
+var testTrustedProxy = netip.MustParsePrefix("10.0.0.0/8")
+
 func setupRouter(t *testing.T) (*http.ServeMux, *mockUserRepo, *mockAuthProviderRepo, *mockRefreshTokenRepo, *mockRecipientRepo, *jwtpkg.Service) {
 	t.Helper()
+	return setupRouterWithNotifier(t, newMockNotifier())
+}
+
+// setupRouterWithNotifier is setupRouter with a notifier the test can inspect.
+func setupRouterWithNotifier(t *testing.T, notifier *mockNotifier) (*http.ServeMux, *mockUserRepo, *mockAuthProviderRepo, *mockRefreshTokenRepo, *mockRecipientRepo, *jwtpkg.Service) {
+	t.Helper()
 
 	userRepo := newMockUserRepo()
 	providerRepo := newMockAuthProviderRepo()
 	tokenRepo := newMockRefreshTokenRepo()
 	recipientRepo := newMockRecipientRepo()
+	prefsRepo := newMockPreferencesRepo()
+	historyRepo := newMockRecipientHistoryRepo()
+	keywordRepo := newMockKeywordRepo()
+	groupRepo := newMockGroupRepo()
+	recipientRepo.groups = groupRepo
+	groupRepo.recipients = recipientRepo
+	occasionRepo := newMockOccasionRepo(recipientRepo)
+	holidayRepo := newMockHolidaySubscriptionRepo(recipientRepo)
+	giftRepo := newMockGiftRepo(recipientRepo)
+	ideaRepo := newMockIdeaRepo()
+	groupGiftRepo := newMockGroupGiftRepo()
+	exchangeRepo := newMockExchangeRepo()
+	wishlistRepo := newMockWishlistRepo()
+	householdRepo := newMockHouseholdRepo(userRepo)
+	budgetRepo := newMockBudgetRepo()
+	rateRepo := newMockExchangeRateRepo()
+	calendarRepo := newMockCalendarFeedRepo()
+	importRepo := newMockImportRepo()
+	tx := mockTransactor{}
 
 	jwtService := jwtpkg.NewService(
 		"test-access-secret-32-chars-long!",
@@ -33,9 +69,34 @@ func setupRouter(t *testing.T) (*http.ServeMux, *mockUserRepo, *mockAuthProvider
 	socialVerifier := &mockSocialVerifier{}
 	authUseCase := usecase.NewAuthUseCase(userRepo, providerRepo, tokenRepo, jwtService, socialVerifier)
 	userUseCase := usecase.NewUserUseCase(userRepo)
-	recipientUseCase := usecase.NewRecipientUseCase(recipientRepo)
+	prefsUseCase := usecase.NewPreferencesUseCase(prefsRepo)
+	keywordUseCase := usecase.NewKeywordUseCase(keywordRepo, prefsUseCase)
+	permissionUseCase := usecase.NewPermissionUseCase(recipientRepo, householdRepo)
+	householdUseCase := usecase.NewHouseholdUseCase(householdRepo, userUseCase, notifier, tx)
+	rateUseCase := usecase.NewExchangeRateUseCase(rateRepo, tx)
+	recipientUseCase := usecase.NewRecipientUseCase(recipientRepo, historyRepo, tx, prefsUseCase, keywordUseCase, permissionUseCase, rateUseCase)
+	duplicateUseCase := usecase.NewDuplicateUseCase(recipientRepo, recipientUseCase, occasionRepo, holidayRepo, groupRepo, giftRepo, ideaRepo, groupGiftRepo, wishlistRepo, tx, permissionUseCase)
+	groupUseCase := usecase.NewGroupUseCase(groupRepo, permissionUseCase)
+	occasionUseCase := usecase.NewOccasionUseCase(occasionRepo, permissionUseCase)
+	giftUseCase := usecase.NewGiftUseCase(giftRepo, permissionUseCase, occasionRepo, keywordUseCase, prefsUseCase)
+	ideaUseCase := usecase.NewIdeaUseCase(ideaRepo, permissionUseCase, giftUseCase, keywordUseCase, prefsUseCase, tx)
+	groupGiftUseCase := usecase.NewGroupGiftUseCase(groupGiftRepo, permissionUseCase, occasionRepo, userUseCase, notifier)
+	exchangeUseCase := usecase.NewExchangeUseCase(exchangeRepo, userUseCase, prefsUseCase, notifier, tx)
+	wishlistUseCase := usecase.NewWishlistUseCase(wishlistRepo, recipientRepo, permissionUseCase, ideaRepo, tx)
+	suggestionUseCase := usecase.NewSuggestionUseCase(recipientUseCase, giftRepo, prefsUseCase, rateUseCase)
+	budgetUseCase := usecase.NewBudgetUseCase(budgetRepo, prefsUseCase)
+	reportUseCase := usecase.NewReportUseCase(giftRepo, recipientRepo, occasionRepo, groupRepo, budgetRepo, prefsUseCase, rateUseCase, permissionUseCase)
+	holidayUseCase := usecase.NewHolidayUseCase(holidayRepo, permissionUseCase, prefsUseCase)
+	upcomingUseCase := usecase.NewUpcomingUseCase(recipientRepo, occasionRepo, holidayRepo, prefsUseCase, permissionUseCase)
+	calendarUseCase := usecase.NewCalendarUseCase(calendarRepo, recipientRepo, occasionRepo, holidayRepo, prefsUseCase, permissionUseCase)
+	importUseCase := usecase.NewImportUseCase(importRepo, recipientRepo, recipientUseCase, occasionUseCase, keywordUseCase, tx, prefsUseCase, permissionUseCase)
+	exportUseCase := usecase.NewExportUseCase(recipientRepo, occasionRepo, permissionUseCase)
+
+	// EUR is left without a rate so tests can cover missing conversions.
+	_, err := rateUseCase.Load(context.Background(), []byte(testExchangeRates))
+	require.NoError(t, err)
 
-	router := handler.NewRouter(authUseCase, userUseCase, recipientUseCase, jwtService)
+	router := handler.NewRouter(authUseCase, userUseCase, recipientUseCase, duplicateUseCase, prefsUseCase, keywordUseCase, groupUseCase, occasionUseCase, giftUseCase, ideaUseCase, groupGiftUseCase, exchangeUseCase, wishlistUseCase, householdUseCase, suggestionUseCase, budgetUseCase, reportUseCase, holidayUseCase, calendarUseCase, importUseCase, exportUseCase, upcomingUseCase, jwtService, handler.NewRateLimiter(testPublicRateLimit, time.Minute), []netip.Prefix{testTrustedProxy})
 
 	mux := http.NewServeMux()
 	mux.Handle("/", router)
@@ -93,6 +154,11 @@ func TestRegister_DuplicateEmail(t *testing.T) {
 	w = httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusConflict, w.Code)
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "/problems/email_already_exists", problem["type"])
+	assert.Equal(t, "Email already registered", problem["title"])
+	assert.Equal(t, float64(http.StatusConflict), problem["status"])
 }
 
 func TestRegister_MissingFields(t *testing.T) {
@@ -284,6 +350,12 @@ func TestGetMe_NoToken(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "/problems/unauthorized", problem["type"])
+	assert.Equal(t, "missing authorization header", problem["detail"])
+	assert.Equal(t, "/api/auth/me", problem["instance"])
+	assert.NotEmpty(t, problem["request_id"])
 }
 
 func TestGetMe_InvalidToken(t *testing.T) {
@@ -306,3 +378,26 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), `"status":"ok"`)
 }
+
+func TestUnknownRoute_Problem(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/nope", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "/problems/not_found", problem["type"])
+	assert.NotContains(t, problem, "errors")
+}
+
+// decodeProblem asserts an application/problem+json response and decodes it.
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var problem map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	return problem
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// BudgetHandler handles yearly budget HTTP requests.
+type BudgetHandler struct {
+	budgetService port.BudgetService
+}
+
+// NewBudgetHandler creates a new BudgetHandler.
+func NewBudgetHandler(budgetService port.BudgetService) *BudgetHandler {
+	return &BudgetHandler{budgetService: budgetService}
+}
+
+// List handles GET /api/budgets.
+func (h *BudgetHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	budgets, err := h.budgetService.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, budgets)
+}
+
+// Set handles PUT /api/budgets/{year}.
+func (h *BudgetHandler) Set(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		badRequest(w, r, "year must be an integer")
+		return
+	}
+
+	var req domain.YearlyBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	budget, err := h.budgetService.Set(r.Context(), userID, year, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, budget)
+}
+
+// Delete handles DELETE /api/budgets/{year}.
+func (h *BudgetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		badRequest(w, r, "year must be an integer")
+		return
+	}
+
+	if err := h.budgetService.Delete(r.Context(), userID, year); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "budget deleted"})
+}
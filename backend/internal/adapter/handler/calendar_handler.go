@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// CalendarHandler handles iCalendar feed HTTP requests.
+type CalendarHandler struct {
+	calendarService port.CalendarService
+}
+
+// NewCalendarHandler creates a new CalendarHandler.
+func NewCalendarHandler(calendarService port.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// GetFeed handles GET /api/me/calendar.
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	feed, err := h.calendarService.GetFeed(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, feed)
+}
+
+// RotateFeed handles POST /api/me/calendar/rotate.
+func (h *CalendarHandler) RotateFeed(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	feed, err := h.calendarService.RotateFeed(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, feed)
+}
+
+// DisableFeed handles DELETE /api/me/calendar.
+func (h *CalendarHandler) DisableFeed(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	if err := h.calendarService.DisableFeed(r.Context(), userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "calendar feed disabled"})
+}
+
+// Feed handles GET /api/calendar/{token}.ics. The token in the URL is the
+// only credential, so calendar apps can subscribe without signing in.
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	body, err := h.calendarService.Render(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="birthdays.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
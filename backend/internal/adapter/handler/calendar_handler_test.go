@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getCalendarFeed(t *testing.T, router http.Handler, token string) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/me/calendar", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var feed map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&feed))
+	return feed
+}
+
+// fetchICS requests a feed path without credentials, as calendar apps do.
+func fetchICS(router http.Handler, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCalendarFeed_RendersEvents(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "calendar@example.com")
+
+	ana := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana", "birthdate": "1992-02-29", "max_budget": 100,
+	})
+	createOccasion(t, router, token, ana, map[string]interface{}{
+		"kind": "custom", "title": "Recital, finally", "date": "2026-11-03", "recurring": false,
+	})
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+ana+"/holidays", token,
+		map[string]interface{}{"country": "US", "holiday": "mothers-day"})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	feed := getCalendarFeed(t, router, token)
+	path := feed["path"].(string)
+	assert.Equal(t, "/api/calendar/"+feed["token"].(string)+".ics", path)
+
+	w = fetchICS(router, path)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.Equal(t, 3, strings.Count(body, "BEGIN:VEVENT"))
+	assert.Contains(t, body, "SUMMARY:Ana's birthday\r\n")
+	assert.Contains(t, body, "DTSTART;VALUE=DATE:19920229\r\n")
+	assert.Contains(t, body, "RRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1\r\n")
+	assert.Contains(t, body, "DESCRIPTION:Budget: 100.00 USD\r\n")
+	assert.Contains(t, body, `SUMMARY:Ana: Recital\, finally`+"\r\n")
+	assert.Contains(t, body, "RRULE:FREQ=YEARLY;BYMONTH=5;BYDAY=2SU\r\n")
+
+	// Default reminder lead times are 7 and 1 days, for each of the 3 events
+	assert.Equal(t, 3, strings.Count(body, "TRIGGER:-P7D\r\n"))
+	assert.Equal(t, 3, strings.Count(body, "TRIGGER:-P1D\r\n"))
+}
+
+func TestCalendarFeed_RotateAndDisable(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "calendar-rotate@example.com")
+
+	first := getCalendarFeed(t, router, token)
+	assert.Equal(t, first["token"], getCalendarFeed(t, router, token)["token"])
+
+	w := doJSON(t, router, http.MethodPost, "/api/me/calendar/rotate", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var rotated map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rotated))
+	assert.NotEqual(t, first["token"], rotated["token"])
+
+	assert.Equal(t, http.StatusNotFound, fetchICS(router, first["path"].(string)).Code)
+	assert.Equal(t, http.StatusOK, fetchICS(router, rotated["path"].(string)).Code)
+
+	w = doJSON(t, router, http.MethodDelete, "/api/me/calendar", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusNotFound, fetchICS(router, rotated["path"].(string)).Code)
+}
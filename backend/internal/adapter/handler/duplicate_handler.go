@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// DuplicateHandler handles duplicate recipient HTTP requests.
+type DuplicateHandler struct {
+	duplicateService port.DuplicateService
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler.
+func NewDuplicateHandler(duplicateService port.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{duplicateService: duplicateService}
+}
+
+// List handles GET /api/recipients/duplicates.
+func (h *DuplicateHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "limit must be an integer")
+			return
+		}
+		limit = n
+	}
+
+	pairs, err := h.duplicateService.Find(r.Context(), userID, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, pairs)
+}
+
+// Merge handles POST /api/recipients/merge.
+func (h *DuplicateHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.MergeRecipientsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	recipient, err := h.duplicateService.Merge(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, recipient)
+}
@@ -0,0 +1,180 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listDuplicates(t *testing.T, router http.Handler, token string) []map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/duplicates", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var pairs []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&pairs))
+	return pairs
+}
+
+func pairNames(pair map[string]interface{}) []string {
+	var names []string
+	for _, r := range pair["recipients"].([]interface{}) {
+		names = append(names, r.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+func TestFindDuplicates(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "duplicates@example.com")
+
+	createRecipient(t, router, token, map[string]interface{}{
+		"name": "João Silva", "birthdate": "1985-07-02", "keywords": []string{"gaming", "reading"},
+	})
+	createRecipient(t, router, token, map[string]interface{}{
+		"name": "joao silva", "birthdate": "1985-07-02", "keywords": []string{"gaming"},
+	})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Ana Souza"})
+	// Same name, but a different birthday: two people
+	createRecipient(t, router, token, map[string]interface{}{"name": "Maria", "birthdate": "1990-01-01"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Maria", "birthdate": "1972-05-20"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Pedro"})
+
+	pairs := listDuplicates(t, router, token)
+	require.Len(t, pairs, 2)
+
+	assert.Equal(t, []string{"João Silva", "joao silva"}, pairNames(pairs[0]), "older recipient comes first")
+	assert.Equal(t, 0.93, pairs[0]["score"])
+	assert.Equal(t, []interface{}{"same_name", "same_birthdate", "shared_keywords"}, pairs[0]["reasons"])
+
+	assert.Equal(t, []string{"Ana", "Ana Souza"}, pairNames(pairs[1]))
+	assert.Equal(t, []interface{}{"similar_name"}, pairs[1]["reasons"])
+
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/duplicates?limit=1", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var limited []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&limited))
+	assert.Len(t, limited, 1)
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/duplicates?limit=500", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Other users' recipients are never compared
+	other := registerAndGetToken(t, router, "duplicates-other@example.com")
+	createRecipient(t, router, other, map[string]interface{}{"name": "Pedro"})
+	assert.Empty(t, listDuplicates(t, router, other))
+}
+
+func TestMergeRecipients(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "merge@example.com")
+
+	keep := createRecipient(t, router, token, map[string]interface{}{
+		"name": "João Silva", "min_budget": 50, "max_budget": 100, "keywords": []string{"gaming"},
+	})
+	dup := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Joao", "relationship": "father", "birthdate": "1985-07-02",
+		"min_budget": 20, "max_budget": 80, "keywords": []string{"reading", "gaming"},
+	})
+
+	createOccasion(t, router, token, keep, map[string]interface{}{"kind": "anniversary", "date": "2010-09-12"})
+	createOccasion(t, router, token, dup, map[string]interface{}{"kind": "anniversary", "date": "2010-09-12"})
+	createOccasion(t, router, token, dup, map[string]interface{}{"kind": "graduation", "date": "2007-12-15", "recurring": false})
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+dup+"/holidays", token, map[string]interface{}{"holiday": "christmas"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	familyID := createGroup(t, router, token, "Family")
+	w = doJSON(t, router, http.MethodPost, "/api/groups/"+familyID+"/members", token,
+		map[string]interface{}{"recipient_ids": []string{dup}})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	createGift(t, router, token, dup, map[string]interface{}{"item": "Scarf", "given_on": "2023-12-25"})
+	createIdea(t, router, token, dup, map[string]interface{}{"title": "Pottery class"})
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/merge", token, map[string]string{"keep_id": keep, "merge_id": dup})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var merged map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&merged))
+	assert.Equal(t, keep, merged["id"])
+	assert.Equal(t, "João Silva", merged["name"])
+	assert.Equal(t, "father", merged["relationship"])
+	assert.Equal(t, "1985-07-02", merged["birthdate"])
+	assert.Equal(t, float64(20), merged["min_budget"])
+	assert.Equal(t, float64(100), merged["max_budget"])
+	assert.Equal(t, []interface{}{"gaming", "reading"}, merged["keywords"])
+
+	// The merged recipient is in the trash
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+dup, token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/trash", token, nil)
+	var trash []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&trash))
+	require.Len(t, trash, 1)
+	assert.Equal(t, dup, trash[0]["id"])
+
+	// Occasions move over, except ones the kept recipient already has
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+keep+"/occasions", token, nil)
+	var occasions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&occasions))
+	var kinds []string
+	for _, o := range occasions {
+		kinds = append(kinds, o["kind"].(string))
+	}
+	assert.ElementsMatch(t, []string{"anniversary", "graduation"}, kinds)
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+keep+"/holidays", token, nil)
+	var subscriptions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&subscriptions))
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, "christmas", subscriptions[0]["holiday"])
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+keep+"/gifts", token, nil)
+	var gifts []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gifts))
+	require.Len(t, gifts, 1)
+	assert.Equal(t, "Scarf", gifts[0]["item"])
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+keep+"/ideas", token, nil)
+	var ideas []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ideas))
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "Pottery class", ideas[0]["title"])
+
+	page := listRecipients(t, router, token, "?group="+familyID)
+	assert.Equal(t, []string{"João Silva"}, recipientNames(page))
+
+	// The merge is recorded in the kept recipient's history
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+keep+"/history", token, nil)
+	var history []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&history))
+	require.Len(t, history, 2)
+	assert.Equal(t, "update", history[0]["action"])
+}
+
+func TestMergeRecipients_Errors(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "merge-errors@example.com")
+	ana := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/merge", token, map[string]string{"keep_id": ana, "merge_id": ana})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"merge_id": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/merge", token, map[string]string{"keep_id": ana})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"merge_id": "required"}, fieldErrorCodes(t, w))
+
+	other := registerAndGetToken(t, router, "merge-errors-other@example.com")
+	theirs := createRecipient(t, router, other, map[string]interface{}{"name": "Ana"})
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/merge", token, map[string]string{"keep_id": ana, "merge_id": theirs})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Nothing changed on either side
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+theirs, other, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
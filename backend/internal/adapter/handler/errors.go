@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+)
+
+// problemTypeBase prefixes error codes to form the problem type URI.
+const problemTypeBase = "/problems/"
+
+var (
+	errInvalidBody           = domain.ErrBadRequest.WithDetail("invalid request body")
+	errInvalidRecipientID    = domain.ErrBadRequest.WithDetail("invalid recipient id")
+	errInvalidGroupID        = domain.ErrBadRequest.WithDetail("invalid group id")
+	errInvalidOccasionID     = domain.ErrBadRequest.WithDetail("invalid occasion id")
+	errInvalidGiftID         = domain.ErrBadRequest.WithDetail("invalid gift id")
+	errInvalidIdeaID         = domain.ErrBadRequest.WithDetail("invalid idea id")
+	errInvalidGroupGiftID    = domain.ErrBadRequest.WithDetail("invalid group gift id")
+	errInvalidContributorID  = domain.ErrBadRequest.WithDetail("invalid contributor id")
+	errInvalidExchangeID     = domain.ErrBadRequest.WithDetail("invalid exchange id")
+	errInvalidParticipantID  = domain.ErrBadRequest.WithDetail("invalid participant id")
+	errInvalidExclusionID    = domain.ErrBadRequest.WithDetail("invalid exclusion id")
+	errInvalidWishlistID     = domain.ErrBadRequest.WithDetail("invalid wishlist id")
+	errInvalidItemID         = domain.ErrBadRequest.WithDetail("invalid item id")
+	errInvalidInvitationID   = domain.ErrBadRequest.WithDetail("invalid invitation id")
+	errInvalidMemberID       = domain.ErrBadRequest.WithDetail("invalid member id")
+	errInvalidSubscriptionID = domain.ErrBadRequest.WithDetail("invalid subscription id")
+	errInvalidImportID       = domain.ErrBadRequest.WithDetail("invalid import id")
+	errMissingUpload         = domain.ErrBadRequest.WithDetail("a file is required")
+	errUploadTooLarge        = domain.NewError(http.StatusRequestEntityTooLarge, "upload_too_large", "Uploaded file is too large")
+	errRateLimited           = domain.NewError(http.StatusTooManyRequests, "rate_limited", "Too many requests")
+)
+
+// writeError renders err as an application/problem+json response. Typed
+// domain errors carry their own status and code; anything else is logged and
+// reported as a generic 500 so internal details never reach the client.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var de *domain.Error
+	if !errors.As(err, &de) {
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		de = domain.ErrInternal
+	}
+
+	p := response.Problem{
+		Type:      problemTypeBase + de.Code,
+		Title:     de.Title,
+		Status:    de.Status,
+		Detail:    de.Detail,
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+	if len(de.Fields) > 0 {
+		p.Errors = de.Fields
+	}
+	response.WriteProblem(w, p)
+}
+
+// badRequest reports a malformed request with the given detail.
+func badRequest(w http.ResponseWriter, r *http.Request, detail string) {
+	writeError(w, r, domain.ErrBadRequest.WithDetail(detail))
+}
+
+// notFound and methodNotAllowed replace chi's plain-text defaults.
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, domain.ErrNotFound)
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, domain.ErrMethodNotAllowed)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionETag formats a resource version as a strong entity tag.
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseETagVersions extracts the versions listed in an If-Match or
+// If-None-Match header. wildcard is true for "*". Weak tags are accepted since
+// versions identify representations exactly. Unparseable tags are ignored,
+// so a header consisting only of unknown tags matches nothing.
+func parseETagVersions(header string) (versions []int, wildcard bool) {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		tag = strings.TrimPrefix(tag, "W/")
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if v, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions, false
+}
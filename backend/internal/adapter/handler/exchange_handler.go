@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// ExchangeHandler handles gift exchange HTTP requests.
+type ExchangeHandler struct {
+	exchangeService port.ExchangeService
+}
+
+// NewExchangeHandler creates a new ExchangeHandler.
+func NewExchangeHandler(exchangeService port.ExchangeService) *ExchangeHandler {
+	return &ExchangeHandler{exchangeService: exchangeService}
+}
+
+// Create handles POST /api/exchanges.
+func (h *ExchangeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.CreateExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	exchange, err := h.exchangeService.Create(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, exchange)
+}
+
+// List handles GET /api/exchanges.
+func (h *ExchangeHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchanges, err := h.exchangeService.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, exchanges)
+}
+
+// GetByID handles GET /api/exchanges/{id}.
+func (h *ExchangeHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	exchange, err := h.exchangeService.GetByID(r.Context(), userID, exchangeID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, exchange)
+}
+
+// Update handles PUT /api/exchanges/{id}.
+func (h *ExchangeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	var req domain.UpdateExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	exchange, err := h.exchangeService.Update(r.Context(), userID, exchangeID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, exchange)
+}
+
+// Delete handles DELETE /api/exchanges/{id}.
+func (h *ExchangeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	if err := h.exchangeService.Delete(r.Context(), userID, exchangeID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "exchange deleted"})
+}
+
+// AddParticipant handles POST /api/exchanges/{id}/participants.
+func (h *ExchangeHandler) AddParticipant(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	var req domain.AddParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	participant, err := h.exchangeService.AddParticipant(r.Context(), userID, exchangeID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, participant)
+}
+
+// RemoveParticipant handles DELETE /api/exchanges/{id}/participants/{participantID}.
+func (h *ExchangeHandler) RemoveParticipant(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, participantID, ok := exchangeChildIDs(w, r, "participantID", errInvalidParticipantID)
+	if !ok {
+		return
+	}
+
+	if err := h.exchangeService.RemoveParticipant(r.Context(), userID, exchangeID, participantID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "participant removed"})
+}
+
+// AddExclusion handles POST /api/exchanges/{id}/exclusions.
+func (h *ExchangeHandler) AddExclusion(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	var req domain.AddExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	exclusion, err := h.exchangeService.AddExclusion(r.Context(), userID, exchangeID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, exclusion)
+}
+
+// RemoveExclusion handles DELETE /api/exchanges/{id}/exclusions/{exclusionID}.
+func (h *ExchangeHandler) RemoveExclusion(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, exclusionID, ok := exchangeChildIDs(w, r, "exclusionID", errInvalidExclusionID)
+	if !ok {
+		return
+	}
+
+	if err := h.exchangeService.RemoveExclusion(r.Context(), userID, exchangeID, exclusionID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "exclusion removed"})
+}
+
+// Draw handles POST /api/exchanges/{id}/draw.
+func (h *ExchangeHandler) Draw(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	exchange, err := h.exchangeService.Draw(r.Context(), userID, exchangeID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, exchange)
+}
+
+// Verify handles POST /api/exchanges/{id}/verify.
+func (h *ExchangeHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	verification, err := h.exchangeService.Verify(r.Context(), userID, exchangeID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, verification)
+}
+
+// Assignment handles GET /api/exchanges/{id}/assignment.
+func (h *ExchangeHandler) Assignment(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return
+	}
+
+	assignment, err := h.exchangeService.Assignment(r.Context(), userID, exchangeID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, assignment)
+}
+
+// AssignmentByToken handles GET /api/exchange-assignments/{token}. The token
+// is the credential, so the route sits outside the auth middleware.
+func (h *ExchangeHandler) AssignmentByToken(w http.ResponseWriter, r *http.Request) {
+	assignment, err := h.exchangeService.AssignmentByToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	response.JSON(w, http.StatusOK, assignment)
+}
+
+// exchangeChildIDs parses the exchange ID and the child ID named by param
+// from the URL, writing a 400 response and returning false if either is
+// malformed.
+func exchangeChildIDs(w http.ResponseWriter, r *http.Request, param string, errInvalid error) (uuid.UUID, uuid.UUID, bool) {
+	exchangeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidExchangeID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	childID, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		writeError(w, r, errInvalid)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return exchangeID, childID, true
+}
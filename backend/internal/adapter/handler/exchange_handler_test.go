@@ -0,0 +1,225 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+var assignmentPathPattern = regexp.MustCompile(`/api/exchange-assignments/[0-9a-f]+`)
+
+// startExchange creates an exchange with the given participants, keyed by
+// name to email, and returns its id and the participant ids by name.
+func startExchange(t *testing.T, router http.Handler, token string, body map[string]interface{}, people [][2]string) (string, map[string]string) {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/exchanges", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var exchange map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&exchange))
+	exchangeID := exchange["id"].(string)
+
+	ids := make(map[string]string, len(people))
+	for _, person := range people {
+		w := doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/participants", token,
+			map[string]interface{}{"name": person[0], "email": person[1]})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var participant map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&participant))
+		ids[person[0]] = participant["id"].(string)
+	}
+	return exchangeID, ids
+}
+
+// drawnPairs maps each giver's name to their receiver's, read through the
+// secret links sent since the notifier was last checked.
+func drawnPairs(t *testing.T, router http.Handler, notifier *mockNotifier, exchangeID string) map[string]string {
+	t.Helper()
+	pairs := make(map[string]string)
+	for _, msg := range notifier.messages(domain.NotifyExchangeDrawn) {
+		path := assignmentPathPattern.FindString(msg.Body)
+		require.NotEmpty(t, path, msg.Body)
+		w := doJSON(t, router, http.MethodGet, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var assignment map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&assignment))
+		if assignment["exchange_id"] == exchangeID {
+			pairs[assignment["giver_name"].(string)] = assignment["receiver_name"].(string)
+		}
+	}
+	return pairs
+}
+
+func TestExchange_DrawAndAssignments(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+	bob := registerAndGetToken(t, router, "bob@example.com")
+
+	exchangeID, ids := startExchange(t, router, organizer,
+		map[string]interface{}{"name": "Office Santa", "budget": 25, "exchange_on": "2026-12-20"},
+		[][2]string{{"Ann", "ann@example.com"}, {"Bob", "Bob@Example.com"}, {"Cid", "cid@example.com"}, {"Dee", "dee@example.com"}})
+
+	w := doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/exclusions", organizer,
+		map[string]interface{}{"giver_id": ids["Ann"], "receiver_id": ids["Bob"], "mutual": true})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = doJSON(t, router, http.MethodGet, "/api/exchanges/"+exchangeID+"/assignment", bob, nil)
+	assert.Equal(t, http.StatusConflict, w.Code, "nothing to see before the draw")
+
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/draw", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var drawn struct {
+		Status       string                   `json:"status"`
+		Participants []map[string]interface{} `json:"participants"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&drawn))
+	assert.Equal(t, "drawn", drawn.Status)
+	for _, p := range drawn.Participants {
+		assert.NotContains(t, p, "receiver_id", "the organizer does not see the pairs")
+		assert.NotContains(t, p, "token")
+	}
+	assert.ElementsMatch(t, []string{"ann@example.com", "bob@example.com", "cid@example.com", "dee@example.com"},
+		notifier.recipients(domain.NotifyExchangeDrawn))
+
+	pairs := drawnPairs(t, router, notifier, exchangeID)
+	require.Len(t, pairs, 4)
+	received := make(map[string]bool)
+	for giver, receiver := range pairs {
+		assert.NotEqual(t, giver, receiver)
+		received[receiver] = true
+	}
+	assert.Len(t, received, 4, "everyone receives exactly one gift")
+	assert.NotEqual(t, "Bob", pairs["Ann"])
+	assert.NotEqual(t, "Ann", pairs["Bob"])
+
+	// A signed-in participant sees only their own assignment.
+	w = doJSON(t, router, http.MethodGet, "/api/exchanges/"+exchangeID+"/assignment", bob, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var assignment map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&assignment))
+	assert.Equal(t, "Bob", assignment["giver_name"])
+	assert.Equal(t, pairs["Bob"], assignment["receiver_name"])
+	assert.Equal(t, 25.0, assignment["budget"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/verify", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var verification map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&verification))
+	assert.Equal(t, true, verification["matches"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/draw", organizer, nil)
+	assert.Equal(t, http.StatusConflict, w.Code, "names are drawn once")
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/participants", organizer,
+		map[string]interface{}{"name": "Eve", "email": "eve@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/verify", bob, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code, "only the organizer verifies")
+
+	w = doJSON(t, router, http.MethodGet, "/api/exchange-assignments/not-a-token", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestExchange_AvoidsLastYearsPairs(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+	people := [][2]string{{"Ann", "ann@example.com"}, {"Bob", "bob@example.com"}, {"Cid", "cid@example.com"}}
+
+	lastYearID, _ := startExchange(t, router, organizer, map[string]interface{}{"name": "Santa 2025"}, people)
+	w := doJSON(t, router, http.MethodPost, "/api/exchanges/"+lastYearID+"/draw", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	lastYear := drawnPairs(t, router, notifier, lastYearID)
+	require.Len(t, lastYear, 3)
+
+	// With three people and last year's cycle forbidden, only the reverse cycle is left.
+	thisYearID, _ := startExchange(t, router, organizer,
+		map[string]interface{}{"name": "Santa 2026", "previous_exchange_id": lastYearID}, people)
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+thisYearID+"/draw", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	thisYear := drawnPairs(t, router, notifier, thisYearID)
+	require.Len(t, thisYear, 3)
+	for giver, receiver := range lastYear {
+		assert.Equal(t, giver, thisYear[receiver])
+	}
+}
+
+func TestExchange_VerifyAfterPreviousDeleted(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+	people := [][2]string{{"Ann", "ann@example.com"}, {"Bob", "bob@example.com"}, {"Cid", "cid@example.com"}}
+
+	lastYearID, _ := startExchange(t, router, organizer, map[string]interface{}{"name": "Santa 2025"}, people)
+	w := doJSON(t, router, http.MethodPost, "/api/exchanges/"+lastYearID+"/draw", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	thisYearID, _ := startExchange(t, router, organizer,
+		map[string]interface{}{"name": "Santa 2026", "previous_exchange_id": lastYearID}, people)
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+thisYearID+"/draw", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = doJSON(t, router, http.MethodDelete, "/api/exchanges/"+lastYearID, organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The draw replays from the pairs stored with it, not from last year's exchange.
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+thisYearID+"/verify", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var verification map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&verification))
+	assert.Equal(t, true, verification["matches"])
+}
+
+func TestExchange_DrawErrors(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+
+	exchangeID, ids := startExchange(t, router, organizer, map[string]interface{}{"name": "Family"},
+		[][2]string{{"Ann", "ann@example.com"}, {"Bob", "bob@example.com"}})
+	w := doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/draw", organizer, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "/problems/not_enough_participants", decodeProblem(t, w)["type"])
+
+	// Every three-person draw is a cycle, so a mutual exclusion rules them all out.
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/participants", organizer,
+		map[string]interface{}{"name": "Cid", "email": "cid@example.com"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/exclusions", organizer,
+		map[string]interface{}{"giver_id": ids["Ann"], "receiver_id": ids["Bob"], "mutual": true})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/draw", organizer, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "/problems/no_valid_draw", decodeProblem(t, w)["type"])
+}
+
+func TestExchange_AccessAndValidation(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+	stranger := registerAndGetToken(t, router, "stranger@example.com")
+
+	exchangeID, ids := startExchange(t, router, organizer, map[string]interface{}{"name": "Family"},
+		[][2]string{{"Ann", "ann@example.com"}})
+
+	w := doJSON(t, router, http.MethodGet, "/api/exchanges/"+exchangeID, stranger, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, "outsiders cannot see the exchange")
+	w = doJSON(t, router, http.MethodGet, "/api/exchanges/"+exchangeID+"/assignment", stranger, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges", organizer,
+		map[string]interface{}{"budget": -5, "currency": "XYZ"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"name": "required", "budget": "out_of_range", "currency": "invalid_choice"},
+		fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/participants", organizer,
+		map[string]interface{}{"name": "Ann again", "email": "ANN@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/exchanges/"+exchangeID+"/exclusions", organizer,
+		map[string]interface{}{"giver_id": ids["Ann"], "receiver_id": ids["Ann"]})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"receiver_id": "duplicate"}, fieldErrorCodes(t, w))
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// ExportHandler handles recipient export HTTP requests.
+type ExportHandler struct {
+	exportService port.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler.
+func NewExportHandler(exportService port.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// RecipientsVCard handles GET /api/export/recipients.vcf. The optional
+// version query parameter selects vCard 3.0 (the default) or 4.0.
+func (h *ExportHandler) RecipientsVCard(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	body, err := h.exportService.VCard(r.Context(), userID, r.URL.Query().Get("version"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vcard; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="recipients.vcf"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// RecipientsCSV handles GET /api/export/recipients.csv.
+func (h *ExportHandler) RecipientsCSV(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	body, err := h.exportService.CSV(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="recipients.csv"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// RecipientsXLSX handles GET /api/export/recipients.xlsx.
+func (h *ExportHandler) RecipientsXLSX(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	body, err := h.exportService.XLSX(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", `attachment; filename="recipients.xlsx"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/xlsx"
+)
+
+func TestExportVCard(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "export-vcard@example.com")
+
+	ana := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana Maria Souza", "gender": "female", "birthdate": "1990-03-15", "keywords": []string{"books"},
+	})
+	createOccasion(t, router, token, ana, map[string]interface{}{"kind": "anniversary", "date": "2015-06-20"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Bob"})
+
+	w := doJSON(t, router, http.MethodGet, "/api/export/recipients.vcf", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/vcard; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "recipients.vcf")
+
+	body := w.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VCARD\r\n"))
+	assert.Less(t, strings.Index(body, "FN:Ana Maria Souza"), strings.Index(body, "FN:Bob"), "cards are sorted by name")
+	assert.Contains(t, body, "UID:urn:uuid:"+ana+"\r\n")
+	assert.Contains(t, body, "N:Souza;Ana Maria;;;\r\n")
+	assert.Contains(t, body, "BDAY:1990-03-15\r\n")
+	assert.Contains(t, body, "X-ANNIVERSARY:2015-06-20\r\n")
+	assert.Contains(t, body, "CATEGORIES:reading\r\n")
+
+	w = doJSON(t, router, http.MethodGet, "/api/export/recipients.vcf?version=4.0", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	body = w.Body.String()
+	assert.Contains(t, body, "VERSION:4.0\r\n")
+	assert.Contains(t, body, "GENDER:F\r\n")
+	assert.Contains(t, body, "ANNIVERSARY:20150620\r\n")
+
+	w = doJSON(t, router, http.MethodGet, "/api/export/recipients.vcf?version=2.1", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportVCard_RoundTrip(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	source := registerAndGetToken(t, router, "export-source@example.com")
+	target := registerAndGetToken(t, router, "export-target@example.com")
+
+	id := createRecipient(t, router, source, map[string]interface{}{
+		"name": "João Silva", "gender": "male", "birthdate": "1985-07-02", "keywords": []string{"gaming"},
+	})
+	createOccasion(t, router, source, id, map[string]interface{}{"kind": "wedding", "date": "2012-09-01"})
+
+	w := doJSON(t, router, http.MethodGet, "/api/export/recipients.vcf?version=4.0", source, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = uploadFile(t, router, target, "/api/import/vcard", "recipients.vcf", w.Body.String())
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	items := importItems(decodeImport(t, w))
+	require.Len(t, items, 1)
+
+	recipient := items[0]["recipient"].(map[string]interface{})
+	assert.Equal(t, "João Silva", recipient["name"])
+	assert.Equal(t, "male", recipient["gender"])
+	assert.Equal(t, "1985-07-02", recipient["birthdate"])
+	assert.Equal(t, []interface{}{"gaming"}, recipient["keywords"])
+	assert.Equal(t, "2012-09-01", items[0]["occasions"].([]interface{})[0].(map[string]interface{})["date"])
+}
+
+func TestExportSpreadsheets(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "export-sheets@example.com")
+
+	createRecipient(t, router, token, map[string]interface{}{
+		"name": "João Silva", "age": 41, "gender": "male", "relationship": "father", "birthdate": "1985-07-02",
+		"min_budget": 20, "max_budget": 99.5, "keywords": []string{"gaming", "reading"},
+	})
+	createRecipient(t, router, token, map[string]interface{}{"name": "=cmd|' /C calc'!A0"})
+
+	w := doJSON(t, router, http.MethodGet, "/api/export/recipients.csv", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "name,age,gender,relationship,birthdate,min_budget,max_budget,currency,keywords\n"+
+		"'=cmd|' /C calc'!A0,0,other,,,0.00,0.00,USD,\n"+
+		"João Silva,41,male,father,1985-07-02,20.00,99.50,USD,\"gaming, reading\"\n", w.Body.String())
+
+	w = doJSON(t, router, http.MethodGet, "/api/export/recipients.xlsx", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+	rows, err := xlsx.ReadRows(w.Body.Bytes())
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"João Silva", "41", "male", "father", "1985-07-02", "20", "99.5", "USD", "gaming, reading"}, rows[2])
+	assert.Equal(t, "=cmd|' /C calc'!A0", rows[1][0], "workbook cells are never formulas")
+}
+
+func TestExportSpreadsheets_RoundTrip(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	source := registerAndGetToken(t, router, "export-sheet-source@example.com")
+	target := registerAndGetToken(t, router, "export-sheet-target@example.com")
+
+	createRecipient(t, router, source, map[string]interface{}{
+		"name": "+Ana", "gender": "female", "birthdate": "1990-03-15", "max_budget": 150, "currency": "BRL",
+		"keywords": []string{"cooking", "reading"},
+	})
+
+	for _, format := range []string{"csv", "xlsx"} {
+		w := doJSON(t, router, http.MethodGet, "/api/export/recipients."+format, source, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = uploadFile(t, router, target, "/api/import/spreadsheet", "recipients."+format, w.Body.String())
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		session := decodeImport(t, w)
+		assert.Equal(t, format, session["source"])
+		assert.Len(t, session["mapping"], 9, "every exported column maps back to its field")
+
+		items := importItems(session)
+		require.Len(t, items, 1)
+		assert.Empty(t, items[0]["errors"], format)
+		recipient := items[0]["recipient"].(map[string]interface{})
+		assert.Equal(t, "+Ana", recipient["name"], format)
+		assert.Equal(t, "female", recipient["gender"], format)
+		assert.Equal(t, "1990-03-15", recipient["birthdate"], format)
+		assert.Equal(t, float64(150), recipient["max_budget"], format)
+		assert.Equal(t, "BRL", recipient["currency"], format)
+		assert.Equal(t, []interface{}{"cooking", "reading"}, recipient["keywords"], format)
+	}
+}
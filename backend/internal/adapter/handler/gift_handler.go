@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// GiftHandler handles recipient gift history HTTP requests.
+type GiftHandler struct {
+	giftService port.GiftService
+}
+
+// NewGiftHandler creates a new GiftHandler.
+func NewGiftHandler(giftService port.GiftService) *GiftHandler {
+	return &GiftHandler{giftService: giftService}
+}
+
+// Create handles POST /api/recipients/{id}/gifts.
+func (h *GiftHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	var req domain.CreateGiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	gift, err := h.giftService.Create(r.Context(), userID, recipientID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, gift)
+}
+
+// List handles GET /api/recipients/{id}/gifts.
+func (h *GiftHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	gifts, err := h.giftService.List(r.Context(), userID, recipientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gifts)
+}
+
+// GetByID handles GET /api/recipients/{id}/gifts/{giftID}.
+func (h *GiftHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, giftID, ok := giftIDs(w, r)
+	if !ok {
+		return
+	}
+
+	gift, err := h.giftService.GetByID(r.Context(), userID, recipientID, giftID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gift)
+}
+
+// Update handles PUT /api/recipients/{id}/gifts/{giftID}.
+func (h *GiftHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, giftID, ok := giftIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateGiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	gift, err := h.giftService.Update(r.Context(), userID, recipientID, giftID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gift)
+}
+
+// Delete handles DELETE /api/recipients/{id}/gifts/{giftID}.
+func (h *GiftHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, giftID, ok := giftIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.giftService.Delete(r.Context(), userID, recipientID, giftID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "gift deleted"})
+}
+
+// giftIDs parses the recipient and gift IDs from the URL, writing a
+// 400 response and returning false if either is malformed.
+func giftIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	giftID, err := uuid.Parse(chi.URLParam(r, "giftID"))
+	if err != nil {
+		writeError(w, r, errInvalidGiftID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return recipientID, giftID, true
+}
@@ -0,0 +1,235 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGift(t *testing.T, router http.Handler, token, recipientID string, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/gifts", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var gift map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gift))
+	return gift
+}
+
+func getSuggestions(t *testing.T, router http.Handler, token, recipientID, query string) []map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/suggestions"+query, token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var suggestions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&suggestions))
+	return suggestions
+}
+
+func suggestionScores(suggestions []map[string]interface{}) map[string]float64 {
+	scores := make(map[string]float64, len(suggestions))
+	for _, s := range suggestions {
+		scores[s["item_id"].(string)] = s["score"].(float64)
+	}
+	return scores
+}
+
+func TestGiftCRUD(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "gifts@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+	occasion := createOccasion(t, router, token, recipientID, map[string]interface{}{"kind": "anniversary", "date": "2015-06-20"})
+
+	gift := createGift(t, router, token, recipientID, map[string]interface{}{
+		"item": "  Scarf ", "category": "Tabletop", "price": 35.5, "given_on": "2024-12-25",
+		"occasion_id": occasion["id"], "rating": 4,
+	})
+	giftID := gift["id"].(string)
+	assert.Equal(t, "Scarf", gift["item"])
+	assert.Equal(t, "board-games", gift["category"], "category is mapped onto the taxonomy")
+	assert.Equal(t, 35.5, gift["price"])
+	assert.Equal(t, "2024-12-25", gift["given_on"])
+	assert.Equal(t, occasion["id"], gift["occasion_id"])
+	assert.Equal(t, float64(4), gift["rating"])
+
+	// A catalog item fills in the name, category and price; the date defaults to today
+	fromCatalog := createGift(t, router, token, recipientID, map[string]interface{}{"catalog_item_id": "cookbook"})
+	assert.Equal(t, "Cookbook", fromCatalog["item"])
+	assert.Equal(t, "cooking", fromCatalog["category"])
+	assert.Equal(t, float64(30), fromCatalog["price"])
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), fromCatalog["given_on"])
+	assert.Nil(t, fromCatalog["rating"])
+
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/gifts", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var gifts []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gifts))
+	require.Len(t, gifts, 2)
+	assert.Equal(t, fromCatalog["id"], gifts[0]["id"], "most recently given first")
+
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+recipientID+"/gifts/"+giftID, token,
+		map[string]interface{}{"rating": 2, "clear_occasion": true})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&updated))
+	assert.Equal(t, float64(2), updated["rating"])
+	assert.Nil(t, updated["occasion_id"])
+	assert.Equal(t, "Scarf", updated["item"])
+
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+recipientID+"/gifts/"+giftID, token,
+		map[string]interface{}{"clear_rating": true})
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&updated))
+	assert.Nil(t, updated["rating"])
+
+	w = doJSON(t, router, http.MethodDelete, "/api/recipients/"+recipientID+"/gifts/"+giftID, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/gifts/"+giftID, token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "/problems/gift_not_found", decodeProblem(t, w)["type"])
+}
+
+func TestGiftValidation(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "gift-validation@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+	otherRecipient := createRecipient(t, router, token, map[string]interface{}{"name": "Bruno"})
+	theirOccasion := createOccasion(t, router, token, otherRecipient, map[string]interface{}{"kind": "anniversary", "date": "2015-06-20"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/gifts", token, map[string]interface{}{
+		"price": -1, "rating": 6, "given_on": time.Now().AddDate(0, 0, 3).Format("2006-01-02"),
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"item": "required", "price": "out_of_range", "rating": "out_of_range", "given_on": "out_of_range",
+	}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/gifts", token,
+		map[string]interface{}{"catalog_item_id": "flying-car"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"catalog_item_id": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	// The occasion must belong to the same recipient
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/gifts", token,
+		map[string]interface{}{"item": "Scarf", "occasion_id": theirOccasion["id"]})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"occasion_id": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/gifts/not-a-uuid", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// A gift of another recipient is not found through this one
+	gift := createGift(t, router, token, otherRecipient, map[string]interface{}{"item": "Scarf"})
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/gifts/"+gift["id"].(string), token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	other := registerAndGetToken(t, router, "gift-validation-other@example.com")
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/gifts", other, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/gifts", other, map[string]interface{}{"item": "Scarf"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGiftSuggestions(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "suggestions@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana", "max_budget": 100, "keywords": []string{"gaming", "board-games", "cooking"},
+	})
+
+	before := suggestionScores(getSuggestions(t, router, token, recipientID, "?limit=50"))
+	assert.Equal(t, 0.85, before["wireless-controller"])
+	assert.Equal(t, 0.85, before["gaming-headset"])
+	assert.Equal(t, 0.85, before["strategy-board-game"])
+	assert.Equal(t, 0.85, before["cookbook"])
+	assert.NotContains(t, before, "e-reader", "over the maximum budget")
+
+	// Loved: the item is left out and its category is boosted
+	createGift(t, router, token, recipientID, map[string]interface{}{"catalog_item_id": "wireless-controller", "rating": 5})
+	// No reaction recorded: the item, matched by name, is left out and its category slightly down-ranked
+	createGift(t, router, token, recipientID, map[string]interface{}{"item": "party card game", "category": "board games"})
+	// Disliked: the whole category drops
+	createGift(t, router, token, recipientID, map[string]interface{}{"item": "Apron", "category": "cooking", "rating": 1})
+
+	suggestions := getSuggestions(t, router, token, recipientID, "?limit=50")
+	after := suggestionScores(suggestions)
+	assert.NotContains(t, after, "wireless-controller")
+	assert.NotContains(t, after, "party-card-game")
+	assert.Equal(t, 1.05, after["gaming-headset"])
+	assert.Equal(t, 0.7, after["strategy-board-game"])
+	assert.Equal(t, 0.45, after["cookbook"])
+
+	assert.Equal(t, "gaming-headset", suggestions[0]["item_id"])
+	assert.Equal(t, []interface{}{"matches_interest", "within_budget", "liked_category"}, suggestions[0]["reasons"])
+
+	// Titles follow the requested locale
+	localized := getSuggestions(t, router, token, recipientID, "?limit=1&locale=pt-BR")
+	require.Len(t, localized, 1)
+	assert.Equal(t, "Headset gamer", localized[0]["title"])
+
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/suggestions?limit=500", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	other := registerAndGetToken(t, router, "suggestions-other@example.com")
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/suggestions", other, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGiftSuggestions_Relationship(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "suggestions-relationship@example.com")
+	keywords := []string{"gaming", "board-games"}
+	partnerID := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana", "relationship": "partner", "max_budget": 100, "keywords": keywords,
+	})
+	colleagueID := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Bia", "relationship": "colleague", "max_budget": 100, "keywords": keywords,
+	})
+
+	ranking := func(recipientID string) []string {
+		var ids []string
+		for _, s := range getSuggestions(t, router, token, recipientID, "?limit=4") {
+			ids = append(ids, s["item_id"].(string))
+		}
+		return ids
+	}
+
+	// A partner gets the dearer gifts first, a colleague the cheaper ones
+	assert.Equal(t, []string{"gaming-headset", "wireless-controller", "strategy-board-game", "party-card-game"},
+		ranking(partnerID))
+	assert.Equal(t, []string{"party-card-game", "strategy-board-game", "wireless-controller", "gaming-headset"},
+		ranking(colleagueID))
+}
+
+func TestGiftSuggestions_PreferredCurrency(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "suggestions-currency@example.com")
+	w := doJSON(t, router, http.MethodPut, "/api/me/preferences", token, map[string]interface{}{"currency": "BRL"})
+	require.Equal(t, http.StatusOK, w.Code)
+	recipientID := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana", "max_budget": 20, "currency": "USD", "keywords": []string{"board-games"},
+	})
+
+	// The 20 USD budget is 100 BRL, so only items up to 20 USD are suggested
+	suggestions := getSuggestions(t, router, token, recipientID, "?limit=50")
+	require.NotEmpty(t, suggestions)
+	prices := make(map[string]float64)
+	for _, s := range suggestions {
+		assert.Equal(t, "BRL", s["currency"])
+		assert.LessOrEqual(t, s["price"].(float64), float64(100))
+		prices[s["item_id"].(string)] = s["price"].(float64)
+	}
+	assert.Equal(t, float64(100), prices["party-card-game"])
+	assert.NotContains(t, prices, "strategy-board-game")
+
+	// Gifts from the catalog keep its prices and currency
+	gift := createGift(t, router, token, recipientID, map[string]interface{}{"catalog_item_id": "party-card-game"})
+	assert.Equal(t, float64(20), gift["price"])
+	assert.Equal(t, "USD", gift["currency"])
+	gift = createGift(t, router, token, recipientID, map[string]interface{}{"item": "Puzzle", "price": 45.9})
+	assert.Equal(t, 45.9, gift["price"])
+	assert.Equal(t, "USD", gift["currency"], "defaults to the recipient's currency")
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// GroupGiftHandler handles group gift HTTP requests.
+type GroupGiftHandler struct {
+	groupGiftService port.GroupGiftService
+}
+
+// NewGroupGiftHandler creates a new GroupGiftHandler.
+func NewGroupGiftHandler(groupGiftService port.GroupGiftService) *GroupGiftHandler {
+	return &GroupGiftHandler{groupGiftService: groupGiftService}
+}
+
+// Create handles POST /api/group-gifts.
+func (h *GroupGiftHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.CreateGroupGiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	gift, err := h.groupGiftService.Create(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, gift)
+}
+
+// List handles GET /api/group-gifts.
+func (h *GroupGiftHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	gifts, err := h.groupGiftService.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gifts)
+}
+
+// GetByID handles GET /api/group-gifts/{id}.
+func (h *GroupGiftHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return
+	}
+
+	gift, err := h.groupGiftService.GetByID(r.Context(), userID, giftID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gift)
+}
+
+// Update handles PUT /api/group-gifts/{id}.
+func (h *GroupGiftHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return
+	}
+
+	var req domain.UpdateGroupGiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	gift, err := h.groupGiftService.Update(r.Context(), userID, giftID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gift)
+}
+
+// Delete handles DELETE /api/group-gifts/{id}.
+func (h *GroupGiftHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return
+	}
+
+	if err := h.groupGiftService.Delete(r.Context(), userID, giftID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "group gift deleted"})
+}
+
+// Progress handles GET /api/group-gifts/{id}/progress.
+func (h *GroupGiftHandler) Progress(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return
+	}
+
+	progress, err := h.groupGiftService.Progress(r.Context(), userID, giftID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, progress)
+}
+
+// Settle handles POST /api/group-gifts/{id}/settle.
+func (h *GroupGiftHandler) Settle(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return
+	}
+
+	gift, err := h.groupGiftService.Settle(r.Context(), userID, giftID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, gift)
+}
+
+// Invite handles POST /api/group-gifts/{id}/contributors.
+func (h *GroupGiftHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return
+	}
+
+	var req domain.InviteContributorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	contributor, err := h.groupGiftService.Invite(r.Context(), userID, giftID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, contributor)
+}
+
+// UpdateContribution handles PUT /api/group-gifts/{id}/contributors/{contributorID}.
+func (h *GroupGiftHandler) UpdateContribution(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, contributorID, ok := contributorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateContributionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	contributor, err := h.groupGiftService.UpdateContribution(r.Context(), userID, giftID, contributorID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, contributor)
+}
+
+// RemoveContributor handles DELETE /api/group-gifts/{id}/contributors/{contributorID}.
+func (h *GroupGiftHandler) RemoveContributor(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	giftID, contributorID, ok := contributorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.groupGiftService.RemoveContributor(r.Context(), userID, giftID, contributorID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "contributor removed"})
+}
+
+// contributorIDs parses the group gift and contributor IDs from the URL,
+// writing a 400 response and returning false if either is malformed.
+func contributorIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	giftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupGiftID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	contributorID, err := uuid.Parse(chi.URLParam(r, "contributorID"))
+	if err != nil {
+		writeError(w, r, errInvalidContributorID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return giftID, contributorID, true
+}
@@ -0,0 +1,163 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// startGroupGift creates a recipient with a birthday occasion and a group
+// gift for it, returning the gift's id.
+func startGroupGift(t *testing.T, router http.Handler, token string, target float64) string {
+	t.Helper()
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Grandma"})
+	occasion := createOccasion(t, router, token, recipientID, map[string]interface{}{"kind": "birthday", "date": "1950-06-01"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/group-gifts", token, map[string]interface{}{
+		"recipient_id": recipientID, "occasion_id": occasion["id"], "title": "Espresso machine", "target_amount": target,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var gift map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gift))
+	return gift["id"].(string)
+}
+
+func inviteContributor(t *testing.T, router http.Handler, token, giftID string, body map[string]interface{}) string {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/group-gifts/"+giftID+"/contributors", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var contributor map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&contributor))
+	return contributor["id"].(string)
+}
+
+func getGroupGiftProgress(t *testing.T, router http.Handler, token, giftID string) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/group-gifts/"+giftID+"/progress", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var progress map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&progress))
+	return progress
+}
+
+func TestGroupGift_ContributionsAndSettle(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+	cousin := registerAndGetToken(t, router, "cousin@example.com")
+
+	giftID := startGroupGift(t, router, organizer, 300)
+	cousinID := inviteContributor(t, router, organizer, giftID, map[string]interface{}{"email": " Cousin@Example.com ", "name": "Cousin"})
+	auntID := inviteContributor(t, router, organizer, giftID, map[string]interface{}{"email": "aunt@example.com", "pledged": 100})
+	assert.Equal(t, []string{"cousin@example.com", "aunt@example.com"}, notifier.recipients(domain.NotifyGroupGiftInvite))
+
+	w := doJSON(t, router, http.MethodPost, "/api/group-gifts/"+giftID+"/contributors", organizer,
+		map[string]interface{}{"email": "AUNT@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// The cousin is a user, so they see the gift and can change their own pledge.
+	w = doJSON(t, router, http.MethodGet, "/api/group-gifts", cousin, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var gifts []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gifts))
+	require.Len(t, gifts, 1)
+	assert.Equal(t, giftID, gifts[0]["id"])
+
+	w = doJSON(t, router, http.MethodPut, "/api/group-gifts/"+giftID+"/contributors/"+cousinID, cousin,
+		map[string]interface{}{"pledged": 200})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"organizer@example.com"}, notifier.recipients(domain.NotifyGroupGiftPledge))
+
+	w = doJSON(t, router, http.MethodPut, "/api/group-gifts/"+giftID+"/contributors/"+auntID, cousin,
+		map[string]interface{}{"pledged": 0})
+	assert.Equal(t, http.StatusForbidden, w.Code, "contributors only change their own pledge")
+	w = doJSON(t, router, http.MethodPut, "/api/group-gifts/"+giftID+"/contributors/"+cousinID, cousin,
+		map[string]interface{}{"paid": 200})
+	assert.Equal(t, http.StatusForbidden, w.Code, "only the organizer records payments")
+	w = doJSON(t, router, http.MethodPost, "/api/group-gifts/"+giftID+"/settle", cousin, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Payments recorded by the organizer drive the progress bar.
+	w = doJSON(t, router, http.MethodPut, "/api/group-gifts/"+giftID+"/contributors/"+cousinID, organizer,
+		map[string]interface{}{"paid": 200})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	progress := getGroupGiftProgress(t, router, cousin, giftID)
+	assert.Equal(t, 300.0, progress["pledged"])
+	assert.Equal(t, 200.0, progress["paid"])
+	assert.Equal(t, 100.0, progress["remaining"])
+	assert.Equal(t, 100.0, progress["pledged_percent"])
+	assert.Equal(t, 66.0, progress["paid_percent"])
+	assert.Equal(t, 1.0, progress["paid_contributors"])
+	assert.Equal(t, false, progress["funded"])
+	assert.Empty(t, notifier.recipients(domain.NotifyGroupGiftFunded))
+
+	w = doJSON(t, router, http.MethodPut, "/api/group-gifts/"+giftID+"/contributors/"+auntID, organizer,
+		map[string]interface{}{"paid": 100})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, true, getGroupGiftProgress(t, router, organizer, giftID)["funded"])
+	assert.Equal(t, []string{"cousin@example.com", "aunt@example.com"}, notifier.recipients(domain.NotifyGroupGiftFunded))
+
+	w = doJSON(t, router, http.MethodPost, "/api/group-gifts/"+giftID+"/settle", organizer, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var settled map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&settled))
+	assert.Equal(t, "settled", settled["status"])
+	assert.NotNil(t, settled["settled_at"])
+	assert.Equal(t, []string{"cousin@example.com", "aunt@example.com"}, notifier.recipients(domain.NotifyGroupGiftSettled))
+
+	w = doJSON(t, router, http.MethodPut, "/api/group-gifts/"+giftID+"/contributors/"+cousinID, organizer,
+		map[string]interface{}{"paid": 250})
+	assert.Equal(t, http.StatusConflict, w.Code, "settled gifts are closed")
+}
+
+func TestGroupGift_Access(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	organizer := registerAndGetToken(t, router, "organizer@example.com")
+	stranger := registerAndGetToken(t, router, "stranger@example.com")
+	giftID := startGroupGift(t, router, organizer, 100)
+
+	w := doJSON(t, router, http.MethodGet, "/api/group-gifts/"+giftID, stranger, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, "uninvited users cannot see the gift")
+	w = doJSON(t, router, http.MethodGet, "/api/group-gifts/"+giftID+"/progress", stranger, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doJSON(t, router, http.MethodGet, "/api/group-gifts", stranger, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var gifts []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gifts))
+	assert.Empty(t, gifts)
+}
+
+func TestGroupGift_Validation(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "organizer@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Grandma"})
+	otherID := createRecipient(t, router, token, map[string]interface{}{"name": "Grandpa"})
+	otherOccasion := createOccasion(t, router, token, otherID, map[string]interface{}{"kind": "birthday", "date": "1948-02-10"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/group-gifts", token, map[string]interface{}{
+		"recipient_id": recipientID, "target_amount": 0, "currency": "XYZ",
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"title":         "required",
+		"target_amount": "out_of_range",
+		"currency":      "invalid_choice",
+	}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/group-gifts", token, map[string]interface{}{
+		"recipient_id": recipientID, "occasion_id": otherOccasion["id"], "title": "Watch", "target_amount": 50,
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"occasion_id": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	giftID := startGroupGift(t, router, token, 100)
+	w = doJSON(t, router, http.MethodPost, "/api/group-gifts/"+giftID+"/contributors", token,
+		map[string]interface{}{"email": "not-an-email", "pledged": -1})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"email": "invalid_format", "pledged": "out_of_range"}, fieldErrorCodes(t, w))
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// GroupHandler handles recipient group HTTP requests.
+type GroupHandler struct {
+	groupService port.GroupService
+}
+
+// NewGroupHandler creates a new GroupHandler.
+func NewGroupHandler(groupService port.GroupService) *GroupHandler {
+	return &GroupHandler{groupService: groupService}
+}
+
+// Create handles POST /api/groups.
+func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	group, err := h.groupService.Create(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, group)
+}
+
+// List handles GET /api/groups.
+func (h *GroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	groups, err := h.groupService.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, groups)
+}
+
+// GetByID handles GET /api/groups/{id}.
+func (h *GroupHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	groupID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupID)
+		return
+	}
+
+	group, err := h.groupService.GetByID(r.Context(), userID, groupID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, group)
+}
+
+// Update handles PUT /api/groups/{id}.
+func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	groupID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupID)
+		return
+	}
+
+	var req domain.GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	group, err := h.groupService.Update(r.Context(), userID, groupID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, group)
+}
+
+// Delete handles DELETE /api/groups/{id}.
+func (h *GroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	groupID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupID)
+		return
+	}
+
+	if err := h.groupService.Delete(r.Context(), userID, groupID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "group deleted"})
+}
+
+// AddMembers handles POST /api/groups/{id}/members.
+func (h *GroupHandler) AddMembers(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	groupID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupID)
+		return
+	}
+
+	var req domain.GroupMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	group, err := h.groupService.AddMembers(r.Context(), userID, groupID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, group)
+}
+
+// RemoveMember handles DELETE /api/groups/{id}/members/{recipientID}.
+func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	groupID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidGroupID)
+		return
+	}
+	recipientID, err := uuid.Parse(chi.URLParam(r, "recipientID"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	if err := h.groupService.RemoveMember(r.Context(), userID, groupID, recipientID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "member removed"})
+}
@@ -0,0 +1,141 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doJSON(t *testing.T, router http.Handler, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func createGroup(t *testing.T, router http.Handler, token, name string) string {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/groups", token, map[string]string{"name": name})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var group map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&group))
+	return group["id"].(string)
+}
+
+func TestGroups_CRUD(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "groups@example.com")
+
+	familyID := createGroup(t, router, token, "  Family ")
+	createGroup(t, router, token, "Coworkers")
+
+	// Names are unique per user, ignoring case
+	w := doJSON(t, router, http.MethodPost, "/api/groups", token, map[string]string{"name": "family"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/groups", token, map[string]string{"name": ""})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	w = doJSON(t, router, http.MethodPut, "/api/groups/"+familyID, token, map[string]string{"name": "Close family"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = doJSON(t, router, http.MethodGet, "/api/groups", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var groups []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&groups))
+	require.Len(t, groups, 2)
+	assert.Equal(t, "Close family", groups[0]["name"])
+	assert.Equal(t, "Coworkers", groups[1]["name"])
+
+	w = doJSON(t, router, http.MethodDelete, "/api/groups/"+familyID, token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/groups/"+familyID, token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGroups_MembersAndListFilter(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "group-members@example.com")
+
+	mom := createRecipient(t, router, token, map[string]interface{}{"name": "Mom", "relationship": "mother"})
+	dad := createRecipient(t, router, token, map[string]interface{}{"name": "Dad", "relationship": "father"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Bob", "relationship": "colleague"})
+	familyID := createGroup(t, router, token, "Family")
+
+	w := doJSON(t, router, http.MethodPost, "/api/groups/"+familyID+"/members", token,
+		map[string]interface{}{"recipient_ids": []string{mom, dad}})
+	require.Equal(t, http.StatusOK, w.Code)
+	var group map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&group))
+	assert.Equal(t, float64(2), group["member_count"])
+
+	page := listRecipients(t, router, token, "?sort=name&group="+familyID)
+	assert.Equal(t, []string{"Dad", "Mom"}, recipientNames(page))
+
+	w = doJSON(t, router, http.MethodDelete, "/api/groups/"+familyID+"/members/"+dad, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	page = listRecipients(t, router, token, "?group="+familyID)
+	assert.Equal(t, []string{"Mom"}, recipientNames(page))
+
+	// Trashed recipients no longer count as members
+	w = doJSON(t, router, http.MethodDelete, "/api/recipients/"+mom, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/groups/"+familyID, token, nil)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&group))
+	assert.Equal(t, float64(0), group["member_count"])
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients?group=not-a-uuid", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGroups_OtherUsersRecipientsAndGroups(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	owner := registerAndGetToken(t, router, "group-owner@example.com")
+	other := registerAndGetToken(t, router, "group-other@example.com")
+
+	groupID := createGroup(t, router, owner, "Friends")
+	foreign := createRecipient(t, router, other, map[string]interface{}{"name": "Stranger"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/groups/"+groupID+"/members", owner,
+		map[string]interface{}{"recipient_ids": []string{foreign}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/groups/"+groupID+"/members", owner,
+		map[string]interface{}{"recipient_ids": []string{}})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	w = doJSON(t, router, http.MethodPut, "/api/groups/"+groupID, other, map[string]string{"name": "Mine"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRecipient_Relationship(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "relationship@example.com")
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients", token,
+		map[string]interface{}{"name": "Ana", "relationship": "Best_Friend"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var recipient map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&recipient))
+	assert.Equal(t, "best_friend", recipient["relationship"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients", token,
+		map[string]interface{}{"name": "Ana", "relationship": "archnemesis"})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"relationship": "invalid_choice"}, fieldErrorCodes(t, w))
+}
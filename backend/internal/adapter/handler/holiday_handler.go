@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// HolidayHandler handles holiday calendar and subscription HTTP requests.
+type HolidayHandler struct {
+	holidayService port.HolidayService
+}
+
+// NewHolidayHandler creates a new HolidayHandler.
+func NewHolidayHandler(holidayService port.HolidayService) *HolidayHandler {
+	return &HolidayHandler{holidayService: holidayService}
+}
+
+// List handles GET /api/holidays?country=&year=&locale=.
+func (h *HolidayHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+	query := r.URL.Query()
+
+	year := 0
+	if v := query.Get("year"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "year must be an integer")
+			return
+		}
+		year = n
+	}
+
+	holidays, err := h.holidayService.List(r.Context(), userID, query.Get("country"), year, query.Get("locale"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, holidays)
+}
+
+// Subscribe handles POST /api/recipients/{id}/holidays.
+func (h *HolidayHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	var req domain.HolidaySubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	subscription, err := h.holidayService.Subscribe(r.Context(), userID, recipientID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, subscription)
+}
+
+// ListSubscriptions handles GET /api/recipients/{id}/holidays.
+func (h *HolidayHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	subscriptions, err := h.holidayService.ListSubscriptions(r.Context(), userID, recipientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, subscriptions)
+}
+
+// Unsubscribe handles DELETE /api/recipients/{id}/holidays/{subscriptionID}.
+func (h *HolidayHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+	subscriptionID, err := uuid.Parse(chi.URLParam(r, "subscriptionID"))
+	if err != nil {
+		writeError(w, r, errInvalidSubscriptionID)
+		return
+	}
+
+	if err := h.holidayService.Unsubscribe(r.Context(), userID, recipientID, subscriptionID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "holiday subscription removed"})
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listHolidays(t *testing.T, router http.Handler, token, query string) map[string]map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/holidays"+query, token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var holidays []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&holidays))
+	bySlug := make(map[string]map[string]interface{}, len(holidays))
+	for _, h := range holidays {
+		bySlug[h["slug"].(string)] = h
+	}
+	return bySlug
+}
+
+func TestListHolidays_RulesPerCountry(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "holidays@example.com")
+
+	us := listHolidays(t, router, token, "?country=us&year=2026")
+	assert.Equal(t, "2026-05-10", us["mothers-day"]["date"])
+	assert.Equal(t, "second Sunday of May", us["mothers-day"]["rule"])
+	assert.Equal(t, "2026-06-21", us["fathers-day"]["date"])
+	assert.Equal(t, "2026-11-26", us["thanksgiving"]["date"])
+	assert.Equal(t, "2026-04-05", us["easter"]["date"])
+
+	br := listHolidays(t, router, token, "?country=BR&year=2026")
+	assert.Equal(t, "2026-06-12", br["valentines-day"]["date"])
+	assert.Equal(t, "2026-08-09", br["fathers-day"]["date"])
+
+	// Mothering Sunday is three weeks before Easter
+	gb := listHolidays(t, router, token, "?country=GB&year=2024")
+	assert.Equal(t, "2024-03-10", gb["mothers-day"]["date"])
+	assert.Equal(t, "21 days before Easter", gb["mothers-day"]["rule"])
+
+	w := doJSON(t, router, http.MethodGet, "/api/holidays?country=XX", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/holidays?year=soon", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListHolidays_DefaultsFromLocale(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "holidays-locale@example.com")
+
+	w := doJSON(t, router, http.MethodPut, "/api/me/preferences", token, map[string]interface{}{"locale": "pt-BR"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	holidays := listHolidays(t, router, token, "?year=2026")
+	require.Contains(t, holidays, "childrens-day")
+	assert.Equal(t, "BR", holidays["mothers-day"]["country"])
+	assert.Equal(t, "Dia das Mães", holidays["mothers-day"]["name"])
+}
+
+func TestHolidaySubscriptions_FeedUpcoming(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "holiday-subs@example.com")
+	ana := createRecipient(t, router, token, map[string]interface{}{"name": "Ana", "max_budget": 80})
+	base := "/api/recipients/" + ana + "/holidays"
+
+	w := doJSON(t, router, http.MethodPost, base, token, map[string]interface{}{"holiday": "Christmas", "budget": 120})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var subscription map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&subscription))
+	assert.Equal(t, "US", subscription["country"])
+	assert.Equal(t, "christmas", subscription["holiday"])
+
+	w = doJSON(t, router, http.MethodPost, base, token, map[string]interface{}{"country": "US", "holiday": "christmas"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, base, token, map[string]interface{}{"country": "US", "holiday": "childrens-day"})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"holiday": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	feed := getUpcoming(t, router, token, "/api/upcoming?days=366")
+	require.Len(t, feed, 1)
+	assert.Equal(t, "holiday", feed[0]["kind"])
+	assert.Equal(t, "Christmas", feed[0]["title"])
+	assert.Equal(t, "christmas", feed[0]["holiday"])
+	assert.Equal(t, float64(120), feed[0]["budget"])
+	assert.Nil(t, feed[0]["turns"])
+
+	w = doJSON(t, router, http.MethodDelete, base+"/"+subscription["id"].(string), token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, getUpcoming(t, router, token, "/api/upcoming?days=366"))
+
+	w = doJSON(t, router, http.MethodGet, base, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var subscriptions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&subscriptions))
+	assert.Empty(t, subscriptions)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// HouseholdHandler handles household HTTP requests.
+type HouseholdHandler struct {
+	householdService port.HouseholdService
+}
+
+// NewHouseholdHandler creates a new HouseholdHandler.
+func NewHouseholdHandler(householdService port.HouseholdService) *HouseholdHandler {
+	return &HouseholdHandler{householdService: householdService}
+}
+
+// Create handles POST /api/household.
+func (h *HouseholdHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.CreateHouseholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	household, err := h.householdService.Create(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, household)
+}
+
+// Get handles GET /api/household.
+func (h *HouseholdHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	household, err := h.householdService.Get(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, household)
+}
+
+// Update handles PUT /api/household.
+func (h *HouseholdHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.UpdateHouseholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	household, err := h.householdService.Update(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, household)
+}
+
+// Delete handles DELETE /api/household.
+func (h *HouseholdHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	if err := h.householdService.Delete(r.Context(), userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "household deleted"})
+}
+
+// Leave handles POST /api/household/leave.
+func (h *HouseholdHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	if err := h.householdService.Leave(r.Context(), userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "left household"})
+}
+
+// Invite handles POST /api/household/invitations.
+func (h *HouseholdHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.InviteHouseholdMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	invitation, err := h.householdService.Invite(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, invitation)
+}
+
+// RevokeInvitation handles DELETE /api/household/invitations/{invitationID}.
+func (h *HouseholdHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitationID"))
+	if err != nil {
+		writeError(w, r, errInvalidInvitationID)
+		return
+	}
+
+	if err := h.householdService.RevokeInvitation(r.Context(), userID, invitationID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "invitation revoked"})
+}
+
+// Accept handles POST /api/household-invitations/{token}/accept.
+func (h *HouseholdHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	household, err := h.householdService.Accept(r.Context(), userID, chi.URLParam(r, "token"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, household)
+}
+
+// UpdateMember handles PUT /api/household/members/{userID}.
+func (h *HouseholdHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	memberID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeError(w, r, errInvalidMemberID)
+		return
+	}
+
+	var req domain.UpdateHouseholdMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	household, err := h.householdService.UpdateMember(r.Context(), userID, memberID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, household)
+}
+
+// RemoveMember handles DELETE /api/household/members/{userID}.
+func (h *HouseholdHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	memberID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeError(w, r, errInvalidMemberID)
+		return
+	}
+
+	if err := h.householdService.RemoveMember(r.Context(), userID, memberID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "member removed"})
+}
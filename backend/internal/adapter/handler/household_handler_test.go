@@ -0,0 +1,338 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// inviteToHousehold invites email and returns the token from the invitation
+// message, as the invitee would follow it.
+func inviteToHousehold(t *testing.T, router http.Handler, notifier *mockNotifier, token, email, role string) string {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/household/invitations", token,
+		map[string]interface{}{"email": email, "role": role})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "token")
+
+	msgs := notifier.messages(domain.NotifyHouseholdInvite)
+	require.NotEmpty(t, msgs)
+	msg := msgs[len(msgs)-1]
+	require.Equal(t, strings.ToLower(email), msg.To)
+	_, path, ok := strings.Cut(msg.Body, "/api/household-invitations/")
+	require.True(t, ok, msg.Body)
+	invitation, _, ok := strings.Cut(path, "/accept")
+	require.True(t, ok, msg.Body)
+	return invitation
+}
+
+func acceptInvitation(t *testing.T, router http.Handler, token, invitation string) *http.Response {
+	t.Helper()
+	return doJSON(t, router, http.MethodPost, "/api/household-invitations/"+invitation+"/accept", token, nil).Result()
+}
+
+// householdMembers returns the caller's household members' roles and user
+// IDs keyed by email.
+func householdMembers(t *testing.T, router http.Handler, token string) (roles, ids map[string]string) {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/household", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var household struct {
+		Members []struct {
+			UserID string `json:"user_id"`
+			Email  string `json:"email"`
+			Role   string `json:"role"`
+		} `json:"members"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&household))
+	roles, ids = map[string]string{}, map[string]string{}
+	for _, m := range household.Members {
+		roles[m.Email] = m.Role
+		ids[m.Email] = m.UserID
+	}
+	return roles, ids
+}
+
+func listRecipientNames(t *testing.T, router http.Handler, token string) []string {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/recipients?sort=name", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page struct {
+		Data []struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	names := []string{}
+	for _, r := range page.Data {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestHousehold_SharesRecipientsByRole(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	owner := registerAndGetToken(t, router, "ana@example.com")
+	spouse := registerAndGetToken(t, router, "ben@example.com")
+	grandma := registerAndGetToken(t, router, "gran@example.com")
+	stranger := registerAndGetToken(t, router, "stranger@example.com")
+	kid := createRecipient(t, router, owner, map[string]interface{}{"name": "Kid", "keywords": []string{"lego"}})
+	benMum := createRecipient(t, router, spouse, map[string]interface{}{"name": "Ben's mum"})
+	createRecipient(t, router, stranger, map[string]interface{}{"name": "Stranger's friend"})
+
+	// Before joining, the spouse cannot see the kid.
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/"+kid, spouse, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": " The Smiths "})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"name":"The Smiths"`)
+	require.Equal(t, http.StatusOK, acceptInvitation(t, router, spouse, inviteToHousehold(t, router, notifier, owner, "BEN@example.com", "editor")).StatusCode)
+	require.Equal(t, http.StatusOK, acceptInvitation(t, router, grandma, inviteToHousehold(t, router, notifier, owner, "gran@example.com", "")).StatusCode)
+
+	roles, _ := householdMembers(t, router, spouse)
+	assert.Equal(t, map[string]string{"ana@example.com": "owner", "ben@example.com": "editor", "gran@example.com": "viewer"}, roles)
+
+	// Every member lists and searches everyone's recipients.
+	assert.Equal(t, []string{"Ben's mum", "Kid"}, listRecipientNames(t, router, owner))
+	assert.Equal(t, []string{"Ben's mum", "Kid"}, listRecipientNames(t, router, grandma))
+	assert.Equal(t, []string{"Stranger's friend"}, listRecipientNames(t, router, stranger))
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/search?q=kid", spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), kid)
+
+	// Editors change shared recipients and what hangs off them.
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+kid, spouse, map[string]interface{}{"age": 7})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	createOccasion(t, router, spouse, kid, map[string]interface{}{"kind": "birthday", "date": "2020-05-01"})
+	createIdea(t, router, spouse, kid, map[string]interface{}{"title": "Lego castle"})
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+benMum, owner, map[string]interface{}{"relationship": "relative"})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Viewers only look.
+	for _, path := range []string{"/api/recipients/" + kid, "/api/recipients/" + kid + "/occasions", "/api/recipients/" + kid + "/ideas", "/api/recipients/" + kid + "/history"} {
+		w = doJSON(t, router, http.MethodGet, path, grandma, nil)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+kid, grandma, map[string]interface{}{"age": 8})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+kid+"/ideas", grandma, map[string]interface{}{"title": "Socks"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodDelete, "/api/recipients/"+kid, grandma, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodDelete, "/api/recipients", grandma, map[string]interface{}{"ids": []string{kid}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, listRecipientNames(t, router, owner), "Kid", "bulk delete skips what a viewer cannot edit")
+
+	// Trash is shared too: the spouse restores what the owner deleted.
+	w = doJSON(t, router, http.MethodDelete, "/api/recipients/"+kid, owner, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/trash", spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), kid)
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+kid+"/restore", grandma, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+kid+"/restore", spouse, nil)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Outsiders get nothing.
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+kid, stranger, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+kid+"/gifts", stranger, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Leaving stops the sharing both ways.
+	w = doJSON(t, router, http.MethodPost, "/api/household/leave", spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+kid, spouse, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, []string{"Kid"}, listRecipientNames(t, router, grandma))
+	assert.Equal(t, []string{"Ben's mum"}, listRecipientNames(t, router, spouse))
+}
+
+func TestHousehold_SharesCalendarsAndExports(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	owner := registerAndGetToken(t, router, "ana@example.com")
+	spouse := registerAndGetToken(t, router, "ben@example.com")
+	kid := createRecipient(t, router, owner, map[string]interface{}{"name": "Kid", "birthdate": daysFromNow(5, 8)})
+	createOccasion(t, router, owner, kid, map[string]interface{}{"kind": "graduation", "date": daysFromNow(12, 0), "recurring": false})
+	createRecipient(t, router, spouse, map[string]interface{}{"name": "Ben's mum", "birthdate": daysFromNow(9, 60)})
+
+	upcomingNames := func(token string) []string {
+		var names []string
+		for _, u := range getUpcoming(t, router, token, "/api/upcoming") {
+			names = append(names, u["recipient_name"].(string)+" "+u["kind"].(string))
+		}
+		return names
+	}
+	assert.Equal(t, []string{"Ben's mum birthday"}, upcomingNames(spouse))
+
+	w := doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": "Home"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, http.StatusOK, acceptInvitation(t, router, spouse, inviteToHousehold(t, router, notifier, owner, "ben@example.com", "viewer")).StatusCode)
+
+	// Both members see every shared birthday and occasion.
+	want := []string{"Kid birthday", "Ben's mum birthday", "Kid graduation"}
+	assert.Equal(t, want, upcomingNames(spouse))
+	assert.Equal(t, want, upcomingNames(owner))
+
+	w = doJSON(t, router, http.MethodGet, "/api/export/recipients.csv", spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Kid")
+	assert.Contains(t, w.Body.String(), "Ben's mum")
+
+	// Duplicates are found across the household.
+	createRecipient(t, router, spouse, map[string]interface{}{"name": "kid", "birthdate": daysFromNow(5, 8)})
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/duplicates", spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), kid)
+}
+
+func TestHousehold_ImportFlagsSharedDuplicates(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	owner := registerAndGetToken(t, router, "ana@example.com")
+	spouse := registerAndGetToken(t, router, "ben@example.com")
+	shared := createRecipient(t, router, owner, map[string]interface{}{"name": "Ana Souza", "birthdate": "1990-03-15"})
+	w := doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": "Home"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, http.StatusOK, acceptInvitation(t, router, spouse, inviteToHousehold(t, router, notifier, owner, "ben@example.com", "editor")).StatusCode)
+
+	// The spouse's import is checked against the recipients they share.
+	w = uploadFile(t, router, spouse, "/api/import/ics", "birthdays.ics", birthdaysICS)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	items := importItems(decodeImport(t, w))
+	assert.Equal(t, shared, items[0]["duplicate_of"])
+	assert.Equal(t, []interface{}{"duplicate"}, items[0]["warnings"])
+}
+
+func TestHousehold_InvitationEmailIgnoresCase(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	owner := registerAndGetToken(t, router, "ana@example.com")
+	invitee := registerAndGetToken(t, router, "Dee@Example.com")
+	w := doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": "Home"})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	invitation := inviteToHousehold(t, router, notifier, owner, "dee@example.com", "viewer")
+	require.Equal(t, http.StatusOK, acceptInvitation(t, router, invitee, invitation).StatusCode)
+
+	w = doJSON(t, router, http.MethodPost, "/api/household/invitations", owner, map[string]interface{}{"email": "DEE@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code, "members cannot be invited again")
+}
+
+func TestHousehold_MembersAndInvitations(t *testing.T) {
+	notifier := newMockNotifier()
+	router, _, _, _, _, _ := setupRouterWithNotifier(t, notifier)
+	owner := registerAndGetToken(t, router, "ana@example.com")
+	spouse := registerAndGetToken(t, router, "ben@example.com")
+	other := registerAndGetToken(t, router, "cara@example.com")
+	kid := createRecipient(t, router, owner, map[string]interface{}{"name": "Kid"})
+
+	w := doJSON(t, router, http.MethodGet, "/api/household", owner, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "/problems/household_not_found", decodeProblem(t, w)["type"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": ""})
+	assert.Equal(t, "required", fieldErrorCodes(t, w)["name"])
+	w = doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": "Home"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/household", owner, map[string]interface{}{"name": "Second home"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "/problems/already_in_household", decodeProblem(t, w)["type"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/household/invitations", owner, map[string]interface{}{"email": "ben@example.com", "role": "owner"})
+	assert.Equal(t, "invalid_choice", fieldErrorCodes(t, w)["role"])
+	w = doJSON(t, router, http.MethodPost, "/api/household/invitations", owner, map[string]interface{}{"email": "nope"})
+	assert.Equal(t, "invalid_format", fieldErrorCodes(t, w)["email"])
+	w = doJSON(t, router, http.MethodPost, "/api/household/invitations", owner, map[string]interface{}{"email": "ana@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code, "members cannot be invited again")
+
+	invitation := inviteToHousehold(t, router, notifier, owner, "ben@example.com", "viewer")
+	w = doJSON(t, router, http.MethodPost, "/api/household/invitations", owner, map[string]interface{}{"email": "ben@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "/problems/invitation_exists", decodeProblem(t, w)["type"])
+
+	// Only the invited email can accept, and only once.
+	resp := acceptInvitation(t, router, other, invitation)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = acceptInvitation(t, router, spouse, "not-a-token")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, http.StatusOK, acceptInvitation(t, router, spouse, invitation).StatusCode)
+	assert.Equal(t, http.StatusNotFound, acceptInvitation(t, router, spouse, invitation).StatusCode)
+
+	// Someone already in a household must leave it first.
+	w = doJSON(t, router, http.MethodPost, "/api/household", other, map[string]interface{}{"name": "Cara's"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	invitation = inviteToHousehold(t, router, notifier, owner, "cara@example.com", "editor")
+	assert.Equal(t, http.StatusConflict, acceptInvitation(t, router, other, invitation).StatusCode)
+
+	// The owner sees pending invitations and can revoke them; members cannot.
+	w = doJSON(t, router, http.MethodGet, "/api/household", owner, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var household struct {
+		Invitations []struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+		} `json:"invitations"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&household))
+	require.Len(t, household.Invitations, 1)
+	assert.Equal(t, "cara@example.com", household.Invitations[0].Email)
+	w = doJSON(t, router, http.MethodGet, "/api/household", spouse, nil)
+	assert.NotContains(t, w.Body.String(), "invitations")
+	w = doJSON(t, router, http.MethodDelete, "/api/household/invitations/"+household.Invitations[0].ID, spouse, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodDelete, "/api/household/invitations/"+household.Invitations[0].ID, owner, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodDelete, "/api/household/invitations/not-a-uuid", owner, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Members only manage themselves.
+	w = doJSON(t, router, http.MethodPost, "/api/household/invitations", spouse, map[string]interface{}{"email": "dan@example.com"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "/problems/not_household_owner", decodeProblem(t, w)["type"])
+	w = doJSON(t, router, http.MethodPut, "/api/household", spouse, map[string]interface{}{"name": "Ours"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Promote the viewer, then hand the household over.
+	_, ids := householdMembers(t, router, owner)
+	w = doJSON(t, router, http.MethodPut, "/api/household/members/"+ids["ana@example.com"], owner, map[string]interface{}{"role": "viewer"})
+	assert.Equal(t, "invalid_choice", fieldErrorCodes(t, w)["role"])
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+kid, spouse, map[string]interface{}{"age": 7})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodPut, "/api/household/members/"+ids["ben@example.com"], owner, map[string]interface{}{"role": "editor"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+kid, spouse, map[string]interface{}{"age": 7})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/household/leave", owner, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "/problems/owner_cannot_leave", decodeProblem(t, w)["type"])
+	w = doJSON(t, router, http.MethodPut, "/api/household/members/"+ids["ben@example.com"], owner, map[string]interface{}{"role": "owner"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	roles, _ := householdMembers(t, router, owner)
+	assert.Equal(t, map[string]string{"ana@example.com": "editor", "ben@example.com": "owner"}, roles)
+
+	// The new owner removes the old one, who keeps their own recipients.
+	w = doJSON(t, router, http.MethodDelete, "/api/household/members/"+ids["ana@example.com"], spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+kid, spouse, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+kid, owner, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodDelete, "/api/household/members/"+ids["ana@example.com"], spouse, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doJSON(t, router, http.MethodDelete, "/api/household", spouse, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/household", spouse, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
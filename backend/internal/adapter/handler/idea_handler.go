@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// IdeaHandler handles gift idea board HTTP requests.
+type IdeaHandler struct {
+	ideaService port.IdeaService
+}
+
+// NewIdeaHandler creates a new IdeaHandler.
+func NewIdeaHandler(ideaService port.IdeaService) *IdeaHandler {
+	return &IdeaHandler{ideaService: ideaService}
+}
+
+// Create handles POST /api/recipients/{id}/ideas.
+func (h *IdeaHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	var req domain.CreateIdeaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	idea, err := h.ideaService.Create(r.Context(), userID, recipientID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, idea)
+}
+
+// Promote handles POST /api/recipients/{id}/ideas/promote.
+func (h *IdeaHandler) Promote(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	var req domain.PromoteSuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	idea, err := h.ideaService.Promote(r.Context(), userID, recipientID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, idea)
+}
+
+// List handles GET /api/recipients/{id}/ideas.
+func (h *IdeaHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	ideas, err := h.ideaService.List(r.Context(), userID, recipientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, ideas)
+}
+
+// GetByID handles GET /api/recipients/{id}/ideas/{ideaID}.
+func (h *IdeaHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, ideaID, ok := ideaIDs(w, r)
+	if !ok {
+		return
+	}
+
+	idea, err := h.ideaService.GetByID(r.Context(), userID, recipientID, ideaID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, idea)
+}
+
+// Update handles PUT /api/recipients/{id}/ideas/{ideaID}.
+func (h *IdeaHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, ideaID, ok := ideaIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateIdeaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	idea, err := h.ideaService.Update(r.Context(), userID, recipientID, ideaID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, idea)
+}
+
+// Transition handles POST /api/recipients/{id}/ideas/{ideaID}/status.
+func (h *IdeaHandler) Transition(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, ideaID, ok := ideaIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.TransitionIdeaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	idea, err := h.ideaService.Transition(r.Context(), userID, recipientID, ideaID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, idea)
+}
+
+// Delete handles DELETE /api/recipients/{id}/ideas/{ideaID}.
+func (h *IdeaHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, ideaID, ok := ideaIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.ideaService.Delete(r.Context(), userID, recipientID, ideaID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "idea deleted"})
+}
+
+// ideaIDs parses the recipient and idea IDs from the URL, writing a
+// 400 response and returning false if either is malformed.
+func ideaIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	ideaID, err := uuid.Parse(chi.URLParam(r, "ideaID"))
+	if err != nil {
+		writeError(w, r, errInvalidIdeaID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return recipientID, ideaID, true
+}
@@ -0,0 +1,174 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createIdea(t *testing.T, router http.Handler, token, recipientID string, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var idea map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&idea))
+	return idea
+}
+
+// moveIdea requests a status transition and returns the response status.
+func moveIdea(t *testing.T, router http.Handler, token, recipientID, ideaID string, body map[string]interface{}) int {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas/"+ideaID+"/status", token, body)
+	return w.Code
+}
+
+func TestIdeaCRUD(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "ideas@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+
+	idea := createIdea(t, router, token, recipientID, map[string]interface{}{
+		"title": " Pottery class ", "url": "https://example.com/pottery", "price": 90, "notes": "Saturday mornings",
+	})
+	ideaID := idea["id"].(string)
+	assert.Equal(t, "Pottery class", idea["title"])
+	assert.Equal(t, "medium", idea["priority"], "priority defaults to medium")
+	assert.Equal(t, "idea", idea["status"])
+	assert.Nil(t, idea["gift_id"])
+
+	createIdea(t, router, token, recipientID, map[string]interface{}{"title": "Scarf", "priority": "low"})
+	createIdea(t, router, token, recipientID, map[string]interface{}{"title": "Concert tickets", "priority": "high"})
+
+	w := doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/ideas", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ideas []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ideas))
+	var titles []string
+	for _, i := range ideas {
+		titles = append(titles, i["title"].(string))
+	}
+	assert.Equal(t, []string{"Concert tickets", "Pottery class", "Scarf"}, titles, "highest priority first")
+
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+recipientID+"/ideas/"+ideaID, token,
+		map[string]interface{}{"priority": "high", "clear_price": true})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&updated))
+	assert.Equal(t, "high", updated["priority"])
+	assert.Nil(t, updated["price"])
+	assert.Equal(t, "Saturday mornings", updated["notes"])
+
+	w = doJSON(t, router, http.MethodDelete, "/api/recipients/"+recipientID+"/ideas/"+ideaID, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/ideas/"+ideaID, token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestIdeaValidation(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "idea-validation@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas", token, map[string]interface{}{
+		"url": "javascript:alert(1)", "price": -5, "priority": "urgent",
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"title": "required", "url": "invalid_format", "price": "out_of_range", "priority": "invalid_choice",
+	}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/ideas/not-a-uuid", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	other := registerAndGetToken(t, router, "idea-validation-other@example.com")
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/ideas", other, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestIdeaWorkflow(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "idea-workflow@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+	idea := createIdea(t, router, token, recipientID, map[string]interface{}{
+		"title": "Pottery class", "price": 90, "category": "Cooking",
+	})
+	ideaID := idea["id"].(string)
+
+	code := moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "planned"})
+	assert.Equal(t, http.StatusOK, code)
+	// Skipping ahead is allowed, and so is stepping back once
+	code = moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "wrapped"})
+	assert.Equal(t, http.StatusOK, code)
+	code = moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "purchased"})
+	assert.Equal(t, http.StatusOK, code)
+
+	// Jumping back more than one step, or to an unknown status, is not
+	code = moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "idea"})
+	assert.Equal(t, http.StatusConflict, code)
+	code = moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "lost"})
+	assert.Equal(t, http.StatusConflict, code)
+
+	// A gift that cannot be recorded leaves the idea where it was
+	code = moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "given", "rating": 9})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas/"+ideaID+"/status", token,
+		map[string]interface{}{"status": "given", "given_on": "2024-12-25", "rating": 5})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var given map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&given))
+	assert.Equal(t, "given", given["status"])
+	require.NotNil(t, given["gift_id"])
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+recipientID+"/gifts/"+given["gift_id"].(string), token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var gift map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&gift))
+	assert.Equal(t, "Pottery class", gift["item"])
+	assert.Equal(t, "cooking", gift["category"])
+	assert.Equal(t, float64(90), gift["price"])
+	assert.Equal(t, "2024-12-25", gift["given_on"])
+	assert.Equal(t, float64(5), gift["rating"])
+
+	// Given is final
+	code = moveIdea(t, router, token, recipientID, ideaID, map[string]interface{}{"status": "wrapped"})
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestPromoteSuggestion(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "idea-promote@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana", "keywords": []string{"gaming"}})
+
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas/promote", token,
+		map[string]interface{}{"item_id": "gaming-headset", "priority": "high"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var idea map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&idea))
+	assert.Equal(t, "gaming-headset", idea["catalog_item_id"])
+	assert.Equal(t, "Gaming headset", idea["title"])
+	assert.Equal(t, "gaming", idea["category"])
+	assert.Equal(t, float64(80), idea["price"])
+	assert.Equal(t, "high", idea["priority"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas/promote", token,
+		map[string]interface{}{"item_id": "gaming-headset"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas/promote", token,
+		map[string]interface{}{"item_id": "flying-car"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"item_id": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/ideas/promote", token, map[string]interface{}{})
+	assert.Equal(t, map[string]string{"item_id": "required"}, fieldErrorCodes(t, w))
+
+	// Once given through the board, the item drops out of the suggestions
+	code := moveIdea(t, router, token, recipientID, idea["id"].(string), map[string]interface{}{"status": "given"})
+	require.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, suggestionScores(getSuggestions(t, router, token, recipientID, "?limit=50")), "gaming-headset")
+}
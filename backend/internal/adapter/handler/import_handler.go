@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// ImportHandler handles bulk import HTTP requests.
+type ImportHandler struct {
+	importService port.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler.
+func NewImportHandler(importService port.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// PreviewICS handles POST /api/import/ics.
+func (h *ImportHandler) PreviewICS(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	data, err := readUpload(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	session, err := h.importService.PreviewICS(r.Context(), userID, data)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, session)
+}
+
+// PreviewVCard handles POST /api/import/vcard.
+func (h *ImportHandler) PreviewVCard(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	data, err := readUpload(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	session, err := h.importService.PreviewVCard(r.Context(), userID, data)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, session)
+}
+
+// PreviewSpreadsheet handles POST /api/import/spreadsheet. CSV and XLSX
+// files are told apart by their content.
+func (h *ImportHandler) PreviewSpreadsheet(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	data, err := readUpload(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	session, err := h.importService.PreviewSpreadsheet(r.Context(), userID, data)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, session)
+}
+
+// MapColumns handles PUT /api/import/{id}/mapping.
+func (h *ImportHandler) MapColumns(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	importID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidImportID)
+		return
+	}
+
+	var req domain.MapColumnsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	session, err := h.importService.MapColumns(r.Context(), userID, importID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, session)
+}
+
+// Get handles GET /api/import/{id}.
+func (h *ImportHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	importID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidImportID)
+		return
+	}
+
+	session, err := h.importService.Get(r.Context(), userID, importID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, session)
+}
+
+// Confirm handles POST /api/import/{id}/confirm. An empty body imports every
+// valid item that is not a duplicate.
+func (h *ImportHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	importID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidImportID)
+		return
+	}
+
+	var req domain.ConfirmImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	result, err := h.importService.Confirm(r.Context(), userID, importID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, result)
+}
+
+// readUpload returns an uploaded file, sent either as the "file" field of a
+// multipart form or as the raw request body.
+func readUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, domain.MaxImportFileBytes)
+
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, errUploadTooLarge
+			}
+			return nil, errMissingUpload
+		}
+		defer file.Close()
+		body = file
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, errUploadTooLarge
+		}
+		return nil, errInvalidBody
+	}
+	if len(data) == 0 {
+		return nil, errMissingUpload
+	}
+	return data, nil
+}
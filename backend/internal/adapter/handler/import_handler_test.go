@@ -0,0 +1,457 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/xlsx"
+)
+
+// icsEvents wraps VEVENT bodies, one per string, into a calendar.
+func icsEvents(events ...string) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\n")
+	for _, ev := range events {
+		b.WriteString("BEGIN:VEVENT\r\n" + ev + "END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
+
+func uploadFile(t *testing.T, router http.Handler, token, path, filename, content string) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	part.Write([]byte(content))
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeImport(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var session map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&session))
+	return session
+}
+
+func importItems(session map[string]interface{}) []map[string]interface{} {
+	var items []map[string]interface{}
+	for _, item := range session["items"].([]interface{}) {
+		items = append(items, item.(map[string]interface{}))
+	}
+	return items
+}
+
+var birthdaysICS = icsEvents(
+	"UID:1\r\nDTSTART;VALUE=DATE:19900315\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:Ana Souza's birthday\r\n",
+	"UID:2\r\nDTSTART;VALUE=DATE:19850702\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:🎂 Aniversário de João\r\n",
+	"UID:3\r\nDTSTART;VALUE=DATE:16041201\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:Birthday: Maria\r\n",
+	"UID:4\r\nDTSTART:20260105T090000Z\r\nRRULE:FREQ=WEEKLY\r\nSUMMARY:Team standup\r\n",
+	"UID:5\r\nDTSTART;VALUE=DATE:20010420\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:Bob\r\nCATEGORIES:BIRTHDAY\r\n",
+	"UID:6\r\nDTSTART;VALUE=DATE:19850702\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:joao's birthday\r\n",
+	"UID:7\r\nDTSTART;VALUE=DATE:20150620\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:Wedding anniversary\r\n",
+)
+
+func TestImportICS_PreviewAndConfirm(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-ics@example.com")
+	existing := createRecipient(t, router, token, map[string]interface{}{"name": "Ana Souza", "birthdate": "1990-03-15"})
+
+	w := uploadFile(t, router, token, "/api/import/ics", "birthdays.ics", birthdaysICS)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	session := decodeImport(t, w)
+
+	assert.Equal(t, "ics", session["source"])
+	assert.Equal(t, map[string]interface{}{
+		"total": float64(5), "new": float64(3), "duplicates": float64(2), "invalid": float64(0),
+	}, session["summary"])
+
+	items := importItems(session)
+	names := []string{}
+	for _, item := range items {
+		names = append(names, item["recipient"].(map[string]interface{})["name"].(string))
+	}
+	assert.Equal(t, []string{"Ana Souza", "João", "Maria", "Bob", "joao"}, names)
+
+	assert.Equal(t, existing, items[0]["duplicate_of"])
+	assert.Equal(t, "1985-07-02", items[1]["recipient"].(map[string]interface{})["birthdate"])
+	assert.Nil(t, items[2]["recipient"].(map[string]interface{})["birthdate"])
+	assert.Equal(t, []interface{}{"birth_year_unknown"}, items[2]["warnings"])
+	assert.Nil(t, items[4]["duplicate_of"], "in-file duplicates have no existing recipient")
+	assert.Equal(t, []interface{}{"duplicate"}, items[4]["warnings"])
+
+	// Nothing is created until the preview is confirmed
+	assert.Len(t, recipientNames(listRecipients(t, router, token, "")), 1)
+
+	importPath := "/api/import/" + session["id"].(string)
+	w = doJSON(t, router, http.MethodPost, importPath+"/confirm", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(t, float64(3), result["created"])
+	assert.Equal(t, float64(2), result["skipped"])
+
+	page := listRecipients(t, router, token, "?sort=name")
+	assert.Equal(t, []string{"Ana Souza", "Bob", "João", "Maria"}, recipientNames(page))
+
+	w = doJSON(t, router, http.MethodPost, importPath+"/confirm", token, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = doJSON(t, router, http.MethodGet, importPath, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotNil(t, decodeImport(t, w)["applied_at"])
+}
+
+func TestImportICS_ConfirmSelectedIndexes(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-ics-select@example.com")
+	createRecipient(t, router, token, map[string]interface{}{"name": "Ana Souza"})
+
+	// Raw text/calendar bodies are accepted as well as multipart uploads
+	req := httptest.NewRequest(http.MethodPost, "/api/import/ics", strings.NewReader(birthdaysICS))
+	req.Header.Set("Content-Type", "text/calendar")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	importPath := "/api/import/" + decodeImport(t, w)["id"].(string)
+
+	w = doJSON(t, router, http.MethodPost, importPath+"/confirm", token, map[string]interface{}{"indexes": []int{0, 99}})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"indexes[1]": "out_of_range"}, fieldErrorCodes(t, w))
+
+	// Explicitly selected duplicates are imported
+	w = doJSON(t, router, http.MethodPost, importPath+"/confirm", token, map[string]interface{}{"indexes": []int{0, 3}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	page := listRecipients(t, router, token, "?sort=name")
+	assert.Equal(t, []string{"Ana Souza", "Ana Souza", "Bob"}, recipientNames(page))
+}
+
+func TestImportICS_Errors(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	owner := registerAndGetToken(t, router, "import-owner@example.com")
+	other := registerAndGetToken(t, router, "import-other@example.com")
+
+	w := uploadFile(t, router, owner, "/api/import/ics", "notes.txt", "just some notes")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "/problems/invalid_import_file", decodeProblem(t, w)["type"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/import/ics", owner, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = uploadFile(t, router, owner, "/api/import/ics", "birthdays.ics", birthdaysICS)
+	require.Equal(t, http.StatusCreated, w.Code)
+	importPath := "/api/import/" + decodeImport(t, w)["id"].(string)
+
+	w = doJSON(t, router, http.MethodGet, importPath, other, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodPost, importPath+"/confirm", other, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+const contactsVCF = "BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"N:Souza;Ana;;;\r\n" +
+	"FN:Ana Souza\r\n" +
+	"BDAY:1990-03-15\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:4.0\r\n" +
+	"N:Silva;João;;;\r\n" +
+	"GENDER:M\r\n" +
+	"BDAY:19850702\r\n" +
+	"ANNIVERSARY:20150620\r\n" +
+	"CATEGORIES:Video games,books\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"FN:Maria\r\n" +
+	"BDAY;X-APPLE-OMIT-YEAR=1604:1604-12-01\r\n" +
+	"X-ANNIVERSARY:--0505\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"FN:\r\n" +
+	"END:VCARD\r\n"
+
+func TestImportVCard_PreviewAndConfirm(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-vcard@example.com")
+	createRecipient(t, router, token, map[string]interface{}{"name": "Ana Souza", "birthdate": "1990-03-15"})
+
+	w := uploadFile(t, router, token, "/api/import/vcard", "contacts.vcf", contactsVCF)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	session := decodeImport(t, w)
+
+	assert.Equal(t, "vcard", session["source"])
+	assert.Equal(t, map[string]interface{}{
+		"total": float64(4), "new": float64(2), "duplicates": float64(1), "invalid": float64(1),
+	}, session["summary"])
+
+	items := importItems(session)
+	joao := items[1]["recipient"].(map[string]interface{})
+	assert.Equal(t, "João Silva", joao["name"])
+	assert.Equal(t, "male", joao["gender"])
+	assert.Equal(t, "1985-07-02", joao["birthdate"])
+	assert.Equal(t, []interface{}{"gaming", "reading"}, joao["keywords"], "mapped onto the taxonomy")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"kind": "anniversary", "title": "", "date": "2015-06-20", "recurring": nil, "budget": nil,
+	}}, items[1]["occasions"])
+
+	assert.Equal(t, []interface{}{"birth_year_unknown", "anniversary_year_unknown"}, items[2]["warnings"])
+	assert.Nil(t, items[2]["occasions"])
+	assert.NotEmpty(t, items[3]["errors"], "cards without a name are invalid")
+
+	w = doJSON(t, router, http.MethodPost, "/api/import/"+session["id"].(string)+"/confirm", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(t, float64(2), result["created"])
+
+	page := listRecipients(t, router, token, "?sort=name")
+	assert.Equal(t, []string{"Ana Souza", "João Silva", "Maria"}, recipientNames(page))
+
+	// Categories go through the keyword taxonomy and anniversaries become occasions
+	joaoID := result["recipient_ids"].([]interface{})[0].(string)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+joaoID, token, nil)
+	var recipient map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&recipient))
+	assert.Equal(t, []interface{}{"gaming", "reading"}, recipient["keywords"])
+
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+joaoID+"/occasions", token, nil)
+	var occasions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&occasions))
+	require.Len(t, occasions, 1)
+	assert.Equal(t, "anniversary", occasions[0]["kind"])
+	assert.Equal(t, "2015-06-20", occasions[0]["date"])
+}
+
+func TestImportVCard_InvalidFile(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-vcard-invalid@example.com")
+
+	w := uploadFile(t, router, token, "/api/import/vcard", "birthdays.ics", birthdaysICS)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "/problems/invalid_import_file", decodeProblem(t, w)["type"])
+}
+
+const recipientsCSV = "\xef\xbb\xbfNome;Data de nascimento;Gender;Relationship;Interests;Budget;Notes\n" +
+	"Ana Souza;15/03/1990;F;best friend;books, games | cooking;R$ 150,00;\n" +
+	"João;02/07/1985;masculino;;;80;likes jazz\n" +
+	"Maria;31/02/1990;;;;;\n" +
+	";01/01/2000;;;;;\n" +
+	"Bob;;;;gaming;lots;\n"
+
+func TestImportSpreadsheet_CSVPreviewAndConfirm(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-csv@example.com")
+
+	w := uploadFile(t, router, token, "/api/import/spreadsheet", "recipients.csv", recipientsCSV)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	session := decodeImport(t, w)
+
+	assert.Equal(t, "csv", session["source"])
+	assert.Equal(t, []interface{}{"Nome", "Data de nascimento", "Gender", "Relationship", "Interests", "Budget", "Notes"}, session["columns"])
+	assert.Equal(t, map[string]interface{}{
+		"name": "Nome", "birthdate": "Data de nascimento", "gender": "Gender",
+		"relationship": "Relationship", "keywords": "Interests", "max_budget": "Budget",
+	}, session["mapping"])
+	assert.Equal(t, "DD/MM/YYYY", session["date_format"], "15/03 rules out month-first dates")
+	assert.Equal(t, map[string]interface{}{
+		"total": float64(5), "new": float64(2), "duplicates": float64(0), "invalid": float64(3),
+	}, session["summary"])
+
+	items := importItems(session)
+	ana := items[0]["recipient"].(map[string]interface{})
+	assert.Equal(t, float64(2), items[0]["row"])
+	assert.Equal(t, "1990-03-15", ana["birthdate"])
+	assert.Equal(t, "female", ana["gender"])
+	assert.Equal(t, "best_friend", ana["relationship"])
+	assert.Equal(t, []interface{}{"reading", "gaming", "cooking"}, ana["keywords"], "mapped onto the taxonomy")
+	assert.Equal(t, float64(150), ana["max_budget"])
+
+	assert.Equal(t, float64(3), items[1]["row"])
+	assert.Equal(t, "male", items[1]["recipient"].(map[string]interface{})["gender"])
+
+	itemErrors := func(item map[string]interface{}) map[string]string {
+		codes := map[string]string{}
+		for _, e := range item["errors"].([]interface{}) {
+			fe := e.(map[string]interface{})
+			codes[fe["field"].(string)] = fe["code"].(string)
+		}
+		return codes
+	}
+	assert.Equal(t, map[string]string{"birthdate": "invalid_format"}, itemErrors(items[2]))
+	assert.Equal(t, map[string]string{"name": "required"}, itemErrors(items[3]))
+	assert.Equal(t, map[string]string{"max_budget": "invalid_format"}, itemErrors(items[4]))
+
+	w = doJSON(t, router, http.MethodPost, "/api/import/"+session["id"].(string)+"/confirm", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(t, float64(2), result["created"])
+	assert.Equal(t, float64(3), result["skipped"])
+
+	page := listRecipients(t, router, token, "?sort=name")
+	assert.Equal(t, []string{"Ana Souza", "João"}, recipientNames(page))
+
+	// Bulk-created recipients still get their first history version
+	anaID := result["recipient_ids"].([]interface{})[0].(string)
+	w = doJSON(t, router, http.MethodGet, "/api/recipients/"+anaID+"/history", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var history []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&history))
+	require.Len(t, history, 1)
+	assert.Equal(t, "create", history[0]["action"])
+}
+
+func TestImportSpreadsheet_PreviewCanonicalizesKeywords(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-keywords@example.com")
+
+	// More keywords than a recipient may have, but they are all synonyms
+	synonyms := []string{"games", "videogames", "jogos"}
+	var keywords []string
+	for i := 0; i < 21; i++ {
+		keywords = append(keywords, synonyms[i%len(synonyms)])
+	}
+	csv := "Name;Interests\nAna;" + strings.Join(keywords, ", ") + "\n"
+
+	w := uploadFile(t, router, token, "/api/import/spreadsheet", "recipients.csv", csv)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	session := decodeImport(t, w)
+	items := importItems(session)
+	require.Len(t, items, 1)
+	assert.Empty(t, items[0]["errors"])
+	assert.Equal(t, []interface{}{"gaming"}, items[0]["recipient"].(map[string]interface{})["keywords"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/import/"+session["id"].(string)+"/confirm", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"created":1`)
+}
+
+func TestImportSpreadsheet_MapColumns(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-mapping@example.com")
+
+	csvData := "Person,When,Likes\nAna,03/15/1990,reading\nJoão,07/02/1985,gaming\n"
+	w := uploadFile(t, router, token, "/api/import/spreadsheet", "people.csv", csvData)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	session := decodeImport(t, w)
+	assert.Equal(t, map[string]interface{}{"name": "Person"}, session["mapping"])
+	assert.Nil(t, importItems(session)[0]["recipient"].(map[string]interface{})["birthdate"])
+
+	mappingPath := "/api/import/" + session["id"].(string) + "/mapping"
+	w = doJSON(t, router, http.MethodPut, mappingPath, token, map[string]interface{}{
+		"mapping": map[string]string{"name": "Person", "birthdate": "When", "keywords": "Likes"},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	session = decodeImport(t, w)
+	assert.Equal(t, "MM/DD/YYYY", session["date_format"], "detected once the birthdate column is mapped")
+	items := importItems(session)
+	assert.Equal(t, "1990-03-15", items[0]["recipient"].(map[string]interface{})["birthdate"])
+	assert.Equal(t, []interface{}{"gaming"}, items[1]["recipient"].(map[string]interface{})["keywords"])
+
+	// Switching to day-first dates makes "03/15/1990" unreadable
+	w = doJSON(t, router, http.MethodPut, mappingPath, token, map[string]interface{}{
+		"mapping":     map[string]string{"name": "Person", "birthdate": "When"},
+		"date_format": "DD/MM/YYYY",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	session = decodeImport(t, w)
+	assert.Equal(t, float64(1), session["summary"].(map[string]interface{})["invalid"])
+	assert.Equal(t, "1985-02-07", importItems(session)[1]["recipient"].(map[string]interface{})["birthdate"])
+
+	w = doJSON(t, router, http.MethodPut, mappingPath, token, map[string]interface{}{
+		"mapping":     map[string]string{"birthdate": "Missing", "shoe_size": "Likes"},
+		"date_format": "YYYY/DD/MM",
+	})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"date_format": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPut, mappingPath, token, map[string]interface{}{
+		"mapping": map[string]string{"birthdate": "Missing", "shoe_size": "Likes"},
+	})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"mapping.name": "required", "mapping.birthdate": "invalid_choice", "mapping.shoe_size": "invalid_choice",
+	}, fieldErrorCodes(t, w))
+
+	// The confirmed import uses the latest mapping; afterwards it is frozen
+	w = doJSON(t, router, http.MethodPost, "/api/import/"+session["id"].(string)+"/confirm", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"João"}, recipientNames(listRecipients(t, router, token, "")))
+	w = doJSON(t, router, http.MethodPut, mappingPath, token, map[string]interface{}{
+		"mapping": map[string]string{"name": "Person"},
+	})
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestImportSpreadsheet_XLSX(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-xlsx@example.com")
+
+	var buf bytes.Buffer
+	require.NoError(t, xlsx.Write(&buf, "People", [][]any{
+		{"Name", "Age", "Birthday", "Min budget", "Max budget"},
+		{"Ana", 36, "1990-03-15", 20, 99.5},
+		{"Bob", "thirty", "", "", ""},
+	}))
+
+	w := uploadFile(t, router, token, "/api/import/spreadsheet", "people.xlsx", buf.String())
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	session := decodeImport(t, w)
+	assert.Equal(t, "xlsx", session["source"])
+
+	items := importItems(session)
+	ana := items[0]["recipient"].(map[string]interface{})
+	assert.Equal(t, float64(36), ana["age"])
+	assert.Equal(t, float64(20), ana["min_budget"])
+	assert.Equal(t, float64(99.5), ana["max_budget"])
+	assert.Equal(t, "age", items[1]["errors"].([]interface{})[0].(map[string]interface{})["field"])
+}
+
+func TestImportSpreadsheet_Errors(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "import-sheet-errors@example.com")
+
+	for name, content := range map[string]string{
+		"empty.csv":   "\n\n",
+		"latin1.csv":  "Name\nJo\xe3o\n",
+		"broken.xlsx": "PK\x03\x04 not really a zip",
+	} {
+		w := uploadFile(t, router, token, "/api/import/spreadsheet", name, content)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+
+	var rows strings.Builder
+	rows.WriteString("name\n")
+	for i := 0; i < 1001; i++ {
+		fmt.Fprintf(&rows, "Person %d\n", i)
+	}
+	w := uploadFile(t, router, token, "/api/import/spreadsheet", "many.csv", rows.String())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	// Only spreadsheet imports can be remapped
+	w = uploadFile(t, router, token, "/api/import/ics", "birthdays.ics", birthdaysICS)
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = doJSON(t, router, http.MethodPut, "/api/import/"+decodeImport(t, w)["id"].(string)+"/mapping", token,
+		map[string]interface{}{"mapping": map[string]string{"name": "Name"}})
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
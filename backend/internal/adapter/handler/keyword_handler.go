@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// KeywordHandler handles interest taxonomy HTTP requests.
+type KeywordHandler struct {
+	keywordService port.KeywordService
+}
+
+// NewKeywordHandler creates a new KeywordHandler.
+func NewKeywordHandler(keywordService port.KeywordService) *KeywordHandler {
+	return &KeywordHandler{keywordService: keywordService}
+}
+
+// Suggest handles GET /api/keywords?prefix=.
+func (h *KeywordHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+	query := r.URL.Query()
+
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "limit must be an integer")
+			return
+		}
+		limit = n
+	}
+
+	suggestions, err := h.keywordService.Suggest(r.Context(), userID, query.Get("prefix"), query.Get("locale"), limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,110 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func suggestKeywords(t *testing.T, router http.Handler, token, query string) []map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/keywords"+query, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var suggestions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&suggestions))
+	return suggestions
+}
+
+func suggestionSlugs(suggestions []map[string]interface{}) []string {
+	slugs := []string{}
+	for _, s := range suggestions {
+		slugs = append(slugs, s["slug"].(string))
+	}
+	return slugs
+}
+
+func TestSuggestKeywords(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "keywords@example.com")
+
+	suggestions := suggestKeywords(t, router, token, "?prefix=gam")
+	assert.Equal(t, []string{"board-games", "gaming"}, suggestionSlugs(suggestions))
+	assert.Equal(t, "Video games", suggestions[1]["label"])
+	assert.Equal(t, "entertainment", suggestions[1]["category"])
+	assert.Equal(t, "Entertainment", suggestions[1]["category_label"])
+
+	// Synonyms match after label matches
+	assert.Equal(t, []string{"reading"}, suggestionSlugs(suggestKeywords(t, router, token, "?prefix=BOO")))
+
+	// Labels follow the requested locale and ignore accents
+	suggestions = suggestKeywords(t, router, token, "?prefix=culi&locale=pt-BR")
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "Culinária", suggestions[0]["label"])
+
+	assert.Empty(t, suggestKeywords(t, router, token, "?prefix="))
+	assert.Len(t, suggestKeywords(t, router, token, "?prefix=g&limit=1"), 1)
+}
+
+func TestSuggestKeywords_UsesPreferredLocale(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "keywords-locale@example.com")
+
+	body, _ := json.Marshal(map[string]interface{}{"locale": "pt-BR"})
+	req := httptest.NewRequest(http.MethodPut, "/api/me/preferences", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	suggestions := suggestKeywords(t, router, token, "?prefix=jog")
+	assert.Equal(t, []string{"board-games", "gaming"}, suggestionSlugs(suggestions))
+	assert.Equal(t, "Jogos de tabuleiro", suggestions[0]["label"])
+	assert.Equal(t, "Entretenimento", suggestions[0]["category_label"])
+}
+
+func TestSuggestKeywords_InvalidLimit(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "keywords-limit@example.com")
+
+	for _, query := range []string{"?prefix=a&limit=x", "?prefix=a&limit=51"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/keywords"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestCreateRecipient_CanonicalKeywords(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "keywords-canonical@example.com")
+
+	id := createRecipient(t, router, token, map[string]interface{}{
+		"name":     "Lucas",
+		"keywords": []string{"Games", "videogames", "Livros", "  Knitting  Club "},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/recipients/"+id, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var recipient map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&recipient))
+	assert.Equal(t, []interface{}{"gaming", "reading", "knitting club"}, recipient["keywords"])
+
+	// Filters are canonicalized the same way
+	page := listRecipients(t, router, token, "?keywords=Video-Games,books")
+	assert.Equal(t, []string{"Lucas"}, recipientNames(page))
+}
@@ -6,8 +6,8 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
 	jwtpkg "github.com/vsssp/birthday-app/backend/internal/pkg/jwt"
-	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
 )
 
 type contextKey string
@@ -32,19 +32,41 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		header := r.Header.Get("Authorization")
 		if header == "" {
-			response.Error(w, http.StatusUnauthorized, "missing authorization header")
+			writeError(w, r, domain.ErrUnauthorized.WithDetail("missing authorization header"))
 			return
 		}
 
 		parts := strings.SplitN(header, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Error(w, http.StatusUnauthorized, "invalid authorization format")
+			writeError(w, r, domain.ErrUnauthorized.WithDetail("invalid authorization format"))
 			return
 		}
 
 		claims, err := m.jwtService.ValidateAccessToken(parts[1])
 		if err != nil {
-			response.Error(w, http.StatusUnauthorized, "invalid or expired token")
+			writeError(w, r, domain.ErrUnauthorized.WithDetail("invalid or expired token"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Identify is Authenticate for public routes: a valid Bearer token
+// identifies the caller, while requests without one, or with an invalid
+// one, go through anonymously.
+func (m *AuthMiddleware) Identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		claims, err := m.jwtService.ValidateAccessToken(token)
+		if err != nil {
+			next.ServeHTTP(w, r)
 			return
 		}
 
@@ -2,6 +2,11 @@ package handler_test
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -159,6 +164,10 @@ func (r *mockRefreshTokenRepo) DeleteExpired(_ context.Context) error {
 type mockRecipientRepo struct {
 	mu         sync.RWMutex
 	recipients map[uuid.UUID]*domain.Recipient
+	groups     *mockGroupRepo
+	// beforeUpdate, when set, runs at the start of Update so tests can
+	// commit a concurrent write.
+	beforeUpdate func(id uuid.UUID)
 }
 
 func newMockRecipientRepo() *mockRecipientRepo {
@@ -172,58 +181,1746 @@ func (r *mockRecipientRepo) Create(_ context.Context, rec *domain.Recipient) err
 	return nil
 }
 
+func (r *mockRecipientRepo) CreateMany(_ context.Context, recs []domain.Recipient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range recs {
+		c := recs[i]
+		r.recipients[c.ID] = &c
+	}
+	return nil
+}
+
 func (r *mockRecipientRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Recipient, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	rec, ok := r.recipients[id]
-	if !ok {
+	if !ok || rec.DeletedAt != nil {
+		return nil, nil
+	}
+	c := *rec
+	return &c, nil
+}
+
+func (r *mockRecipientRepo) GetDeletedByID(_ context.Context, id uuid.UUID) (*domain.Recipient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rec, ok := r.recipients[id]
+	if !ok || rec.DeletedAt == nil {
 		return nil, nil
 	}
 	return rec, nil
 }
 
-func (r *mockRecipientRepo) ListByUserID(_ context.Context, userID uuid.UUID) ([]domain.Recipient, error) {
+func (r *mockRecipientRepo) ListByOwners(_ context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	var result []domain.Recipient
 	for _, rec := range r.recipients {
-		if rec.UserID == userID {
+		if slices.Contains(ownerIDs, rec.UserID) && rec.DeletedAt == nil {
 			result = append(result, *rec)
 		}
 	}
 	return result, nil
 }
 
+func (r *mockRecipientRepo) ListPage(_ context.Context, ownerIDs []uuid.UUID, q domain.RecipientQuery) ([]domain.Recipient, *domain.RecipientCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// converted expresses a budget in q.Currency, as the SQL does.
+	converted := func(m domain.Money, currency string) (domain.Money, bool) {
+		rate, ok := q.BudgetRates[currency]
+		return domain.Money(math.Round(float64(m) * rate)), ok
+	}
+	sortKey := func(rec domain.Recipient) string {
+		switch q.Sort {
+		case domain.RecipientSortName:
+			return strings.ToLower(rec.Name)
+		case domain.RecipientSortAge:
+			return fmt.Sprintf("%010d", rec.Age)
+		case domain.RecipientSortBudget:
+			// Shifted by one so budgets that cannot be converted sort lowest.
+			budget, ok := converted(rec.MaxBudget, rec.Currency)
+			if !ok {
+				budget = -1
+			}
+			return fmt.Sprintf("%020d", budget+1)
+		case domain.RecipientSortNextBirthday:
+			if rec.Birthdate == nil {
+				return "100000"
+			}
+			return fmt.Sprintf("%06d", rec.Birthdate.NextAnniversary(q.Today).DaysUntil(q.Today))
+		default:
+			return rec.CreatedAt.Format(time.RFC3339Nano)
+		}
+	}
+	less := func(ka string, ida uuid.UUID, kb string, idb uuid.UUID) bool {
+		if ka != kb {
+			return ka < kb
+		}
+		return ida.String() < idb.String()
+	}
+
+	var result []domain.Recipient
+	for _, rec := range r.recipients {
+		if !slices.Contains(ownerIDs, rec.UserID) || rec.DeletedAt != nil {
+			continue
+		}
+		if q.Gender != "" && rec.Gender != q.Gender {
+			continue
+		}
+		if (q.MinAge != nil && rec.Age < *q.MinAge) || (q.MaxAge != nil && rec.Age > *q.MaxAge) {
+			continue
+		}
+		if q.BudgetMin != nil {
+			if top, ok := converted(rec.MaxBudget, rec.Currency); !ok || top < *q.BudgetMin {
+				continue
+			}
+		}
+		if q.BudgetMax != nil {
+			if bottom, ok := converted(rec.MinBudget, rec.Currency); !ok || bottom > *q.BudgetMax {
+				continue
+			}
+		}
+		if !containsAll(rec.Keywords, q.Keywords) {
+			continue
+		}
+		if q.GroupID != nil && !r.groups.isMember(*q.GroupID, rec.ID) {
+			continue
+		}
+		if q.After != nil {
+			key := sortKey(*rec)
+			if q.Desc && !less(key, rec.ID, q.After.Key, q.After.ID) {
+				continue
+			}
+			if !q.Desc && !less(q.After.Key, q.After.ID, key, rec.ID) {
+				continue
+			}
+		}
+		result = append(result, *rec)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if q.Desc {
+			return less(sortKey(b), b.ID, sortKey(a), a.ID)
+		}
+		return less(sortKey(a), a.ID, sortKey(b), b.ID)
+	})
+
+	if len(result) <= q.Limit {
+		return result, nil, nil
+	}
+	result = result[:q.Limit]
+	last := result[q.Limit-1]
+	return result, &domain.RecipientCursor{Sort: q.Sort, Desc: q.Desc, Key: sortKey(last), ID: last.ID}, nil
+}
+
+func (r *mockRecipientRepo) Search(_ context.Context, ownerIDs []uuid.UUID, term string, limit int) ([]domain.RecipientSearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	term = foldAccents(strings.ToLower(term))
+	var results []domain.RecipientSearchResult
+	for _, rec := range r.recipients {
+		if !slices.Contains(ownerIDs, rec.UserID) || rec.DeletedAt != nil {
+			continue
+		}
+		text := foldAccents(strings.ToLower(rec.Name + " " + strings.Join(rec.Keywords, " ")))
+		score := trigramSimilarity(foldAccents(strings.ToLower(rec.Name)), term)
+		for _, word := range strings.Fields(text) {
+			score = max(score, trigramSimilarity(word, term))
+		}
+		if score < 0.3 && !strings.Contains(text, term) {
+			continue
+		}
+		results = append(results, domain.RecipientSearchResult{Recipient: *rec, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+var accentFolder = strings.NewReplacer("á", "a", "à", "a", "â", "a", "ã", "a", "é", "e", "ê", "e", "í", "i", "ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c")
+
+func foldAccents(s string) string {
+	return accentFolder.Replace(s)
+}
+
+// trigramSimilarity approximates pg_trgm's similarity() for the in-memory repo.
+func trigramSimilarity(a, b string) float64 {
+	trigrams := func(s string) map[string]bool {
+		padded := []rune("  " + s + " ")
+		set := make(map[string]bool)
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+		return set
+	}
+	ta, tb := trigrams(a), trigrams(b)
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	union := len(ta) + len(tb) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+func containsAll(haystack, needles []string) bool {
+	for _, n := range needles {
+		if !slices.Contains(haystack, n) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *mockRecipientRepo) Update(_ context.Context, rec *domain.Recipient) error {
+	if r.beforeUpdate != nil {
+		r.beforeUpdate(rec.ID)
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.recipients[rec.ID] = rec
+	if stored, ok := r.recipients[rec.ID]; ok && stored.Version != rec.Version {
+		return domain.ErrVersionConflict
+	}
+	rec.Version++
+	c := *rec
+	r.recipients[rec.ID] = &c
 	return nil
 }
 
 func (r *mockRecipientRepo) Delete(_ context.Context, id uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	delete(r.recipients, id)
+	if rec, ok := r.recipients[id]; ok && rec.DeletedAt == nil {
+		now := time.Now()
+		rec.DeletedAt = &now
+		rec.Version++
+	}
 	return nil
 }
 
-func (r *mockRecipientRepo) BulkDelete(_ context.Context, _ uuid.UUID, ids []uuid.UUID) error {
+func (r *mockRecipientRepo) BulkDelete(_ context.Context, ids []uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	now := time.Now()
 	for _, id := range ids {
-		delete(r.recipients, id)
+		if rec, ok := r.recipients[id]; ok && rec.DeletedAt == nil {
+			rec.DeletedAt = &now
+			rec.Version++
+		}
 	}
 	return nil
 }
 
-// mockSocialVerifier implements port.SocialVerifier.
-type mockSocialVerifier struct{}
+func (r *mockRecipientRepo) ListDeleted(_ context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.Recipient
+	for _, rec := range r.recipients {
+		if slices.Contains(ownerIDs, rec.UserID) && rec.DeletedAt != nil {
+			result = append(result, *rec)
+		}
+	}
+	return result, nil
+}
 
-func (v *mockSocialVerifier) VerifyGoogleToken(_ context.Context, _ string) (string, string, string, error) {
-	return "google@example.com", "Google User", "google-sub-123", nil
+func (r *mockRecipientRepo) Restore(_ context.Context, id uuid.UUID, updatedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.recipients[id]
+	if !ok {
+		return 0, nil
+	}
+	rec.DeletedAt = nil
+	rec.UpdatedAt = updatedAt
+	rec.Version++
+	return rec.Version, nil
 }
 
-func (v *mockSocialVerifier) VerifyAppleToken(_ context.Context, _ string) (string, string, error) {
-	return "apple@example.com", "apple-sub-123", nil
+func (r *mockRecipientRepo) PurgeDeleted(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var purged int64
+	for id, rec := range r.recipients {
+		if rec.DeletedAt != nil && rec.DeletedAt.Before(before) {
+			delete(r.recipients, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// mockPreferencesRepo implements port.PreferencesRepository in memory.
+type mockPreferencesRepo struct {
+	mu    sync.RWMutex
+	prefs map[uuid.UUID]*domain.UserPreferences
+}
+
+func newMockPreferencesRepo() *mockPreferencesRepo {
+	return &mockPreferencesRepo{prefs: make(map[uuid.UUID]*domain.UserPreferences)}
+}
+
+func (r *mockPreferencesRepo) GetByUserID(_ context.Context, userID uuid.UUID) (*domain.UserPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.prefs[userID]
+	if !ok {
+		return nil, nil
+	}
+	return p, nil
+}
+
+func (r *mockPreferencesRepo) Upsert(_ context.Context, prefs *domain.UserPreferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefs[prefs.UserID] = prefs
+	return nil
+}
+
+// mockRecipientHistoryRepo implements port.RecipientHistoryRepository in memory.
+type mockRecipientHistoryRepo struct {
+	mu       sync.RWMutex
+	versions map[uuid.UUID][]domain.RecipientVersion
+}
+
+func newMockRecipientHistoryRepo() *mockRecipientHistoryRepo {
+	return &mockRecipientHistoryRepo{versions: make(map[uuid.UUID][]domain.RecipientVersion)}
+}
+
+func (r *mockRecipientHistoryRepo) Create(_ context.Context, v *domain.RecipientVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v.Version = len(r.versions[v.RecipientID]) + 1
+	r.versions[v.RecipientID] = append(r.versions[v.RecipientID], *v)
+	return nil
+}
+
+func (r *mockRecipientHistoryRepo) CreateMany(_ context.Context, versions []domain.RecipientVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range versions {
+		versions[i].Version = 1
+		r.versions[versions[i].RecipientID] = []domain.RecipientVersion{versions[i]}
+	}
+	return nil
+}
+
+func (r *mockRecipientHistoryRepo) ListByRecipientID(_ context.Context, recipientID uuid.UUID) ([]domain.RecipientVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := slices.Clone(r.versions[recipientID])
+	slices.Reverse(versions)
+	return versions, nil
+}
+
+func (r *mockRecipientHistoryRepo) GetByVersion(_ context.Context, recipientID uuid.UUID, version int) (*domain.RecipientVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, v := range r.versions[recipientID] {
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+// mockKeywordRepo implements port.KeywordRepository with a small fixed taxonomy.
+type mockKeywordRepo struct{}
+
+func newMockKeywordRepo() *mockKeywordRepo {
+	return &mockKeywordRepo{}
+}
+
+func (r *mockKeywordRepo) ListInterests(_ context.Context) ([]domain.Interest, error) {
+	entertainment, learning := "entertainment", "learning"
+	return []domain.Interest{
+		{Slug: "entertainment", Labels: map[string]string{"en-US": "Entertainment", "pt-BR": "Entretenimento"}},
+		{Slug: "learning", Labels: map[string]string{"en-US": "Learning", "pt-BR": "Aprendizado"}},
+		{Slug: "gaming", ParentSlug: &entertainment,
+			Labels:   map[string]string{"en-US": "Video games", "pt-BR": "Videogames"},
+			Synonyms: []string{"games", "videogames", "jogos"}},
+		{Slug: "board-games", ParentSlug: &entertainment,
+			Labels:   map[string]string{"en-US": "Board games", "pt-BR": "Jogos de tabuleiro"},
+			Synonyms: []string{"boardgames", "tabletop"}},
+		{Slug: "reading", ParentSlug: &learning,
+			Labels:   map[string]string{"en-US": "Reading", "pt-BR": "Leitura"},
+			Synonyms: []string{"books", "livros"}},
+		{Slug: "cooking", Labels: map[string]string{"en-US": "Cooking", "pt-BR": "Culinária"}},
+	}, nil
+}
+
+// mockGroupRepo implements port.GroupRepository in memory.
+type mockGroupRepo struct {
+	mu         sync.RWMutex
+	groups     map[uuid.UUID]*domain.Group
+	members    map[uuid.UUID]map[uuid.UUID]bool
+	recipients *mockRecipientRepo
+}
+
+func newMockGroupRepo() *mockGroupRepo {
+	return &mockGroupRepo{
+		groups:  make(map[uuid.UUID]*domain.Group),
+		members: make(map[uuid.UUID]map[uuid.UUID]bool),
+	}
+}
+
+func (r *mockGroupRepo) nameTaken(g *domain.Group) bool {
+	for _, other := range r.groups {
+		if other.ID != g.ID && other.UserID == g.UserID && strings.EqualFold(other.Name, g.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *mockGroupRepo) Create(_ context.Context, g *domain.Group) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.nameTaken(g) {
+		return domain.ErrAlreadyExists
+	}
+	c := *g
+	r.groups[g.ID] = &c
+	return nil
+}
+
+func (r *mockGroupRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.groups[id]
+	if !ok {
+		return nil, nil
+	}
+	return r.withCount(ctx, *g), nil
+}
+
+func (r *mockGroupRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Group, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.Group
+	for _, g := range r.groups {
+		if g.UserID == userID {
+			result = append(result, *r.withCount(ctx, *g))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result, nil
+}
+
+// withCount fills MemberCount with the group's members that are not in the trash.
+func (r *mockGroupRepo) withCount(ctx context.Context, g domain.Group) *domain.Group {
+	g.MemberCount = 0
+	for id := range r.members[g.ID] {
+		if rec, _ := r.recipients.GetByID(ctx, id); rec != nil {
+			g.MemberCount++
+		}
+	}
+	return &g
+}
+
+func (r *mockGroupRepo) Update(_ context.Context, g *domain.Group) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.nameTaken(g) {
+		return domain.ErrAlreadyExists
+	}
+	c := *g
+	r.groups[g.ID] = &c
+	return nil
+}
+
+func (r *mockGroupRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.groups, id)
+	delete(r.members, id)
+	return nil
+}
+
+func (r *mockGroupRepo) AddMembers(_ context.Context, groupID uuid.UUID, recipientIDs []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.members[groupID] == nil {
+		r.members[groupID] = make(map[uuid.UUID]bool)
+	}
+	for _, id := range recipientIDs {
+		r.members[groupID][id] = true
+	}
+	return nil
+}
+
+func (r *mockGroupRepo) RemoveMember(_ context.Context, groupID, recipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.members[groupID], recipientID)
+	return nil
+}
+
+func (r *mockGroupRepo) ReassignMembers(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, members := range r.members {
+		if members[fromRecipientID] {
+			delete(members, fromRecipientID)
+			members[toRecipientID] = true
+		}
+	}
+	return nil
+}
+
+func (r *mockGroupRepo) ListMemberships(_ context.Context, userID uuid.UUID) ([]domain.GroupMembership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.GroupMembership
+	for groupID, members := range r.members {
+		if g, ok := r.groups[groupID]; !ok || g.UserID != userID {
+			continue
+		}
+		for recipientID := range members {
+			result = append(result, domain.GroupMembership{GroupID: groupID, RecipientID: recipientID})
+		}
+	}
+	return result, nil
+}
+
+func (r *mockGroupRepo) isMember(groupID, recipientID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.members[groupID][recipientID]
+}
+
+// mockOccasionRepo implements port.OccasionRepository in memory.
+type mockOccasionRepo struct {
+	mu         sync.RWMutex
+	occasions  map[uuid.UUID]*domain.Occasion
+	recipients *mockRecipientRepo
+}
+
+func newMockOccasionRepo(recipients *mockRecipientRepo) *mockOccasionRepo {
+	return &mockOccasionRepo{occasions: make(map[uuid.UUID]*domain.Occasion), recipients: recipients}
+}
+
+func (r *mockOccasionRepo) Create(_ context.Context, o *domain.Occasion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *o
+	r.occasions[o.ID] = &c
+	return nil
+}
+
+func (r *mockOccasionRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Occasion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, ok := r.occasions[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *o
+	return &c, nil
+}
+
+func (r *mockOccasionRepo) list(keep func(o *domain.Occasion) bool) []domain.Occasion {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.Occasion
+	for _, o := range r.occasions {
+		if keep(o) {
+			result = append(result, *o)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date.Time) })
+	return result
+}
+
+func (r *mockOccasionRepo) ListByRecipientID(_ context.Context, recipientID uuid.UUID) ([]domain.Occasion, error) {
+	return r.list(func(o *domain.Occasion) bool { return o.RecipientID == recipientID }), nil
+}
+
+func (r *mockOccasionRepo) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Occasion, error) {
+	recipients, _ := r.recipients.ListByOwners(ctx, ownerIDs)
+	owned := make(map[uuid.UUID]bool, len(recipients))
+	for _, rec := range recipients {
+		owned[rec.ID] = true
+	}
+	return r.list(func(o *domain.Occasion) bool { return owned[o.RecipientID] }), nil
+}
+
+func (r *mockOccasionRepo) Update(_ context.Context, o *domain.Occasion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *o
+	r.occasions[o.ID] = &c
+	return nil
+}
+
+func (r *mockOccasionRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.occasions, id)
+	return nil
+}
+
+func (r *mockOccasionRepo) Reassign(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, o := range r.occasions {
+		if o.RecipientID != fromRecipientID {
+			continue
+		}
+		taken := false
+		for _, t := range r.occasions {
+			taken = taken || (t.RecipientID == toRecipientID && t.Kind == o.Kind && t.Title == o.Title && t.Date.Equal(o.Date.Time))
+		}
+		if !taken {
+			o.RecipientID = toRecipientID
+		}
+	}
+	return nil
+}
+
+// mockGiftRepo implements port.GiftRepository in memory.
+type mockGiftRepo struct {
+	mu         sync.RWMutex
+	gifts      map[uuid.UUID]*domain.GiftRecord
+	recipients *mockRecipientRepo
+}
+
+func newMockGiftRepo(recipients *mockRecipientRepo) *mockGiftRepo {
+	return &mockGiftRepo{gifts: make(map[uuid.UUID]*domain.GiftRecord), recipients: recipients}
+}
+
+func (r *mockGiftRepo) Create(_ context.Context, g *domain.GiftRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *g
+	r.gifts[g.ID] = &c
+	return nil
+}
+
+func (r *mockGiftRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.GiftRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.gifts[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *g
+	return &c, nil
+}
+
+func (r *mockGiftRepo) ListByRecipientID(_ context.Context, recipientID uuid.UUID) ([]domain.GiftRecord, error) {
+	result := r.list(func(g *domain.GiftRecord) bool { return g.RecipientID == recipientID })
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].GivenOn.Equal(result[j].GivenOn.Time) {
+			return result[i].GivenOn.After(result[j].GivenOn.Time)
+		}
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *mockGiftRepo) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID, from, to domain.Date) ([]domain.GiftRecord, error) {
+	recipients, _ := r.recipients.ListByOwners(ctx, ownerIDs)
+	owned := make(map[uuid.UUID]bool, len(recipients))
+	for _, rec := range recipients {
+		owned[rec.ID] = true
+	}
+	result := r.list(func(g *domain.GiftRecord) bool {
+		return owned[g.RecipientID] && !g.GivenOn.Before(from.Time) && !g.GivenOn.After(to.Time)
+	})
+	sort.Slice(result, func(i, j int) bool { return result[i].GivenOn.Before(result[j].GivenOn.Time) })
+	return result, nil
+}
+
+func (r *mockGiftRepo) list(keep func(g *domain.GiftRecord) bool) []domain.GiftRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.GiftRecord
+	for _, g := range r.gifts {
+		if keep(g) {
+			result = append(result, *g)
+		}
+	}
+	return result
+}
+
+func (r *mockGiftRepo) Update(_ context.Context, g *domain.GiftRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *g
+	r.gifts[g.ID] = &c
+	return nil
+}
+
+func (r *mockGiftRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.gifts, id)
+	return nil
+}
+
+func (r *mockGiftRepo) Reassign(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, g := range r.gifts {
+		if g.RecipientID == fromRecipientID {
+			g.RecipientID = toRecipientID
+		}
+	}
+	return nil
+}
+
+// mockBudgetRepo implements port.BudgetRepository in memory.
+type mockBudgetRepo struct {
+	mu      sync.RWMutex
+	budgets map[uuid.UUID]map[int]*domain.YearlyBudget
+}
+
+func newMockBudgetRepo() *mockBudgetRepo {
+	return &mockBudgetRepo{budgets: make(map[uuid.UUID]map[int]*domain.YearlyBudget)}
+}
+
+func (r *mockBudgetRepo) Upsert(_ context.Context, b *domain.YearlyBudget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.budgets[b.UserID] == nil {
+		r.budgets[b.UserID] = make(map[int]*domain.YearlyBudget)
+	}
+	if existing, ok := r.budgets[b.UserID][b.Year]; ok {
+		b.CreatedAt = existing.CreatedAt
+	}
+	c := *b
+	r.budgets[b.UserID][b.Year] = &c
+	return nil
+}
+
+func (r *mockBudgetRepo) Get(_ context.Context, userID uuid.UUID, year int) (*domain.YearlyBudget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.budgets[userID][year]
+	if !ok {
+		return nil, nil
+	}
+	c := *b
+	return &c, nil
+}
+
+func (r *mockBudgetRepo) ListByUserID(_ context.Context, userID uuid.UUID) ([]domain.YearlyBudget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.YearlyBudget
+	for _, b := range r.budgets[userID] {
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Year > result[j].Year })
+	return result, nil
+}
+
+func (r *mockBudgetRepo) Delete(_ context.Context, userID uuid.UUID, year int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.budgets[userID], year)
+	return nil
+}
+
+// mockIdeaRepo implements port.IdeaRepository in memory.
+type mockIdeaRepo struct {
+	mu    sync.RWMutex
+	ideas map[uuid.UUID]*domain.GiftIdea
+}
+
+func newMockIdeaRepo() *mockIdeaRepo {
+	return &mockIdeaRepo{ideas: make(map[uuid.UUID]*domain.GiftIdea)}
+}
+
+func (r *mockIdeaRepo) Create(_ context.Context, i *domain.GiftIdea) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *i
+	r.ideas[i.ID] = &c
+	return nil
+}
+
+func (r *mockIdeaRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.GiftIdea, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, ok := r.ideas[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *i
+	return &c, nil
+}
+
+func (r *mockIdeaRepo) ListByRecipientID(_ context.Context, recipientID uuid.UUID) ([]domain.GiftIdea, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.GiftIdea
+	for _, i := range r.ideas {
+		if i.RecipientID == recipientID {
+			result = append(result, *i)
+		}
+	}
+	rank := map[domain.IdeaPriority]int{domain.IdeaPriorityHigh: 0, domain.IdeaPriorityMedium: 1, domain.IdeaPriorityLow: 2}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Priority != result[j].Priority {
+			return rank[result[i].Priority] < rank[result[j].Priority]
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *mockIdeaRepo) Update(_ context.Context, i *domain.GiftIdea) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *i
+	r.ideas[i.ID] = &c
+	return nil
+}
+
+func (r *mockIdeaRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.ideas, id)
+	return nil
+}
+
+func (r *mockIdeaRepo) Reassign(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range r.ideas {
+		if i.RecipientID == fromRecipientID {
+			i.RecipientID = toRecipientID
+		}
+	}
+	return nil
+}
+
+// mockHolidaySubscriptionRepo implements port.HolidaySubscriptionRepository in memory.
+type mockHolidaySubscriptionRepo struct {
+	mu            sync.RWMutex
+	subscriptions map[uuid.UUID]*domain.HolidaySubscription
+	recipients    *mockRecipientRepo
+}
+
+func newMockHolidaySubscriptionRepo(recipients *mockRecipientRepo) *mockHolidaySubscriptionRepo {
+	return &mockHolidaySubscriptionRepo{
+		subscriptions: make(map[uuid.UUID]*domain.HolidaySubscription),
+		recipients:    recipients,
+	}
+}
+
+func (r *mockHolidaySubscriptionRepo) Create(_ context.Context, s *domain.HolidaySubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.subscriptions {
+		if other.RecipientID == s.RecipientID && other.Country == s.Country && other.Holiday == s.Holiday {
+			return domain.ErrAlreadyExists
+		}
+	}
+	c := *s
+	r.subscriptions[s.ID] = &c
+	return nil
+}
+
+func (r *mockHolidaySubscriptionRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.HolidaySubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.subscriptions[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *s
+	return &c, nil
+}
+
+func (r *mockHolidaySubscriptionRepo) list(keep func(s *domain.HolidaySubscription) bool) []domain.HolidaySubscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.HolidaySubscription
+	for _, s := range r.subscriptions {
+		if keep(s) {
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result
+}
+
+func (r *mockHolidaySubscriptionRepo) ListByRecipientID(_ context.Context, recipientID uuid.UUID) ([]domain.HolidaySubscription, error) {
+	return r.list(func(s *domain.HolidaySubscription) bool { return s.RecipientID == recipientID }), nil
+}
+
+func (r *mockHolidaySubscriptionRepo) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.HolidaySubscription, error) {
+	recipients, _ := r.recipients.ListByOwners(ctx, ownerIDs)
+	owned := make(map[uuid.UUID]bool, len(recipients))
+	for _, rec := range recipients {
+		owned[rec.ID] = true
+	}
+	return r.list(func(s *domain.HolidaySubscription) bool { return owned[s.RecipientID] }), nil
+}
+
+func (r *mockHolidaySubscriptionRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *mockHolidaySubscriptionRepo) Reassign(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.subscriptions {
+		if s.RecipientID != fromRecipientID {
+			continue
+		}
+		taken := false
+		for _, t := range r.subscriptions {
+			taken = taken || (t.RecipientID == toRecipientID && t.Country == s.Country && t.Holiday == s.Holiday)
+		}
+		if !taken {
+			s.RecipientID = toRecipientID
+		}
+	}
+	return nil
+}
+
+// mockCalendarFeedRepo implements port.CalendarFeedRepository in memory.
+type mockCalendarFeedRepo struct {
+	mu    sync.RWMutex
+	feeds map[uuid.UUID]*domain.CalendarFeed
+}
+
+func newMockCalendarFeedRepo() *mockCalendarFeedRepo {
+	return &mockCalendarFeedRepo{feeds: make(map[uuid.UUID]*domain.CalendarFeed)}
+}
+
+func (r *mockCalendarFeedRepo) GetByUserID(_ context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	feed, ok := r.feeds[userID]
+	if !ok {
+		return nil, nil
+	}
+	c := *feed
+	return &c, nil
+}
+
+func (r *mockCalendarFeedRepo) GetByToken(_ context.Context, token string) (*domain.CalendarFeed, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, feed := range r.feeds {
+		if feed.Token == token {
+			c := *feed
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockCalendarFeedRepo) Save(_ context.Context, feed *domain.CalendarFeed) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *feed
+	r.feeds[feed.UserID] = &c
+	return nil
+}
+
+func (r *mockCalendarFeedRepo) Delete(_ context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.feeds, userID)
+	return nil
+}
+
+// mockImportRepo implements port.ImportRepository in memory.
+type mockImportRepo struct {
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]*domain.ImportSession
+}
+
+func newMockImportRepo() *mockImportRepo {
+	return &mockImportRepo{sessions: make(map[uuid.UUID]*domain.ImportSession)}
+}
+
+func (r *mockImportRepo) Create(_ context.Context, s *domain.ImportSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *s
+	r.sessions[s.ID] = &c
+	return nil
+}
+
+func (r *mockImportRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.ImportSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *s
+	return &c, nil
+}
+
+func (r *mockImportRepo) UpdateItems(_ context.Context, s *domain.ImportSession) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions[s.ID]
+	if !ok || stored.AppliedAt != nil {
+		return false, nil
+	}
+	stored.Mapping = s.Mapping
+	stored.DateFormat = s.DateFormat
+	stored.Items = s.Items
+	return true, nil
+}
+
+func (r *mockImportRepo) MarkApplied(_ context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	if !ok || s.AppliedAt != nil {
+		return false, nil
+	}
+	s.AppliedAt = &at
+	return true, nil
+}
+
+func (r *mockImportRepo) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for id, s := range r.sessions {
+		if s.ExpiresAt.Before(before) {
+			delete(r.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// mockTransactor implements port.Transactor by running fn directly.
+type mockTransactor struct{}
+
+func (mockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockSocialVerifier implements port.SocialVerifier.
+type mockSocialVerifier struct{}
+
+func (v *mockSocialVerifier) VerifyGoogleToken(_ context.Context, _ string) (string, string, string, error) {
+	return "google@example.com", "Google User", "google-sub-123", nil
+}
+
+func (v *mockSocialVerifier) VerifyAppleToken(_ context.Context, _ string) (string, string, error) {
+	return "apple@example.com", "apple-sub-123", nil
+}
+
+// mockExchangeRateRepo implements port.ExchangeRateRepository in memory.
+type mockExchangeRateRepo struct {
+	mu    sync.RWMutex
+	rates []domain.ExchangeRate
+}
+
+func newMockExchangeRateRepo() *mockExchangeRateRepo {
+	return &mockExchangeRateRepo{}
+}
+
+func (r *mockExchangeRateRepo) List(_ context.Context) ([]domain.ExchangeRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]domain.ExchangeRate(nil), r.rates...), nil
+}
+
+func (r *mockExchangeRateRepo) ReplaceAll(_ context.Context, rates []domain.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rates = append([]domain.ExchangeRate(nil), rates...)
+	return nil
+}
+
+// mockGroupGiftRepo implements port.GroupGiftRepository in memory.
+type mockGroupGiftRepo struct {
+	mu           sync.RWMutex
+	gifts        map[uuid.UUID]*domain.GroupGift
+	contributors map[uuid.UUID]*domain.GroupGiftContributor
+}
+
+func newMockGroupGiftRepo() *mockGroupGiftRepo {
+	return &mockGroupGiftRepo{
+		gifts:        make(map[uuid.UUID]*domain.GroupGift),
+		contributors: make(map[uuid.UUID]*domain.GroupGiftContributor),
+	}
+}
+
+func (r *mockGroupGiftRepo) Create(_ context.Context, g *domain.GroupGift) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *g
+	c.Contributors = nil
+	r.gifts[g.ID] = &c
+	return nil
+}
+
+// withContributors copies g and fills in its contributors. Callers hold the lock.
+func (r *mockGroupGiftRepo) withContributors(g *domain.GroupGift) domain.GroupGift {
+	c := *g
+	c.Contributors = []domain.GroupGiftContributor{}
+	for _, contributor := range r.contributors {
+		if contributor.GroupGiftID == g.ID {
+			c.Contributors = append(c.Contributors, *contributor)
+		}
+	}
+	sort.Slice(c.Contributors, func(i, j int) bool {
+		a, b := c.Contributors[i], c.Contributors[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.Email < b.Email
+	})
+	return c
+}
+
+func (r *mockGroupGiftRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.GroupGift, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.gifts[id]
+	if !ok {
+		return nil, nil
+	}
+	c := r.withContributors(g)
+	return &c, nil
+}
+
+func (r *mockGroupGiftRepo) ListForUser(_ context.Context, userID uuid.UUID, email string) ([]domain.GroupGift, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.GroupGift
+	for _, g := range r.gifts {
+		c := r.withContributors(g)
+		if g.OrganizerID == userID || c.Contributor(email) != nil {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *mockGroupGiftRepo) Update(_ context.Context, g *domain.GroupGift) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *g
+	c.Contributors = nil
+	r.gifts[g.ID] = &c
+	return nil
+}
+
+func (r *mockGroupGiftRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.gifts, id)
+	for cid, c := range r.contributors {
+		if c.GroupGiftID == id {
+			delete(r.contributors, cid)
+		}
+	}
+	return nil
+}
+
+func (r *mockGroupGiftRepo) AddContributor(_ context.Context, c *domain.GroupGiftContributor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.contributors {
+		if existing.GroupGiftID == c.GroupGiftID && existing.Email == c.Email {
+			return domain.ErrAlreadyExists
+		}
+	}
+	cp := *c
+	r.contributors[c.ID] = &cp
+	return nil
+}
+
+func (r *mockGroupGiftRepo) UpdateContributor(_ context.Context, c *domain.GroupGiftContributor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *c
+	r.contributors[c.ID] = &cp
+	return nil
+}
+
+func (r *mockGroupGiftRepo) DeleteContributor(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.contributors, id)
+	return nil
+}
+
+func (r *mockGroupGiftRepo) Reassign(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, g := range r.gifts {
+		if g.RecipientID == fromRecipientID {
+			g.RecipientID = toRecipientID
+		}
+	}
+	return nil
+}
+
+// mockExchangeRepo implements port.ExchangeRepository in memory.
+type mockExchangeRepo struct {
+	mu           sync.RWMutex
+	exchanges    map[uuid.UUID]*domain.GiftExchange
+	participants map[uuid.UUID]*domain.ExchangeParticipant
+	exclusions   map[uuid.UUID]*domain.ExchangeExclusion
+}
+
+func newMockExchangeRepo() *mockExchangeRepo {
+	return &mockExchangeRepo{
+		exchanges:    make(map[uuid.UUID]*domain.GiftExchange),
+		participants: make(map[uuid.UUID]*domain.ExchangeParticipant),
+		exclusions:   make(map[uuid.UUID]*domain.ExchangeExclusion),
+	}
+}
+
+func (r *mockExchangeRepo) Create(_ context.Context, e *domain.GiftExchange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *e
+	c.Participants, c.Exclusions = nil, nil
+	r.exchanges[e.ID] = &c
+	return nil
+}
+
+// withMembers copies e and fills in its participants and exclusions.
+// Callers hold the lock.
+func (r *mockExchangeRepo) withMembers(e *domain.GiftExchange) domain.GiftExchange {
+	c := *e
+	c.Participants = []domain.ExchangeParticipant{}
+	for _, p := range r.participants {
+		if p.ExchangeID == e.ID {
+			c.Participants = append(c.Participants, *p)
+		}
+	}
+	sort.Slice(c.Participants, func(i, j int) bool {
+		a, b := c.Participants[i], c.Participants[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.Email < b.Email
+	})
+	c.Exclusions = []domain.ExchangeExclusion{}
+	for _, x := range r.exclusions {
+		if x.ExchangeID == e.ID {
+			c.Exclusions = append(c.Exclusions, *x)
+		}
+	}
+	sort.Slice(c.Exclusions, func(i, j int) bool { return c.Exclusions[i].CreatedAt.Before(c.Exclusions[j].CreatedAt) })
+	return c
+}
+
+func (r *mockExchangeRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.GiftExchange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.exchanges[id]
+	if !ok {
+		return nil, nil
+	}
+	c := r.withMembers(e)
+	return &c, nil
+}
+
+func (r *mockExchangeRepo) ListForUser(_ context.Context, userID uuid.UUID, email string) ([]domain.GiftExchange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.GiftExchange
+	for _, e := range r.exchanges {
+		c := r.withMembers(e)
+		if e.OrganizerID == userID || c.Participant(email) != nil {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *mockExchangeRepo) Update(_ context.Context, e *domain.GiftExchange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *e
+	c.Participants, c.Exclusions = nil, nil
+	r.exchanges[e.ID] = &c
+	return nil
+}
+
+func (r *mockExchangeRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.exchanges, id)
+	for _, e := range r.exchanges {
+		if e.PreviousExchangeID != nil && *e.PreviousExchangeID == id {
+			e.PreviousExchangeID = nil
+		}
+	}
+	for pid, p := range r.participants {
+		if p.ExchangeID == id {
+			delete(r.participants, pid)
+		}
+	}
+	for xid, x := range r.exclusions {
+		if x.ExchangeID == id {
+			delete(r.exclusions, xid)
+		}
+	}
+	return nil
+}
+
+func (r *mockExchangeRepo) AddParticipant(_ context.Context, p *domain.ExchangeParticipant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.participants {
+		if existing.ExchangeID == p.ExchangeID && existing.Email == p.Email {
+			return domain.ErrAlreadyExists
+		}
+	}
+	cp := *p
+	r.participants[p.ID] = &cp
+	return nil
+}
+
+func (r *mockExchangeRepo) DeleteParticipant(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.participants, id)
+	for xid, x := range r.exclusions {
+		if x.GiverID == id || x.ReceiverID == id {
+			delete(r.exclusions, xid)
+		}
+	}
+	return nil
+}
+
+func (r *mockExchangeRepo) AddExclusion(_ context.Context, x *domain.ExchangeExclusion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.exclusions {
+		if existing.ExchangeID == x.ExchangeID && existing.GiverID == x.GiverID && existing.ReceiverID == x.ReceiverID {
+			return domain.ErrAlreadyExists
+		}
+	}
+	cp := *x
+	r.exclusions[x.ID] = &cp
+	return nil
+}
+
+func (r *mockExchangeRepo) DeleteExclusion(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.exclusions, id)
+	return nil
+}
+
+func (r *mockExchangeRepo) SaveAssignments(_ context.Context, participants []domain.ExchangeParticipant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range participants {
+		if stored, ok := r.participants[p.ID]; ok {
+			stored.ReceiverID, stored.Token = p.ReceiverID, p.Token
+		}
+	}
+	return nil
+}
+
+func (r *mockExchangeRepo) GetParticipantByToken(_ context.Context, token string) (*domain.ExchangeParticipant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.participants {
+		if p.Token != "" && p.Token == token {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+// mockWishlistRepo implements port.WishlistRepository in memory.
+type mockWishlistRepo struct {
+	mu        sync.RWMutex
+	wishlists map[uuid.UUID]*domain.Wishlist
+	claims    map[uuid.UUID]*domain.WishlistClaim
+}
+
+func newMockWishlistRepo() *mockWishlistRepo {
+	return &mockWishlistRepo{
+		wishlists: make(map[uuid.UUID]*domain.Wishlist),
+		claims:    make(map[uuid.UUID]*domain.WishlistClaim),
+	}
+}
+
+// copyWishlist returns a copy of l that shares no items with it.
+func copyWishlist(l *domain.Wishlist) *domain.Wishlist {
+	c := *l
+	c.Items = append([]domain.WishlistItem{}, l.Items...)
+	return &c
+}
+
+func (r *mockWishlistRepo) Create(_ context.Context, l *domain.Wishlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wishlists[l.ID] = copyWishlist(l)
+	return nil
+}
+
+func (r *mockWishlistRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Wishlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	l, ok := r.wishlists[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyWishlist(l), nil
+}
+
+func (r *mockWishlistRepo) GetBySlug(_ context.Context, slug string) (*domain.Wishlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, l := range r.wishlists {
+		if l.Slug == slug {
+			return copyWishlist(l), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockWishlistRepo) ListByUserID(_ context.Context, userID uuid.UUID) ([]domain.Wishlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.Wishlist
+	for _, l := range r.wishlists {
+		if l.UserID == userID {
+			result = append(result, *copyWishlist(l))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *mockWishlistRepo) Update(_ context.Context, l *domain.Wishlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wishlists[l.ID] = copyWishlist(l)
+	for id, c := range r.claims {
+		if !r.itemExists(c.ItemID) {
+			delete(r.claims, id)
+		}
+	}
+	return nil
+}
+
+// itemExists reports whether any wishlist has the item. Callers hold the lock.
+func (r *mockWishlistRepo) itemExists(itemID uuid.UUID) bool {
+	for _, l := range r.wishlists {
+		if l.Item(itemID) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *mockWishlistRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.wishlists, id)
+	for cid, c := range r.claims {
+		if !r.itemExists(c.ItemID) {
+			delete(r.claims, cid)
+		}
+	}
+	return nil
+}
+
+func (r *mockWishlistRepo) Reassign(_ context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, l := range r.wishlists {
+		if l.RecipientID == fromRecipientID {
+			l.RecipientID = toRecipientID
+		}
+	}
+	return nil
+}
+
+func (r *mockWishlistRepo) CreateClaim(_ context.Context, c *domain.WishlistClaim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.claims {
+		if existing.ItemID == c.ItemID {
+			return domain.ErrAlreadyExists
+		}
+	}
+	cp := *c
+	r.claims[c.ID] = &cp
+	return nil
+}
+
+func (r *mockWishlistRepo) GetClaimByToken(_ context.Context, token string) (*domain.WishlistClaim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.claims {
+		if c.Token == token {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockWishlistRepo) DeleteClaim(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.claims, id)
+	return nil
+}
+
+func (r *mockWishlistRepo) ClaimedItemIDs(_ context.Context, wishlistID uuid.UUID) (map[uuid.UUID]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	claimed := make(map[uuid.UUID]bool)
+	l, ok := r.wishlists[wishlistID]
+	if !ok {
+		return claimed, nil
+	}
+	for _, c := range r.claims {
+		if l.Item(c.ItemID) != nil {
+			claimed[c.ItemID] = true
+		}
+	}
+	return claimed, nil
+}
+
+// mockHouseholdRepo implements port.HouseholdRepository. Members' emails
+// and names are read from the user repo, as the real one joins users.
+type mockHouseholdRepo struct {
+	mu          sync.RWMutex
+	users       *mockUserRepo
+	households  map[uuid.UUID]*domain.Household
+	members     map[uuid.UUID]domain.HouseholdMember
+	invitations map[uuid.UUID]*domain.HouseholdInvitation
+}
+
+func newMockHouseholdRepo(users *mockUserRepo) *mockHouseholdRepo {
+	return &mockHouseholdRepo{
+		users:       users,
+		households:  make(map[uuid.UUID]*domain.Household),
+		members:     make(map[uuid.UUID]domain.HouseholdMember),
+		invitations: make(map[uuid.UUID]*domain.HouseholdInvitation),
+	}
+}
+
+func (r *mockHouseholdRepo) Create(ctx context.Context, h *domain.Household) error {
+	r.mu.Lock()
+	c := *h
+	c.Members = nil
+	r.households[h.ID] = &c
+	r.mu.Unlock()
+	for i := range h.Members {
+		if err := r.AddMember(ctx, &h.Members[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mockHouseholdRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.households[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *h
+	c.Members = []domain.HouseholdMember{}
+	for _, m := range r.members {
+		if m.HouseholdID != id {
+			continue
+		}
+		if u, _ := r.users.GetByID(ctx, m.UserID); u != nil {
+			m.Email, m.Name = u.Email, u.Name
+		}
+		c.Members = append(c.Members, m)
+	}
+	sort.Slice(c.Members, func(i, j int) bool {
+		a, b := c.Members[i], c.Members[j]
+		if (a.Role == domain.HouseholdOwner) != (b.Role == domain.HouseholdOwner) {
+			return a.Role == domain.HouseholdOwner
+		}
+		return a.JoinedAt.Before(b.JoinedAt)
+	})
+	return &c, nil
+}
+
+func (r *mockHouseholdRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Household, error) {
+	r.mu.RLock()
+	m, ok := r.members[userID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return r.GetByID(ctx, m.HouseholdID)
+}
+
+func (r *mockHouseholdRepo) Update(_ context.Context, h *domain.Household) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.households[h.ID]; ok {
+		stored.Name = h.Name
+		stored.UpdatedAt = h.UpdatedAt
+	}
+	return nil
+}
+
+func (r *mockHouseholdRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.households, id)
+	for userID, m := range r.members {
+		if m.HouseholdID == id {
+			delete(r.members, userID)
+		}
+	}
+	for invID, inv := range r.invitations {
+		if inv.HouseholdID == id {
+			delete(r.invitations, invID)
+		}
+	}
+	return nil
+}
+
+func (r *mockHouseholdRepo) AddMember(_ context.Context, m *domain.HouseholdMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.members[m.UserID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	r.members[m.UserID] = *m
+	return nil
+}
+
+func (r *mockHouseholdRepo) UpdateMemberRole(_ context.Context, householdID, userID uuid.UUID, role domain.HouseholdRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if m, ok := r.members[userID]; ok && m.HouseholdID == householdID {
+		m.Role = role
+		r.members[userID] = m
+	}
+	return nil
+}
+
+func (r *mockHouseholdRepo) RemoveMember(_ context.Context, householdID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if m, ok := r.members[userID]; ok && m.HouseholdID == householdID {
+		delete(r.members, userID)
+	}
+	return nil
+}
+
+func (r *mockHouseholdRepo) CreateInvitation(_ context.Context, inv *domain.HouseholdInvitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.invitations {
+		if existing.HouseholdID == inv.HouseholdID && existing.Email == inv.Email {
+			return domain.ErrAlreadyExists
+		}
+	}
+	c := *inv
+	r.invitations[inv.ID] = &c
+	return nil
+}
+
+func (r *mockHouseholdRepo) ListInvitations(_ context.Context, householdID uuid.UUID) ([]domain.HouseholdInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []domain.HouseholdInvitation
+	for _, inv := range r.invitations {
+		if inv.HouseholdID == householdID {
+			result = append(result, *inv)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *mockHouseholdRepo) GetInvitationByToken(_ context.Context, token string) (*domain.HouseholdInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, inv := range r.invitations {
+		if inv.Token == token {
+			c := *inv
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockHouseholdRepo) DeleteInvitation(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.invitations, id)
+	return nil
+}
+
+// mockNotifier implements port.Notifier by recording what was sent.
+type mockNotifier struct {
+	mu   sync.Mutex
+	sent []domain.Notification
+}
+
+func newMockNotifier() *mockNotifier {
+	return &mockNotifier{}
+}
+
+func (n *mockNotifier) Notify(_ context.Context, msg domain.Notification) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, msg)
+}
+
+// recipients returns who was sent notifications of the given kind, in order.
+func (n *mockNotifier) recipients(kind domain.NotificationKind) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var to []string
+	for _, msg := range n.sent {
+		if msg.Kind == kind {
+			to = append(to, msg.To)
+		}
+	}
+	return to
+}
+
+// messages returns the notifications of the given kind, in order.
+func (n *mockNotifier) messages(kind domain.NotificationKind) []domain.Notification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var msgs []domain.Notification
+	for _, msg := range n.sent {
+		if msg.Kind == kind {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
 }
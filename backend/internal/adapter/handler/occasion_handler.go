@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// OccasionHandler handles recipient occasion HTTP requests.
+type OccasionHandler struct {
+	occasionService port.OccasionService
+}
+
+// NewOccasionHandler creates a new OccasionHandler.
+func NewOccasionHandler(occasionService port.OccasionService) *OccasionHandler {
+	return &OccasionHandler{occasionService: occasionService}
+}
+
+// Create handles POST /api/recipients/{id}/occasions.
+func (h *OccasionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	var req domain.CreateOccasionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	occasion, err := h.occasionService.Create(r.Context(), userID, recipientID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, occasion)
+}
+
+// List handles GET /api/recipients/{id}/occasions.
+func (h *OccasionHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	occasions, err := h.occasionService.List(r.Context(), userID, recipientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, occasions)
+}
+
+// GetByID handles GET /api/recipients/{id}/occasions/{occasionID}.
+func (h *OccasionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, occasionID, ok := occasionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	occasion, err := h.occasionService.GetByID(r.Context(), userID, recipientID, occasionID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, occasion)
+}
+
+// Update handles PUT /api/recipients/{id}/occasions/{occasionID}.
+func (h *OccasionHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, occasionID, ok := occasionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateOccasionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	occasion, err := h.occasionService.Update(r.Context(), userID, recipientID, occasionID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, occasion)
+}
+
+// Delete handles DELETE /api/recipients/{id}/occasions/{occasionID}.
+func (h *OccasionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, occasionID, ok := occasionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.occasionService.Delete(r.Context(), userID, recipientID, occasionID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "occasion deleted"})
+}
+
+// occasionIDs parses the recipient and occasion IDs from the URL, writing a
+// 400 response and returning false if either is malformed.
+func occasionIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	occasionID, err := uuid.Parse(chi.URLParam(r, "occasionID"))
+	if err != nil {
+		writeError(w, r, errInvalidOccasionID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return recipientID, occasionID, true
+}
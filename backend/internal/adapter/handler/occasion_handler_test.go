@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// daysFromNow formats the date n days from today, shifted back by years.
+func daysFromNow(n, years int) string {
+	return time.Now().UTC().AddDate(-years, 0, n).Format("2006-01-02")
+}
+
+func createOccasion(t *testing.T, router http.Handler, token, recipientID string, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/recipients/"+recipientID+"/occasions", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var occasion map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&occasion))
+	return occasion
+}
+
+func getUpcoming(t *testing.T, router http.Handler, token, path string) []map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, path, token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var feed []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&feed))
+	return feed
+}
+
+func TestOccasions_CRUD(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "occasions@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+	base := "/api/recipients/" + recipientID + "/occasions"
+
+	occasion := createOccasion(t, router, token, recipientID, map[string]interface{}{
+		"kind": " Anniversary ", "date": "2015-06-20", "budget": 150,
+	})
+	assert.Equal(t, "anniversary", occasion["kind"])
+	assert.Equal(t, true, occasion["recurring"])
+	assert.Equal(t, float64(150), occasion["budget"])
+	occasionID := occasion["id"].(string)
+
+	w := doJSON(t, router, http.MethodPut, base+"/"+occasionID, token,
+		map[string]interface{}{"title": "Dating anniversary", "recurring": false, "clear_budget": true})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&occasion))
+	assert.Equal(t, "Dating anniversary", occasion["title"])
+	assert.Equal(t, false, occasion["recurring"])
+	assert.Nil(t, occasion["budget"])
+
+	w = doJSON(t, router, http.MethodGet, base, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var occasions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&occasions))
+	require.Len(t, occasions, 1)
+	assert.Equal(t, "2015-06-20", occasions[0]["date"])
+
+	w = doJSON(t, router, http.MethodDelete, base+"/"+occasionID, token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodGet, base+"/"+occasionID, token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doJSON(t, router, http.MethodGet, base+"/not-a-uuid", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOccasions_Validation(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "occasions-validation@example.com")
+	recipientID := createRecipient(t, router, token, map[string]interface{}{"name": "Ana"})
+
+	path := "/api/recipients/" + recipientID + "/occasions"
+
+	w := doJSON(t, router, http.MethodPost, path, token, map[string]interface{}{"kind": "custom", "budget": -1})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"title":  "required",
+		"date":   "required",
+		"budget": "out_of_range",
+	}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, path, token, map[string]interface{}{"kind": "bar-mitzvah", "date": "2020-01-01"})
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"kind": "invalid_choice"}, fieldErrorCodes(t, w))
+}
+
+func TestOccasions_OtherUsersRecipient(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	owner := registerAndGetToken(t, router, "occasion-owner@example.com")
+	other := registerAndGetToken(t, router, "occasion-other@example.com")
+
+	recipientID := createRecipient(t, router, owner, map[string]interface{}{"name": "Ana"})
+	occasion := createOccasion(t, router, owner, recipientID, map[string]interface{}{"kind": "wedding", "date": "2019-09-14"})
+	occasionPath := "/api/recipients/" + recipientID + "/occasions/" + occasion["id"].(string)
+
+	w := doJSON(t, router, http.MethodGet, occasionPath, other, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// An occasion is only reachable through the recipient it belongs to
+	siblingID := createRecipient(t, router, owner, map[string]interface{}{"name": "Bia"})
+	w = doJSON(t, router, http.MethodDelete,
+		"/api/recipients/"+siblingID+"/occasions/"+occasion["id"].(string), owner, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpcoming_MergesOccasionsAndBirthdates(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "upcoming@example.com")
+
+	ana := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana", "birthdate": daysFromNow(5, 28), "max_budget": 100,
+	})
+	createOccasion(t, router, token, ana, map[string]interface{}{
+		"kind": "anniversary", "date": daysFromNow(10, 4), "budget": 250,
+	})
+	createOccasion(t, router, token, ana, map[string]interface{}{
+		"kind": "graduation", "date": daysFromNow(-3, 0), "recurring": false,
+	})
+
+	// A stored birthday replaces the one implied by the birthdate
+	bia := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Bia", "birthdate": daysFromNow(1, 20),
+	})
+	createOccasion(t, router, token, bia, map[string]interface{}{"kind": "birthday", "date": daysFromNow(20, 20)})
+
+	feed := getUpcoming(t, router, token, "/api/upcoming")
+	require.Len(t, feed, 3)
+
+	assert.Equal(t, "Ana", feed[0]["recipient_name"])
+	assert.Equal(t, "birthday", feed[0]["kind"])
+	assert.Nil(t, feed[0]["occasion_id"])
+	assert.Equal(t, float64(5), feed[0]["days_until"])
+	assert.Equal(t, float64(28), feed[0]["turns"])
+	assert.Equal(t, float64(100), feed[0]["budget"])
+
+	assert.Equal(t, "anniversary", feed[1]["kind"])
+	assert.NotNil(t, feed[1]["occasion_id"])
+	assert.Equal(t, float64(4), feed[1]["turns"])
+	assert.Equal(t, float64(250), feed[1]["budget"])
+
+	assert.Equal(t, "Bia", feed[2]["recipient_name"])
+	assert.Equal(t, float64(20), feed[2]["days_until"])
+
+	assert.Len(t, getUpcoming(t, router, token, "/api/upcoming?days=7"), 1)
+
+	w := doJSON(t, router, http.MethodGet, "/api/upcoming?days=400", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReminders_UsePreferredLeadDays(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "reminders@example.com")
+
+	ana := createRecipient(t, router, token, map[string]interface{}{"name": "Ana", "birthdate": daysFromNow(7, 28)})
+	createOccasion(t, router, token, ana, map[string]interface{}{"kind": "wedding", "date": daysFromNow(3, 2)})
+
+	reminders := getUpcoming(t, router, token, "/api/reminders")
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "birthday", reminders[0]["kind"])
+	assert.Equal(t, float64(7), reminders[0]["lead_days"])
+
+	w := doJSON(t, router, http.MethodPut, "/api/me/preferences", token,
+		map[string]interface{}{"reminder_lead_days": []int{3}})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	reminders = getUpcoming(t, router, token, "/api/reminders")
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "wedding", reminders[0]["kind"])
+	assert.Equal(t, float64(3), reminders[0]["lead_days"])
+}
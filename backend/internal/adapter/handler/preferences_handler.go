@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// PreferencesHandler handles user preference HTTP requests.
+type PreferencesHandler struct {
+	prefsService port.PreferencesService
+}
+
+// NewPreferencesHandler creates a new PreferencesHandler.
+func NewPreferencesHandler(prefsService port.PreferencesService) *PreferencesHandler {
+	return &PreferencesHandler{prefsService: prefsService}
+}
+
+// Get handles GET /api/me/preferences.
+func (h *PreferencesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	prefs, err := h.prefsService.Get(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, prefs)
+}
+
+// Update handles PUT /api/me/preferences.
+func (h *PreferencesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	prefs, err := h.prefsService.Update(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, prefs)
+}
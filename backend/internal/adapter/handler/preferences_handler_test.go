@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPreferences_Defaults(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "prefs-default@example.com")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/me/preferences", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	assert.Equal(t, "UTC", resp["timezone"])
+	assert.Equal(t, "en-US", resp["locale"])
+	assert.Equal(t, "USD", resp["currency"])
+	assert.Equal(t, []interface{}{float64(7), float64(1)}, resp["reminder_lead_days"])
+}
+
+func TestUpdatePreferences_Success(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "prefs-update@example.com")
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"timezone":           "America/Sao_Paulo",
+		"locale":             "pt-BR",
+		"currency":           "brl",
+		"reminder_lead_days": []int{1, 14, 3},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/api/me/preferences", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Read back
+	req = httptest.NewRequest(http.MethodGet, "/api/me/preferences", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	assert.Equal(t, "America/Sao_Paulo", resp["timezone"])
+	assert.Equal(t, "pt-BR", resp["locale"])
+	assert.Equal(t, "BRL", resp["currency"])
+	assert.Equal(t, []interface{}{float64(14), float64(3), float64(1)}, resp["reminder_lead_days"])
+}
+
+func TestUpdatePreferences_InvalidTimezone(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "prefs-tz@example.com")
+
+	for _, tz := range []string{"Mars/Olympus_Mons", "Local", ""} {
+		body, _ := json.Marshal(map[string]interface{}{"timezone": tz})
+		req := httptest.NewRequest(http.MethodPut, "/api/me/preferences", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, tz)
+	}
+}
+
+func TestUpdatePreferences_InvalidReminderDays(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "prefs-days@example.com")
+
+	body, _ := json.Marshal(map[string]interface{}{"reminder_lead_days": []int{7, 7}})
+	req := httptest.NewRequest(http.MethodPut, "/api/me/preferences", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter caps how many requests each client IP may make per window.
+// Counts reset at the end of every window, so memory stays bounded by the
+// number of clients seen in one window. Clients are told apart by the address
+// RealIP settles on, which only trusted proxies can influence.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]int
+}
+
+// NewRateLimiter creates a RateLimiter allowing limit requests per client
+// per window. A limit of zero or less disables it.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{limit: limit, window: window, counts: make(map[string]int)}
+}
+
+// Limit is the middleware handler that rejects clients over the limit with
+// 429 Too Many Requests and a Retry-After header.
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, retryAfter := l.allow(clientIP(r), time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			writeError(w, r, errRateLimited)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow counts a request from client at now, reporting whether it is
+// within the limit and, if not, how long until the window resets.
+func (l *RateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.start) >= l.window {
+		l.start = now
+		clear(l.counts)
+	}
+	if l.counts[client] >= l.limit {
+		return false, l.start.Add(l.window).Sub(now)
+	}
+	l.counts[client]++
+	return true, 0
+}
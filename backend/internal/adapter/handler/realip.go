@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// RealIP replaces a request's RemoteAddr with the client address forwarded
+// in X-Forwarded-For or X-Real-IP, but only when the connection comes from
+// one of the trusted proxies. Any other peer keeps its own address, so
+// clients cannot choose the address that logs and rate limits see.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if peer, ok := parseAddr(clientIP(r)); ok && isTrusted(peer, trusted) {
+				if ip, ok := forwardedFor(r, trusted); ok {
+					r.RemoteAddr = ip.String()
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedFor returns the client address the trusted proxies forwarded.
+// X-Forwarded-For is read from the right, skipping the proxies' own hops,
+// since everything to the left of them was written by the client.
+func forwardedFor(r *http.Request, trusted []netip.Prefix) (netip.Addr, bool) {
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip, ok := parseAddr(hops[i])
+			if !ok {
+				return netip.Addr{}, false
+			}
+			if !isTrusted(ip, trusted) {
+				return ip, true
+			}
+		}
+		return netip.Addr{}, false
+	}
+	return parseAddr(r.Header.Get("X-Real-IP"))
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	for _, p := range trusted {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAddr parses an IP address, dropping any IPv6 zone and unmapping
+// IPv4-in-IPv6 so it compares equal to the plain IPv4 form.
+func parseAddr(s string) (netip.Addr, bool) {
+	ip, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.WithZone("").Unmap(), true
+}
+
+// clientIP returns the request's client address without the port. RealIP
+// has already replaced it with the forwarded address when that is trusted.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -29,33 +33,62 @@ func (h *RecipientHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	var req domain.CreateRecipientRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
-		return
-	}
-
-	if req.Name == "" {
-		response.Error(w, http.StatusBadRequest, "name is required")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	recipient, err := h.recipientService.Create(r.Context(), userID, req)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to create recipient")
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusCreated, recipient)
 }
 
 // List handles GET /api/recipients.
+//
+// Query parameters: sort (created_at, name, age, budget, next_birthday),
+// order (asc, desc), cursor, limit, gender, min_age, max_age, budget_min,
+// budget_max, currency (of the budget bounds and ordering, the user's
+// preferred currency by default) and keywords (comma-separated, all must
+// match).
 func (h *RecipientHandler) List(w http.ResponseWriter, r *http.Request) {
 	userID := UserIDFromContext(r.Context())
 
-	recipients, err := h.recipientService.ListByUserID(r.Context(), userID)
+	q, err := parseRecipientQuery(r.URL.Query())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to list recipients")
+		badRequest(w, r, err.Error())
 		return
 	}
-	response.JSON(w, http.StatusOK, recipients)
+
+	page, err := h.recipientService.List(r.Context(), userID, q)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, page)
+}
+
+// Search handles GET /api/recipients/search?q=.
+func (h *RecipientHandler) Search(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "limit must be an integer")
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.recipientService.Search(r.Context(), userID, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, results)
 }
 
 // GetByID handles GET /api/recipients/{id}.
@@ -64,15 +97,24 @@ func (h *RecipientHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid recipient id")
+		writeError(w, r, errInvalidRecipientID)
 		return
 	}
 
 	recipient, err := h.recipientService.GetByID(r.Context(), userID, recipientID)
 	if err != nil {
-		handleRecipientError(w, err)
+		writeError(w, r, err)
 		return
 	}
+
+	w.Header().Set("ETag", versionETag(recipient.Version))
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		versions, wildcard := parseETagVersions(header)
+		if wildcard || slices.Contains(versions, recipient.Version) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
 	response.JSON(w, http.StatusOK, recipient)
 }
 
@@ -82,21 +124,33 @@ func (h *RecipientHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid recipient id")
+		writeError(w, r, errInvalidRecipientID)
 		return
 	}
 
 	var req domain.UpdateRecipientRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
+	if header := r.Header.Get("If-Match"); header != "" {
+		versions, wildcard := parseETagVersions(header)
+		if !wildcard {
+			if len(versions) == 0 {
+				writeError(w, r, usecase.ErrPreconditionFailed)
+				return
+			}
+			req.IfMatch = versions
+		}
+	}
+
 	recipient, err := h.recipientService.Update(r.Context(), userID, recipientID, req)
 	if err != nil {
-		handleRecipientError(w, err)
+		writeError(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", versionETag(recipient.Version))
 	response.JSON(w, http.StatusOK, recipient)
 }
 
@@ -106,12 +160,12 @@ func (h *RecipientHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid recipient id")
+		writeError(w, r, errInvalidRecipientID)
 		return
 	}
 
 	if err := h.recipientService.Delete(r.Context(), userID, recipientID); err != nil {
-		handleRecipientError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, map[string]string{"message": "recipient deleted"})
@@ -123,29 +177,180 @@ func (h *RecipientHandler) BulkDelete(w http.ResponseWriter, r *http.Request) {
 
 	var req domain.BulkDeleteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, errInvalidBody)
 		return
 	}
 
 	if len(req.IDs) == 0 {
-		response.Error(w, http.StatusBadRequest, "ids are required")
+		badRequest(w, r, "ids are required")
 		return
 	}
 
 	if err := h.recipientService.BulkDelete(r.Context(), userID, req.IDs); err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to delete recipients")
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, map[string]string{"message": "recipients deleted"})
 }
 
-func handleRecipientError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, usecase.ErrRecipientNotFound):
-		response.Error(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, usecase.ErrForbidden):
-		response.Error(w, http.StatusForbidden, err.Error())
+// ListTrash handles GET /api/recipients/trash.
+func (h *RecipientHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipients, err := h.recipientService.ListTrash(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, recipients)
+}
+
+// Restore handles POST /api/recipients/{id}/restore.
+func (h *RecipientHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	recipient, err := h.recipientService.Restore(r.Context(), userID, recipientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, recipient)
+}
+
+// History handles GET /api/recipients/{id}/history.
+func (h *RecipientHandler) History(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	versions, err := h.recipientService.History(r.Context(), userID, recipientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, versions)
+}
+
+// Revert handles POST /api/recipients/{id}/history/{version}/revert.
+func (h *RecipientHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version < 1 {
+		badRequest(w, r, "invalid version")
+		return
+	}
+
+	recipient, err := h.recipientService.Revert(r.Context(), userID, recipientID, version)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, recipient)
+}
+
+func parseRecipientQuery(values url.Values) (domain.RecipientQuery, error) {
+	q := domain.RecipientQuery{
+		Sort:   domain.RecipientSort(values.Get("sort")),
+		Cursor: values.Get("cursor"),
+		Gender: values.Get("gender"),
+	}
+	if q.Sort == "" {
+		q.Sort = domain.RecipientSortCreatedAt
+	}
+
+	switch values.Get("order") {
+	case "":
+		q.Desc = q.Sort == domain.RecipientSortCreatedAt
+	case "asc":
+	case "desc":
+		q.Desc = true
 	default:
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		return q, errors.New("order must be asc or desc")
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return q, errors.New("limit must be an integer")
+		}
+		q.Limit = limit
+	}
+
+	var err error
+	if q.MinAge, err = optionalInt(values, "min_age"); err != nil {
+		return q, err
+	}
+	if q.MaxAge, err = optionalInt(values, "max_age"); err != nil {
+		return q, err
+	}
+	if q.BudgetMin, err = optionalMoney(values, "budget_min"); err != nil {
+		return q, err
+	}
+	if q.BudgetMax, err = optionalMoney(values, "budget_max"); err != nil {
+		return q, err
+	}
+	if v := values.Get("currency"); v != "" {
+		q.Currency = strings.ToUpper(strings.TrimSpace(v))
+		if !slices.Contains(domain.SupportedCurrencies, q.Currency) {
+			return q, errors.New("currency must be one of " + strings.Join(domain.SupportedCurrencies, ", "))
+		}
+	}
+
+	if v := values.Get("keywords"); v != "" {
+		for _, kw := range strings.Split(v, ",") {
+			if kw = strings.TrimSpace(kw); kw != "" {
+				q.Keywords = append(q.Keywords, kw)
+			}
+		}
+	}
+
+	if v := values.Get("group"); v != "" {
+		groupID, err := uuid.Parse(v)
+		if err != nil {
+			return q, errors.New("group must be a valid id")
+		}
+		q.GroupID = &groupID
+	}
+	return q, nil
+}
+
+func optionalInt(values url.Values, key string) (*int, error) {
+	v := values.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, errors.New(key + " must be an integer")
+	}
+	return &n, nil
+}
+
+func optionalMoney(values url.Values, key string) (*domain.Money, error) {
+	v := values.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	m, err := domain.ParseMoney(v)
+	if err != nil {
+		return nil, errors.New(key + " must be a number")
 	}
+	return &m, nil
 }
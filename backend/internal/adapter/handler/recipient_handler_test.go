@@ -3,10 +3,14 @@ package handler_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,6 +34,44 @@ func registerAndGetToken(t *testing.T, router http.Handler, email string) string
 	return resp["access_token"].(string)
 }
 
+// helper to create a recipient and return its id
+func createRecipient(t *testing.T, router http.Handler, token string, fields map[string]interface{}) string {
+	t.Helper()
+	body, _ := json.Marshal(fields)
+	req := httptest.NewRequest(http.MethodPost, "/api/recipients", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&created)
+	return created["id"].(string)
+}
+
+// helper to list recipients and return the decoded page
+func listRecipients(t *testing.T, router http.Handler, token, query string) map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/recipients"+query, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	return resp
+}
+
+func recipientNames(page map[string]interface{}) []string {
+	var names []string
+	for _, item := range page["data"].([]interface{}) {
+		names = append(names, item.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
 func TestCreateRecipient_Success(t *testing.T) {
 	router, _, _, _, _, _ := setupRouter(t)
 	token := registerAndGetToken(t, router, "create-rec@example.com")
@@ -73,7 +115,7 @@ func TestCreateRecipient_MissingName(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 }
 
 func TestCreateRecipient_Unauthorized(t *testing.T) {
@@ -99,9 +141,11 @@ func TestListRecipients_Empty(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp []interface{}
+	var resp map[string]interface{}
 	json.NewDecoder(w.Body).Decode(&resp)
-	assert.Empty(t, resp)
+	assert.Empty(t, resp["data"])
+	assert.Nil(t, resp["next_cursor"])
+	assert.Equal(t, false, resp["has_more"])
 }
 
 func TestListRecipients_WithData(t *testing.T) {
@@ -134,9 +178,9 @@ func TestListRecipients_WithData(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var resp []interface{}
+	var resp map[string]interface{}
 	json.NewDecoder(w.Body).Decode(&resp)
-	assert.Len(t, resp, 2)
+	assert.Len(t, resp["data"], 2)
 }
 
 func TestUpdateRecipient_Success(t *testing.T) {
@@ -282,3 +326,479 @@ func TestGetRecipient_InvalidID(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestListRecipients_CursorPagination(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "list-pages@example.com")
+
+	for _, name := range []string{"Carla", "alice", "Bob", "Eve", "Dan"} {
+		createRecipient(t, router, token, map[string]interface{}{"name": name})
+	}
+
+	page := listRecipients(t, router, token, "?sort=name&limit=2")
+	assert.Equal(t, []string{"alice", "Bob"}, recipientNames(page))
+	assert.Equal(t, true, page["has_more"])
+
+	page = listRecipients(t, router, token, "?sort=name&limit=2&cursor="+page["next_cursor"].(string))
+	assert.Equal(t, []string{"Carla", "Dan"}, recipientNames(page))
+
+	page = listRecipients(t, router, token, "?sort=name&limit=2&cursor="+page["next_cursor"].(string))
+	assert.Equal(t, []string{"Eve"}, recipientNames(page))
+	assert.Equal(t, false, page["has_more"])
+	assert.Nil(t, page["next_cursor"])
+}
+
+func TestListRecipients_Filters(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "list-filter@example.com")
+
+	createRecipient(t, router, token, map[string]interface{}{
+		"name": "Kid", "age": 10, "gender": "male", "min_budget": 20, "max_budget": 50,
+		"keywords": []string{"gaming", "lego"},
+	})
+	createRecipient(t, router, token, map[string]interface{}{
+		"name": "Mom", "age": 60, "gender": "female", "min_budget": 100, "max_budget": 300,
+		"keywords": []string{"gardening"},
+	})
+	createRecipient(t, router, token, map[string]interface{}{
+		"name": "Teen", "age": 16, "gender": "female", "min_budget": 40, "max_budget": 120,
+		"keywords": []string{"gaming"},
+	})
+
+	page := listRecipients(t, router, token, "?sort=name&gender=female")
+	assert.Equal(t, []string{"Mom", "Teen"}, recipientNames(page))
+
+	page = listRecipients(t, router, token, "?sort=name&min_age=12&max_age=20")
+	assert.Equal(t, []string{"Teen"}, recipientNames(page))
+
+	page = listRecipients(t, router, token, "?sort=name&budget_min=60&budget_max=90")
+	assert.Equal(t, []string{"Teen"}, recipientNames(page))
+
+	page = listRecipients(t, router, token, "?sort=age&order=desc&keywords=gaming")
+	assert.Equal(t, []string{"Teen", "Kid"}, recipientNames(page))
+}
+
+func TestListRecipients_BudgetAcrossCurrencies(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "list-currency@example.com")
+
+	// The test rates make 1 USD worth 5 BRL and give EUR no rate at all.
+	createRecipient(t, router, token, map[string]interface{}{"name": "Ana", "min_budget": 20, "max_budget": 50})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Bia", "min_budget": 100, "max_budget": 200, "currency": "BRL"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Caio", "min_budget": 200, "max_budget": 500, "currency": "BRL"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Dora", "max_budget": 80, "currency": "EUR"})
+
+	page := listRecipients(t, router, token, "?sort=budget")
+	assert.Equal(t, []string{"Dora", "Bia", "Ana", "Caio"}, recipientNames(page), "compared in USD")
+
+	page = listRecipients(t, router, token, "?sort=name&budget_min=45")
+	assert.Equal(t, []string{"Ana", "Caio"}, recipientNames(page))
+	page = listRecipients(t, router, token, "?sort=name&budget_max=19")
+	assert.Empty(t, recipientNames(page), "Bia's 100 BRL is 20 USD")
+
+	page = listRecipients(t, router, token, "?sort=name&budget_min=240&currency=brl")
+	assert.Equal(t, []string{"Ana", "Caio"}, recipientNames(page))
+
+	page = listRecipients(t, router, token, "?sort=budget&limit=2")
+	for _, query := range []string{"?sort=budget&limit=2&currency=BRL&cursor=", "?sort=budget&currency=XYZ&cursor="} {
+		req := httptest.NewRequest(http.MethodGet, "/api/recipients"+query+page["next_cursor"].(string), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	page = listRecipients(t, router, token, "?sort=budget&limit=2&cursor="+page["next_cursor"].(string))
+	assert.Equal(t, []string{"Ana", "Caio"}, recipientNames(page))
+}
+
+func TestListRecipients_NextBirthday(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "list-bday@example.com")
+
+	today := time.Now().UTC()
+	soon := today.AddDate(-30, 0, 3)
+	later := today.AddDate(-30, 1, 0)
+	createRecipient(t, router, token, map[string]interface{}{"name": "NoDate"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Later", "birthdate": later.Format("2006-01-02")})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Soon", "birthdate": soon.Format("2006-01-02")})
+
+	page := listRecipients(t, router, token, "?sort=next_birthday")
+	assert.Equal(t, []string{"Soon", "Later", "NoDate"}, recipientNames(page))
+}
+
+func TestListRecipients_InvalidParams(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "list-invalid@example.com")
+
+	for _, query := range []string{"?sort=shoe_size", "?order=up", "?limit=-1", "?limit=500", "?min_age=x", "?cursor=garbage"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/recipients"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestSearchRecipients_FuzzyMatch(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "search@example.com")
+
+	createRecipient(t, router, token, map[string]interface{}{"name": "João Pereira"})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Maria Silva", "keywords": []string{"gardening"}})
+	createRecipient(t, router, token, map[string]interface{}{"name": "Pedro Santos"})
+
+	search := func(q string) []string {
+		req := httptest.NewRequest(http.MethodGet, "/api/recipients/search?q="+url.QueryEscape(q), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var results []map[string]interface{}
+		json.NewDecoder(w.Body).Decode(&results)
+		var names []string
+		for _, r := range results {
+			names = append(names, r["name"].(string))
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"João Pereira"}, search("Joao"))
+	assert.Equal(t, []string{"Maria Silva"}, search("gardenin"))
+	assert.Equal(t, []string{"Pedro Santos"}, search("pedor santos"))
+}
+
+func TestSearchRecipients_MissingQuery(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "search-empty@example.com")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/recipients/search?q=%20", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteRecipient_TrashAndRestore(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "trash@example.com")
+	other := registerAndGetToken(t, router, "trash-other@example.com")
+
+	id := createRecipient(t, router, token, map[string]interface{}{"name": "Oops"})
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/recipients/"+id, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Trashed recipients drop out of the list but show up in the trash
+	assert.Empty(t, listRecipients(t, router, token, "")["data"])
+
+	req = httptest.NewRequest(http.MethodGet, "/api/recipients/trash", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var trash []map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&trash)
+	require.Len(t, trash, 1)
+	assert.Equal(t, id, trash[0]["id"])
+	assert.NotEmpty(t, trash[0]["deleted_at"])
+
+	// Another user cannot restore it
+	req = httptest.NewRequest(http.MethodPost, "/api/recipients/"+id+"/restore", nil)
+	req.Header.Set("Authorization", "Bearer "+other)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/recipients/"+id+"/restore", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, []string{"Oops"}, recipientNames(listRecipients(t, router, token, "")))
+
+	// Restoring a recipient that is not in the trash is a 404
+	req = httptest.NewRequest(http.MethodPost, "/api/recipients/"+id+"/restore", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRecipientHistory_RecordsAndReverts(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "history@example.com")
+
+	id := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Ana", "max_budget": 100, "keywords": []string{"travel"},
+	})
+
+	body, _ := json.Marshal(map[string]interface{}{"max_budget": 250, "keywords": []string{"travel", "fitness"}})
+	req := httptest.NewRequest(http.MethodPut, "/api/recipients/"+id, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	history := func() []map[string]interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/api/recipients/"+id+"/history", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var versions []map[string]interface{}
+		json.NewDecoder(w.Body).Decode(&versions)
+		return versions
+	}
+
+	versions := history()
+	require.Len(t, versions, 2)
+	assert.Equal(t, "update", versions[0]["action"])
+	assert.Equal(t, float64(2), versions[0]["version"])
+	assert.Equal(t, "create", versions[1]["action"])
+
+	var fields []string
+	for _, c := range versions[0]["changes"].([]interface{}) {
+		fields = append(fields, c.(map[string]interface{})["field"].(string))
+	}
+	assert.ElementsMatch(t, []string{"max_budget", "keywords"}, fields)
+
+	// Revert to the original version
+	req = httptest.NewRequest(http.MethodPost, "/api/recipients/"+id+"/history/1/revert", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var reverted map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&reverted)
+	assert.Equal(t, float64(100), reverted["max_budget"])
+	assert.Equal(t, []interface{}{"travel"}, reverted["keywords"])
+
+	versions = history()
+	require.Len(t, versions, 3)
+	assert.Equal(t, "revert", versions[0]["action"])
+
+	// Unknown versions are a 404
+	req = httptest.NewRequest(http.MethodPost, "/api/recipients/"+id+"/history/42/revert", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRecipientETag_ConditionalRequests(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "etag@example.com")
+
+	id := createRecipient(t, router, token, map[string]interface{}{"name": "Shared"})
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/recipients/"+id, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	put := func(ifMatch, name string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"name": name})
+		req := httptest.NewRequest(http.MethodPut, "/api/recipients/"+id, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	// Unchanged representation
+	w = get(etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// First device wins
+	w = put(etag, "Device A")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	// Second device still holds the old ETag
+	w = put(etag, "Device B")
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	w = get(etag)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	assert.Equal(t, "Device A", resp["name"])
+
+	// Unconditional writes still go through
+	w = put("", "No Precondition")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRecipientETag_ConcurrentWriteAfterCheck(t *testing.T) {
+	router, _, _, _, recipientRepo, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "etag-race@example.com")
+	id := createRecipient(t, router, token, map[string]interface{}{"name": "Shared"})
+
+	// Another device commits between the If-Match check and the write
+	recipientRepo.beforeUpdate = func(id uuid.UUID) {
+		recipientRepo.beforeUpdate = nil
+		recipientRepo.mu.Lock()
+		defer recipientRepo.mu.Unlock()
+		recipientRepo.recipients[id].Version++
+	}
+	body, _ := json.Marshal(map[string]interface{}{"name": "Device B"})
+	req := httptest.NewRequest(http.MethodPut, "/api/recipients/"+id, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, "/problems/precondition_failed", decodeProblem(t, w)["type"])
+}
+
+func fieldErrorCodes(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var resp struct {
+		Type   string `json:"type"`
+		Errors []struct {
+			Field string `json:"field"`
+			Code  string `json:"code"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "/problems/validation_failed", resp.Type)
+	codes := make(map[string]string)
+	for _, e := range resp.Errors {
+		codes[e.Field] = e.Code
+	}
+	return codes
+}
+
+func TestCreateRecipient_ValidationErrors(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "validate-create@example.com")
+
+	keywords := make([]string, 21)
+	for i := range keywords {
+		keywords[i] = fmt.Sprintf("kw%d", i)
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":       "  ",
+		"age":        -3,
+		"gender":     "helicopter",
+		"birthdate":  time.Now().AddDate(1, 0, 0).Format("2006-01-02"),
+		"min_budget": 300,
+		"max_budget": 100,
+		"keywords":   keywords,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/recipients", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"name":       "required",
+		"age":        "out_of_range",
+		"gender":     "invalid_choice",
+		"birthdate":  "out_of_range",
+		"min_budget": "min_exceeds_max",
+		"keywords":   "too_many",
+	}, fieldErrorCodes(t, w))
+}
+
+func TestUpdateRecipient_ValidationErrors(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "validate-update@example.com")
+
+	id := createRecipient(t, router, token, map[string]interface{}{
+		"name": "Valid", "min_budget": 10, "max_budget": 50,
+	})
+
+	// Lowering max below the stored min is caught against the merged state.
+	// Repeated keywords are merged, so only the empty one is reported.
+	body, _ := json.Marshal(map[string]interface{}{
+		"max_budget": 5,
+		"keywords":   []string{"books", "Books", ""},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/api/recipients/"+id, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"min_budget":  "min_exceeds_max",
+		"keywords[1]": "required",
+	}, fieldErrorCodes(t, w))
+
+	// Nothing was persisted
+	req = httptest.NewRequest(http.MethodGet, "/api/recipients/"+id, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	assert.Equal(t, float64(50), resp["max_budget"])
+}
+
+func TestRecipientCurrencyAndAmounts(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "currency@example.com")
+
+	// Amounts are kept to the cent, whatever float noise the client sends
+	w := doJSON(t, router, http.MethodPost, "/api/recipients", token, map[string]interface{}{
+		"name": "Ana", "min_budget": json.RawMessage("0.30000000000000004"), "max_budget": "19.999",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.Equal(t, 0.3, created["min_budget"])
+	assert.Equal(t, float64(20), created["max_budget"])
+	assert.Equal(t, "USD", created["currency"], "defaults to the preferred currency")
+
+	w = doJSON(t, router, http.MethodPut, "/api/me/preferences", token, map[string]interface{}{"currency": "BRL"})
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/recipients", token, map[string]interface{}{"name": "Bia"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.Equal(t, "BRL", created["currency"])
+
+	w = doJSON(t, router, http.MethodPut, "/api/recipients/"+created["id"].(string), token, map[string]interface{}{"currency": " eur "})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&updated))
+	assert.Equal(t, "EUR", updated["currency"])
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients", token, map[string]interface{}{"name": "Caio", "currency": "JPY"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"currency": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/recipients", token, map[string]interface{}{"name": "Davi", "max_budget": "lots"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// ReportHandler handles reporting HTTP requests.
+type ReportHandler struct {
+	reportService port.ReportService
+}
+
+// NewReportHandler creates a new ReportHandler.
+func NewReportHandler(reportService port.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// Spending handles GET /api/reports/spending?year=.
+func (h *ReportHandler) Spending(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	year := 0
+	if v := r.URL.Query().Get("year"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "year must be an integer")
+			return
+		}
+		year = n
+	}
+
+	report, err := h.reportService.Spending(r.Context(), userID, year)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,169 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getSpendingReport(t *testing.T, router http.Handler, token, query string) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/reports/spending"+query, token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var report map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	return report
+}
+
+// breakdown flattens a report breakdown into key → total.
+func breakdown(report map[string]interface{}, field, key string) map[string]float64 {
+	totals := make(map[string]float64)
+	for _, row := range report[field].([]interface{}) {
+		r := row.(map[string]interface{})
+		totals[r[key].(string)] = r["total"].(float64)
+	}
+	return totals
+}
+
+func TestYearlyBudgets(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "budgets@example.com")
+
+	w := doJSON(t, router, http.MethodPut, "/api/budgets/2024", token, map[string]interface{}{"amount": 1500})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = doJSON(t, router, http.MethodPut, "/api/budgets/2025", token, map[string]interface{}{"amount": 1000})
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodPut, "/api/budgets/2024", token, map[string]interface{}{"amount": 1800})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = doJSON(t, router, http.MethodGet, "/api/budgets", token, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var budgets []map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&budgets))
+	require.Len(t, budgets, 2)
+	assert.Equal(t, float64(2025), budgets[0]["year"])
+	assert.Equal(t, float64(1800), budgets[1]["amount"])
+
+	w = doJSON(t, router, http.MethodPut, "/api/budgets/1800", token, map[string]interface{}{"amount": 0})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"year": "out_of_range", "amount": "out_of_range"}, fieldErrorCodes(t, w))
+	w = doJSON(t, router, http.MethodPut, "/api/budgets/next", token, map[string]interface{}{"amount": 10})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = doJSON(t, router, http.MethodDelete, "/api/budgets/2025", token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodDelete, "/api/budgets/2025", token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Budgets are per user
+	other := registerAndGetToken(t, router, "budgets-other@example.com")
+	w = doJSON(t, router, http.MethodGet, "/api/budgets", other, nil)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&budgets))
+	assert.Empty(t, budgets)
+}
+
+func TestSpendingReport(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "spending@example.com")
+
+	ana := createRecipient(t, router, token, map[string]interface{}{"name": "Ana", "max_budget": 50})
+	bruno := createRecipient(t, router, token, map[string]interface{}{"name": "Bruno"})
+	family := createGroup(t, router, token, "Family")
+	work := createGroup(t, router, token, "Work")
+	w := doJSON(t, router, http.MethodPost, "/api/groups/"+family+"/members", token, map[string]interface{}{"recipient_ids": []string{ana, bruno}})
+	require.Equal(t, http.StatusOK, w.Code)
+	w = doJSON(t, router, http.MethodPost, "/api/groups/"+work+"/members", token, map[string]interface{}{"recipient_ids": []string{bruno}})
+	require.Equal(t, http.StatusOK, w.Code)
+	birthday := createOccasion(t, router, token, ana, map[string]interface{}{"kind": "birthday", "date": "1990-03-10", "budget": 100})
+
+	createGift(t, router, token, ana, map[string]interface{}{"item": "Scarf", "price": 40, "given_on": "2024-03-10", "occasion_id": birthday["id"]})
+	book := createGift(t, router, token, ana, map[string]interface{}{"item": "Book", "price": 60, "given_on": "2024-12-20"})
+	createGift(t, router, token, bruno, map[string]interface{}{"item": "Watch", "price": 120.5, "given_on": "2024-12-25"})
+	createGift(t, router, token, ana, map[string]interface{}{"item": "Mug", "price": 30, "given_on": "2023-05-01"})
+	w = doJSON(t, router, http.MethodPut, "/api/budgets/2024", token, map[string]interface{}{"amount": 200})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	report := getSpendingReport(t, router, token, "?year=2024")
+	assert.Equal(t, float64(2024), report["year"])
+	assert.Equal(t, "USD", report["currency"])
+	assert.Equal(t, 220.5, report["total"])
+	assert.Equal(t, float64(3), report["gift_count"])
+	assert.Equal(t, float64(200), report["budget"])
+	assert.Equal(t, -20.5, report["remaining"])
+
+	assert.Equal(t, map[string]float64{"Bruno": 120.5, "Ana": 100}, breakdown(report, "by_recipient", "name"))
+	assert.Equal(t, "Bruno", report["by_recipient"].([]interface{})[0].(map[string]interface{})["name"], "biggest spend first")
+	assert.Equal(t, map[string]float64{"Family": 220.5, "Work": 120.5}, breakdown(report, "by_group", "name"))
+	assert.Equal(t, map[string]float64{"birthday": 40, "none": 180.5}, breakdown(report, "by_occasion", "kind"))
+
+	months := report["by_month"].([]interface{})
+	require.Len(t, months, 12)
+	assert.Equal(t, float64(40), months[2].(map[string]interface{})["total"])
+	assert.Equal(t, 180.5, months[11].(map[string]interface{})["total"])
+	assert.Equal(t, float64(2), months[11].(map[string]interface{})["count"])
+	assert.Equal(t, float64(0), months[0].(map[string]interface{})["total"])
+
+	// The yearly warning comes first; the scarf stayed within its occasion's budget
+	warnings := report["warnings"].([]interface{})
+	require.Len(t, warnings, 2)
+	yearly := warnings[0].(map[string]interface{})
+	assert.Equal(t, "over_yearly_budget", yearly["code"])
+	assert.Equal(t, float64(200), yearly["limit"])
+	gift := warnings[1].(map[string]interface{})
+	assert.Equal(t, "over_gift_budget", gift["code"])
+	assert.Equal(t, book["id"], gift["gift_id"])
+	assert.Equal(t, ana, gift["recipient_id"])
+	assert.Equal(t, float64(50), gift["limit"])
+
+	earlier := getSpendingReport(t, router, token, "?year=2023")
+	assert.Equal(t, float64(30), earlier["total"])
+	assert.Nil(t, earlier["budget"])
+	assert.Nil(t, earlier["remaining"])
+	assert.Empty(t, earlier["warnings"])
+	// Groups use current memberships, whatever the year
+	assert.Equal(t, map[string]float64{"Family": 30}, breakdown(earlier, "by_group", "name"))
+
+	w = doJSON(t, router, http.MethodGet, "/api/reports/spending?year=1800", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/reports/spending?year=last", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Other users see only their own spending
+	other := registerAndGetToken(t, router, "spending-other@example.com")
+	assert.Equal(t, float64(0), getSpendingReport(t, router, other, "?year=2024")["total"])
+}
+
+func TestSpendingReport_ConvertsCurrencies(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "report-currencies@example.com")
+	w := doJSON(t, router, http.MethodPut, "/api/me/preferences", token, map[string]interface{}{"currency": "BRL"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	ana := createRecipient(t, router, token, map[string]interface{}{"name": "Ana", "max_budget": 20, "currency": "USD"})
+	createGift(t, router, token, ana, map[string]interface{}{"item": "Book", "price": 30, "given_on": "2024-03-01"})
+	createGift(t, router, token, ana, map[string]interface{}{"item": "Cake", "price": 50, "currency": "BRL", "given_on": "2024-03-02"})
+	createGift(t, router, token, ana, map[string]interface{}{"item": "Scarf", "price": 10, "currency": "EUR", "given_on": "2024-03-03"})
+	w = doJSON(t, router, http.MethodPut, "/api/budgets/2024", token, map[string]interface{}{"amount": 30, "currency": "USD"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	report := getSpendingReport(t, router, token, "?year=2024")
+	assert.Equal(t, "BRL", report["currency"])
+	assert.Equal(t, float64(200), report["total"], "USD at 5 BRL, EUR left out")
+	assert.Equal(t, float64(2), report["gift_count"])
+	assert.Equal(t, float64(150), report["budget"])
+	assert.Equal(t, float64(-50), report["remaining"])
+
+	var codes []string
+	for _, warning := range report["warnings"].([]interface{}) {
+		codes = append(codes, warning.(map[string]interface{})["code"].(string))
+	}
+	assert.Equal(t, []string{"over_yearly_budget", "over_gift_budget", "missing_exchange_rate"}, codes)
+	giftWarning := report["warnings"].([]interface{})[1].(map[string]interface{})
+	assert.Equal(t, float64(150), giftWarning["amount"])
+	assert.Equal(t, float64(100), giftWarning["limit"])
+	assert.Equal(t, "EUR", report["warnings"].([]interface{})[2].(map[string]interface{})["currency"])
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"net/netip"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -15,18 +16,41 @@ func NewRouter(
 	authService port.AuthService,
 	userService port.UserService,
 	recipientService port.RecipientService,
+	duplicateService port.DuplicateService,
+	prefsService port.PreferencesService,
+	keywordService port.KeywordService,
+	groupService port.GroupService,
+	occasionService port.OccasionService,
+	giftService port.GiftService,
+	ideaService port.IdeaService,
+	groupGiftService port.GroupGiftService,
+	exchangeService port.ExchangeService,
+	wishlistService port.WishlistService,
+	householdService port.HouseholdService,
+	suggestionService port.SuggestionService,
+	budgetService port.BudgetService,
+	reportService port.ReportService,
+	holidayService port.HolidayService,
+	calendarService port.CalendarService,
+	importService port.ImportService,
+	exportService port.ExportService,
+	upcomingService port.UpcomingService,
 	jwtService *jwtpkg.Service,
+	publicLimiter *RateLimiter,
+	trustedProxies []netip.Prefix,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
+	// RequestID runs first so the logger and problem responses share the ID.
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(RealIP(trustedProxies))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match"},
+		ExposedHeaders:   []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -34,9 +58,31 @@ func NewRouter(
 	authHandler := NewAuthHandler(authService)
 	userHandler := NewUserHandler(userService)
 	recipientHandler := NewRecipientHandler(recipientService)
+	duplicateHandler := NewDuplicateHandler(duplicateService)
+	prefsHandler := NewPreferencesHandler(prefsService)
+	keywordHandler := NewKeywordHandler(keywordService)
+	groupHandler := NewGroupHandler(groupService)
+	occasionHandler := NewOccasionHandler(occasionService)
+	giftHandler := NewGiftHandler(giftService)
+	ideaHandler := NewIdeaHandler(ideaService)
+	groupGiftHandler := NewGroupGiftHandler(groupGiftService)
+	exchangeHandler := NewExchangeHandler(exchangeService)
+	wishlistHandler := NewWishlistHandler(wishlistService)
+	householdHandler := NewHouseholdHandler(householdService)
+	suggestionHandler := NewSuggestionHandler(suggestionService)
+	budgetHandler := NewBudgetHandler(budgetService)
+	reportHandler := NewReportHandler(reportService)
+	holidayHandler := NewHolidayHandler(holidayService)
+	calendarHandler := NewCalendarHandler(calendarService)
+	importHandler := NewImportHandler(importService)
+	exportHandler := NewExportHandler(exportService)
+	upcomingHandler := NewUpcomingHandler(upcomingService)
 	authMiddleware := NewAuthMiddleware(jwtService)
 
 	// Health check
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed)
+
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -53,19 +99,171 @@ func NewRouter(
 			r.Post("/refresh", authHandler.RefreshToken)
 		})
 
+		// Calendar feeds authenticate with the secret token in the URL
+		r.Get("/calendar/{token}.ics", calendarHandler.Feed)
+
+		// Gift exchange participants read their assignment through a secret link
+		r.Get("/exchange-assignments/{token}", exchangeHandler.AssignmentByToken)
+
+		// Public pages anyone with the link may use, rate limited per client.
+		// A signed-in caller is identified but never required.
+		r.Route("/public", func(r chi.Router) {
+			r.Use(publicLimiter.Limit)
+			r.Use(authMiddleware.Identify)
+
+			r.Get("/wishlists/{slug}", wishlistHandler.View)
+			r.Post("/wishlists/{slug}/items/{itemID}/claim", wishlistHandler.Claim)
+			r.Delete("/wishlists/{slug}/claims/{token}", wishlistHandler.ReleaseClaim)
+		})
+
 		// Protected routes
 		r.Group(func(r chi.Router) {
 			r.Use(authMiddleware.Authenticate)
 
 			r.Get("/auth/me", userHandler.GetCurrentUser)
 
+			r.Route("/me", func(r chi.Router) {
+				r.Get("/preferences", prefsHandler.Get)
+				r.Put("/preferences", prefsHandler.Update)
+				r.Get("/calendar", calendarHandler.GetFeed)
+				r.Post("/calendar/rotate", calendarHandler.RotateFeed)
+				r.Delete("/calendar", calendarHandler.DisableFeed)
+			})
+
+			r.Get("/keywords", keywordHandler.Suggest)
+			r.Get("/holidays", holidayHandler.List)
+			r.Get("/upcoming", upcomingHandler.Upcoming)
+			r.Get("/reminders", upcomingHandler.Reminders)
+			r.Get("/reports/spending", reportHandler.Spending)
+
+			r.Route("/budgets", func(r chi.Router) {
+				r.Get("/", budgetHandler.List)
+				r.Put("/{year}", budgetHandler.Set)
+				r.Delete("/{year}", budgetHandler.Delete)
+			})
+
+			r.Route("/import", func(r chi.Router) {
+				r.Post("/ics", importHandler.PreviewICS)
+				r.Post("/vcard", importHandler.PreviewVCard)
+				r.Post("/spreadsheet", importHandler.PreviewSpreadsheet)
+				r.Get("/{id}", importHandler.Get)
+				r.Put("/{id}/mapping", importHandler.MapColumns)
+				r.Post("/{id}/confirm", importHandler.Confirm)
+			})
+
+			r.Route("/export", func(r chi.Router) {
+				r.Get("/recipients.vcf", exportHandler.RecipientsVCard)
+				r.Get("/recipients.csv", exportHandler.RecipientsCSV)
+				r.Get("/recipients.xlsx", exportHandler.RecipientsXLSX)
+			})
+
+			r.Route("/groups", func(r chi.Router) {
+				r.Post("/", groupHandler.Create)
+				r.Get("/", groupHandler.List)
+				r.Get("/{id}", groupHandler.GetByID)
+				r.Put("/{id}", groupHandler.Update)
+				r.Delete("/{id}", groupHandler.Delete)
+				r.Post("/{id}/members", groupHandler.AddMembers)
+				r.Delete("/{id}/members/{recipientID}", groupHandler.RemoveMember)
+			})
+
+			r.Route("/group-gifts", func(r chi.Router) {
+				r.Post("/", groupGiftHandler.Create)
+				r.Get("/", groupGiftHandler.List)
+				r.Get("/{id}", groupGiftHandler.GetByID)
+				r.Put("/{id}", groupGiftHandler.Update)
+				r.Delete("/{id}", groupGiftHandler.Delete)
+				r.Get("/{id}/progress", groupGiftHandler.Progress)
+				r.Post("/{id}/settle", groupGiftHandler.Settle)
+				r.Post("/{id}/contributors", groupGiftHandler.Invite)
+				r.Put("/{id}/contributors/{contributorID}", groupGiftHandler.UpdateContribution)
+				r.Delete("/{id}/contributors/{contributorID}", groupGiftHandler.RemoveContributor)
+			})
+
+			r.Route("/exchanges", func(r chi.Router) {
+				r.Post("/", exchangeHandler.Create)
+				r.Get("/", exchangeHandler.List)
+				r.Get("/{id}", exchangeHandler.GetByID)
+				r.Put("/{id}", exchangeHandler.Update)
+				r.Delete("/{id}", exchangeHandler.Delete)
+				r.Post("/{id}/participants", exchangeHandler.AddParticipant)
+				r.Delete("/{id}/participants/{participantID}", exchangeHandler.RemoveParticipant)
+				r.Post("/{id}/exclusions", exchangeHandler.AddExclusion)
+				r.Delete("/{id}/exclusions/{exclusionID}", exchangeHandler.RemoveExclusion)
+				r.Post("/{id}/draw", exchangeHandler.Draw)
+				r.Post("/{id}/verify", exchangeHandler.Verify)
+				r.Get("/{id}/assignment", exchangeHandler.Assignment)
+			})
+
+			r.Route("/wishlists", func(r chi.Router) {
+				r.Post("/", wishlistHandler.Create)
+				r.Get("/", wishlistHandler.List)
+				r.Get("/{id}", wishlistHandler.GetByID)
+				r.Put("/{id}", wishlistHandler.Update)
+				r.Delete("/{id}", wishlistHandler.Delete)
+				r.Post("/{id}/rotate", wishlistHandler.RotateSlug)
+			})
+
+			r.Route("/household", func(r chi.Router) {
+				r.Post("/", householdHandler.Create)
+				r.Get("/", householdHandler.Get)
+				r.Put("/", householdHandler.Update)
+				r.Delete("/", householdHandler.Delete)
+				r.Post("/leave", householdHandler.Leave)
+				r.Post("/invitations", householdHandler.Invite)
+				r.Delete("/invitations/{invitationID}", householdHandler.RevokeInvitation)
+				r.Put("/members/{userID}", householdHandler.UpdateMember)
+				r.Delete("/members/{userID}", householdHandler.RemoveMember)
+			})
+			r.Post("/household-invitations/{token}/accept", householdHandler.Accept)
+
 			r.Route("/recipients", func(r chi.Router) {
 				r.Post("/", recipientHandler.Create)
 				r.Get("/", recipientHandler.List)
 				r.Delete("/", recipientHandler.BulkDelete)
+				r.Get("/search", recipientHandler.Search)
+				r.Get("/trash", recipientHandler.ListTrash)
+				r.Get("/duplicates", duplicateHandler.List)
+				r.Post("/merge", duplicateHandler.Merge)
 				r.Get("/{id}", recipientHandler.GetByID)
 				r.Put("/{id}", recipientHandler.Update)
 				r.Delete("/{id}", recipientHandler.Delete)
+				r.Post("/{id}/restore", recipientHandler.Restore)
+				r.Get("/{id}/history", recipientHandler.History)
+				r.Post("/{id}/history/{version}/revert", recipientHandler.Revert)
+				r.Get("/{id}/suggestions", suggestionHandler.Suggest)
+
+				r.Route("/{id}/occasions", func(r chi.Router) {
+					r.Post("/", occasionHandler.Create)
+					r.Get("/", occasionHandler.List)
+					r.Get("/{occasionID}", occasionHandler.GetByID)
+					r.Put("/{occasionID}", occasionHandler.Update)
+					r.Delete("/{occasionID}", occasionHandler.Delete)
+				})
+
+				r.Route("/{id}/gifts", func(r chi.Router) {
+					r.Post("/", giftHandler.Create)
+					r.Get("/", giftHandler.List)
+					r.Get("/{giftID}", giftHandler.GetByID)
+					r.Put("/{giftID}", giftHandler.Update)
+					r.Delete("/{giftID}", giftHandler.Delete)
+				})
+
+				r.Route("/{id}/ideas", func(r chi.Router) {
+					r.Post("/", ideaHandler.Create)
+					r.Get("/", ideaHandler.List)
+					r.Post("/promote", ideaHandler.Promote)
+					r.Get("/{ideaID}", ideaHandler.GetByID)
+					r.Put("/{ideaID}", ideaHandler.Update)
+					r.Delete("/{ideaID}", ideaHandler.Delete)
+					r.Post("/{ideaID}/status", ideaHandler.Transition)
+				})
+
+				r.Route("/{id}/holidays", func(r chi.Router) {
+					r.Post("/", holidayHandler.Subscribe)
+					r.Get("/", holidayHandler.ListSubscriptions)
+					r.Delete("/{subscriptionID}", holidayHandler.Unsubscribe)
+				})
 			})
 		})
 	})
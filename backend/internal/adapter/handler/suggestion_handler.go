@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// SuggestionHandler handles gift suggestion HTTP requests.
+type SuggestionHandler struct {
+	suggestionService port.SuggestionService
+}
+
+// NewSuggestionHandler creates a new SuggestionHandler.
+func NewSuggestionHandler(suggestionService port.SuggestionService) *SuggestionHandler {
+	return &SuggestionHandler{suggestionService: suggestionService}
+}
+
+// Suggest handles GET /api/recipients/{id}/suggestions.
+func (h *SuggestionHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+	query := r.URL.Query()
+
+	recipientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidRecipientID)
+		return
+	}
+
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "limit must be an integer")
+			return
+		}
+		limit = n
+	}
+
+	suggestions, err := h.suggestionService.Suggest(r.Context(), userID, recipientID, limit, query.Get("locale"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// UpcomingHandler handles the upcoming feed and reminder HTTP requests.
+type UpcomingHandler struct {
+	upcomingService port.UpcomingService
+}
+
+// NewUpcomingHandler creates a new UpcomingHandler.
+func NewUpcomingHandler(upcomingService port.UpcomingService) *UpcomingHandler {
+	return &UpcomingHandler{upcomingService: upcomingService}
+}
+
+// Upcoming handles GET /api/upcoming?days=.
+func (h *UpcomingHandler) Upcoming(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	days := 0
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			badRequest(w, r, "days must be an integer")
+			return
+		}
+		days = n
+	}
+
+	feed, err := h.upcomingService.Upcoming(r.Context(), userID, days)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, feed)
+}
+
+// Reminders handles GET /api/reminders.
+func (h *UpcomingHandler) Reminders(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	reminders, err := h.upcomingService.Reminders(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, reminders)
+}
@@ -23,7 +23,7 @@ func (h *UserHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userService.GetByID(r.Context(), userID)
 	if err != nil {
-		response.Error(w, http.StatusNotFound, "user not found")
+		writeError(w, r, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, user)
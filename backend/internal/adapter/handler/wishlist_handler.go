@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/response"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// WishlistHandler handles wishlist HTTP requests, both the owner's and
+// the public ones.
+type WishlistHandler struct {
+	wishlistService port.WishlistService
+}
+
+// NewWishlistHandler creates a new WishlistHandler.
+func NewWishlistHandler(wishlistService port.WishlistService) *WishlistHandler {
+	return &WishlistHandler{wishlistService: wishlistService}
+}
+
+// Create handles POST /api/wishlists.
+func (h *WishlistHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	var req domain.CreateWishlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	wishlist, err := h.wishlistService.Create(r.Context(), userID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, wishlist)
+}
+
+// List handles GET /api/wishlists.
+func (h *WishlistHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	wishlists, err := h.wishlistService.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, wishlists)
+}
+
+// GetByID handles GET /api/wishlists/{id}.
+func (h *WishlistHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidWishlistID)
+		return
+	}
+
+	wishlist, err := h.wishlistService.GetByID(r.Context(), userID, wishlistID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, wishlist)
+}
+
+// Update handles PUT /api/wishlists/{id}.
+func (h *WishlistHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidWishlistID)
+		return
+	}
+
+	var req domain.UpdateWishlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	wishlist, err := h.wishlistService.Update(r.Context(), userID, wishlistID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, wishlist)
+}
+
+// Delete handles DELETE /api/wishlists/{id}.
+func (h *WishlistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidWishlistID)
+		return
+	}
+
+	if err := h.wishlistService.Delete(r.Context(), userID, wishlistID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "wishlist deleted"})
+}
+
+// RotateSlug handles POST /api/wishlists/{id}/rotate.
+func (h *WishlistHandler) RotateSlug(w http.ResponseWriter, r *http.Request) {
+	userID := UserIDFromContext(r.Context())
+
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, errInvalidWishlistID)
+		return
+	}
+
+	wishlist, err := h.wishlistService.RotateSlug(r.Context(), userID, wishlistID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, wishlist)
+}
+
+// View handles GET /api/public/wishlists/{slug}.
+func (h *WishlistHandler) View(w http.ResponseWriter, r *http.Request) {
+	viewerID := UserIDFromContext(r.Context())
+
+	wishlist, err := h.wishlistService.View(r.Context(), chi.URLParam(r, "slug"), viewerID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	response.JSON(w, http.StatusOK, wishlist)
+}
+
+// Claim handles POST /api/public/wishlists/{slug}/items/{itemID}/claim.
+func (h *WishlistHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	viewerID := UserIDFromContext(r.Context())
+
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemID"))
+	if err != nil {
+		writeError(w, r, errInvalidItemID)
+		return
+	}
+
+	// The body is optional: a claim needs no details.
+	var req domain.ClaimWishlistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, errInvalidBody)
+		return
+	}
+
+	claim, err := h.wishlistService.Claim(r.Context(), chi.URLParam(r, "slug"), viewerID, itemID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, claim)
+}
+
+// ReleaseClaim handles DELETE /api/public/wishlists/{slug}/claims/{token}.
+func (h *WishlistHandler) ReleaseClaim(w http.ResponseWriter, r *http.Request) {
+	if err := h.wishlistService.ReleaseClaim(r.Context(), chi.URLParam(r, "slug"), chi.URLParam(r, "token")); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"message": "claim released"})
+}
@@ -0,0 +1,198 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createWishlist(t *testing.T, router http.Handler, token string, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodPost, "/api/wishlists", token, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var wishlist map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&wishlist))
+	return wishlist
+}
+
+// viewWishlist fetches a public wishlist, anonymously when token is empty.
+func viewWishlist(t *testing.T, router http.Handler, token, slug string) []map[string]interface{} {
+	t.Helper()
+	w := doJSON(t, router, http.MethodGet, "/api/public/wishlists/"+slug, token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var view struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&view))
+	return view.Items
+}
+
+func claimedTitles(items []map[string]interface{}) []string {
+	var titles []string
+	for _, item := range items {
+		if item["claimed"] == true {
+			titles = append(titles, item["title"].(string))
+		}
+	}
+	return titles
+}
+
+func TestWishlist_PublicViewAndClaims(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	owner := registerAndGetToken(t, router, "owner@example.com")
+	friend := registerAndGetToken(t, router, "friend@example.com")
+	recipientID := createRecipient(t, router, owner, map[string]interface{}{"name": "Lia"})
+	kite := createIdea(t, router, owner, recipientID, map[string]interface{}{"title": "Kite", "price": 30, "notes": "hide in the garage"})
+	puzzle := createIdea(t, router, owner, recipientID, map[string]interface{}{"title": "Puzzle"})
+	createIdea(t, router, owner, recipientID, map[string]interface{}{"title": "Not on the list"})
+
+	wishlist := createWishlist(t, router, owner, map[string]interface{}{
+		"recipient_id": recipientID, "title": "Lia turns 8", "idea_ids": []interface{}{puzzle["id"], kite["id"]},
+	})
+	slug := wishlist["slug"].(string)
+	assert.Len(t, slug, 32)
+
+	w := doJSON(t, router, http.MethodGet, "/api/public/wishlists/"+slug, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "garage", "private notes stay private")
+	items := viewWishlist(t, router, "", slug)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Puzzle", items[0]["title"])
+	assert.Equal(t, "Kite", items[1]["title"])
+	assert.Empty(t, claimedTitles(items))
+	kiteItem := items[1]["id"].(string)
+
+	// Visitors claim without an account.
+	w = doJSON(t, router, http.MethodPost, "/api/public/wishlists/"+slug+"/items/"+kiteItem+"/claim", "",
+		map[string]interface{}{"name": "Aunt May"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var claim map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&claim))
+	claimToken := claim["token"].(string)
+	require.NotEmpty(t, claimToken)
+
+	w = doJSON(t, router, http.MethodPost, "/api/public/wishlists/"+slug+"/items/"+kiteItem+"/claim", friend, nil)
+	assert.Equal(t, http.StatusConflict, w.Code, "an item is bought once")
+
+	assert.Equal(t, []string{"Kite"}, claimedTitles(viewWishlist(t, router, "", slug)))
+	assert.Equal(t, []string{"Kite"}, claimedTitles(viewWishlist(t, router, friend, slug)))
+
+	// The owner never learns what was claimed.
+	assert.Empty(t, claimedTitles(viewWishlist(t, router, owner, slug)))
+	w = doJSON(t, router, http.MethodGet, "/api/wishlists/"+wishlist["id"].(string), owner, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "claim")
+	w = doJSON(t, router, http.MethodPost, "/api/public/wishlists/"+slug+"/items/"+kiteItem+"/claim", owner, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Given ideas drop off the list.
+	assert.Equal(t, http.StatusOK, moveIdea(t, router, owner, recipientID, puzzle["id"].(string),
+		map[string]interface{}{"status": "given"}))
+	items = viewWishlist(t, router, "", slug)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Kite", items[0]["title"])
+
+	w = doJSON(t, router, http.MethodDelete, "/api/public/wishlists/"+slug+"/claims/"+claimToken, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Empty(t, claimedTitles(viewWishlist(t, router, "", slug)))
+	w = doJSON(t, router, http.MethodDelete, "/api/public/wishlists/"+slug+"/claims/"+claimToken, "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Rotating the slug retires the old link.
+	w = doJSON(t, router, http.MethodPost, "/api/wishlists/"+wishlist["id"].(string)+"/rotate", owner, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var rotated map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rotated))
+	assert.NotEqual(t, slug, rotated["slug"])
+	w = doJSON(t, router, http.MethodGet, "/api/public/wishlists/"+slug, "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Len(t, viewWishlist(t, router, "", rotated["slug"].(string)), 1)
+}
+
+func TestWishlist_Validation(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	owner := registerAndGetToken(t, router, "owner@example.com")
+	stranger := registerAndGetToken(t, router, "stranger@example.com")
+	recipientID := createRecipient(t, router, owner, map[string]interface{}{"name": "Lia"})
+	otherID := createRecipient(t, router, owner, map[string]interface{}{"name": "Leo"})
+	idea := createIdea(t, router, owner, recipientID, map[string]interface{}{"title": "Kite"})
+	otherIdea := createIdea(t, router, owner, otherID, map[string]interface{}{"title": "Drum"})
+
+	w := doJSON(t, router, http.MethodPost, "/api/wishlists", owner, map[string]interface{}{
+		"recipient_id": recipientID, "idea_ids": []interface{}{otherIdea["id"]},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"title": "required"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/wishlists", owner, map[string]interface{}{
+		"recipient_id": recipientID, "title": "Lia", "idea_ids": []interface{}{otherIdea["id"]},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"idea_ids": "invalid_choice"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/wishlists", owner, map[string]interface{}{
+		"recipient_id": recipientID, "title": "Lia", "idea_ids": []interface{}{idea["id"], idea["id"]},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{"idea_ids": "duplicate"}, fieldErrorCodes(t, w))
+
+	w = doJSON(t, router, http.MethodPost, "/api/wishlists", stranger, map[string]interface{}{
+		"recipient_id": recipientID, "title": "Not mine",
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	wishlist := createWishlist(t, router, owner, map[string]interface{}{"recipient_id": recipientID, "title": "Lia"})
+	w = doJSON(t, router, http.MethodGet, "/api/wishlists/"+wishlist["id"].(string), stranger, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = doJSON(t, router, http.MethodGet, "/api/public/wishlists/not-a-slug", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWishlist_PublicRoutesAreRateLimited(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	token := registerAndGetToken(t, router, "owner@example.com")
+
+	for i := 0; i < testPublicRateLimit; i++ {
+		w := doJSON(t, router, http.MethodGet, "/api/public/wishlists/missing", "", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	}
+	w := doJSON(t, router, http.MethodGet, "/api/public/wishlists/missing", "", nil)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Equal(t, "/problems/rate_limited", decodeProblem(t, w)["type"])
+
+	w = doJSON(t, router, http.MethodGet, "/api/wishlists", token, nil)
+	assert.Equal(t, http.StatusOK, w.Code, "signed-in routes are not limited")
+}
+
+func TestWishlist_RateLimitTrustsOnlyKnownProxies(t *testing.T) {
+	router, _, _, _, _, _ := setupRouter(t)
+	get := func(peer, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/public/wishlists/missing", nil)
+		req.RemoteAddr = peer
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// A client talking to the API directly cannot dodge the limit by
+	// claiming a new address on every request
+	for i := 0; i < testPublicRateLimit; i++ {
+		require.Equal(t, http.StatusNotFound, get("198.51.100.7:4000", fmt.Sprintf("203.0.113.%d", i)))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get("198.51.100.7:4000", "203.0.113.250"))
+
+	// Behind a trusted proxy each forwarded client has its own allowance,
+	// and hops the client prepended itself are ignored
+	for i := 0; i < testPublicRateLimit; i++ {
+		require.Equal(t, http.StatusNotFound, get("10.0.0.2:5000", fmt.Sprintf("192.0.2.%d, 203.0.113.9", i)))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get("10.0.0.2:5000", "192.0.2.250, 203.0.113.9"))
+	assert.Equal(t, http.StatusNotFound, get("10.0.0.3:5000", "203.0.113.10, 10.0.0.4"))
+}
@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"context"
+	"log"
+
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// LogNotifier implements port.Notifier by writing notifications to the
+// server log. It stands in until an email provider is configured.
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new LogNotifier.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify logs who was notified and about what. The body is left out: it
+// can carry secrets, such as a gift exchange assignment link or an
+// invitation token, that must not end up in the server log.
+func (n *LogNotifier) Notify(_ context.Context, msg domain.Notification) {
+	log.Printf("notification %s to %s: %s", msg.Kind, msg.To, msg.Subject)
+}
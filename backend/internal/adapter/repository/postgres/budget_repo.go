@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const budgetColumns = `b.user_id, b.year, b.amount, b.currency, b.created_at, b.updated_at`
+
+// BudgetRepository implements port.BudgetRepository with PostgreSQL.
+type BudgetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBudgetRepository creates a new BudgetRepository.
+func NewBudgetRepository(pool *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{pool: pool}
+}
+
+// Upsert inserts or replaces a user's budget for a year.
+func (r *BudgetRepository) Upsert(ctx context.Context, b *domain.YearlyBudget) error {
+	query := `
+		INSERT INTO yearly_budgets (user_id, year, amount, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, year) DO UPDATE
+		SET amount = EXCLUDED.amount, currency = EXCLUDED.currency, updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+
+	err := conn(ctx, r.pool).QueryRow(ctx, query, b.UserID, b.Year, b.Amount, b.Currency, b.CreatedAt, b.UpdatedAt).Scan(&b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert yearly budget: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a user's budget for a year.
+func (r *BudgetRepository) Get(ctx context.Context, userID uuid.UUID, year int) (*domain.YearlyBudget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM yearly_budgets b WHERE b.user_id = $1 AND b.year = $2`
+
+	b, err := scanBudget(conn(ctx, r.pool).QueryRow(ctx, query, userID, year))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yearly budget: %w", err)
+	}
+	return b, nil
+}
+
+// ListByUserID returns a user's budgets, latest year first.
+func (r *BudgetRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.YearlyBudget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM yearly_budgets b WHERE b.user_id = $1 ORDER BY b.year DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list yearly budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var budgets []domain.YearlyBudget
+	for rows.Next() {
+		b, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan yearly budget: %w", err)
+		}
+		budgets = append(budgets, *b)
+	}
+	return budgets, rows.Err()
+}
+
+// Delete removes a user's budget for a year.
+func (r *BudgetRepository) Delete(ctx context.Context, userID uuid.UUID, year int) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM yearly_budgets WHERE user_id = $1 AND year = $2`, userID, year)
+	if err != nil {
+		return fmt.Errorf("failed to delete yearly budget: %w", err)
+	}
+	return nil
+}
+
+func scanBudget(row pgx.Row) (*domain.YearlyBudget, error) {
+	b := &domain.YearlyBudget{}
+	err := row.Scan(&b.UserID, &b.Year, &b.Amount, &b.Currency, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
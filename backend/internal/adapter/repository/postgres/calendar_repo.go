@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// CalendarFeedRepository implements port.CalendarFeedRepository with PostgreSQL.
+type CalendarFeedRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository.
+func NewCalendarFeedRepository(pool *pgxpool.Pool) *CalendarFeedRepository {
+	return &CalendarFeedRepository{pool: pool}
+}
+
+// GetByUserID retrieves a user's feed.
+func (r *CalendarFeedRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	query := `SELECT user_id, token, created_at FROM calendar_feeds WHERE user_id = $1`
+	return r.get(ctx, query, userID)
+}
+
+// GetByToken retrieves the feed a token belongs to.
+func (r *CalendarFeedRepository) GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error) {
+	query := `SELECT user_id, token, created_at FROM calendar_feeds WHERE token = $1`
+	return r.get(ctx, query, token)
+}
+
+func (r *CalendarFeedRepository) get(ctx context.Context, query string, arg any) (*domain.CalendarFeed, error) {
+	feed := &domain.CalendarFeed{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, arg).Scan(&feed.UserID, &feed.Token, &feed.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// Save stores a user's feed, replacing any previous token.
+func (r *CalendarFeedRepository) Save(ctx context.Context, feed *domain.CalendarFeed) error {
+	query := `
+		INSERT INTO calendar_feeds (user_id, token, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = EXCLUDED.created_at`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, feed.UserID, feed.Token, feed.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+	return nil
+}
+
+// Delete disables a user's feed.
+func (r *CalendarFeedRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM calendar_feeds WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+	return nil
+}
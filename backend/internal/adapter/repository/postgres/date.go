@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// dateArg converts an optional domain.Date into a value pgx binds to a DATE column.
+func dateArg(d *domain.Date) *time.Time {
+	if d == nil {
+		return nil
+	}
+	return &d.Time
+}
+
+// dateValue wraps a scanned DATE column into an optional domain.Date.
+func dateValue(t *time.Time) *domain.Date {
+	if t == nil {
+		return nil
+	}
+	d := domain.NewDate(*t)
+	return &d
+}
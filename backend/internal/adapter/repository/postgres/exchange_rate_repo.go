@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// ExchangeRateRepository implements port.ExchangeRateRepository with PostgreSQL.
+type ExchangeRateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewExchangeRateRepository creates a new ExchangeRateRepository.
+func NewExchangeRateRepository(pool *pgxpool.Pool) *ExchangeRateRepository {
+	return &ExchangeRateRepository{pool: pool}
+}
+
+// List returns every rate in the table.
+func (r *ExchangeRateRepository) List(ctx context.Context) ([]domain.ExchangeRate, error) {
+	query := `SELECT base, quote, rate, updated_at FROM exchange_rates ORDER BY base, quote`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []domain.ExchangeRate
+	for rows.Next() {
+		var rate domain.ExchangeRate
+		if err := rows.Scan(&rate.Base, &rate.Quote, &rate.Rate, &rate.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+// ReplaceAll deletes the current rates and copies in the new ones. Callers
+// run it in a transaction so readers never see an empty table.
+func (r *ExchangeRateRepository) ReplaceAll(ctx context.Context, rates []domain.ExchangeRate) error {
+	q := conn(ctx, r.pool)
+	if _, err := q.Exec(ctx, `DELETE FROM exchange_rates`); err != nil {
+		return fmt.Errorf("failed to clear exchange rates: %w", err)
+	}
+
+	columns := []string{"base", "quote", "rate", "updated_at"}
+	_, err := q.CopyFrom(ctx, pgx.Identifier{"exchange_rates"}, columns,
+		pgx.CopyFromSlice(len(rates), func(i int) ([]any, error) {
+			rate := &rates[i]
+			return []any{rate.Base, rate.Quote, rate.Rate, rate.UpdatedAt}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+	return nil
+}
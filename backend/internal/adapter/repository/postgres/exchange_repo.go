@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const exchangeColumns = `e.id, e.organizer_id, e.name, e.budget, e.currency, e.exchange_on, e.previous_exchange_id, e.status, e.seed, e.previous_pairs, e.drawn_at, e.created_at, e.updated_at`
+
+const participantColumns = `p.id, p.exchange_id, p.name, p.email, COALESCE(p.token, ''), p.receiver_id, p.created_at`
+
+const exclusionColumns = `x.id, x.exchange_id, x.giver_id, x.receiver_id, x.mutual, x.created_at`
+
+// ExchangeRepository implements port.ExchangeRepository with PostgreSQL.
+type ExchangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewExchangeRepository creates a new ExchangeRepository.
+func NewExchangeRepository(pool *pgxpool.Pool) *ExchangeRepository {
+	return &ExchangeRepository{pool: pool}
+}
+
+// Create inserts a new exchange. Participants and exclusions are added separately.
+func (r *ExchangeRepository) Create(ctx context.Context, e *domain.GiftExchange) error {
+	previousPairs, err := encodePreviousPairs(e.PreviousPairs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO gift_exchanges (id, organizer_id, name, budget, currency, exchange_on, previous_exchange_id, status, seed, previous_pairs, drawn_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		e.ID, e.OrganizerID, e.Name, e.Budget, e.Currency, dateArg(e.ExchangeOn), e.PreviousExchangeID, e.Status,
+		e.Seed, previousPairs, e.DrawnAt, e.CreatedAt, e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create gift exchange: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an exchange with its participants and exclusions.
+func (r *ExchangeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GiftExchange, error) {
+	query := `SELECT ` + exchangeColumns + ` FROM gift_exchanges e WHERE e.id = $1`
+
+	e, err := scanExchange(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift exchange: %w", err)
+	}
+
+	exchanges := []domain.GiftExchange{*e}
+	if err := r.loadMembers(ctx, exchanges); err != nil {
+		return nil, err
+	}
+	return &exchanges[0], nil
+}
+
+// ListForUser returns the exchanges a user organizes or takes part in by
+// email, newest first.
+func (r *ExchangeRepository) ListForUser(ctx context.Context, userID uuid.UUID, email string) ([]domain.GiftExchange, error) {
+	query := `
+		SELECT ` + exchangeColumns + `
+		FROM gift_exchanges e
+		WHERE e.organizer_id = $1
+		   OR EXISTS (SELECT 1 FROM exchange_participants p WHERE p.exchange_id = e.id AND p.email = LOWER($2))
+		ORDER BY e.created_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gift exchanges: %w", err)
+	}
+	defer rows.Close()
+
+	var exchanges []domain.GiftExchange
+	for rows.Next() {
+		e, err := scanExchange(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gift exchange: %w", err)
+		}
+		exchanges = append(exchanges, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadMembers(ctx, exchanges); err != nil {
+		return nil, err
+	}
+	return exchanges, nil
+}
+
+// loadMembers fills in the participants and exclusions of each exchange,
+// in the order they were added.
+func (r *ExchangeRepository) loadMembers(ctx context.Context, exchanges []domain.GiftExchange) error {
+	if len(exchanges) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(exchanges))
+	index := make(map[uuid.UUID]int, len(exchanges))
+	for i := range exchanges {
+		ids[i] = exchanges[i].ID
+		index[exchanges[i].ID] = i
+		exchanges[i].Participants = []domain.ExchangeParticipant{}
+		exchanges[i].Exclusions = []domain.ExchangeExclusion{}
+	}
+
+	query := `
+		SELECT ` + participantColumns + `
+		FROM exchange_participants p WHERE p.exchange_id = ANY($1)
+		ORDER BY p.created_at, p.email`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list exchange participants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanParticipant(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan exchange participant: %w", err)
+		}
+		e := &exchanges[index[p.ExchangeID]]
+		e.Participants = append(e.Participants, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query = `
+		SELECT ` + exclusionColumns + `
+		FROM exchange_exclusions x WHERE x.exchange_id = ANY($1)
+		ORDER BY x.created_at`
+
+	rows, err = conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list exchange exclusions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var x domain.ExchangeExclusion
+		if err := rows.Scan(&x.ID, &x.ExchangeID, &x.GiverID, &x.ReceiverID, &x.Mutual, &x.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan exchange exclusion: %w", err)
+		}
+		e := &exchanges[index[x.ExchangeID]]
+		e.Exclusions = append(e.Exclusions, x)
+	}
+	return rows.Err()
+}
+
+// Update modifies an exchange's details and draw state.
+func (r *ExchangeRepository) Update(ctx context.Context, e *domain.GiftExchange) error {
+	previousPairs, err := encodePreviousPairs(e.PreviousPairs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE gift_exchanges
+		SET name = $2, budget = $3, currency = $4, exchange_on = $5, previous_exchange_id = $6,
+		    status = $7, seed = $8, previous_pairs = $9, drawn_at = $10, updated_at = $11
+		WHERE id = $1`
+
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		e.ID, e.Name, e.Budget, e.Currency, dateArg(e.ExchangeOn), e.PreviousExchangeID,
+		e.Status, e.Seed, previousPairs, e.DrawnAt, e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update gift exchange: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an exchange and, by cascade, its participants and exclusions.
+func (r *ExchangeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM gift_exchanges WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete gift exchange: %w", err)
+	}
+	return nil
+}
+
+// AddParticipant inserts a participant. An email already taking part in
+// the same exchange yields domain.ErrAlreadyExists.
+func (r *ExchangeRepository) AddParticipant(ctx context.Context, p *domain.ExchangeParticipant) error {
+	query := `
+		INSERT INTO exchange_participants (id, exchange_id, name, email, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, p.ID, p.ExchangeID, p.Name, p.Email, p.CreatedAt)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add exchange participant: %w", err)
+	}
+	return nil
+}
+
+// DeleteParticipant removes a participant and, by cascade, the exclusions
+// that name them.
+func (r *ExchangeRepository) DeleteParticipant(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM exchange_participants WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange participant: %w", err)
+	}
+	return nil
+}
+
+// AddExclusion inserts an exclusion. A pair already excluded yields
+// domain.ErrAlreadyExists.
+func (r *ExchangeRepository) AddExclusion(ctx context.Context, x *domain.ExchangeExclusion) error {
+	query := `
+		INSERT INTO exchange_exclusions (id, exchange_id, giver_id, receiver_id, mutual, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, x.ID, x.ExchangeID, x.GiverID, x.ReceiverID, x.Mutual, x.CreatedAt)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add exchange exclusion: %w", err)
+	}
+	return nil
+}
+
+// DeleteExclusion removes an exclusion.
+func (r *ExchangeRepository) DeleteExclusion(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM exchange_exclusions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange exclusion: %w", err)
+	}
+	return nil
+}
+
+// SaveAssignments stores each participant's receiver and link token.
+func (r *ExchangeRepository) SaveAssignments(ctx context.Context, participants []domain.ExchangeParticipant) error {
+	for _, p := range participants {
+		_, err := conn(ctx, r.pool).Exec(ctx,
+			`UPDATE exchange_participants SET receiver_id = $2, token = $3 WHERE id = $1`,
+			p.ID, p.ReceiverID, p.Token,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save exchange assignment: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetParticipantByToken finds the participant holding a secret link.
+func (r *ExchangeRepository) GetParticipantByToken(ctx context.Context, token string) (*domain.ExchangeParticipant, error) {
+	query := `SELECT ` + participantColumns + ` FROM exchange_participants p WHERE p.token = $1`
+
+	p, err := scanParticipant(conn(ctx, r.pool).QueryRow(ctx, query, token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange participant: %w", err)
+	}
+	return p, nil
+}
+
+func scanExchange(row pgx.Row) (*domain.GiftExchange, error) {
+	e := &domain.GiftExchange{}
+	var exchangeOn *time.Time
+	var previousPairs []byte
+	err := row.Scan(&e.ID, &e.OrganizerID, &e.Name, &e.Budget, &e.Currency, &exchangeOn, &e.PreviousExchangeID,
+		&e.Status, &e.Seed, &previousPairs, &e.DrawnAt, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.ExchangeOn = dateValue(exchangeOn)
+	if err := json.Unmarshal(previousPairs, &e.PreviousPairs); err != nil {
+		return nil, fmt.Errorf("failed to decode previous pairs: %w", err)
+	}
+	return e, nil
+}
+
+// encodePreviousPairs encodes the pairs for the previous_pairs column, which
+// holds an empty array rather than null before the draw.
+func encodePreviousPairs(pairs []domain.ExchangePair) ([]byte, error) {
+	if pairs == nil {
+		pairs = []domain.ExchangePair{}
+	}
+	data, err := json.Marshal(pairs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode previous pairs: %w", err)
+	}
+	return data, nil
+}
+
+func scanParticipant(row pgx.Row) (*domain.ExchangeParticipant, error) {
+	p := &domain.ExchangeParticipant{}
+	err := row.Scan(&p.ID, &p.ExchangeID, &p.Name, &p.Email, &p.Token, &p.ReceiverID, &p.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
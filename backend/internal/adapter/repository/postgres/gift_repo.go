@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const giftColumns = `g.id, g.recipient_id, g.occasion_id, g.catalog_item_id, g.item, g.category, g.price, g.currency, g.given_on, g.rating, g.created_at, g.updated_at`
+
+// GiftRepository implements port.GiftRepository with PostgreSQL.
+type GiftRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGiftRepository creates a new GiftRepository.
+func NewGiftRepository(pool *pgxpool.Pool) *GiftRepository {
+	return &GiftRepository{pool: pool}
+}
+
+// Create inserts a new gift record.
+func (r *GiftRepository) Create(ctx context.Context, g *domain.GiftRecord) error {
+	query := `
+		INSERT INTO gift_records (id, recipient_id, occasion_id, catalog_item_id, item, category, price, currency, given_on, rating, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		g.ID, g.RecipientID, g.OccasionID, g.CatalogItemID, g.Item, g.Category, g.Price, g.Currency, g.GivenOn.Time, g.Rating,
+		g.CreatedAt, g.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create gift record: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a gift record by ID.
+func (r *GiftRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GiftRecord, error) {
+	query := `SELECT ` + giftColumns + ` FROM gift_records g WHERE g.id = $1`
+
+	g, err := scanGift(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift record: %w", err)
+	}
+	return g, nil
+}
+
+// ListByRecipientID returns a recipient's gifts, most recently given first.
+func (r *GiftRepository) ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.GiftRecord, error) {
+	query := `
+		SELECT ` + giftColumns + `
+		FROM gift_records g WHERE g.recipient_id = $1
+		ORDER BY g.given_on DESC, g.created_at DESC`
+
+	return r.list(ctx, query, recipientID)
+}
+
+// ListByOwners returns the gifts given between from and to, inclusive, to
+// every recipient the owners have outside the trash.
+func (r *GiftRepository) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID, from, to domain.Date) ([]domain.GiftRecord, error) {
+	query := `
+		SELECT ` + giftColumns + `
+		FROM gift_records g
+		JOIN recipients r ON r.id = g.recipient_id
+		WHERE r.user_id = ANY($1) AND r.deleted_at IS NULL AND g.given_on BETWEEN $2 AND $3
+		ORDER BY g.given_on, g.created_at`
+	return r.list(ctx, query, ownerIDs, from.Time, to.Time)
+}
+
+func (r *GiftRepository) list(ctx context.Context, query string, args ...any) ([]domain.GiftRecord, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gift records: %w", err)
+	}
+	defer rows.Close()
+
+	var gifts []domain.GiftRecord
+	for rows.Next() {
+		g, err := scanGift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gift record: %w", err)
+		}
+		gifts = append(gifts, *g)
+	}
+	return gifts, rows.Err()
+}
+
+// Update modifies a gift record's fields.
+func (r *GiftRepository) Update(ctx context.Context, g *domain.GiftRecord) error {
+	query := `
+		UPDATE gift_records
+		SET occasion_id = $2, item = $3, category = $4, price = $5, currency = $6, given_on = $7, rating = $8, updated_at = $9
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		g.ID, g.OccasionID, g.Item, g.Category, g.Price, g.Currency, g.GivenOn.Time, g.Rating, g.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update gift record: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a gift record.
+func (r *GiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM gift_records WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete gift record: %w", err)
+	}
+	return nil
+}
+
+// Reassign moves a recipient's gift history to another recipient.
+func (r *GiftRepository) Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE gift_records SET recipient_id = $2, updated_at = NOW() WHERE recipient_id = $1`,
+		fromRecipientID, toRecipientID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reassign gift records: %w", err)
+	}
+	return nil
+}
+
+func scanGift(row pgx.Row) (*domain.GiftRecord, error) {
+	g := &domain.GiftRecord{}
+	var givenOn time.Time
+	var rating *int16
+	err := row.Scan(&g.ID, &g.RecipientID, &g.OccasionID, &g.CatalogItemID, &g.Item, &g.Category, &g.Price,
+		&g.Currency, &givenOn, &rating, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	g.GivenOn = domain.NewDate(givenOn)
+	if rating != nil {
+		v := int(*rating)
+		g.Rating = &v
+	}
+	return g, nil
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const groupGiftColumns = `g.id, g.organizer_id, g.recipient_id, g.occasion_id, g.title, g.description, g.target_amount, g.currency, g.status, g.settled_at, g.created_at, g.updated_at`
+
+const contributorColumns = `c.id, c.group_gift_id, c.email, c.name, c.pledged, c.paid, c.created_at, c.updated_at`
+
+// GroupGiftRepository implements port.GroupGiftRepository with PostgreSQL.
+type GroupGiftRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGroupGiftRepository creates a new GroupGiftRepository.
+func NewGroupGiftRepository(pool *pgxpool.Pool) *GroupGiftRepository {
+	return &GroupGiftRepository{pool: pool}
+}
+
+// Create inserts a new group gift. Contributors are added separately.
+func (r *GroupGiftRepository) Create(ctx context.Context, g *domain.GroupGift) error {
+	query := `
+		INSERT INTO group_gifts (id, organizer_id, recipient_id, occasion_id, title, description, target_amount, currency, status, settled_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		g.ID, g.OrganizerID, g.RecipientID, g.OccasionID, g.Title, g.Description, g.TargetAmount, g.Currency, g.Status,
+		g.SettledAt, g.CreatedAt, g.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create group gift: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a group gift with its contributors.
+func (r *GroupGiftRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GroupGift, error) {
+	query := `SELECT ` + groupGiftColumns + ` FROM group_gifts g WHERE g.id = $1`
+
+	g, err := scanGroupGift(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group gift: %w", err)
+	}
+
+	gifts := []domain.GroupGift{*g}
+	if err := r.loadContributors(ctx, gifts); err != nil {
+		return nil, err
+	}
+	return &gifts[0], nil
+}
+
+// ListForUser returns the group gifts a user organizes or was invited to
+// by email, newest first.
+func (r *GroupGiftRepository) ListForUser(ctx context.Context, userID uuid.UUID, email string) ([]domain.GroupGift, error) {
+	query := `
+		SELECT ` + groupGiftColumns + `
+		FROM group_gifts g
+		WHERE g.organizer_id = $1
+		   OR EXISTS (SELECT 1 FROM group_gift_contributors c WHERE c.group_gift_id = g.id AND c.email = LOWER($2))
+		ORDER BY g.created_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group gifts: %w", err)
+	}
+	defer rows.Close()
+
+	var gifts []domain.GroupGift
+	for rows.Next() {
+		g, err := scanGroupGift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group gift: %w", err)
+		}
+		gifts = append(gifts, *g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadContributors(ctx, gifts); err != nil {
+		return nil, err
+	}
+	return gifts, nil
+}
+
+// loadContributors fills in the contributors of each gift, in invitation order.
+func (r *GroupGiftRepository) loadContributors(ctx context.Context, gifts []domain.GroupGift) error {
+	if len(gifts) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(gifts))
+	index := make(map[uuid.UUID]int, len(gifts))
+	for i := range gifts {
+		ids[i] = gifts[i].ID
+		index[gifts[i].ID] = i
+		gifts[i].Contributors = []domain.GroupGiftContributor{}
+	}
+
+	query := `
+		SELECT ` + contributorColumns + `
+		FROM group_gift_contributors c WHERE c.group_gift_id = ANY($1)
+		ORDER BY c.created_at, c.email`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list group gift contributors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.GroupGiftContributor
+		err := rows.Scan(&c.ID, &c.GroupGiftID, &c.Email, &c.Name, &c.Pledged, &c.Paid, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan group gift contributor: %w", err)
+		}
+		g := &gifts[index[c.GroupGiftID]]
+		g.Contributors = append(g.Contributors, c)
+	}
+	return rows.Err()
+}
+
+// Update modifies a group gift's details and status.
+func (r *GroupGiftRepository) Update(ctx context.Context, g *domain.GroupGift) error {
+	query := `
+		UPDATE group_gifts
+		SET title = $2, description = $3, target_amount = $4, status = $5, settled_at = $6, updated_at = $7
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		g.ID, g.Title, g.Description, g.TargetAmount, g.Status, g.SettledAt, g.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update group gift: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a group gift and, by cascade, its contributors.
+func (r *GroupGiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM group_gifts WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete group gift: %w", err)
+	}
+	return nil
+}
+
+// AddContributor inserts a contributor. An email already invited to the
+// same gift yields domain.ErrAlreadyExists.
+func (r *GroupGiftRepository) AddContributor(ctx context.Context, c *domain.GroupGiftContributor) error {
+	query := `
+		INSERT INTO group_gift_contributors (id, group_gift_id, email, name, pledged, paid, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		c.ID, c.GroupGiftID, c.Email, c.Name, c.Pledged, c.Paid, c.CreatedAt, c.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add group gift contributor: %w", err)
+	}
+	return nil
+}
+
+// UpdateContributor modifies a contributor's pledged and paid amounts.
+func (r *GroupGiftRepository) UpdateContributor(ctx context.Context, c *domain.GroupGiftContributor) error {
+	query := `
+		UPDATE group_gift_contributors
+		SET pledged = $2, paid = $3, updated_at = $4
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, c.ID, c.Pledged, c.Paid, c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update group gift contributor: %w", err)
+	}
+	return nil
+}
+
+// DeleteContributor removes a contributor.
+func (r *GroupGiftRepository) DeleteContributor(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM group_gift_contributors WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete group gift contributor: %w", err)
+	}
+	return nil
+}
+
+// Reassign moves a recipient's group gifts to another recipient.
+func (r *GroupGiftRepository) Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE group_gifts SET recipient_id = $2, updated_at = NOW() WHERE recipient_id = $1`,
+		fromRecipientID, toRecipientID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reassign group gifts: %w", err)
+	}
+	return nil
+}
+
+func scanGroupGift(row pgx.Row) (*domain.GroupGift, error) {
+	g := &domain.GroupGift{}
+	err := row.Scan(&g.ID, &g.OrganizerID, &g.RecipientID, &g.OccasionID, &g.Title, &g.Description, &g.TargetAmount,
+		&g.Currency, &g.Status, &g.SettledAt, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// groupColumns selects a group with the number of its recipients still out of the trash.
+const groupColumns = `g.id, g.user_id, g.name, g.created_at, g.updated_at,
+	(SELECT COUNT(*) FROM recipient_group_members m
+	 JOIN recipients r ON r.id = m.recipient_id
+	 WHERE m.group_id = g.id AND r.deleted_at IS NULL)`
+
+// GroupRepository implements port.GroupRepository with PostgreSQL.
+type GroupRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGroupRepository creates a new GroupRepository.
+func NewGroupRepository(pool *pgxpool.Pool) *GroupRepository {
+	return &GroupRepository{pool: pool}
+}
+
+// Create inserts a new group. A name already used by the same user yields
+// domain.ErrAlreadyExists.
+func (r *GroupRepository) Create(ctx context.Context, group *domain.Group) error {
+	query := `
+		INSERT INTO recipient_groups (id, user_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		group.ID, group.UserID, group.Name, group.CreatedAt, group.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create group: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a group by ID.
+func (r *GroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
+	query := `SELECT ` + groupColumns + ` FROM recipient_groups g WHERE g.id = $1`
+
+	group, err := scanGroup(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	return group, nil
+}
+
+// ListByUserID returns a user's groups ordered by name.
+func (r *GroupRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Group, error) {
+	query := `
+		SELECT ` + groupColumns + `
+		FROM recipient_groups g WHERE g.user_id = $1
+		ORDER BY lower(g.name), g.id`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []domain.Group
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, *group)
+	}
+	return groups, rows.Err()
+}
+
+// Update renames a group. A name already used by the same user yields
+// domain.ErrAlreadyExists.
+func (r *GroupRepository) Update(ctx context.Context, group *domain.Group) error {
+	query := `UPDATE recipient_groups SET name = $2, updated_at = $3 WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, group.ID, group.Name, group.UpdatedAt)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update group: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a group and its memberships. Recipients are kept.
+func (r *GroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM recipient_groups WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	return nil
+}
+
+// AddMembers adds recipients to a group, ignoring ones that are already members.
+func (r *GroupRepository) AddMembers(ctx context.Context, groupID uuid.UUID, recipientIDs []uuid.UUID) error {
+	query := `
+		INSERT INTO recipient_group_members (group_id, recipient_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, groupID, recipientIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add group members: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember removes a recipient from a group.
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, recipientID uuid.UUID) error {
+	query := `DELETE FROM recipient_group_members WHERE group_id = $1 AND recipient_id = $2`
+	_, err := conn(ctx, r.pool).Exec(ctx, query, groupID, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+	return nil
+}
+
+// ReassignMembers moves a recipient's group memberships to another recipient.
+func (r *GroupRepository) ReassignMembers(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	query := `
+		WITH moved AS (
+			DELETE FROM recipient_group_members WHERE recipient_id = $1
+			RETURNING group_id, created_at
+		)
+		INSERT INTO recipient_group_members (group_id, recipient_id, created_at)
+		SELECT group_id, $2, created_at FROM moved
+		ON CONFLICT DO NOTHING`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, fromRecipientID, toRecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to reassign group members: %w", err)
+	}
+	return nil
+}
+
+// ListMemberships returns the group memberships of a user's recipients
+// outside the trash.
+func (r *GroupRepository) ListMemberships(ctx context.Context, userID uuid.UUID) ([]domain.GroupMembership, error) {
+	query := `
+		SELECT m.group_id, m.recipient_id
+		FROM recipient_group_members m
+		JOIN recipients r ON r.id = m.recipient_id
+		WHERE r.user_id = $1 AND r.deleted_at IS NULL`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []domain.GroupMembership
+	for rows.Next() {
+		var m domain.GroupMembership
+		if err := rows.Scan(&m.GroupID, &m.RecipientID); err != nil {
+			return nil, fmt.Errorf("failed to scan group membership: %w", err)
+		}
+		memberships = append(memberships, m)
+	}
+	return memberships, rows.Err()
+}
+
+func scanGroup(row pgx.Row) (*domain.Group, error) {
+	group := &domain.Group{}
+	err := row.Scan(&group.ID, &group.UserID, &group.Name, &group.CreatedAt, &group.UpdatedAt, &group.MemberCount)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// RecipientHistoryRepository implements port.RecipientHistoryRepository with PostgreSQL.
+type RecipientHistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRecipientHistoryRepository creates a new RecipientHistoryRepository.
+func NewRecipientHistoryRepository(pool *pgxpool.Pool) *RecipientHistoryRepository {
+	return &RecipientHistoryRepository{pool: pool}
+}
+
+// Create appends a version, assigning it the next version number for the recipient.
+func (r *RecipientHistoryRepository) Create(ctx context.Context, v *domain.RecipientVersion) error {
+	snapshot, err := json.Marshal(v.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode recipient snapshot: %w", err)
+	}
+	changes, err := json.Marshal(v.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode recipient changes: %w", err)
+	}
+
+	query := `
+		INSERT INTO recipient_versions (id, recipient_id, version, action, changed_by, snapshot, changes, created_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7
+		FROM recipient_versions WHERE recipient_id = $2
+		RETURNING version`
+
+	err = conn(ctx, r.pool).QueryRow(ctx, query,
+		v.ID, v.RecipientID, v.Action, v.ChangedBy, snapshot, changes, v.CreatedAt,
+	).Scan(&v.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create recipient version: %w", err)
+	}
+	return nil
+}
+
+// CreateMany inserts the first version of recipients that have no history
+// yet with a single COPY.
+func (r *RecipientHistoryRepository) CreateMany(ctx context.Context, versions []domain.RecipientVersion) error {
+	columns := []string{"id", "recipient_id", "version", "action", "changed_by", "snapshot", "changes", "created_at"}
+	_, err := conn(ctx, r.pool).CopyFrom(ctx, pgx.Identifier{"recipient_versions"}, columns,
+		pgx.CopyFromSlice(len(versions), func(i int) ([]any, error) {
+			v := &versions[i]
+			v.Version = 1
+			snapshot, err := json.Marshal(v.Snapshot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode recipient snapshot: %w", err)
+			}
+			changes, err := json.Marshal(v.Changes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode recipient changes: %w", err)
+			}
+			return []any{v.ID, v.RecipientID, v.Version, v.Action, v.ChangedBy, snapshot, changes, v.CreatedAt}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recipient versions: %w", err)
+	}
+	return nil
+}
+
+// ListByRecipientID returns all versions of a recipient, newest first.
+func (r *RecipientHistoryRepository) ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.RecipientVersion, error) {
+	query := `
+		SELECT id, recipient_id, version, action, changed_by, snapshot, changes, created_at
+		FROM recipient_versions WHERE recipient_id = $1
+		ORDER BY version DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipient versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []domain.RecipientVersion
+	for rows.Next() {
+		v, err := scanRecipientVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recipient version: %w", err)
+		}
+		versions = append(versions, *v)
+	}
+	return versions, rows.Err()
+}
+
+// GetByVersion retrieves a single version of a recipient.
+func (r *RecipientHistoryRepository) GetByVersion(ctx context.Context, recipientID uuid.UUID, version int) (*domain.RecipientVersion, error) {
+	query := `
+		SELECT id, recipient_id, version, action, changed_by, snapshot, changes, created_at
+		FROM recipient_versions WHERE recipient_id = $1 AND version = $2`
+
+	v, err := scanRecipientVersion(conn(ctx, r.pool).QueryRow(ctx, query, recipientID, version))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient version: %w", err)
+	}
+	return v, nil
+}
+
+func scanRecipientVersion(row pgx.Row) (*domain.RecipientVersion, error) {
+	v := &domain.RecipientVersion{}
+	var (
+		changedBy         *uuid.UUID
+		snapshot, changes []byte
+	)
+	if err := row.Scan(
+		&v.ID, &v.RecipientID, &v.Version, &v.Action, &changedBy,
+		&snapshot, &changes, &v.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if changedBy != nil {
+		v.ChangedBy = *changedBy
+	}
+	if err := json.Unmarshal(snapshot, &v.Snapshot); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changes, &v.Changes); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const holidaySubscriptionColumns = `s.id, s.recipient_id, s.country, s.holiday, s.budget, s.created_at`
+
+// HolidaySubscriptionRepository implements port.HolidaySubscriptionRepository with PostgreSQL.
+type HolidaySubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHolidaySubscriptionRepository creates a new HolidaySubscriptionRepository.
+func NewHolidaySubscriptionRepository(pool *pgxpool.Pool) *HolidaySubscriptionRepository {
+	return &HolidaySubscriptionRepository{pool: pool}
+}
+
+// Create inserts a new subscription, returning domain.ErrAlreadyExists if the
+// recipient is already subscribed to the holiday.
+func (r *HolidaySubscriptionRepository) Create(ctx context.Context, s *domain.HolidaySubscription) error {
+	query := `
+		INSERT INTO holiday_subscriptions (id, recipient_id, country, holiday, budget, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		s.ID, s.RecipientID, s.Country, s.Holiday, s.Budget, s.CreatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create holiday subscription: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a subscription by ID.
+func (r *HolidaySubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.HolidaySubscription, error) {
+	query := `SELECT ` + holidaySubscriptionColumns + ` FROM holiday_subscriptions s WHERE s.id = $1`
+
+	s, err := scanHolidaySubscription(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holiday subscription: %w", err)
+	}
+	return s, nil
+}
+
+// ListByRecipientID returns a recipient's subscriptions.
+func (r *HolidaySubscriptionRepository) ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.HolidaySubscription, error) {
+	query := `
+		SELECT ` + holidaySubscriptionColumns + `
+		FROM holiday_subscriptions s WHERE s.recipient_id = $1
+		ORDER BY s.created_at`
+	return r.list(ctx, query, recipientID)
+}
+
+// ListByOwners returns the subscriptions of every recipient the owners have outside the trash.
+func (r *HolidaySubscriptionRepository) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.HolidaySubscription, error) {
+	query := `
+		SELECT ` + holidaySubscriptionColumns + `
+		FROM holiday_subscriptions s
+		JOIN recipients r ON r.id = s.recipient_id
+		WHERE r.user_id = ANY($1) AND r.deleted_at IS NULL
+		ORDER BY s.created_at`
+	return r.list(ctx, query, ownerIDs)
+}
+
+func (r *HolidaySubscriptionRepository) list(ctx context.Context, query string, args ...any) ([]domain.HolidaySubscription, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holiday subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []domain.HolidaySubscription
+	for rows.Next() {
+		s, err := scanHolidaySubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan holiday subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, *s)
+	}
+	return subscriptions, rows.Err()
+}
+
+// Delete removes a subscription.
+func (r *HolidaySubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM holiday_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete holiday subscription: %w", err)
+	}
+	return nil
+}
+
+// Reassign moves a recipient's subscriptions to another recipient, leaving
+// behind those the target is already subscribed to.
+func (r *HolidaySubscriptionRepository) Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	query := `
+		UPDATE holiday_subscriptions s SET recipient_id = $2
+		WHERE s.recipient_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM holiday_subscriptions t
+			WHERE t.recipient_id = $2 AND t.country = s.country AND t.holiday = s.holiday)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, fromRecipientID, toRecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to reassign holiday subscriptions: %w", err)
+	}
+	return nil
+}
+
+func scanHolidaySubscription(row pgx.Row) (*domain.HolidaySubscription, error) {
+	s := &domain.HolidaySubscription{}
+	err := row.Scan(&s.ID, &s.RecipientID, &s.Country, &s.Holiday, &s.Budget, &s.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const householdColumns = `h.id, h.name, h.created_at, h.updated_at`
+
+const invitationColumns = `i.id, i.household_id, i.email, i.role, i.token, i.invited_by, i.created_at`
+
+// HouseholdRepository implements port.HouseholdRepository with PostgreSQL.
+type HouseholdRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHouseholdRepository creates a new HouseholdRepository.
+func NewHouseholdRepository(pool *pgxpool.Pool) *HouseholdRepository {
+	return &HouseholdRepository{pool: pool}
+}
+
+// Create inserts a new household with its members.
+func (r *HouseholdRepository) Create(ctx context.Context, h *domain.Household) error {
+	query := `
+		INSERT INTO households (id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, h.ID, h.Name, h.CreatedAt, h.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create household: %w", err)
+	}
+	for i := range h.Members {
+		if err := r.AddMember(ctx, &h.Members[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID retrieves a household with its members.
+func (r *HouseholdRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	return r.getOne(ctx, `SELECT `+householdColumns+` FROM households h WHERE h.id = $1`, id)
+}
+
+// GetByUserID retrieves the household a user belongs to, with its members.
+func (r *HouseholdRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Household, error) {
+	query := `
+		SELECT ` + householdColumns + `
+		FROM households h
+		JOIN household_members m ON m.household_id = h.id
+		WHERE m.user_id = $1`
+	return r.getOne(ctx, query, userID)
+}
+
+func (r *HouseholdRepository) getOne(ctx context.Context, query string, arg any) (*domain.Household, error) {
+	var h domain.Household
+	err := conn(ctx, r.pool).QueryRow(ctx, query, arg).Scan(&h.ID, &h.Name, &h.CreatedAt, &h.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household: %w", err)
+	}
+	if err := r.loadMembers(ctx, &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// loadMembers fills in a household's members, owner first, with the email
+// and name from their accounts.
+func (r *HouseholdRepository) loadMembers(ctx context.Context, h *domain.Household) error {
+	query := `
+		SELECT m.household_id, m.user_id, u.email, u.name, m.role, m.joined_at
+		FROM household_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.household_id = $1
+		ORDER BY m.role = 'owner' DESC, m.joined_at, u.email`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, h.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list household members: %w", err)
+	}
+	defer rows.Close()
+
+	h.Members = []domain.HouseholdMember{}
+	for rows.Next() {
+		var m domain.HouseholdMember
+		if err := rows.Scan(&m.HouseholdID, &m.UserID, &m.Email, &m.Name, &m.Role, &m.JoinedAt); err != nil {
+			return fmt.Errorf("failed to scan household member: %w", err)
+		}
+		h.Members = append(h.Members, m)
+	}
+	return rows.Err()
+}
+
+// Update modifies a household's name.
+func (r *HouseholdRepository) Update(ctx context.Context, h *domain.Household) error {
+	query := `UPDATE households SET name = $2, updated_at = $3 WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, h.ID, h.Name, h.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a household and, by cascade, its members and invitations.
+func (r *HouseholdRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM households WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete household: %w", err)
+	}
+	return nil
+}
+
+// AddMember inserts a membership. A user who already belongs to a
+// household yields domain.ErrAlreadyExists.
+func (r *HouseholdRepository) AddMember(ctx context.Context, m *domain.HouseholdMember) error {
+	query := `
+		INSERT INTO household_members (household_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, m.HouseholdID, m.UserID, m.Role, m.JoinedAt)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add household member: %w", err)
+	}
+	return nil
+}
+
+// UpdateMemberRole changes a member's role.
+func (r *HouseholdRepository) UpdateMemberRole(ctx context.Context, householdID, userID uuid.UUID, role domain.HouseholdRole) error {
+	query := `UPDATE household_members SET role = $3 WHERE household_id = $1 AND user_id = $2`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, householdID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update household member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember takes a user out of a household.
+func (r *HouseholdRepository) RemoveMember(ctx context.Context, householdID, userID uuid.UUID) error {
+	query := `DELETE FROM household_members WHERE household_id = $1 AND user_id = $2`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, householdID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove household member: %w", err)
+	}
+	return nil
+}
+
+// CreateInvitation inserts an invitation. An email already invited to the
+// same household yields domain.ErrAlreadyExists.
+func (r *HouseholdRepository) CreateInvitation(ctx context.Context, i *domain.HouseholdInvitation) error {
+	query := `
+		INSERT INTO household_invitations (id, household_id, email, role, token, invited_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		i.ID, i.HouseholdID, i.Email, i.Role, i.Token, i.InvitedBy, i.CreatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create household invitation: %w", err)
+	}
+	return nil
+}
+
+// ListInvitations returns a household's pending invitations, oldest first.
+func (r *HouseholdRepository) ListInvitations(ctx context.Context, householdID uuid.UUID) ([]domain.HouseholdInvitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM household_invitations i WHERE i.household_id = $1
+		ORDER BY i.created_at, i.email`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, householdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list household invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []domain.HouseholdInvitation
+	for rows.Next() {
+		i, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan household invitation: %w", err)
+		}
+		invitations = append(invitations, *i)
+	}
+	return invitations, rows.Err()
+}
+
+// GetInvitationByToken retrieves the invitation sent with token.
+func (r *HouseholdRepository) GetInvitationByToken(ctx context.Context, token string) (*domain.HouseholdInvitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM household_invitations i WHERE i.token = $1`
+
+	i, err := scanInvitation(conn(ctx, r.pool).QueryRow(ctx, query, token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household invitation: %w", err)
+	}
+	return i, nil
+}
+
+// DeleteInvitation removes an invitation.
+func (r *HouseholdRepository) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM household_invitations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete household invitation: %w", err)
+	}
+	return nil
+}
+
+func scanInvitation(row pgx.Row) (*domain.HouseholdInvitation, error) {
+	var i domain.HouseholdInvitation
+	err := row.Scan(&i.ID, &i.HouseholdID, &i.Email, &i.Role, &i.Token, &i.InvitedBy, &i.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
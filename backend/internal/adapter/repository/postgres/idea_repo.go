@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const ideaColumns = `i.id, i.recipient_id, i.catalog_item_id, i.title, i.url, i.price, i.currency, i.notes, i.category, i.priority, i.status, i.gift_id, i.created_at, i.updated_at`
+
+// IdeaRepository implements port.IdeaRepository with PostgreSQL.
+type IdeaRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdeaRepository creates a new IdeaRepository.
+func NewIdeaRepository(pool *pgxpool.Pool) *IdeaRepository {
+	return &IdeaRepository{pool: pool}
+}
+
+// Create inserts a new gift idea.
+func (r *IdeaRepository) Create(ctx context.Context, i *domain.GiftIdea) error {
+	query := `
+		INSERT INTO gift_ideas (id, recipient_id, catalog_item_id, title, url, price, currency, notes, category, priority, status, gift_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		i.ID, i.RecipientID, i.CatalogItemID, i.Title, i.URL, i.Price, i.Currency, i.Notes, i.Category, i.Priority, i.Status, i.GiftID,
+		i.CreatedAt, i.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create gift idea: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a gift idea by ID.
+func (r *IdeaRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GiftIdea, error) {
+	query := `SELECT ` + ideaColumns + ` FROM gift_ideas i WHERE i.id = $1`
+
+	i, err := scanIdea(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift idea: %w", err)
+	}
+	return i, nil
+}
+
+// ListByRecipientID returns a recipient's ideas, highest priority first.
+func (r *IdeaRepository) ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.GiftIdea, error) {
+	query := `
+		SELECT ` + ideaColumns + `
+		FROM gift_ideas i WHERE i.recipient_id = $1
+		ORDER BY array_position(ARRAY['high', 'medium', 'low']::VARCHAR[], i.priority), i.created_at`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gift ideas: %w", err)
+	}
+	defer rows.Close()
+
+	var ideas []domain.GiftIdea
+	for rows.Next() {
+		i, err := scanIdea(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gift idea: %w", err)
+		}
+		ideas = append(ideas, *i)
+	}
+	return ideas, rows.Err()
+}
+
+// Update modifies a gift idea's fields and status.
+func (r *IdeaRepository) Update(ctx context.Context, i *domain.GiftIdea) error {
+	query := `
+		UPDATE gift_ideas
+		SET title = $2, url = $3, price = $4, currency = $5, notes = $6, category = $7, priority = $8, status = $9, gift_id = $10,
+		    updated_at = $11
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		i.ID, i.Title, i.URL, i.Price, i.Currency, i.Notes, i.Category, i.Priority, i.Status, i.GiftID, i.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update gift idea: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a gift idea. A gift record it produced is kept.
+func (r *IdeaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM gift_ideas WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete gift idea: %w", err)
+	}
+	return nil
+}
+
+// Reassign moves a recipient's ideas to another recipient.
+func (r *IdeaRepository) Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE gift_ideas SET recipient_id = $2, updated_at = NOW() WHERE recipient_id = $1`,
+		fromRecipientID, toRecipientID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reassign gift ideas: %w", err)
+	}
+	return nil
+}
+
+func scanIdea(row pgx.Row) (*domain.GiftIdea, error) {
+	i := &domain.GiftIdea{}
+	err := row.Scan(&i.ID, &i.RecipientID, &i.CatalogItemID, &i.Title, &i.URL, &i.Price, &i.Currency, &i.Notes,
+		&i.Category, &i.Priority, &i.Status, &i.GiftID, &i.CreatedAt, &i.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return i, nil
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// ImportRepository implements port.ImportRepository with PostgreSQL.
+type ImportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewImportRepository creates a new ImportRepository.
+func NewImportRepository(pool *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{pool: pool}
+}
+
+// Create stores a previewed import.
+func (r *ImportRepository) Create(ctx context.Context, session *domain.ImportSession) error {
+	items, err := json.Marshal(session.Items)
+	if err != nil {
+		return fmt.Errorf("failed to encode import items: %w", err)
+	}
+	columns, mapping, rows, err := encodeImportSheet(session)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO import_sessions (id, user_id, source, columns, mapping, date_format, rows, items, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)`
+
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		session.ID, session.UserID, session.Source, columns, mapping, session.DateFormat, rows, items,
+		session.CreatedAt, session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create import session: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an import session by ID.
+func (r *ImportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportSession, error) {
+	query := `
+		SELECT id, user_id, source, columns, mapping, COALESCE(date_format, ''), rows, items,
+		       created_at, expires_at, applied_at
+		FROM import_sessions WHERE id = $1`
+
+	session := &domain.ImportSession{}
+	var columns, mapping, rows, items []byte
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
+		&session.ID, &session.UserID, &session.Source, &columns, &mapping, &session.DateFormat, &rows, &items,
+		&session.CreatedAt, &session.ExpiresAt, &session.AppliedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import session: %w", err)
+	}
+	if err := json.Unmarshal(items, &session.Items); err != nil {
+		return nil, fmt.Errorf("failed to decode import items: %w", err)
+	}
+	for _, part := range []struct {
+		data []byte
+		dest any
+	}{{columns, &session.Columns}, {mapping, &session.Mapping}, {rows, &session.Rows}} {
+		if part.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(part.data, part.dest); err != nil {
+			return nil, fmt.Errorf("failed to decode import sheet: %w", err)
+		}
+	}
+	session.Summarize()
+	return session, nil
+}
+
+// UpdateItems stores a remapped spreadsheet preview. It returns false if the
+// session has been applied in the meantime.
+func (r *ImportRepository) UpdateItems(ctx context.Context, session *domain.ImportSession) (bool, error) {
+	items, err := json.Marshal(session.Items)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode import items: %w", err)
+	}
+	mapping, err := json.Marshal(session.Mapping)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode import mapping: %w", err)
+	}
+
+	query := `
+		UPDATE import_sessions SET mapping = $2, date_format = NULLIF($3, ''), items = $4
+		WHERE id = $1 AND applied_at IS NULL`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, session.ID, mapping, session.DateFormat, items)
+	if err != nil {
+		return false, fmt.Errorf("failed to update import items: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// encodeImportSheet encodes the spreadsheet parts of a session, leaving
+// them NULL for other sources.
+func encodeImportSheet(session *domain.ImportSession) (columns, mapping, rows []byte, err error) {
+	if !session.Spreadsheet() {
+		return nil, nil, nil, nil
+	}
+	if columns, err = json.Marshal(session.Columns); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encode import columns: %w", err)
+	}
+	if mapping, err = json.Marshal(session.Mapping); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encode import mapping: %w", err)
+	}
+	if rows, err = json.Marshal(session.Rows); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encode import rows: %w", err)
+	}
+	return columns, mapping, rows, nil
+}
+
+// MarkApplied records that a session has been imported. It returns false if
+// the session was already applied, so concurrent confirmations import once.
+func (r *ImportRepository) MarkApplied(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	query := `UPDATE import_sessions SET applied_at = $2 WHERE id = $1 AND applied_at IS NULL`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, id, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark import applied: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// DeleteExpired removes sessions that expired before the cutoff.
+func (r *ImportRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM import_sessions WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired import sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// KeywordRepository implements port.KeywordRepository with PostgreSQL.
+type KeywordRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewKeywordRepository creates a new KeywordRepository.
+func NewKeywordRepository(pool *pgxpool.Pool) *KeywordRepository {
+	return &KeywordRepository{pool: pool}
+}
+
+// ListInterests loads the whole taxonomy with its labels and synonyms.
+func (r *KeywordRepository) ListInterests(ctx context.Context) ([]domain.Interest, error) {
+	query := `
+		SELECT i.slug, i.parent_slug,
+		       COALESCE((SELECT jsonb_object_agg(l.locale, l.label) FROM interest_labels l WHERE l.slug = i.slug), '{}'::jsonb),
+		       COALESCE((SELECT array_agg(s.term ORDER BY s.term) FROM interest_synonyms s WHERE s.slug = i.slug), '{}')
+		FROM interests i
+		ORDER BY i.slug`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interests: %w", err)
+	}
+	defer rows.Close()
+
+	var interests []domain.Interest
+	for rows.Next() {
+		var in domain.Interest
+		if err := rows.Scan(&in.Slug, &in.ParentSlug, &in.Labels, &in.Synonyms); err != nil {
+			return nil, fmt.Errorf("failed to scan interest: %w", err)
+		}
+		interests = append(interests, in)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list interests: %w", err)
+	}
+	return interests, nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const occasionColumns = `o.id, o.recipient_id, o.kind, o.title, o.date, o.recurring, o.budget, o.created_at, o.updated_at`
+
+// OccasionRepository implements port.OccasionRepository with PostgreSQL.
+type OccasionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOccasionRepository creates a new OccasionRepository.
+func NewOccasionRepository(pool *pgxpool.Pool) *OccasionRepository {
+	return &OccasionRepository{pool: pool}
+}
+
+// Create inserts a new occasion.
+func (r *OccasionRepository) Create(ctx context.Context, o *domain.Occasion) error {
+	query := `
+		INSERT INTO occasions (id, recipient_id, kind, title, date, recurring, budget, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		o.ID, o.RecipientID, o.Kind, o.Title, o.Date.Time, o.Recurring, o.Budget, o.CreatedAt, o.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create occasion: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an occasion by ID.
+func (r *OccasionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Occasion, error) {
+	query := `SELECT ` + occasionColumns + ` FROM occasions o WHERE o.id = $1`
+
+	o, err := scanOccasion(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occasion: %w", err)
+	}
+	return o, nil
+}
+
+// ListByRecipientID returns a recipient's occasions ordered by date.
+func (r *OccasionRepository) ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.Occasion, error) {
+	query := `
+		SELECT ` + occasionColumns + `
+		FROM occasions o WHERE o.recipient_id = $1
+		ORDER BY o.date, o.created_at`
+	return r.list(ctx, query, recipientID)
+}
+
+// ListByOwners returns the occasions of every recipient the owners have outside the trash.
+func (r *OccasionRepository) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Occasion, error) {
+	query := `
+		SELECT ` + occasionColumns + `
+		FROM occasions o
+		JOIN recipients r ON r.id = o.recipient_id
+		WHERE r.user_id = ANY($1) AND r.deleted_at IS NULL
+		ORDER BY o.date, o.created_at`
+	return r.list(ctx, query, ownerIDs)
+}
+
+func (r *OccasionRepository) list(ctx context.Context, query string, args ...any) ([]domain.Occasion, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list occasions: %w", err)
+	}
+	defer rows.Close()
+
+	var occasions []domain.Occasion
+	for rows.Next() {
+		o, err := scanOccasion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan occasion: %w", err)
+		}
+		occasions = append(occasions, *o)
+	}
+	return occasions, rows.Err()
+}
+
+// Update modifies an occasion's fields.
+func (r *OccasionRepository) Update(ctx context.Context, o *domain.Occasion) error {
+	query := `
+		UPDATE occasions
+		SET kind = $2, title = $3, date = $4, recurring = $5, budget = $6, updated_at = $7
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		o.ID, o.Kind, o.Title, o.Date.Time, o.Recurring, o.Budget, o.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update occasion: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an occasion.
+func (r *OccasionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM occasions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete occasion: %w", err)
+	}
+	return nil
+}
+
+// Reassign moves a recipient's occasions to another recipient. Occasions
+// the target already has, with the same kind, title and date, are left
+// behind.
+func (r *OccasionRepository) Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	query := `
+		UPDATE occasions o SET recipient_id = $2, updated_at = NOW()
+		WHERE o.recipient_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM occasions t
+			WHERE t.recipient_id = $2 AND t.kind = o.kind AND t.title = o.title AND t.date = o.date)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, fromRecipientID, toRecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to reassign occasions: %w", err)
+	}
+	return nil
+}
+
+func scanOccasion(row pgx.Row) (*domain.Occasion, error) {
+	o := &domain.Occasion{}
+	var date time.Time
+	err := row.Scan(&o.ID, &o.RecipientID, &o.Kind, &o.Title, &date, &o.Recurring, &o.Budget, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	o.Date = domain.NewDate(date)
+	return o, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+// PreferencesRepository implements port.PreferencesRepository with PostgreSQL.
+type PreferencesRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPreferencesRepository creates a new PreferencesRepository.
+func NewPreferencesRepository(pool *pgxpool.Pool) *PreferencesRepository {
+	return &PreferencesRepository{pool: pool}
+}
+
+// GetByUserID retrieves the preferences saved by a user.
+func (r *PreferencesRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserPreferences, error) {
+	query := `
+		SELECT user_id, timezone, locale, currency, reminder_lead_days, created_at, updated_at
+		FROM user_preferences WHERE user_id = $1`
+
+	prefs := &domain.UserPreferences{}
+	err := r.pool.QueryRow(ctx, query, userID).Scan(
+		&prefs.UserID, &prefs.Timezone, &prefs.Locale, &prefs.Currency,
+		&prefs.ReminderLeadDays, &prefs.CreatedAt, &prefs.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// Upsert inserts or replaces a user's preferences.
+func (r *PreferencesRepository) Upsert(ctx context.Context, prefs *domain.UserPreferences) error {
+	query := `
+		INSERT INTO user_preferences (user_id, timezone, locale, currency, reminder_lead_days, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone, locale = EXCLUDED.locale, currency = EXCLUDED.currency,
+		    reminder_lead_days = EXCLUDED.reminder_lead_days, updated_at = EXCLUDED.updated_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		prefs.UserID, prefs.Timezone, prefs.Locale, prefs.Currency,
+		prefs.ReminderLeadDays, prefs.CreatedAt, prefs.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert user preferences: %w", err)
+	}
+	return nil
+}
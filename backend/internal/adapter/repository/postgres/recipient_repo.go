@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,6 +13,29 @@ import (
 	"github.com/vsssp/birthday-app/backend/internal/domain"
 )
 
+const recipientColumns = `id, user_id, name, age, gender, relationship, birthdate, min_budget, max_budget, currency, keywords, version, created_at, updated_at, deleted_at`
+
+// nextBirthdayExpr computes the days until a recipient's next birthday relative
+// to the date bound to the %[1]s placeholder. Recipients without a birthdate sort last.
+const nextBirthdayExpr = `COALESCE(
+	((birthdate + (EXTRACT(YEAR FROM age(%[1]s::date - 1, birthdate))::int + 1) * INTERVAL '1 year')::date - %[1]s::date),
+	100000)`
+
+// budgetSortExpr orders by the top of the budget converted into the query's
+// currency, bound to the %[1]s placeholder. Budgets that cannot be converted
+// sort as the lowest.
+const budgetSortExpr = `COALESCE(%[1]s, -1)`
+
+// recipientSortColumns maps each sort field to its SQL expression and the type
+// its cursor key is cast back to.
+var recipientSortColumns = map[domain.RecipientSort]struct{ expr, cast string }{
+	domain.RecipientSortCreatedAt:    {"created_at", "timestamptz"},
+	domain.RecipientSortName:         {"lower(name)", "text"},
+	domain.RecipientSortAge:          {"age", "int"},
+	domain.RecipientSortBudget:       {budgetSortExpr, "bigint"},
+	domain.RecipientSortNextBirthday: {nextBirthdayExpr, "int"},
+}
+
 // RecipientRepository implements port.RecipientRepository with PostgreSQL.
 type RecipientRepository struct {
 	pool *pgxpool.Pool
@@ -24,13 +49,13 @@ func NewRecipientRepository(pool *pgxpool.Pool) *RecipientRepository {
 // Create inserts a new recipient.
 func (r *RecipientRepository) Create(ctx context.Context, recipient *domain.Recipient) error {
 	query := `
-		INSERT INTO recipients (id, user_id, name, age, gender, min_budget, max_budget, keywords, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		INSERT INTO recipients (id, user_id, name, age, gender, relationship, birthdate, min_budget, max_budget, currency, keywords, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		recipient.ID, recipient.UserID, recipient.Name, recipient.Age, recipient.Gender,
-		recipient.MinBudget, recipient.MaxBudget, recipient.Keywords,
-		recipient.CreatedAt, recipient.UpdatedAt,
+		recipient.Relationship, dateArg(recipient.Birthdate), recipient.MinBudget, recipient.MaxBudget,
+		recipient.Currency, recipient.Keywords, recipient.Version, recipient.CreatedAt, recipient.UpdatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create recipient: %w", err)
@@ -38,18 +63,32 @@ func (r *RecipientRepository) Create(ctx context.Context, recipient *domain.Reci
 	return nil
 }
 
+// CreateMany inserts recipients with a single COPY.
+func (r *RecipientRepository) CreateMany(ctx context.Context, recipients []domain.Recipient) error {
+	columns := []string{
+		"id", "user_id", "name", "age", "gender", "relationship", "birthdate",
+		"min_budget", "max_budget", "currency", "keywords", "version", "created_at", "updated_at",
+	}
+	_, err := conn(ctx, r.pool).CopyFrom(ctx, pgx.Identifier{"recipients"}, columns,
+		pgx.CopyFromSlice(len(recipients), func(i int) ([]any, error) {
+			rec := &recipients[i]
+			return []any{
+				rec.ID, rec.UserID, rec.Name, rec.Age, rec.Gender, rec.Relationship, dateArg(rec.Birthdate),
+				rec.MinBudget, rec.MaxBudget, rec.Currency, rec.Keywords, rec.Version, rec.CreatedAt, rec.UpdatedAt,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recipients: %w", err)
+	}
+	return nil
+}
+
 // GetByID retrieves a recipient by ID.
 func (r *RecipientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Recipient, error) {
-	query := `
-		SELECT id, user_id, name, age, gender, min_budget, max_budget, keywords, created_at, updated_at
-		FROM recipients WHERE id = $1`
+	query := `SELECT ` + recipientColumns + ` FROM recipients WHERE id = $1 AND deleted_at IS NULL`
 
-	rec := &domain.Recipient{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&rec.ID, &rec.UserID, &rec.Name, &rec.Age, &rec.Gender,
-		&rec.MinBudget, &rec.MaxBudget, &rec.Keywords,
-		&rec.CreatedAt, &rec.UpdatedAt,
-	)
+	rec, err := scanRecipient(conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -59,14 +98,28 @@ func (r *RecipientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 	return rec, nil
 }
 
-// ListByUserID returns all recipients belonging to a user.
-func (r *RecipientRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Recipient, error) {
+// GetDeletedByID retrieves a recipient that is in the trash.
+func (r *RecipientRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Recipient, error) {
+	query := `SELECT ` + recipientColumns + ` FROM recipients WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	rec, err := scanRecipient(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted recipient: %w", err)
+	}
+	return rec, nil
+}
+
+// ListByOwners returns all recipients belonging to the owners outside the trash.
+func (r *RecipientRepository) ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error) {
 	query := `
-		SELECT id, user_id, name, age, gender, min_budget, max_budget, keywords, created_at, updated_at
-		FROM recipients WHERE user_id = $1
+		SELECT ` + recipientColumns + `
+		FROM recipients WHERE user_id = ANY($1) AND deleted_at IS NULL
 		ORDER BY created_at DESC`
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ownerIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list recipients: %w", err)
 	}
@@ -74,54 +127,282 @@ func (r *RecipientRepository) ListByUserID(ctx context.Context, userID uuid.UUID
 
 	var recipients []domain.Recipient
 	for rows.Next() {
-		var rec domain.Recipient
-		if err := rows.Scan(
-			&rec.ID, &rec.UserID, &rec.Name, &rec.Age, &rec.Gender,
-			&rec.MinBudget, &rec.MaxBudget, &rec.Keywords,
-			&rec.CreatedAt, &rec.UpdatedAt,
-		); err != nil {
+		rec, err := scanRecipient(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipient: %w", err)
 		}
-		recipients = append(recipients, rec)
+		recipients = append(recipients, *rec)
 	}
-	return recipients, nil
+	return recipients, rows.Err()
 }
 
-// Update modifies a recipient's fields.
+// ListPage returns one filtered, sorted page of the owners' recipients using
+// keyset pagination. The returned cursor is nil on the last page.
+func (r *RecipientRepository) ListPage(ctx context.Context, ownerIDs []uuid.UUID, q domain.RecipientQuery) ([]domain.Recipient, *domain.RecipientCursor, error) {
+	sortCol, ok := recipientSortColumns[q.Sort]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported recipient sort %q", q.Sort)
+	}
+
+	args := []any{ownerIDs}
+	where := []string{"user_id = ANY($1)", "deleted_at IS NULL"}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// converted expresses a budget column in q.Currency, or NULL when the
+	// recipient's currency has no rate to it.
+	converted := func(col string) string {
+		if len(q.BudgetRates) == 0 {
+			return "NULL::bigint"
+		}
+		var rate strings.Builder
+		for _, currency := range domain.SupportedCurrencies {
+			if r, ok := q.BudgetRates[currency]; ok {
+				fmt.Fprintf(&rate, " WHEN %s THEN %s::float8", arg(currency), arg(r))
+			}
+		}
+		return fmt.Sprintf("ROUND(%s * CASE currency%s END)::bigint", col, rate.String())
+	}
+
+	sortExpr := sortCol.expr
+	switch q.Sort {
+	case domain.RecipientSortNextBirthday:
+		sortExpr = fmt.Sprintf(sortCol.expr, arg(q.Today))
+	case domain.RecipientSortBudget:
+		sortExpr = fmt.Sprintf(sortCol.expr, converted("max_budget"))
+	}
+
+	if q.Gender != "" {
+		where = append(where, "gender = "+arg(q.Gender))
+	}
+	if q.MinAge != nil {
+		where = append(where, "age >= "+arg(*q.MinAge))
+	}
+	if q.MaxAge != nil {
+		where = append(where, "age <= "+arg(*q.MaxAge))
+	}
+	if q.BudgetMin != nil {
+		where = append(where, converted("max_budget")+" >= "+arg(*q.BudgetMin))
+	}
+	if q.BudgetMax != nil {
+		where = append(where, converted("min_budget")+" <= "+arg(*q.BudgetMax))
+	}
+	if len(q.Keywords) > 0 {
+		where = append(where, "keywords @> "+arg(q.Keywords))
+	}
+	if q.GroupID != nil {
+		where = append(where, `EXISTS (
+			SELECT 1 FROM recipient_group_members m
+			WHERE m.recipient_id = recipients.id AND m.group_id = `+arg(*q.GroupID)+`)`)
+	}
+
+	cmp, dir := ">", "ASC"
+	if q.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	if q.After != nil {
+		where = append(where, fmt.Sprintf("(%s, id) %s (%s::%s, %s)",
+			sortExpr, cmp, arg(q.After.Key), sortCol.cast, arg(q.After.ID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, (%s)::text
+		FROM recipients WHERE %s
+		ORDER BY %s %s, id %s
+		LIMIT %s`,
+		recipientColumns, sortExpr, strings.Join(where, " AND "),
+		sortExpr, dir, dir, arg(q.Limit+1))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		recipients []domain.Recipient
+		keys       []string
+	)
+	for rows.Next() {
+		var key string
+		rec, err := scanRecipient(rows, &key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan recipient: %w", err)
+		}
+		recipients = append(recipients, *rec)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+
+	if len(recipients) <= q.Limit {
+		return recipients, nil, nil
+	}
+	recipients = recipients[:q.Limit]
+	last := recipients[q.Limit-1]
+	return recipients, &domain.RecipientCursor{
+		Sort: q.Sort,
+		Desc: q.Desc,
+		Key:  keys[q.Limit-1],
+		ID:   last.ID,
+	}, nil
+}
+
+// likeEscaper escapes the LIKE wildcards, and the escape character itself,
+// so user input only ever matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike returns term as a literal LIKE pattern using '\' as the escape.
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+// Search ranks the owners' recipients by trigram similarity of the accent- and
+// case-folded term against their name and keywords.
+func (r *RecipientRepository) Search(ctx context.Context, ownerIDs []uuid.UUID, term string, limit int) ([]domain.RecipientSearchResult, error) {
+	query := `
+		WITH q AS (SELECT lower(immutable_unaccent($2)) AS term,
+		                  lower(immutable_unaccent($4)) AS pattern)
+		SELECT ` + recipientColumns + `,
+		       GREATEST(
+		           similarity(lower(immutable_unaccent(name)), q.term),
+		           word_similarity(q.term, recipient_search_text(name, keywords))
+		       ) AS score
+		FROM recipients, q
+		WHERE user_id = ANY($1) AND deleted_at IS NULL
+		  AND (q.term <% recipient_search_text(name, keywords)
+		       OR recipient_search_text(name, keywords) LIKE '%' || q.pattern || '%' ESCAPE '\')
+		ORDER BY score DESC, lower(name), id
+		LIMIT $3`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ownerIDs, term, limit, escapeLike(term))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var results []domain.RecipientSearchResult
+	for rows.Next() {
+		var score float64
+		rec, err := scanRecipient(rows, &score)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recipient: %w", err)
+		}
+		results = append(results, domain.RecipientSearchResult{Recipient: *rec, Score: score})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search recipients: %w", err)
+	}
+	return results, nil
+}
+
+// Update modifies a recipient's fields if its version still matches the one
+// that was read, then bumps the version. A mismatch yields domain.ErrVersionConflict.
 func (r *RecipientRepository) Update(ctx context.Context, recipient *domain.Recipient) error {
 	query := `
 		UPDATE recipients
-		SET name = $2, age = $3, gender = $4, min_budget = $5, max_budget = $6,
-		    keywords = $7, updated_at = $8
-		WHERE id = $1`
-
-	_, err := r.pool.Exec(ctx, query,
-		recipient.ID, recipient.Name, recipient.Age, recipient.Gender,
-		recipient.MinBudget, recipient.MaxBudget, recipient.Keywords,
-		recipient.UpdatedAt,
+		SET name = $2, age = $3, gender = $4, relationship = $5, birthdate = $6, min_budget = $7,
+		    max_budget = $8, currency = $9, keywords = $10, updated_at = $11, version = version + 1
+		WHERE id = $1 AND version = $12`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		recipient.ID, recipient.Name, recipient.Age, recipient.Gender, recipient.Relationship,
+		dateArg(recipient.Birthdate), recipient.MinBudget, recipient.MaxBudget, recipient.Currency,
+		recipient.Keywords, recipient.UpdatedAt, recipient.Version,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update recipient: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrVersionConflict
+	}
+	recipient.Version++
 	return nil
 }
 
-// Delete removes a recipient by ID.
+// Delete moves a recipient to the trash.
 func (r *RecipientRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM recipients WHERE id = $1`
-	_, err := r.pool.Exec(ctx, query, id)
+	query := `UPDATE recipients SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL`
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete recipient: %w", err)
 	}
 	return nil
 }
 
-// BulkDelete removes multiple recipients belonging to a user.
-func (r *RecipientRepository) BulkDelete(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
-	query := `DELETE FROM recipients WHERE user_id = $1 AND id = ANY($2)`
-	_, err := r.pool.Exec(ctx, query, userID, ids)
+// BulkDelete moves multiple recipients to the trash.
+func (r *RecipientRepository) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
+	query := `
+		UPDATE recipients SET deleted_at = NOW(), version = version + 1
+		WHERE id = ANY($1) AND deleted_at IS NULL`
+	_, err := conn(ctx, r.pool).Exec(ctx, query, ids)
 	if err != nil {
 		return fmt.Errorf("failed to bulk delete recipients: %w", err)
 	}
 	return nil
 }
+
+// ListDeleted returns the owners' trashed recipients, most recently deleted first.
+func (r *RecipientRepository) ListDeleted(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error) {
+	query := `
+		SELECT ` + recipientColumns + `
+		FROM recipients WHERE user_id = ANY($1) AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ownerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []domain.Recipient
+	for rows.Next() {
+		rec, err := scanRecipient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recipient: %w", err)
+		}
+		recipients = append(recipients, *rec)
+	}
+	return recipients, rows.Err()
+}
+
+// Restore takes a recipient out of the trash and returns its new version.
+func (r *RecipientRepository) Restore(ctx context.Context, id uuid.UUID, updatedAt time.Time) (int, error) {
+	query := `
+		UPDATE recipients SET deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $1 RETURNING version`
+	var version int
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id, updatedAt).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to restore recipient: %w", err)
+	}
+	return version, nil
+}
+
+// PurgeDeleted permanently removes recipients trashed before the cutoff.
+func (r *RecipientRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM recipients WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted recipients: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// scanRecipient reads a row selected with recipientColumns followed by any extra columns.
+func scanRecipient(row pgx.Row, extra ...any) (*domain.Recipient, error) {
+	rec := &domain.Recipient{}
+	var birthdate *time.Time
+	dest := []any{
+		&rec.ID, &rec.UserID, &rec.Name, &rec.Age, &rec.Gender, &rec.Relationship, &birthdate,
+		&rec.MinBudget, &rec.MaxBudget, &rec.Currency, &rec.Keywords, &rec.Version,
+		&rec.CreatedAt, &rec.UpdatedAt, &rec.DeletedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	rec.Birthdate = dateValue(birthdate)
+	return rec, nil
+}
@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+)
+
+const wishlistColumns = `l.id, l.user_id, l.recipient_id, l.title, l.description, l.slug, l.created_at, l.updated_at`
+
+// WishlistRepository implements port.WishlistRepository with PostgreSQL.
+type WishlistRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWishlistRepository creates a new WishlistRepository.
+func NewWishlistRepository(pool *pgxpool.Pool) *WishlistRepository {
+	return &WishlistRepository{pool: pool}
+}
+
+// Create inserts a new wishlist with its items.
+func (r *WishlistRepository) Create(ctx context.Context, l *domain.Wishlist) error {
+	query := `
+		INSERT INTO wishlists (id, user_id, recipient_id, title, description, slug, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		l.ID, l.UserID, l.RecipientID, l.Title, l.Description, l.Slug, l.CreatedAt, l.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create wishlist: %w", err)
+	}
+	return r.saveItems(ctx, l)
+}
+
+// GetByID retrieves a wishlist with its items.
+func (r *WishlistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Wishlist, error) {
+	return r.getOne(ctx, `SELECT `+wishlistColumns+` FROM wishlists l WHERE l.id = $1`, id)
+}
+
+// GetBySlug retrieves the wishlist published at slug, with its items.
+func (r *WishlistRepository) GetBySlug(ctx context.Context, slug string) (*domain.Wishlist, error) {
+	return r.getOne(ctx, `SELECT `+wishlistColumns+` FROM wishlists l WHERE l.slug = $1`, slug)
+}
+
+func (r *WishlistRepository) getOne(ctx context.Context, query string, arg any) (*domain.Wishlist, error) {
+	l, err := scanWishlist(conn(ctx, r.pool).QueryRow(ctx, query, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+
+	wishlists := []domain.Wishlist{*l}
+	if err := r.loadItems(ctx, wishlists); err != nil {
+		return nil, err
+	}
+	return &wishlists[0], nil
+}
+
+// ListByUserID returns a user's wishlists, newest first.
+func (r *WishlistRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Wishlist, error) {
+	query := `
+		SELECT ` + wishlistColumns + `
+		FROM wishlists l WHERE l.user_id = $1
+		ORDER BY l.created_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlists: %w", err)
+	}
+	defer rows.Close()
+
+	var wishlists []domain.Wishlist
+	for rows.Next() {
+		l, err := scanWishlist(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist: %w", err)
+		}
+		wishlists = append(wishlists, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadItems(ctx, wishlists); err != nil {
+		return nil, err
+	}
+	return wishlists, nil
+}
+
+// loadItems fills in the items of each wishlist, in position order.
+func (r *WishlistRepository) loadItems(ctx context.Context, wishlists []domain.Wishlist) error {
+	if len(wishlists) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(wishlists))
+	index := make(map[uuid.UUID]int, len(wishlists))
+	for i := range wishlists {
+		ids[i] = wishlists[i].ID
+		index[wishlists[i].ID] = i
+		wishlists[i].Items = []domain.WishlistItem{}
+	}
+
+	query := `
+		SELECT i.id, i.wishlist_id, i.idea_id, i.position
+		FROM wishlist_items i WHERE i.wishlist_id = ANY($1)
+		ORDER BY i.position`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list wishlist items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.WishlistItem
+		if err := rows.Scan(&item.ID, &item.WishlistID, &item.IdeaID, &item.Position); err != nil {
+			return fmt.Errorf("failed to scan wishlist item: %w", err)
+		}
+		l := &wishlists[index[item.WishlistID]]
+		l.Items = append(l.Items, item)
+	}
+	return rows.Err()
+}
+
+// Update modifies a wishlist's details and replaces its items. Items that
+// stay keep their claims; removed items lose theirs by cascade.
+func (r *WishlistRepository) Update(ctx context.Context, l *domain.Wishlist) error {
+	query := `
+		UPDATE wishlists
+		SET title = $2, description = $3, slug = $4, updated_at = $5
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, l.ID, l.Title, l.Description, l.Slug, l.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update wishlist: %w", err)
+	}
+
+	itemIDs := make([]uuid.UUID, len(l.Items))
+	for i, item := range l.Items {
+		itemIDs[i] = item.ID
+	}
+	_, err = conn(ctx, r.pool).Exec(ctx,
+		`DELETE FROM wishlist_items WHERE wishlist_id = $1 AND id <> ALL($2)`, l.ID, itemIDs)
+	if err != nil {
+		return fmt.Errorf("failed to remove wishlist items: %w", err)
+	}
+	return r.saveItems(ctx, l)
+}
+
+// saveItems inserts the wishlist's items, or updates the positions of the
+// ones already stored.
+func (r *WishlistRepository) saveItems(ctx context.Context, l *domain.Wishlist) error {
+	query := `
+		INSERT INTO wishlist_items (id, wishlist_id, idea_id, position)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET position = EXCLUDED.position`
+
+	for _, item := range l.Items {
+		if _, err := conn(ctx, r.pool).Exec(ctx, query, item.ID, l.ID, item.IdeaID, item.Position); err != nil {
+			return fmt.Errorf("failed to save wishlist item: %w", err)
+		}
+	}
+	return nil
+}
+
+// Delete removes a wishlist and, by cascade, its items and claims.
+func (r *WishlistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM wishlists WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete wishlist: %w", err)
+	}
+	return nil
+}
+
+// Reassign moves a recipient's wishlists to another recipient.
+func (r *WishlistRepository) Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE wishlists SET recipient_id = $2, updated_at = NOW() WHERE recipient_id = $1`,
+		fromRecipientID, toRecipientID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reassign wishlists: %w", err)
+	}
+	return nil
+}
+
+// CreateClaim inserts a claim. An item that is already claimed yields
+// domain.ErrAlreadyExists.
+func (r *WishlistRepository) CreateClaim(ctx context.Context, c *domain.WishlistClaim) error {
+	query := `
+		INSERT INTO wishlist_claims (id, item_id, name, token, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, c.ID, c.ItemID, c.Name, c.Token, c.CreatedAt)
+	if isUniqueViolation(err) {
+		return domain.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create wishlist claim: %w", err)
+	}
+	return nil
+}
+
+// GetClaimByToken finds the claim released by token.
+func (r *WishlistRepository) GetClaimByToken(ctx context.Context, token string) (*domain.WishlistClaim, error) {
+	query := `SELECT id, item_id, name, token, created_at FROM wishlist_claims WHERE token = $1`
+
+	var c domain.WishlistClaim
+	err := conn(ctx, r.pool).QueryRow(ctx, query, token).Scan(&c.ID, &c.ItemID, &c.Name, &c.Token, &c.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist claim: %w", err)
+	}
+	return &c, nil
+}
+
+// DeleteClaim removes a claim.
+func (r *WishlistRepository) DeleteClaim(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM wishlist_claims WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete wishlist claim: %w", err)
+	}
+	return nil
+}
+
+// ClaimedItemIDs returns which of a wishlist's items are claimed.
+func (r *WishlistRepository) ClaimedItemIDs(ctx context.Context, wishlistID uuid.UUID) (map[uuid.UUID]bool, error) {
+	query := `
+		SELECT c.item_id
+		FROM wishlist_claims c
+		JOIN wishlist_items i ON i.id = c.item_id
+		WHERE i.wishlist_id = $1`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, wishlistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlist claims: %w", err)
+	}
+	defer rows.Close()
+
+	claimed := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist claim: %w", err)
+		}
+		claimed[id] = true
+	}
+	return claimed, rows.Err()
+}
+
+func scanWishlist(row pgx.Row) (*domain.Wishlist, error) {
+	l := &domain.Wishlist{}
+	err := row.Scan(&l.ID, &l.UserID, &l.RecipientID, &l.Title, &l.Description, &l.Slug, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"net/netip"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -14,12 +16,19 @@ type Config struct {
 	JWT      JWTConfig
 	Google   GoogleConfig
 	Apple    AppleConfig
+	Jobs     JobsConfig
 }
 
 // ServerConfig holds HTTP server settings.
 type ServerConfig struct {
 	Port string `env:"SERVER_PORT" envDefault:"8080"`
 	Env  string `env:"SERVER_ENV" envDefault:"development"`
+	// PublicRateLimit is how many requests per minute each client IP may
+	// make to the public routes; 0 disables the limit.
+	PublicRateLimit int `env:"PUBLIC_RATE_LIMIT" envDefault:"60"`
+	// TrustedProxies lists the networks, in CIDR notation, of the reverse
+	// proxies whose X-Forwarded-For and X-Real-IP headers are believed.
+	TrustedProxies []netip.Prefix `env:"TRUSTED_PROXIES" envSeparator:","`
 }
 
 // DatabaseConfig holds database connection settings.
@@ -50,11 +59,36 @@ type AppleConfig struct {
 	ClientID string `env:"APPLE_CLIENT_ID" envDefault:""`
 }
 
+// JobsConfig holds background job settings.
+type JobsConfig struct {
+	TrashRetentionDays  int           `env:"TRASH_RETENTION_DAYS" envDefault:"30"`
+	TrashPurgeInterval  time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+	ImportPurgeInterval time.Duration `env:"IMPORT_PURGE_INTERVAL" envDefault:"1h"`
+}
+
 // Load parses environment variables into a Config struct.
 func Load() (*Config, error) {
 	cfg := &Config{}
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Jobs.validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
+
+// validate rejects settings that would crash a job's ticker or purge the
+// trash on every run.
+func (c JobsConfig) validate() error {
+	if c.TrashRetentionDays <= 0 {
+		return fmt.Errorf("TRASH_RETENTION_DAYS must be positive, got %d", c.TrashRetentionDays)
+	}
+	if c.TrashPurgeInterval <= 0 {
+		return fmt.Errorf("TRASH_PURGE_INTERVAL must be positive, got %s", c.TrashPurgeInterval)
+	}
+	if c.ImportPurgeInterval <= 0 {
+		return fmt.Errorf("IMPORT_PURGE_INTERVAL must be positive, got %s", c.ImportPurgeInterval)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed is a user's secret iCalendar subscription. Anyone holding the
+// token can read the feed, so a leaked token is rotated rather than reused.
+type CalendarFeed struct {
+	UserID    uuid.UUID
+	Token     string
+	CreatedAt time.Time
+}
+
+// Path returns the feed's URL path, relative to the API host.
+func (f *CalendarFeed) Path() string {
+	return "/api/calendar/" + f.Token + ".ics"
+}
+
+// MarshalJSON implements json.Marshaler, exposing the feed path alongside the token.
+func (f CalendarFeed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Token     string    `json:"token"`
+		Path      string    `json:"path"`
+		CreatedAt time.Time `json:"created_at"`
+	}{f.Token, f.Path(), f.CreatedAt})
+}
@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// CatalogItem is a gift idea from the built-in catalog. Category is the
+// interest slug the item suits.
+type CatalogItem struct {
+	ID       string
+	Category string
+	Labels   map[string]string
+	Price    Money // in CatalogCurrency
+	MinAge   int
+	MaxAge   int // 0 when there is no upper bound
+}
+
+// CatalogCurrency is the currency catalog prices are given in.
+const CatalogCurrency = "USD"
+
+// Label returns the item's name in locale, falling back to DefaultLocale.
+func (c CatalogItem) Label(locale string) string {
+	if l, ok := c.Labels[locale]; ok {
+		return l
+	}
+	if l, ok := c.Labels[DefaultLocale]; ok {
+		return l
+	}
+	return c.ID
+}
+
+// suits reports whether the item is meant for someone of age. An unknown
+// age (0) suits every item.
+func (c CatalogItem) suits(age int) bool {
+	if age == 0 {
+		return true
+	}
+	return age >= c.MinAge && (c.MaxAge == 0 || age <= c.MaxAge)
+}
+
+// LookupCatalogItem finds a catalog item by ID.
+func LookupCatalogItem(id string) (CatalogItem, bool) {
+	for _, item := range giftCatalog {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return CatalogItem{}, false
+}
+
+// Reasons given for a gift suggestion.
+const (
+	SuggestionReasonInterest      = "matches_interest"
+	SuggestionReasonBudget        = "within_budget"
+	SuggestionReasonLikedCategory = "liked_category"
+	SuggestionReasonGivenCategory = "category_given_before"
+)
+
+// Weights of the suggestion signals.
+const (
+	suggestionInterestWeight = 0.6
+	suggestionBudgetWeight   = 0.25
+	// Without a budget, or when an item costs less than the minimum, the
+	// budget counts for less.
+	suggestionLooseBudgetWeight = 0.1
+	// Within the budget, close relationships favour the dearer items and
+	// distant ones the cheaper, by up to this much either way.
+	suggestionClosenessWeight = 0.15
+	// Each earlier gift in a category moves that category's items by one of
+	// these, depending on the reaction; the total is capped to the bounds.
+	suggestionLikedBoost     = 0.2
+	suggestionRepeatPenalty  = -0.15
+	suggestionDislikePenalty = -0.4
+	suggestionMaxBoost       = 0.4
+	suggestionMaxPenalty     = -0.6
+)
+
+// Ratings at or above likedRating count as a good reaction; at or below
+// dislikedRating as a bad one.
+const (
+	likedRating    = 4
+	dislikedRating = 2
+)
+
+// GiftSuggestion is a catalog item ranked for a recipient.
+type GiftSuggestion struct {
+	ItemID   string   `json:"item_id"`
+	Title    string   `json:"title"`
+	Category string   `json:"category"`
+	Price    Money    `json:"price"`
+	Currency string   `json:"currency"`
+	Score    float64  `json:"score"`
+	Reasons  []string `json:"reasons"`
+}
+
+// SuggestGifts ranks the catalog for a recipient. Items that were already
+// given, are over the maximum budget or do not suit the recipient's age are
+// left out. The rest score for matching an interest and fitting the budget,
+// and are boosted or down-ranked by how earlier gifts in their category
+// were received. Titles use locale.
+//
+// closeness, from 0 to 1, moves items within the budget towards its top for
+// close relationships and towards its bottom for distant ones; at
+// DefaultCloseness it has no effect.
+//
+// Prices are converted into currency, and the recipient's budget with them.
+// Without a rate into currency prices stay in CatalogCurrency; without a
+// rate for the budget it is treated as unset.
+func SuggestGifts(r *Recipient, closeness float64, history []GiftRecord, locale, currency string, rates *ExchangeRates, limit int) []GiftSuggestion {
+	if _, ok := rates.Rate(CatalogCurrency, currency); !ok {
+		currency = CatalogCurrency
+	}
+	minBudget, minOK := rates.Convert(r.MinBudget, r.Currency, currency)
+	maxBudget, maxOK := rates.Convert(r.MaxBudget, r.Currency, currency)
+	if !minOK || !maxOK {
+		minBudget, maxBudget = 0, 0
+	}
+
+	givenItems := make(map[string]bool, len(history))
+	givenNames := make(map[string]bool, len(history))
+	categoryAdjust := make(map[string]float64)
+	for _, g := range history {
+		givenItems[g.CatalogItemID] = true
+		givenNames[KeywordKey(g.Item)] = true
+		if g.Category == "" {
+			continue
+		}
+		switch {
+		case g.Rating != nil && *g.Rating >= likedRating:
+			categoryAdjust[g.Category] += suggestionLikedBoost
+		case g.Rating != nil && *g.Rating <= dislikedRating:
+			categoryAdjust[g.Category] += suggestionDislikePenalty
+		default:
+			categoryAdjust[g.Category] += suggestionRepeatPenalty
+		}
+	}
+
+	suggestions := []GiftSuggestion{}
+	for _, item := range giftCatalog {
+		if givenItems[item.ID] || wasGivenByName(item, givenNames) || !item.suits(r.Age) {
+			continue
+		}
+		price, _ := rates.Convert(item.Price, CatalogCurrency, currency)
+		if maxBudget > 0 && price > maxBudget {
+			continue
+		}
+
+		var score float64
+		var reasons []string
+		if slices.Contains(r.Keywords, item.Category) {
+			score += suggestionInterestWeight
+			reasons = append(reasons, SuggestionReasonInterest)
+		}
+		switch {
+		case maxBudget > 0 && price >= minBudget:
+			score += suggestionBudgetWeight
+			reasons = append(reasons, SuggestionReasonBudget)
+			score += closenessAdjust(closeness, float64(price)/float64(maxBudget))
+		default:
+			score += suggestionLooseBudgetWeight
+		}
+		if adjust, ok := categoryAdjust[item.Category]; ok {
+			adjust = max(suggestionMaxPenalty, min(suggestionMaxBoost, adjust))
+			score += adjust
+			if adjust > 0 {
+				reasons = append(reasons, SuggestionReasonLikedCategory)
+			} else {
+				reasons = append(reasons, SuggestionReasonGivenCategory)
+			}
+		}
+		if score <= 0 {
+			continue
+		}
+
+		suggestions = append(suggestions, GiftSuggestion{
+			ItemID:   item.ID,
+			Title:    item.Label(locale),
+			Category: item.Category,
+			Price:    price,
+			Currency: currency,
+			Score:    math.Round(score*100) / 100,
+			Reasons:  reasons,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Price < b.Price
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// closenessAdjust scores where an item sits in the budget, fit being its
+// price as a share of the maximum. Both are centred so that average
+// closeness or a mid-budget item count for nothing.
+func closenessAdjust(closeness, fit float64) float64 {
+	return suggestionClosenessWeight * (2*closeness - 1) * (2*fit - 1)
+}
+
+// wasGivenByName reports whether a gift recorded by hand names the item in
+// any locale.
+func wasGivenByName(item CatalogItem, givenNames map[string]bool) bool {
+	for _, label := range item.Labels {
+		if givenNames[KeywordKey(label)] {
+			return true
+		}
+	}
+	return false
+}
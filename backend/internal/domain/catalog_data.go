@@ -0,0 +1,130 @@
+package domain
+
+// giftCatalog is the offline gift idea dataset, grouped by interest in the
+// taxonomy's order. Prices are typical retail prices in US dollars.
+var giftCatalog = []CatalogItem{
+	{ID: "wireless-controller", Category: "gaming", Price: 60_00, MinAge: 8,
+		Labels: map[string]string{"en-US": "Wireless game controller", "pt-BR": "Controle sem fio para videogame"}},
+	{ID: "gaming-headset", Category: "gaming", Price: 80_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Gaming headset", "pt-BR": "Headset gamer"}},
+	{ID: "strategy-board-game", Category: "board-games", Price: 45_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Strategy board game", "pt-BR": "Jogo de tabuleiro de estratégia"}},
+	{ID: "party-card-game", Category: "board-games", Price: 20_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Party card game", "pt-BR": "Jogo de cartas para festas"}},
+	{ID: "movie-night-kit", Category: "movies", Price: 25_00,
+		Labels: map[string]string{"en-US": "Movie night popcorn kit", "pt-BR": "Kit de pipoca para noite de cinema"}},
+	{ID: "cinema-gift-card", Category: "movies", Price: 30_00, MinAge: 6,
+		Labels: map[string]string{"en-US": "Cinema gift card", "pt-BR": "Vale-presente de cinema"}},
+	{ID: "series-box-set", Category: "tv-series", Price: 40_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "TV series box set", "pt-BR": "Box de série de TV"}},
+	{ID: "streaming-gift-card", Category: "tv-series", Price: 50_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Streaming service gift card", "pt-BR": "Vale-presente de streaming"}},
+	{ID: "bluetooth-speaker", Category: "music", Price: 70_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Portable Bluetooth speaker", "pt-BR": "Caixa de som Bluetooth portátil"}},
+	{ID: "vinyl-record", Category: "music", Price: 30_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Vinyl record", "pt-BR": "Disco de vinil"}},
+	{ID: "manga-volume-set", Category: "anime", Price: 35_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Manga volume set", "pt-BR": "Coleção de mangás"}},
+	{ID: "anime-figure", Category: "anime", Price: 55_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Anime collectible figure", "pt-BR": "Action figure de anime"}},
+	{ID: "team-jersey", Category: "football", Price: 90_00,
+		Labels: map[string]string{"en-US": "Team jersey", "pt-BR": "Camisa de time"}},
+	{ID: "match-ball", Category: "football", Price: 40_00, MinAge: 5,
+		Labels: map[string]string{"en-US": "Match football", "pt-BR": "Bola de futebol oficial"}},
+	{ID: "running-belt", Category: "running", Price: 20_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Running belt", "pt-BR": "Pochete de corrida"}},
+	{ID: "gps-running-watch", Category: "running", Price: 200_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "GPS running watch", "pt-BR": "Relógio de corrida com GPS"}},
+	{ID: "resistance-bands", Category: "fitness", Price: 25_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Resistance band set", "pt-BR": "Kit de elásticos de treino"}},
+	{ID: "smart-water-bottle", Category: "fitness", Price: 30_00,
+		Labels: map[string]string{"en-US": "Insulated water bottle", "pt-BR": "Garrafa térmica"}},
+	{ID: "yoga-mat", Category: "yoga", Price: 35_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Yoga mat", "pt-BR": "Tapete de ioga"}},
+	{ID: "yoga-blocks", Category: "yoga", Price: 20_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Yoga block set", "pt-BR": "Kit de blocos de ioga"}},
+	{ID: "bike-light-set", Category: "cycling", Price: 30_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Bike light set", "pt-BR": "Kit de luzes para bicicleta"}},
+	{ID: "cycling-gloves", Category: "cycling", Price: 25_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Cycling gloves", "pt-BR": "Luvas de ciclismo"}},
+	{ID: "watercolor-set", Category: "painting", Price: 30_00, MinAge: 6,
+		Labels: map[string]string{"en-US": "Watercolor paint set", "pt-BR": "Estojo de aquarela"}},
+	{ID: "sketchbook", Category: "painting", Price: 15_00, MinAge: 6,
+		Labels: map[string]string{"en-US": "Artist sketchbook", "pt-BR": "Caderno de desenho"}},
+	{ID: "camera-strap", Category: "photography", Price: 35_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Leather camera strap", "pt-BR": "Alça de couro para câmera"}},
+	{ID: "instant-camera", Category: "photography", Price: 80_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Instant camera", "pt-BR": "Câmera instantânea"}},
+	{ID: "embroidery-kit", Category: "crafts", Price: 25_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Embroidery kit", "pt-BR": "Kit de bordado"}},
+	{ID: "pottery-class", Category: "crafts", Price: 60_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Pottery class", "pt-BR": "Aula de cerâmica"}},
+	{ID: "chef-knife", Category: "cooking", Price: 70_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Chef's knife", "pt-BR": "Faca de chef"}},
+	{ID: "cookbook", Category: "cooking", Price: 30_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Cookbook", "pt-BR": "Livro de receitas"}},
+	{ID: "pour-over-set", Category: "coffee", Price: 45_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Pour-over coffee set", "pt-BR": "Kit de café coado"}},
+	{ID: "specialty-coffee-beans", Category: "coffee", Price: 25_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Specialty coffee beans", "pt-BR": "Café especial em grãos"}},
+	{ID: "wine-aerator", Category: "wine", Price: 25_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Wine aerator", "pt-BR": "Aerador de vinho"}},
+	{ID: "wine-tasting", Category: "wine", Price: 90_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Wine tasting experience", "pt-BR": "Experiência de degustação de vinhos"}},
+	{ID: "craft-beer-box", Category: "beer", Price: 40_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Craft beer sampler box", "pt-BR": "Kit de cervejas artesanais"}},
+	{ID: "beer-glasses", Category: "beer", Price: 30_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Beer glass set", "pt-BR": "Jogo de copos de cerveja"}},
+	{ID: "wireless-earbuds", Category: "gadgets", Price: 90_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Wireless earbuds", "pt-BR": "Fones de ouvido sem fio"}},
+	{ID: "power-bank", Category: "gadgets", Price: 35_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Power bank", "pt-BR": "Carregador portátil"}},
+	{ID: "mechanical-keyboard", Category: "programming", Price: 100_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Mechanical keyboard", "pt-BR": "Teclado mecânico"}},
+	{ID: "coding-book", Category: "programming", Price: 45_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Programming book", "pt-BR": "Livro de programação"}},
+	{ID: "sci-fi-lego-set", Category: "geek", Price: 70_00, MinAge: 8,
+		Labels: map[string]string{"en-US": "Sci-fi building set", "pt-BR": "Kit de montar de ficção científica"}},
+	{ID: "geek-tshirt", Category: "geek", Price: 25_00,
+		Labels: map[string]string{"en-US": "Pop-culture T-shirt", "pt-BR": "Camiseta de cultura pop"}},
+	{ID: "travel-organizer", Category: "travel", Price: 30_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Travel organizer set", "pt-BR": "Kit de organizadores de viagem"}},
+	{ID: "packable-backpack", Category: "travel", Price: 40_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Packable travel backpack", "pt-BR": "Mochila dobrável de viagem"}},
+	{ID: "trekking-poles", Category: "hiking", Price: 50_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Trekking poles", "pt-BR": "Bastões de caminhada"}},
+	{ID: "hydration-pack", Category: "hiking", Price: 55_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Hydration pack", "pt-BR": "Mochila de hidratação"}},
+	{ID: "camping-lantern", Category: "camping", Price: 30_00, MinAge: 8,
+		Labels: map[string]string{"en-US": "Camping lantern", "pt-BR": "Lanterna de camping"}},
+	{ID: "hammock", Category: "camping", Price: 45_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Camping hammock", "pt-BR": "Rede de camping"}},
+	{ID: "herb-garden-kit", Category: "gardening", Price: 35_00, MinAge: 8,
+		Labels: map[string]string{"en-US": "Indoor herb garden kit", "pt-BR": "Kit de horta em casa"}},
+	{ID: "gardening-tool-set", Category: "gardening", Price: 40_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Gardening tool set", "pt-BR": "Kit de ferramentas de jardinagem"}},
+	{ID: "leather-wallet", Category: "fashion", Price: 50_00, MinAge: 16,
+		Labels: map[string]string{"en-US": "Leather wallet", "pt-BR": "Carteira de couro"}},
+	{ID: "silk-scarf", Category: "fashion", Price: 45_00, MinAge: 16,
+		Labels: map[string]string{"en-US": "Silk scarf", "pt-BR": "Lenço de seda"}},
+	{ID: "skincare-set", Category: "beauty", Price: 50_00, MinAge: 16,
+		Labels: map[string]string{"en-US": "Skincare gift set", "pt-BR": "Kit de cuidados com a pele"}},
+	{ID: "makeup-brush-set", Category: "beauty", Price: 35_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Makeup brush set", "pt-BR": "Kit de pincéis de maquiagem"}},
+	{ID: "pet-bed", Category: "pets", Price: 45_00,
+		Labels: map[string]string{"en-US": "Cozy pet bed", "pt-BR": "Caminha para pet"}},
+	{ID: "interactive-pet-toy", Category: "pets", Price: 20_00,
+		Labels: map[string]string{"en-US": "Interactive pet toy", "pt-BR": "Brinquedo interativo para pet"}},
+	{ID: "aromatherapy-diffuser", Category: "wellness", Price: 40_00, MinAge: 14,
+		Labels: map[string]string{"en-US": "Aromatherapy diffuser", "pt-BR": "Difusor de aromas"}},
+	{ID: "spa-day", Category: "wellness", Price: 120_00, MinAge: 18,
+		Labels: map[string]string{"en-US": "Spa day voucher", "pt-BR": "Vale day spa"}},
+	{ID: "e-reader", Category: "reading", Price: 130_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "E-reader", "pt-BR": "Leitor de livros digitais"}},
+	{ID: "bestseller-novel", Category: "reading", Price: 20_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Bestselling novel", "pt-BR": "Romance best-seller"}},
+	{ID: "language-course", Category: "languages", Price: 80_00, MinAge: 12,
+		Labels: map[string]string{"en-US": "Online language course", "pt-BR": "Curso de idiomas online"}},
+	{ID: "phrasebook-set", Category: "languages", Price: 20_00, MinAge: 10,
+		Labels: map[string]string{"en-US": "Phrasebook and flashcards", "pt-BR": "Guia de conversação e flashcards"}},
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DateLayout is the wire format for calendar dates without a time of day.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date serialized as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+// NewDate truncates t to its calendar date in t's location, expressed in UTC.
+func NewDate(t time.Time) Date {
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a YYYY-MM-DD string.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+// String formats the date as YYYY-MM-DD.
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// NextAnniversary returns the first yearly recurrence of d on or after today.
+// February 29 falls back to February 28 in non-leap years.
+func (d Date) NextAnniversary(today time.Time) Date {
+	today = NewDate(today).Time
+	for year := today.Year(); ; year++ {
+		next := anniversaryIn(d, year)
+		if !next.Before(today) {
+			return Date{next}
+		}
+	}
+}
+
+// DaysUntil returns the number of whole days from today until d.
+func (d Date) DaysUntil(today time.Time) int {
+	return int(d.Sub(NewDate(today).Time).Hours() / 24)
+}
+
+func anniversaryIn(d Date, year int) time.Time {
+	day := d.Day()
+	if d.Month() == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, d.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// AgeOn returns the number of whole years from d until today.
+func (d Date) AgeOn(today time.Time) int {
+	today = NewDate(today).Time
+	age := today.Year() - d.Year()
+	if today.Before(anniversaryIn(d, today.Year())) {
+		age--
+	}
+	return age
+}
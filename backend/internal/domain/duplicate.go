@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateThreshold is the lowest score at which two recipients are
+// reported as likely duplicates.
+const DuplicateThreshold = 0.65
+
+// Weights of each signal in a duplicate score. They add up to 1.
+const (
+	duplicateNameWeight      = 0.6
+	duplicateBirthdateWeight = 0.25
+	duplicateKeywordWeight   = 0.15
+)
+
+// minDuplicateNameSimilarity skips pairs whose names are too far apart for
+// the other signals to matter.
+const minDuplicateNameSimilarity = 0.5
+
+// Reasons given for a duplicate match.
+const (
+	DuplicateReasonSameName      = "same_name"
+	DuplicateReasonSimilarName   = "similar_name"
+	DuplicateReasonSameBirthdate = "same_birthdate"
+	DuplicateReasonSharedKeyword = "shared_keywords"
+)
+
+// DuplicatePair is two recipients that likely describe the same person.
+type DuplicatePair struct {
+	Recipients [2]Recipient `json:"recipients"`
+	// Score runs from 0 to 1; higher is more likely the same person.
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// MergeRecipientsRequest is the payload for merging one recipient into
+// another. KeepID survives; MergeID is moved to the trash.
+type MergeRecipientsRequest struct {
+	KeepID  uuid.UUID `json:"keep_id"`
+	MergeID uuid.UUID `json:"merge_id"`
+}
+
+// Validate checks that both recipients are given and differ.
+func (r MergeRecipientsRequest) Validate() error {
+	var v Validator
+	v.Check(r.KeepID != uuid.Nil, "keep_id", CodeRequired, "keep_id is required")
+	v.Check(r.MergeID != uuid.Nil, "merge_id", CodeRequired, "merge_id is required")
+	v.Check(r.KeepID == uuid.Nil || r.KeepID != r.MergeID, "merge_id", CodeInvalidChoice,
+		"a recipient cannot be merged into itself")
+	return v.Err()
+}
+
+// FindDuplicates scores every pair of recipients and returns those at or
+// above DuplicateThreshold, best first. Within a pair the older recipient
+// comes first, as the one to keep.
+func FindDuplicates(recipients []Recipient) []DuplicatePair {
+	profiles := make([]duplicateProfile, len(recipients))
+	for i := range recipients {
+		profiles[i] = newDuplicateProfile(&recipients[i])
+	}
+
+	pairs := []DuplicatePair{}
+	for i := range profiles {
+		for j := i + 1; j < len(profiles); j++ {
+			score, reasons := profiles[i].score(&profiles[j])
+			score = math.Round(score*100) / 100
+			if score < DuplicateThreshold {
+				continue
+			}
+			a, b := recipients[i], recipients[j]
+			if b.CreatedAt.Before(a.CreatedAt) {
+				a, b = b, a
+			}
+			pairs = append(pairs, DuplicatePair{
+				Recipients: [2]Recipient{a, b},
+				Score:      score,
+				Reasons:    reasons,
+			})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+	return pairs
+}
+
+// duplicateProfile caches the folded name and its trigrams so each
+// recipient is only folded once when scoring every pair.
+type duplicateProfile struct {
+	recipient *Recipient
+	name      string
+	trigrams  map[string]bool
+}
+
+func newDuplicateProfile(r *Recipient) duplicateProfile {
+	name := RecipientNameKey(r.Name)
+	return duplicateProfile{recipient: r, name: name, trigrams: trigrams(name)}
+}
+
+// score weighs name similarity, birthdate and keyword overlap. Like
+// SamePerson, two known birthdates that differ rule a match out; a missing
+// birthdate or keyword list counts as neither for nor against.
+func (p *duplicateProfile) score(other *duplicateProfile) (float64, []string) {
+	name := nameSimilarity(p.name, other.name, p.trigrams, other.trigrams)
+	if name < minDuplicateNameSimilarity {
+		return 0, nil
+	}
+	var reasons []string
+	if p.name == other.name {
+		reasons = append(reasons, DuplicateReasonSameName)
+	} else {
+		reasons = append(reasons, DuplicateReasonSimilarName)
+	}
+
+	a, b := p.recipient, other.recipient
+	birthdate := 0.5
+	if a.Birthdate != nil && b.Birthdate != nil {
+		if !a.Birthdate.Equal(b.Birthdate.Time) {
+			return 0, nil
+		}
+		birthdate = 1
+		reasons = append(reasons, DuplicateReasonSameBirthdate)
+	}
+
+	keywords := 0.5
+	if len(a.Keywords) > 0 && len(b.Keywords) > 0 {
+		shared := 0
+		for _, kw := range a.Keywords {
+			if slices.Contains(b.Keywords, kw) {
+				shared++
+			}
+		}
+		keywords = float64(shared) / float64(len(a.Keywords)+len(b.Keywords)-shared)
+		if shared > 0 {
+			reasons = append(reasons, DuplicateReasonSharedKeyword)
+		}
+	}
+
+	score := duplicateNameWeight*name + duplicateBirthdateWeight*birthdate + duplicateKeywordWeight*keywords
+	return score, reasons
+}
+
+// nameSimilarity compares two folded names from 0 to 1. It takes their
+// trigram similarity, as pg_trgm computes it, but rates a name whose words
+// all appear in the other ("ana" and "ana souza") at least 0.75.
+func nameSimilarity(a, b string, ta, tb map[string]bool) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	sim := trigramSimilarity(ta, tb)
+	if sim < 0.75 && (wordsWithin(a, b) || wordsWithin(b, a)) {
+		sim = 0.75
+	}
+	return sim
+}
+
+// wordsWithin reports whether every word of a is a word of b.
+func wordsWithin(a, b string) bool {
+	words := strings.Fields(b)
+	for _, w := range strings.Fields(a) {
+		if !slices.Contains(words, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func trigramSimilarity(ta, tb map[string]bool) float64 {
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	total := len(ta) + len(tb) - shared
+	if total == 0 {
+		return 0
+	}
+	return float64(shared) / float64(total)
+}
+
+// trigrams returns the trigram set of s, padding each word with two spaces
+// in front and one behind like pg_trgm.
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// MergeFrom folds other into r: keywords are unioned, the budget range is
+// widened to cover both, and fields r leaves blank are taken from other.
+// A recipient with no budget set does not narrow the other's range, and a
+// range in another currency is only taken when r has none.
+func (r *Recipient) MergeFrom(other *Recipient) {
+	for _, kw := range other.Keywords {
+		if !slices.Contains(r.Keywords, kw) {
+			r.Keywords = append(r.Keywords, kw)
+		}
+	}
+
+	switch {
+	case other.MinBudget == 0 && other.MaxBudget == 0:
+	case r.MinBudget == 0 && r.MaxBudget == 0:
+		r.MinBudget, r.MaxBudget, r.Currency = other.MinBudget, other.MaxBudget, other.Currency
+	case r.Currency != other.Currency:
+	default:
+		r.MinBudget = min(r.MinBudget, other.MinBudget)
+		r.MaxBudget = max(r.MaxBudget, other.MaxBudget)
+	}
+
+	if r.Birthdate == nil && other.Birthdate != nil {
+		d := *other.Birthdate
+		r.Birthdate = &d
+	}
+	if r.Age == 0 {
+		r.Age = other.Age
+	}
+	if r.Relationship == "" {
+		r.Relationship = other.Relationship
+	}
+	if (r.Gender == GenderOther || r.Gender == "") && other.Gender != "" {
+		r.Gender = other.Gender
+	}
+}
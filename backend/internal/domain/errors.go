@@ -0,0 +1,62 @@
+package domain
+
+import "net/http"
+
+// Error is a typed application error. Code is a stable, machine-readable
+// identifier; Status is the HTTP status the error is reported with.
+type Error struct {
+	Code   string
+	Status int
+	Title  string
+	Detail string
+	Fields []FieldError
+}
+
+// NewError creates a sentinel error. Derive request-specific variants with
+// WithDetail or WithFields; errors.Is matches them by Code.
+func NewError(status int, code, title string) *Error {
+	return &Error{Code: code, Status: status, Title: title}
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Title
+}
+
+// Is reports whether target is an *Error with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithDetail returns a copy of e carrying an occurrence-specific explanation.
+func (e *Error) WithDetail(detail string) *Error {
+	c := *e
+	c.Detail = detail
+	return &c
+}
+
+// WithFields returns a copy of e listing the invalid fields.
+func (e *Error) WithFields(fields []FieldError) *Error {
+	c := *e
+	c.Fields = fields
+	return &c
+}
+
+// Generic errors shared across features.
+var (
+	ErrBadRequest       = NewError(http.StatusBadRequest, "bad_request", "Bad request")
+	ErrUnauthorized     = NewError(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	ErrNotFound         = NewError(http.StatusNotFound, "not_found", "Resource not found")
+	ErrMethodNotAllowed = NewError(http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	ErrAlreadyExists    = NewError(http.StatusConflict, "already_exists", "Resource already exists")
+	ErrValidation       = NewError(http.StatusUnprocessableEntity, "validation_failed", "Validation failed")
+	ErrInternal         = NewError(http.StatusInternalServerError, "internal_error", "Internal server error")
+
+	// ErrVersionConflict is returned by repositories when a guarded write
+	// finds that the row was changed since it was read.
+	ErrVersionConflict = NewError(http.StatusConflict, "version_conflict", "Resource was modified concurrently")
+)
@@ -0,0 +1,340 @@
+package domain
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeStatus says whether an exchange's names have been drawn.
+type ExchangeStatus string
+
+const (
+	ExchangeDraft ExchangeStatus = "draft"
+	ExchangeDrawn ExchangeStatus = "drawn"
+)
+
+// GiftExchange is a Secret Santa style event: every participant gives a
+// gift to exactly one other participant, drawn at random.
+type GiftExchange struct {
+	ID          uuid.UUID `json:"id"`
+	OrganizerID uuid.UUID `json:"organizer_id"`
+	Name        string    `json:"name"`
+	Budget      *Money    `json:"budget"`
+	Currency    string    `json:"currency"`
+	ExchangeOn  *Date     `json:"exchange_on"`
+	// PreviousExchangeID points at last year's exchange, whose pairs are
+	// not drawn again.
+	PreviousExchangeID *uuid.UUID     `json:"previous_exchange_id"`
+	Status             ExchangeStatus `json:"status"`
+	// Seed drives the draw, so replaying it reproduces the assignments. It
+	// never leaves the server: with it, anyone could work out every pair.
+	Seed int64 `json:"-"`
+	// PreviousPairs are the pairs the draw kept from repeating last year's,
+	// fixed when names are drawn so the draw still replays if the previous
+	// exchange is later deleted. Like the seed, they are secret.
+	PreviousPairs []ExchangePair        `json:"-"`
+	DrawnAt       *time.Time            `json:"drawn_at"`
+	Participants  []ExchangeParticipant `json:"participants"`
+	Exclusions    []ExchangeExclusion   `json:"exclusions"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// ExchangeParticipant is someone taking part in an exchange, identified by
+// email so they need not have an account. Their assignment and secret link
+// token are never serialized.
+type ExchangeParticipant struct {
+	ID         uuid.UUID  `json:"id"`
+	ExchangeID uuid.UUID  `json:"exchange_id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Token      string     `json:"-"`
+	ReceiverID *uuid.UUID `json:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ExchangePair is a giver and the participant they give to.
+type ExchangePair struct {
+	GiverID    uuid.UUID `json:"giver_id"`
+	ReceiverID uuid.UUID `json:"receiver_id"`
+}
+
+// ExchangeExclusion forbids GiverID from drawing ReceiverID. Mutual
+// exclusions also forbid the reverse, as for couples.
+type ExchangeExclusion struct {
+	ID         uuid.UUID `json:"id"`
+	ExchangeID uuid.UUID `json:"exchange_id"`
+	GiverID    uuid.UUID `json:"giver_id"`
+	ReceiverID uuid.UUID `json:"receiver_id"`
+	Mutual     bool      `json:"mutual"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExchangeAssignment is what one participant may see after the draw.
+type ExchangeAssignment struct {
+	ExchangeID   uuid.UUID `json:"exchange_id"`
+	ExchangeName string    `json:"exchange_name"`
+	ExchangeOn   *Date     `json:"exchange_on"`
+	Budget       *Money    `json:"budget"`
+	Currency     string    `json:"currency"`
+	GiverName    string    `json:"giver_name"`
+	ReceiverName string    `json:"receiver_name"`
+}
+
+// ExchangeVerification reports whether replaying the stored seed gives the
+// stored assignments.
+type ExchangeVerification struct {
+	ExchangeID uuid.UUID  `json:"exchange_id"`
+	DrawnAt    *time.Time `json:"drawn_at"`
+	Matches    bool       `json:"matches"`
+}
+
+// CreateExchangeRequest is the payload for creating an exchange. Currency
+// defaults to the organizer's preferred currency.
+type CreateExchangeRequest struct {
+	Name               string     `json:"name"`
+	Budget             *Money     `json:"budget"`
+	Currency           string     `json:"currency"`
+	ExchangeOn         *Date      `json:"exchange_on"`
+	PreviousExchangeID *uuid.UUID `json:"previous_exchange_id"`
+}
+
+// UpdateExchangeRequest is the payload for editing an exchange before the
+// draw. Set ClearBudget or ClearPrevious to drop those fields.
+type UpdateExchangeRequest struct {
+	Name               *string    `json:"name"`
+	Budget             *Money     `json:"budget"`
+	ClearBudget        bool       `json:"clear_budget"`
+	Currency           *string    `json:"currency"`
+	ExchangeOn         *Date      `json:"exchange_on"`
+	PreviousExchangeID *uuid.UUID `json:"previous_exchange_id"`
+	ClearPrevious      bool       `json:"clear_previous"`
+}
+
+// AddParticipantRequest is the payload for adding someone to an exchange.
+type AddParticipantRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// AddExclusionRequest is the payload for forbidding a pair.
+type AddExclusionRequest struct {
+	GiverID    uuid.UUID `json:"giver_id"`
+	ReceiverID uuid.UUID `json:"receiver_id"`
+	Mutual     bool      `json:"mutual"`
+}
+
+// Limits enforced on exchanges.
+const (
+	MaxExchangeNameLength   = 100
+	MinExchangeParticipants = 3
+	MaxExchangeParticipants = 100
+	// maxDrawSteps bounds the draw's backtracking search, so heavily
+	// constrained exchanges fail fast instead of hanging.
+	maxDrawSteps = 1_000_000
+)
+
+// Normalize trims user input before validation.
+func (e *GiftExchange) Normalize() {
+	e.Name = strings.TrimSpace(e.Name)
+	e.Currency = normalizeCurrency(e.Currency)
+}
+
+// Validate checks the exchange against the field rules.
+func (e *GiftExchange) Validate() error {
+	var v Validator
+
+	v.Check(e.Name != "", "name", CodeRequired, "name is required")
+	v.Check(utf8.RuneCountInString(e.Name) <= MaxExchangeNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxExchangeNameLength)
+	if e.Budget != nil {
+		v.Check(*e.Budget >= 0 && *e.Budget <= MaxRecipientBudget, "budget", CodeOutOfRange,
+			"budget must be between 0 and %s", MaxRecipientBudget)
+	}
+	v.Check(validCurrency(e.Currency), "currency", CodeInvalidChoice,
+		"currency must be one of %s", strings.Join(SupportedCurrencies, ", "))
+
+	return v.Err()
+}
+
+// Participant returns the participant with email, if any.
+func (e *GiftExchange) Participant(email string) *ExchangeParticipant {
+	for i := range e.Participants {
+		if strings.EqualFold(e.Participants[i].Email, email) {
+			return &e.Participants[i]
+		}
+	}
+	return nil
+}
+
+// ParticipantByID returns the participant with id, if any.
+func (e *GiftExchange) ParticipantByID(id uuid.UUID) *ExchangeParticipant {
+	for i := range e.Participants {
+		if e.Participants[i].ID == id {
+			return &e.Participants[i]
+		}
+	}
+	return nil
+}
+
+// Assignment describes whom p gives to. It reports false before the draw.
+func (e *GiftExchange) Assignment(p *ExchangeParticipant) (*ExchangeAssignment, bool) {
+	if p.ReceiverID == nil {
+		return nil, false
+	}
+	receiver := e.ParticipantByID(*p.ReceiverID)
+	if receiver == nil {
+		return nil, false
+	}
+	return &ExchangeAssignment{
+		ExchangeID:   e.ID,
+		ExchangeName: e.Name,
+		ExchangeOn:   e.ExchangeOn,
+		Budget:       e.Budget,
+		Currency:     e.Currency,
+		GiverName:    p.Name,
+		ReceiverName: receiver.Name,
+	}, true
+}
+
+// RepeatedPairs returns the pairs drawn in previous, matched onto this
+// exchange's participants by email. previous may be nil.
+func (e *GiftExchange) RepeatedPairs(previous *GiftExchange) []ExchangePair {
+	pairs := []ExchangePair{}
+	if previous == nil {
+		return pairs
+	}
+	for i := range previous.Participants {
+		last := &previous.Participants[i]
+		if last.ReceiverID == nil {
+			continue
+		}
+		lastReceiver := previous.ParticipantByID(*last.ReceiverID)
+		if lastReceiver == nil {
+			continue
+		}
+		giver, receiver := e.Participant(last.Email), e.Participant(lastReceiver.Email)
+		if giver != nil && receiver != nil {
+			pairs = append(pairs, ExchangePair{GiverID: giver.ID, ReceiverID: receiver.ID})
+		}
+	}
+	return pairs
+}
+
+// Forbidden lists the giver→receiver pairs the draw must avoid: the
+// exclusions, in both directions when mutual, and PreviousPairs.
+func (e *GiftExchange) Forbidden() map[[2]uuid.UUID]bool {
+	forbidden := make(map[[2]uuid.UUID]bool)
+	for _, x := range e.Exclusions {
+		forbidden[[2]uuid.UUID{x.GiverID, x.ReceiverID}] = true
+		if x.Mutual {
+			forbidden[[2]uuid.UUID{x.ReceiverID, x.GiverID}] = true
+		}
+	}
+	for _, p := range e.PreviousPairs {
+		forbidden[[2]uuid.UUID{p.GiverID, p.ReceiverID}] = true
+	}
+	return forbidden
+}
+
+// DrawAssignments assigns every participant a receiver other than
+// themselves, avoiding the forbidden pairs. The same participants,
+// constraints and seed always give the same result, whatever order the
+// participants come in. It reports false when no assignment exists.
+func DrawAssignments(participantIDs []uuid.UUID, forbidden map[[2]uuid.UUID]bool, seed int64) (map[uuid.UUID]uuid.UUID, bool) {
+	ids := slices.Clone(participantIDs)
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	n := len(ids)
+	if n < 2 {
+		return nil, false
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(seed), uint64(seed)^0x9e3779b97f4a7c15))
+	candidates := make([][]int, n)
+	for g := range ids {
+		for r := range ids {
+			if r != g && !forbidden[[2]uuid.UUID{ids[g], ids[r]}] {
+				candidates[g] = append(candidates[g], r)
+			}
+		}
+		rng.Shuffle(len(candidates[g]), func(i, j int) {
+			candidates[g][i], candidates[g][j] = candidates[g][j], candidates[g][i]
+		})
+	}
+
+	// Place the most constrained givers first so dead ends show up early.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(candidates[a]) - len(candidates[b]) })
+
+	receiverOf := make([]int, n)
+	taken := make([]bool, n)
+	steps := 0
+	var place func(k int) bool
+	place = func(k int) bool {
+		if k == n {
+			return true
+		}
+		if steps++; steps > maxDrawSteps {
+			return false
+		}
+		g := order[k]
+		for _, r := range candidates[g] {
+			if taken[r] {
+				continue
+			}
+			taken[r], receiverOf[g] = true, r
+			if place(k + 1) {
+				return true
+			}
+			taken[r] = false
+		}
+		return false
+	}
+	if !place(0) {
+		return nil, false
+	}
+
+	assignments := make(map[uuid.UUID]uuid.UUID, n)
+	for g, r := range receiverOf {
+		assignments[ids[g]] = ids[r]
+	}
+	return assignments, true
+}
+
+// Normalize trims user input before validation. Emails are compared
+// case-insensitively, so they are stored lower-cased.
+func (p *ExchangeParticipant) Normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Email = strings.ToLower(strings.TrimSpace(p.Email))
+}
+
+// Validate checks the participant against the field rules.
+func (p *ExchangeParticipant) Validate() error {
+	var v Validator
+
+	v.Check(p.Name != "", "name", CodeRequired, "name is required")
+	v.Check(utf8.RuneCountInString(p.Name) <= MaxContributorNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxContributorNameLength)
+	v.Check(p.Email != "", "email", CodeRequired, "email is required")
+	if p.Email != "" {
+		v.Check(len(p.Email) <= MaxContributorEmailLength, "email", CodeTooLong,
+			"email must be at most %d characters", MaxContributorEmailLength)
+		v.Check(isEmailAddress(p.Email), "email", CodeInvalidFormat, "email must be a valid email address")
+	}
+
+	return v.Err()
+}
+
+// AssignmentPath returns the participant's secret assignment URL path,
+// relative to the API host.
+func (p *ExchangeParticipant) AssignmentPath() string {
+	return "/api/exchange-assignments/" + p.Token
+}
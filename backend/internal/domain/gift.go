@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// GiftRecord is a gift the user actually gave a recipient.
+type GiftRecord struct {
+	ID          uuid.UUID  `json:"id"`
+	RecipientID uuid.UUID  `json:"recipient_id"`
+	OccasionID  *uuid.UUID `json:"occasion_id"`
+	// CatalogItemID links the gift to the built-in catalog when it came
+	// from a suggestion.
+	CatalogItemID string `json:"catalog_item_id,omitempty"`
+	Item          string `json:"item"`
+	// Category is an interest slug; the suggestion engine learns from it.
+	Category string `json:"category"`
+	Price    Money  `json:"price"`
+	Currency string `json:"currency"`
+	GivenOn  Date   `json:"given_on"`
+	// Rating is the recipient's reaction, from 1 (disliked) to 5 (loved).
+	Rating    *int      `json:"rating"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateGiftRequest is the payload for recording a gift. When
+// CatalogItemID is set, Item, Category and a zero Price default to the
+// catalog entry's, priced in CatalogCurrency. Otherwise Currency defaults
+// to the recipient's. GivenOn defaults to today.
+type CreateGiftRequest struct {
+	OccasionID    *uuid.UUID `json:"occasion_id"`
+	CatalogItemID string     `json:"catalog_item_id"`
+	Item          string     `json:"item"`
+	Category      string     `json:"category"`
+	Price         Money      `json:"price"`
+	Currency      string     `json:"currency"`
+	GivenOn       *Date      `json:"given_on"`
+	Rating        *int       `json:"rating"`
+}
+
+// UpdateGiftRequest is the payload for updating a gift record. Set
+// ClearOccasion or ClearRating to drop those fields.
+type UpdateGiftRequest struct {
+	OccasionID    *uuid.UUID `json:"occasion_id"`
+	ClearOccasion bool       `json:"clear_occasion"`
+	Item          *string    `json:"item"`
+	Category      *string    `json:"category"`
+	Price         *Money     `json:"price"`
+	Currency      *string    `json:"currency"`
+	GivenOn       *Date      `json:"given_on"`
+	Rating        *int       `json:"rating"`
+	ClearRating   bool       `json:"clear_rating"`
+}
+
+// Limits enforced on gift records.
+const (
+	MaxGiftItemLength = 200
+	MinGiftRating     = 1
+	MaxGiftRating     = 5
+)
+
+// Normalize trims user input before validation.
+func (g *GiftRecord) Normalize() {
+	g.Item = strings.TrimSpace(g.Item)
+	g.Category = strings.TrimSpace(g.Category)
+	g.Currency = normalizeCurrency(g.Currency)
+}
+
+// Validate checks the gift record against the field rules. today bounds
+// the date it was given.
+func (g *GiftRecord) Validate(today time.Time) error {
+	var v Validator
+
+	v.Check(g.Item != "", "item", CodeRequired, "item is required")
+	v.Check(utf8.RuneCountInString(g.Item) <= MaxGiftItemLength, "item", CodeTooLong,
+		"item must be at most %d characters", MaxGiftItemLength)
+	v.Check(utf8.RuneCountInString(g.Category) <= MaxKeywordLength, "category", CodeTooLong,
+		"category must be at most %d characters", MaxKeywordLength)
+	v.Check(g.Price >= 0 && g.Price <= MaxRecipientBudget, "price", CodeOutOfRange,
+		"price must be between 0 and %s", MaxRecipientBudget)
+	v.Check(validCurrency(g.Currency), "currency", CodeInvalidChoice,
+		"currency must be one of %s", strings.Join(SupportedCurrencies, ", "))
+	v.Check(!g.GivenOn.IsZero(), "given_on", CodeRequired, "given_on is required")
+	if !g.GivenOn.IsZero() {
+		v.Check(!g.GivenOn.After(NewDate(today).Time), "given_on", CodeOutOfRange,
+			"given_on cannot be in the future")
+		v.Check(g.GivenOn.Year() >= 1900, "given_on", CodeOutOfRange, "given_on must be after 1900")
+	}
+	if g.Rating != nil {
+		v.Check(*g.Rating >= MinGiftRating && *g.Rating <= MaxGiftRating, "rating", CodeOutOfRange,
+			"rating must be between %d and %d", MinGiftRating, MaxGiftRating)
+	}
+
+	return v.Err()
+}
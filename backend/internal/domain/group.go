@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Group is a user-defined collection of recipients, such as "Family" or "Work".
+type Group struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GroupRequest is the payload for creating or renaming a group.
+type GroupRequest struct {
+	Name string `json:"name"`
+}
+
+// GroupMembersRequest is the payload for adding recipients to a group.
+type GroupMembersRequest struct {
+	RecipientIDs []uuid.UUID `json:"recipient_ids"`
+}
+
+// Limits enforced on groups.
+const (
+	MaxGroupNameLength     = 100
+	MaxGroupMembersPerCall = 100
+)
+
+// Normalize trims user input before validation.
+func (g *Group) Normalize() {
+	g.Name = strings.TrimSpace(g.Name)
+}
+
+// Validate checks the group against the field rules.
+func (g *Group) Validate() error {
+	var v Validator
+	v.Check(g.Name != "", "name", CodeRequired, "name is required")
+	v.Check(utf8.RuneCountInString(g.Name) <= MaxGroupNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxGroupNameLength)
+	return v.Err()
+}
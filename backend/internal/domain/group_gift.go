@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// GroupGiftStatus says whether a group gift still takes contributions.
+type GroupGiftStatus string
+
+const (
+	GroupGiftOpen    GroupGiftStatus = "open"
+	GroupGiftSettled GroupGiftStatus = "settled"
+)
+
+// GroupGift is a gift for one of the organizer's recipients that several
+// people pay for together.
+type GroupGift struct {
+	ID          uuid.UUID `json:"id"`
+	OrganizerID uuid.UUID `json:"organizer_id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+	// OccasionID is cleared if the occasion is deleted later.
+	OccasionID   *uuid.UUID             `json:"occasion_id"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	TargetAmount Money                  `json:"target_amount"`
+	Currency     string                 `json:"currency"`
+	Status       GroupGiftStatus        `json:"status"`
+	SettledAt    *time.Time             `json:"settled_at"`
+	Contributors []GroupGiftContributor `json:"contributors"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// GroupGiftContributor is someone invited to chip in, identified by email
+// so they need not have an account. Amounts are in the gift's currency.
+type GroupGiftContributor struct {
+	ID          uuid.UUID `json:"id"`
+	GroupGiftID uuid.UUID `json:"group_gift_id"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	Pledged     Money     `json:"pledged"`
+	Paid        Money     `json:"paid"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateGroupGiftRequest is the payload for starting a group gift for one
+// of a recipient's occasions. Currency defaults to the recipient's.
+type CreateGroupGiftRequest struct {
+	RecipientID  uuid.UUID  `json:"recipient_id"`
+	OccasionID   *uuid.UUID `json:"occasion_id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	TargetAmount Money      `json:"target_amount"`
+	Currency     string     `json:"currency"`
+}
+
+// UpdateGroupGiftRequest is the payload for editing an open group gift.
+type UpdateGroupGiftRequest struct {
+	Title        *string `json:"title"`
+	Description  *string `json:"description"`
+	TargetAmount *Money  `json:"target_amount"`
+}
+
+// InviteContributorRequest is the payload for inviting someone to a group
+// gift, optionally with the amount they already promised.
+type InviteContributorRequest struct {
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+	Pledged Money  `json:"pledged"`
+}
+
+// UpdateContributionRequest is the payload for changing a contributor's
+// pledge or the amount they have paid. Only the organizer records payments.
+type UpdateContributionRequest struct {
+	Pledged *Money `json:"pledged"`
+	Paid    *Money `json:"paid"`
+}
+
+// GroupGiftProgress summarizes how close a group gift is to its target.
+// Percentages are of the target, capped at 100 for progress bars.
+type GroupGiftProgress struct {
+	GroupGiftID      uuid.UUID       `json:"group_gift_id"`
+	Status           GroupGiftStatus `json:"status"`
+	Currency         string          `json:"currency"`
+	TargetAmount     Money           `json:"target_amount"`
+	Pledged          Money           `json:"pledged"`
+	Paid             Money           `json:"paid"`
+	Remaining        Money           `json:"remaining"`
+	PledgedPercent   int             `json:"pledged_percent"`
+	PaidPercent      int             `json:"paid_percent"`
+	Contributors     int             `json:"contributors"`
+	PaidContributors int             `json:"paid_contributors"`
+	Funded           bool            `json:"funded"`
+}
+
+// Limits enforced on group gifts.
+const (
+	MaxGroupGiftDescriptionLength = 1000
+	MaxGroupGiftContributors      = 50
+	MaxContributorEmailLength     = 255
+	MaxContributorNameLength      = 100
+)
+
+// Normalize trims user input before validation.
+func (g *GroupGift) Normalize() {
+	g.Title = strings.TrimSpace(g.Title)
+	g.Description = strings.TrimSpace(g.Description)
+	g.Currency = normalizeCurrency(g.Currency)
+}
+
+// Validate checks the group gift against the field rules.
+func (g *GroupGift) Validate() error {
+	var v Validator
+
+	v.Check(g.Title != "", "title", CodeRequired, "title is required")
+	v.Check(utf8.RuneCountInString(g.Title) <= MaxGiftItemLength, "title", CodeTooLong,
+		"title must be at most %d characters", MaxGiftItemLength)
+	v.Check(utf8.RuneCountInString(g.Description) <= MaxGroupGiftDescriptionLength, "description", CodeTooLong,
+		"description must be at most %d characters", MaxGroupGiftDescriptionLength)
+	v.Check(g.TargetAmount > 0 && g.TargetAmount <= MaxRecipientBudget, "target_amount", CodeOutOfRange,
+		"target_amount must be greater than 0 and at most %s", MaxRecipientBudget)
+	v.Check(validCurrency(g.Currency), "currency", CodeInvalidChoice,
+		"currency must be one of %s", strings.Join(SupportedCurrencies, ", "))
+
+	return v.Err()
+}
+
+// Progress totals the contributions against the target.
+func (g *GroupGift) Progress() GroupGiftProgress {
+	p := GroupGiftProgress{
+		GroupGiftID:  g.ID,
+		Status:       g.Status,
+		Currency:     g.Currency,
+		TargetAmount: g.TargetAmount,
+		Contributors: len(g.Contributors),
+	}
+	for _, c := range g.Contributors {
+		p.Pledged += c.Pledged
+		p.Paid += c.Paid
+		if c.Paid > 0 {
+			p.PaidContributors++
+		}
+	}
+	p.Remaining = max(g.TargetAmount-p.Paid, 0)
+	p.PledgedPercent = percentOf(p.Pledged, g.TargetAmount)
+	p.PaidPercent = percentOf(p.Paid, g.TargetAmount)
+	p.Funded = g.TargetAmount > 0 && p.Paid >= g.TargetAmount
+	return p
+}
+
+// Contributor returns the contributor invited with email, if any.
+func (g *GroupGift) Contributor(email string) *GroupGiftContributor {
+	for i := range g.Contributors {
+		if strings.EqualFold(g.Contributors[i].Email, email) {
+			return &g.Contributors[i]
+		}
+	}
+	return nil
+}
+
+func percentOf(part, whole Money) int {
+	if whole <= 0 {
+		return 0
+	}
+	return int(min(part*100/whole, 100))
+}
+
+// Normalize trims user input before validation. Emails are compared
+// case-insensitively, so they are stored lower-cased.
+func (c *GroupGiftContributor) Normalize() {
+	c.Email = strings.ToLower(strings.TrimSpace(c.Email))
+	c.Name = strings.TrimSpace(c.Name)
+}
+
+// Validate checks the contributor against the field rules.
+func (c *GroupGiftContributor) Validate() error {
+	var v Validator
+
+	v.Check(c.Email != "", "email", CodeRequired, "email is required")
+	if c.Email != "" {
+		v.Check(len(c.Email) <= MaxContributorEmailLength, "email", CodeTooLong,
+			"email must be at most %d characters", MaxContributorEmailLength)
+		v.Check(isEmailAddress(c.Email), "email", CodeInvalidFormat, "email must be a valid email address")
+	}
+	v.Check(utf8.RuneCountInString(c.Name) <= MaxContributorNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxContributorNameLength)
+	v.Check(c.Pledged >= 0 && c.Pledged <= MaxRecipientBudget, "pledged", CodeOutOfRange,
+		"pledged must be between 0 and %s", MaxRecipientBudget)
+	v.Check(c.Paid >= 0 && c.Paid <= MaxRecipientBudget, "paid", CodeOutOfRange,
+		"paid must be between 0 and %s", MaxRecipientBudget)
+
+	return v.Err()
+}
+
+// DisplayName is the contributor's name, or their email when they gave none.
+func (c *GroupGiftContributor) DisplayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Email
+}
+
+// isEmailAddress reports whether s is a bare address such as a@b.com.
+func isEmailAddress(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s && strings.Contains(s[strings.LastIndex(s, "@")+1:], ".")
+}
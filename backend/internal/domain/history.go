@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChangeAction identifies what kind of change produced a recipient version.
+type ChangeAction string
+
+const (
+	ChangeActionCreate  ChangeAction = "create"
+	ChangeActionUpdate  ChangeAction = "update"
+	ChangeActionDelete  ChangeAction = "delete"
+	ChangeActionRestore ChangeAction = "restore"
+	ChangeActionRevert  ChangeAction = "revert"
+)
+
+// FieldChange describes the old and new value of a single recipient field.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// RecipientVersion is an immutable snapshot of a recipient after a change.
+type RecipientVersion struct {
+	ID          uuid.UUID     `json:"id"`
+	RecipientID uuid.UUID     `json:"recipient_id"`
+	Version     int           `json:"version"`
+	Action      ChangeAction  `json:"action"`
+	ChangedBy   uuid.UUID     `json:"changed_by"`
+	Snapshot    Recipient     `json:"snapshot"`
+	Changes     []FieldChange `json:"changes"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// DiffRecipients lists the user-editable fields that differ between two
+// snapshots. A nil before yields every field as newly set.
+func DiffRecipients(before, after *Recipient) []FieldChange {
+	changes := []FieldChange{}
+	if after == nil {
+		return changes
+	}
+	if before == nil {
+		before = &Recipient{}
+	}
+
+	add := func(field string, from, to any) {
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+	add("name", before.Name, after.Name)
+	add("age", before.Age, after.Age)
+	add("gender", before.Gender, after.Gender)
+	add("relationship", before.Relationship, after.Relationship)
+	add("birthdate", before.Birthdate, after.Birthdate)
+	add("min_budget", before.MinBudget, after.MinBudget)
+	add("max_budget", before.MaxBudget, after.MaxBudget)
+	add("currency", before.Currency, after.Currency)
+	add("keywords", normalizeNil(before.Keywords), normalizeNil(after.Keywords))
+	return changes
+}
+
+// ApplySnapshot copies the user-editable fields of a snapshot onto r.
+func (r *Recipient) ApplySnapshot(snapshot Recipient) {
+	r.Name = snapshot.Name
+	r.Age = snapshot.Age
+	r.Gender = snapshot.Gender
+	r.Relationship = snapshot.Relationship
+	r.Birthdate = snapshot.Birthdate
+	r.MinBudget = snapshot.MinBudget
+	r.MaxBudget = snapshot.MaxBudget
+	// Snapshots taken before recipients had a currency keep the current one.
+	if snapshot.Currency != "" {
+		r.Currency = snapshot.Currency
+	}
+	r.Keywords = append([]string{}, snapshot.Keywords...)
+}
+
+func normalizeNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
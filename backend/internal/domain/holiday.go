@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OccasionHoliday marks upcoming feed entries produced by a holiday
+// subscription. It is not a kind occasions can be stored with.
+const OccasionHoliday OccasionKind = "holiday"
+
+type holidayRuleKind int
+
+const (
+	ruleFixedDate holidayRuleKind = iota
+	ruleNthWeekday
+	ruleEasterOffset
+)
+
+// HolidayRule computes the date a holiday falls on in a given year.
+type HolidayRule struct {
+	kind    holidayRuleKind
+	month   time.Month
+	day     int
+	weekday time.Weekday
+	nth     int
+	offset  int
+}
+
+// FixedDate is a holiday on the same month and day every year.
+func FixedDate(month time.Month, day int) HolidayRule {
+	return HolidayRule{kind: ruleFixedDate, month: month, day: day}
+}
+
+// NthWeekday is a holiday on the nth weekday of month, such as the second
+// Sunday of May. A negative n counts from the end of the month, so -1 is the
+// last such weekday.
+func NthWeekday(n int, weekday time.Weekday, month time.Month) HolidayRule {
+	return HolidayRule{kind: ruleNthWeekday, month: month, weekday: weekday, nth: n}
+}
+
+// EasterOffset is a holiday a fixed number of days from Western Easter
+// Sunday. Negative offsets fall before Easter.
+func EasterOffset(days int) HolidayRule {
+	return HolidayRule{kind: ruleEasterOffset, offset: days}
+}
+
+// In returns the date the rule falls on in year.
+func (r HolidayRule) In(year int) Date {
+	switch r.kind {
+	case ruleNthWeekday:
+		if r.nth < 0 {
+			last := time.Date(year, r.month+1, 0, 0, 0, 0, 0, time.UTC)
+			back := (int(last.Weekday()) - int(r.weekday) + 7) % 7
+			return Date{last.AddDate(0, 0, -back+7*(r.nth+1))}
+		}
+		first := time.Date(year, r.month, 1, 0, 0, 0, 0, time.UTC)
+		ahead := (int(r.weekday) - int(first.Weekday()) + 7) % 7
+		return Date{first.AddDate(0, 0, ahead+7*(r.nth-1))}
+	case ruleEasterOffset:
+		return Date{easterSunday(year).AddDate(0, 0, r.offset)}
+	default:
+		return Date{time.Date(year, r.month, r.day, 0, 0, 0, 0, time.UTC)}
+	}
+}
+
+// RRule returns the iCalendar recurrence rule for the holiday, or false if
+// RFC 5545 cannot express it, as with dates relative to Easter.
+func (r HolidayRule) RRule() (string, bool) {
+	switch r.kind {
+	case ruleFixedDate:
+		return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYMONTHDAY=%d", r.month, r.day), true
+	case ruleNthWeekday:
+		day := strings.ToUpper(r.weekday.String()[:2])
+		return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", r.month, r.nth, day), true
+	default:
+		return "", false
+	}
+}
+
+var ordinals = map[int]string{1: "first", 2: "second", 3: "third", 4: "fourth", 5: "fifth", -1: "last"}
+
+// String describes the rule in English, e.g. "second Sunday of May".
+func (r HolidayRule) String() string {
+	switch r.kind {
+	case ruleNthWeekday:
+		nth, ok := ordinals[r.nth]
+		if !ok {
+			nth = fmt.Sprintf("#%d", r.nth)
+		}
+		return fmt.Sprintf("%s %s of %s", nth, r.weekday, r.month)
+	case ruleEasterOffset:
+		switch {
+		case r.offset == 0:
+			return "Easter Sunday"
+		case r.offset < 0:
+			return fmt.Sprintf("%d days before Easter", -r.offset)
+		default:
+			return fmt.Sprintf("%d days after Easter", r.offset)
+		}
+	default:
+		return fmt.Sprintf("%s %d", r.month, r.day)
+	}
+}
+
+// easterSunday computes Western Easter with the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Holiday is a gifting date observed in one country.
+type Holiday struct {
+	Slug    string
+	Country string
+	Labels  map[string]string
+	Rule    HolidayRule
+}
+
+// Label returns the holiday's name in locale, falling back to DefaultLocale
+// and finally to the slug.
+func (h Holiday) Label(locale string) string {
+	if l, ok := h.Labels[locale]; ok {
+		return l
+	}
+	if l, ok := h.Labels[DefaultLocale]; ok {
+		return l
+	}
+	return h.Slug
+}
+
+// NextOccurrence returns the first date on or after today the holiday falls on.
+func (h Holiday) NextOccurrence(today time.Time) Date {
+	today = NewDate(today).Time
+	next := h.Rule.In(today.Year())
+	if next.Before(today) {
+		next = h.Rule.In(today.Year() + 1)
+	}
+	return next
+}
+
+// HolidayDate is a holiday resolved to a concrete date for display.
+type HolidayDate struct {
+	Slug    string `json:"slug"`
+	Country string `json:"country"`
+	Name    string `json:"name"`
+	Date    Date   `json:"date"`
+	Rule    string `json:"rule"`
+}
+
+// HolidaysFor returns the built-in holidays of country in calendar order.
+func HolidaysFor(country string) []Holiday {
+	return holidaysByCountry[strings.ToUpper(country)]
+}
+
+// LookupHoliday finds a built-in holiday by country and slug.
+func LookupHoliday(country, slug string) (Holiday, bool) {
+	for _, h := range HolidaysFor(country) {
+		if h.Slug == slug {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// CountryForLocale picks the holiday calendar matching a locale's region,
+// falling back to DefaultCountry.
+func CountryForLocale(locale string) string {
+	if _, region, ok := strings.Cut(locale, "-"); ok {
+		region = strings.ToUpper(region)
+		if slices.Contains(HolidayCountries, region) {
+			return region
+		}
+	}
+	return DefaultCountry
+}
+
+// HolidaySubscription puts a holiday on a recipient's calendar so it shows
+// up in the upcoming feed and reminders.
+type HolidaySubscription struct {
+	ID          uuid.UUID `json:"id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+	Country     string    `json:"country"`
+	Holiday     string    `json:"holiday"`
+	Budget      *Money    `json:"budget"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// HolidaySubscriptionRequest is the payload for subscribing a recipient to a
+// holiday. Country defaults to the one matching the user's locale.
+type HolidaySubscriptionRequest struct {
+	Country string `json:"country"`
+	Holiday string `json:"holiday"`
+	Budget  *Money `json:"budget"`
+}
+
+// Normalize canonicalizes the country code and holiday slug.
+func (s *HolidaySubscription) Normalize() {
+	s.Country = strings.ToUpper(strings.TrimSpace(s.Country))
+	s.Holiday = strings.ToLower(strings.TrimSpace(s.Holiday))
+}
+
+// Validate checks the subscription refers to a built-in holiday.
+func (s *HolidaySubscription) Validate() error {
+	var v Validator
+
+	countryOK := slices.Contains(HolidayCountries, s.Country)
+	v.Check(countryOK, "country", CodeInvalidChoice,
+		"country must be one of %s", strings.Join(HolidayCountries, ", "))
+	if countryOK {
+		_, ok := LookupHoliday(s.Country, s.Holiday)
+		v.Check(ok, "holiday", CodeInvalidChoice, "holiday is not observed in %s", s.Country)
+	}
+	if s.Budget != nil {
+		v.Check(*s.Budget >= 0 && *s.Budget <= MaxRecipientBudget, "budget", CodeOutOfRange,
+			"budget must be between 0 and %s", MaxRecipientBudget)
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// DefaultCountry is the holiday calendar used when none can be inferred.
+const DefaultCountry = "US"
+
+// HolidayCountries lists the ISO 3166-1 alpha-2 codes with a built-in
+// holiday calendar.
+var HolidayCountries = []string{"BR", "GB", "US"}
+
+// holidaysByCountry is the offline gifting holiday dataset. Each country's
+// list is kept in calendar order.
+var holidaysByCountry = map[string][]Holiday{
+	"BR": {
+		{Slug: "easter", Country: "BR", Rule: EasterOffset(0),
+			Labels: map[string]string{"en-US": "Easter", "pt-BR": "Páscoa"}},
+		{Slug: "mothers-day", Country: "BR", Rule: NthWeekday(2, time.Sunday, time.May),
+			Labels: map[string]string{"en-US": "Mother's Day", "pt-BR": "Dia das Mães"}},
+		{Slug: "valentines-day", Country: "BR", Rule: FixedDate(time.June, 12),
+			Labels: map[string]string{"en-US": "Valentine's Day (Dia dos Namorados)", "pt-BR": "Dia dos Namorados"}},
+		{Slug: "fathers-day", Country: "BR", Rule: NthWeekday(2, time.Sunday, time.August),
+			Labels: map[string]string{"en-US": "Father's Day", "pt-BR": "Dia dos Pais"}},
+		{Slug: "childrens-day", Country: "BR", Rule: FixedDate(time.October, 12),
+			Labels: map[string]string{"en-US": "Children's Day", "pt-BR": "Dia das Crianças"}},
+		{Slug: "christmas", Country: "BR", Rule: FixedDate(time.December, 25),
+			Labels: map[string]string{"en-US": "Christmas", "pt-BR": "Natal"}},
+	},
+	"GB": {
+		{Slug: "valentines-day", Country: "GB", Rule: FixedDate(time.February, 14),
+			Labels: map[string]string{"en-US": "Valentine's Day", "pt-BR": "Dia de São Valentim"}},
+		{Slug: "mothers-day", Country: "GB", Rule: EasterOffset(-21),
+			Labels: map[string]string{"en-US": "Mothering Sunday", "pt-BR": "Dia das Mães"}},
+		{Slug: "easter", Country: "GB", Rule: EasterOffset(0),
+			Labels: map[string]string{"en-US": "Easter", "pt-BR": "Páscoa"}},
+		{Slug: "fathers-day", Country: "GB", Rule: NthWeekday(3, time.Sunday, time.June),
+			Labels: map[string]string{"en-US": "Father's Day", "pt-BR": "Dia dos Pais"}},
+		{Slug: "christmas", Country: "GB", Rule: FixedDate(time.December, 25),
+			Labels: map[string]string{"en-US": "Christmas", "pt-BR": "Natal"}},
+	},
+	"US": {
+		{Slug: "valentines-day", Country: "US", Rule: FixedDate(time.February, 14),
+			Labels: map[string]string{"en-US": "Valentine's Day", "pt-BR": "Dia de São Valentim"}},
+		{Slug: "easter", Country: "US", Rule: EasterOffset(0),
+			Labels: map[string]string{"en-US": "Easter", "pt-BR": "Páscoa"}},
+		{Slug: "mothers-day", Country: "US", Rule: NthWeekday(2, time.Sunday, time.May),
+			Labels: map[string]string{"en-US": "Mother's Day", "pt-BR": "Dia das Mães"}},
+		{Slug: "fathers-day", Country: "US", Rule: NthWeekday(3, time.Sunday, time.June),
+			Labels: map[string]string{"en-US": "Father's Day", "pt-BR": "Dia dos Pais"}},
+		{Slug: "halloween", Country: "US", Rule: FixedDate(time.October, 31),
+			Labels: map[string]string{"en-US": "Halloween", "pt-BR": "Halloween"}},
+		{Slug: "thanksgiving", Country: "US", Rule: NthWeekday(4, time.Thursday, time.November),
+			Labels: map[string]string{"en-US": "Thanksgiving", "pt-BR": "Dia de Ação de Graças"}},
+		{Slug: "christmas", Country: "US", Rule: FixedDate(time.December, 25),
+			Labels: map[string]string{"en-US": "Christmas", "pt-BR": "Natal"}},
+	},
+}
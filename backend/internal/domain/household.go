@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// HouseholdRole says what a member may do with the recipients of the other
+// members of their household. Everyone keeps full rights over their own.
+type HouseholdRole string
+
+const (
+	HouseholdOwner  HouseholdRole = "owner"
+	HouseholdEditor HouseholdRole = "editor"
+	HouseholdViewer HouseholdRole = "viewer"
+)
+
+// Valid reports whether r is a known role.
+func (r HouseholdRole) Valid() bool {
+	switch r {
+	case HouseholdOwner, HouseholdEditor, HouseholdViewer:
+		return true
+	}
+	return false
+}
+
+// Grants reports whether the role allows p on another member's recipients.
+func (r HouseholdRole) Grants(p Permission) bool {
+	switch p {
+	case PermissionView:
+		return r.Valid()
+	case PermissionEdit:
+		return r == HouseholdOwner || r == HouseholdEditor
+	}
+	return false
+}
+
+// Permission is a level of access to a recipient and everything hanging off
+// it (occasions, gifts, ideas, holiday subscriptions).
+type Permission string
+
+const (
+	PermissionView Permission = "view"
+	PermissionEdit Permission = "edit"
+)
+
+// Household is a set of users who share their recipients, such as spouses
+// looking after the same kids and parents. A user belongs to at most one.
+type Household struct {
+	ID      uuid.UUID         `json:"id"`
+	Name    string            `json:"name"`
+	Members []HouseholdMember `json:"members"`
+	// Invitations lists pending invitations and is only filled in for the owner.
+	Invitations []HouseholdInvitation `json:"invitations,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// HouseholdMember is a user's membership of a household. Email and Name are
+// read from the user's account.
+type HouseholdMember struct {
+	HouseholdID uuid.UUID     `json:"household_id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	Email       string        `json:"email"`
+	Name        string        `json:"name"`
+	Role        HouseholdRole `json:"role"`
+	JoinedAt    time.Time     `json:"joined_at"`
+}
+
+// HouseholdInvitation asks someone, by email, to join a household with the
+// given role. The token is only ever sent to the invitee.
+type HouseholdInvitation struct {
+	ID          uuid.UUID     `json:"id"`
+	HouseholdID uuid.UUID     `json:"household_id"`
+	Email       string        `json:"email"`
+	Role        HouseholdRole `json:"role"`
+	Token       string        `json:"-"`
+	InvitedBy   uuid.UUID     `json:"invited_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// CreateHouseholdRequest is the payload for starting a household. The
+// creator becomes its owner.
+type CreateHouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateHouseholdRequest is the payload for renaming a household.
+type UpdateHouseholdRequest struct {
+	Name *string `json:"name"`
+}
+
+// InviteHouseholdMemberRequest is the payload for inviting someone to a
+// household. Role defaults to viewer.
+type InviteHouseholdMemberRequest struct {
+	Email string        `json:"email"`
+	Role  HouseholdRole `json:"role"`
+}
+
+// UpdateHouseholdMemberRequest is the payload for changing a member's role.
+// Making someone the owner hands the household over and leaves the previous
+// owner an editor.
+type UpdateHouseholdMemberRequest struct {
+	Role HouseholdRole `json:"role"`
+}
+
+// Limits enforced on households.
+const (
+	MaxHouseholdNameLength  = 100
+	MaxHouseholdMembers     = 10
+	MaxHouseholdInvitations = 20
+)
+
+// Normalize trims user input before validation.
+func (h *Household) Normalize() {
+	h.Name = strings.TrimSpace(h.Name)
+}
+
+// Validate checks the household against the field rules.
+func (h *Household) Validate() error {
+	var v Validator
+
+	v.Check(h.Name != "", "name", CodeRequired, "name is required")
+	v.Check(utf8.RuneCountInString(h.Name) <= MaxHouseholdNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxHouseholdNameLength)
+
+	return v.Err()
+}
+
+// Member returns the membership of userID, or nil.
+func (h *Household) Member(userID uuid.UUID) *HouseholdMember {
+	for i := range h.Members {
+		if h.Members[i].UserID == userID {
+			return &h.Members[i]
+		}
+	}
+	return nil
+}
+
+// Owner returns the owning member, or nil if the household has none yet.
+func (h *Household) Owner() *HouseholdMember {
+	for i := range h.Members {
+		if h.Members[i].Role == HouseholdOwner {
+			return &h.Members[i]
+		}
+	}
+	return nil
+}
+
+// MemberIDs returns the user IDs of every member.
+func (h *Household) MemberIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(h.Members))
+	for i, m := range h.Members {
+		ids[i] = m.UserID
+	}
+	return ids
+}
+
+// Normalize trims user input before validation. Emails are compared
+// case-insensitively, so they are stored lower-cased.
+func (i *HouseholdInvitation) Normalize() {
+	i.Email = strings.ToLower(strings.TrimSpace(i.Email))
+	i.Role = HouseholdRole(strings.ToLower(strings.TrimSpace(string(i.Role))))
+	if i.Role == "" {
+		i.Role = HouseholdViewer
+	}
+}
+
+// AcceptPath returns the URL path the invitee posts to, signed in, to join
+// the household. It is relative to the API host.
+func (i *HouseholdInvitation) AcceptPath() string {
+	return "/api/household-invitations/" + i.Token + "/accept"
+}
+
+// Validate checks the invitation against the field rules. Invitations can
+// only grant editor or viewer; ownership is handed over explicitly.
+func (i *HouseholdInvitation) Validate() error {
+	var v Validator
+
+	v.Check(i.Email != "", "email", CodeRequired, "email is required")
+	if i.Email != "" {
+		v.Check(len(i.Email) <= MaxContributorEmailLength, "email", CodeTooLong,
+			"email must be at most %d characters", MaxContributorEmailLength)
+		v.Check(isEmailAddress(i.Email), "email", CodeInvalidFormat, "email must be a valid email address")
+	}
+	v.Check(i.Role == HouseholdEditor || i.Role == HouseholdViewer, "role", CodeInvalidChoice,
+		"role must be one of editor, viewer")
+
+	return v.Err()
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// IdeaStatus is where a gift idea stands on its way to being given.
+type IdeaStatus string
+
+const (
+	IdeaStatusIdea      IdeaStatus = "idea"
+	IdeaStatusPlanned   IdeaStatus = "planned"
+	IdeaStatusPurchased IdeaStatus = "purchased"
+	IdeaStatusWrapped   IdeaStatus = "wrapped"
+	IdeaStatusGiven     IdeaStatus = "given"
+)
+
+// IdeaStatuses lists the statuses in workflow order.
+var IdeaStatuses = []IdeaStatus{
+	IdeaStatusIdea, IdeaStatusPlanned, IdeaStatusPurchased, IdeaStatusWrapped, IdeaStatusGiven,
+}
+
+// CanTransition reports whether an idea may move from s to next. Ideas move
+// forward any number of steps, or back a single step to undo a change;
+// given is final.
+func (s IdeaStatus) CanTransition(next IdeaStatus) bool {
+	from, to := slices.Index(IdeaStatuses, s), slices.Index(IdeaStatuses, next)
+	if from < 0 || to < 0 || s == IdeaStatusGiven {
+		return false
+	}
+	return to > from || to == from-1
+}
+
+// IdeaPriority ranks ideas on a recipient's board.
+type IdeaPriority string
+
+const (
+	IdeaPriorityLow    IdeaPriority = "low"
+	IdeaPriorityMedium IdeaPriority = "medium"
+	IdeaPriorityHigh   IdeaPriority = "high"
+)
+
+// IdeaPriorities lists every accepted priority.
+var IdeaPriorities = []IdeaPriority{IdeaPriorityLow, IdeaPriorityMedium, IdeaPriorityHigh}
+
+// GiftIdea is a gift the user is considering for a recipient.
+type GiftIdea struct {
+	ID          uuid.UUID `json:"id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+	// CatalogItemID is set when the idea was promoted from a suggestion.
+	CatalogItemID string       `json:"catalog_item_id,omitempty"`
+	Title         string       `json:"title"`
+	URL           string       `json:"url"`
+	Price         *Money       `json:"price"`
+	Currency      string       `json:"currency"`
+	Notes         string       `json:"notes"`
+	Category      string       `json:"category"`
+	Priority      IdeaPriority `json:"priority"`
+	Status        IdeaStatus   `json:"status"`
+	// GiftID points at the gift record created when the idea was given.
+	GiftID    *uuid.UUID `json:"gift_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CreateIdeaRequest is the payload for saving a gift idea. Priority
+// defaults to medium and Currency to the recipient's.
+type CreateIdeaRequest struct {
+	Title    string       `json:"title"`
+	URL      string       `json:"url"`
+	Price    *Money       `json:"price"`
+	Currency string       `json:"currency"`
+	Notes    string       `json:"notes"`
+	Category string       `json:"category"`
+	Priority IdeaPriority `json:"priority"`
+}
+
+// UpdateIdeaRequest is the payload for editing a gift idea. Status changes
+// go through TransitionIdeaRequest instead. Set ClearPrice to drop the
+// price.
+type UpdateIdeaRequest struct {
+	Title      *string       `json:"title"`
+	URL        *string       `json:"url"`
+	Price      *Money        `json:"price"`
+	Currency   *string       `json:"currency"`
+	ClearPrice bool          `json:"clear_price"`
+	Notes      *string       `json:"notes"`
+	Category   *string       `json:"category"`
+	Priority   *IdeaPriority `json:"priority"`
+}
+
+// TransitionIdeaRequest moves an idea to another status. OccasionID,
+// GivenOn and Rating only apply when moving to given, and are copied onto
+// the gift record that is created.
+type TransitionIdeaRequest struct {
+	Status     IdeaStatus `json:"status"`
+	OccasionID *uuid.UUID `json:"occasion_id"`
+	GivenOn    *Date      `json:"given_on"`
+	Rating     *int       `json:"rating"`
+}
+
+// PromoteSuggestionRequest is the payload for saving a catalog suggestion
+// as an idea. Priority defaults to medium.
+type PromoteSuggestionRequest struct {
+	ItemID   string       `json:"item_id"`
+	Notes    string       `json:"notes"`
+	Priority IdeaPriority `json:"priority"`
+}
+
+// Limits enforced on gift ideas.
+const (
+	MaxIdeaURLLength   = 2048
+	MaxIdeaNotesLength = 1000
+)
+
+// Normalize trims user input before validation.
+func (i *GiftIdea) Normalize() {
+	i.Title = strings.TrimSpace(i.Title)
+	i.URL = strings.TrimSpace(i.URL)
+	i.Notes = strings.TrimSpace(i.Notes)
+	i.Category = strings.TrimSpace(i.Category)
+	i.Currency = normalizeCurrency(i.Currency)
+	i.Priority = IdeaPriority(strings.ToLower(strings.TrimSpace(string(i.Priority))))
+	if i.Priority == "" {
+		i.Priority = IdeaPriorityMedium
+	}
+}
+
+// Validate checks the idea against the field rules.
+func (i *GiftIdea) Validate() error {
+	var v Validator
+
+	v.Check(i.Title != "", "title", CodeRequired, "title is required")
+	v.Check(utf8.RuneCountInString(i.Title) <= MaxGiftItemLength, "title", CodeTooLong,
+		"title must be at most %d characters", MaxGiftItemLength)
+	if i.URL != "" {
+		v.Check(len(i.URL) <= MaxIdeaURLLength, "url", CodeTooLong,
+			"url must be at most %d characters", MaxIdeaURLLength)
+		v.Check(isWebURL(i.URL), "url", CodeInvalidFormat, "url must be an http or https address")
+	}
+	if i.Price != nil {
+		v.Check(*i.Price >= 0 && *i.Price <= MaxRecipientBudget, "price", CodeOutOfRange,
+			"price must be between 0 and %s", MaxRecipientBudget)
+	}
+	v.Check(validCurrency(i.Currency), "currency", CodeInvalidChoice,
+		"currency must be one of %s", strings.Join(SupportedCurrencies, ", "))
+	v.Check(utf8.RuneCountInString(i.Notes) <= MaxIdeaNotesLength, "notes", CodeTooLong,
+		"notes must be at most %d characters", MaxIdeaNotesLength)
+	v.Check(utf8.RuneCountInString(i.Category) <= MaxKeywordLength, "category", CodeTooLong,
+		"category must be at most %d characters", MaxKeywordLength)
+	v.Check(slices.Contains(IdeaPriorities, i.Priority), "priority", CodeInvalidChoice,
+		"priority must be one of low, medium, high")
+
+	return v.Err()
+}
+
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// ImportSource identifies the file format an import was read from.
+type ImportSource string
+
+const (
+	ImportSourceICS   ImportSource = "ics"
+	ImportSourceVCard ImportSource = "vcard"
+	ImportSourceCSV   ImportSource = "csv"
+	ImportSourceXLSX  ImportSource = "xlsx"
+)
+
+// Limits for bulk imports.
+const (
+	MaxImportItems     = 1000
+	MaxImportFileBytes = 5 << 20
+	ImportSessionTTL   = 24 * time.Hour
+)
+
+// Import item warnings.
+const (
+	WarningBirthYearUnknown       = "birth_year_unknown"
+	WarningAnniversaryYearUnknown = "anniversary_year_unknown"
+	WarningDuplicate              = "duplicate"
+)
+
+// ImportItem is one recipient an import would create.
+type ImportItem struct {
+	Index int `json:"index"`
+	// Row is the spreadsheet row the item was read from, counting the
+	// header as row 1.
+	Row       int                    `json:"row,omitempty"`
+	Recipient CreateRecipientRequest `json:"recipient"`
+	// Occasions are created for the recipient along with it.
+	Occasions []CreateOccasionRequest `json:"occasions,omitempty"`
+	// DuplicateOf points at an existing recipient that looks like the same
+	// person. Duplicates of earlier items in the same file point at nothing
+	// but still carry the duplicate warning.
+	DuplicateOf *uuid.UUID   `json:"duplicate_of,omitempty"`
+	Warnings    []string     `json:"warnings,omitempty"`
+	Errors      []FieldError `json:"errors,omitempty"`
+}
+
+// Valid reports whether the item passed validation.
+func (i *ImportItem) Valid() bool {
+	return len(i.Errors) == 0
+}
+
+// Duplicate reports whether the item looks like a person already known.
+func (i *ImportItem) Duplicate() bool {
+	return slices.Contains(i.Warnings, WarningDuplicate)
+}
+
+// ImportSummary counts the items of an import by outcome.
+type ImportSummary struct {
+	Total      int `json:"total"`
+	New        int `json:"new"`
+	Duplicates int `json:"duplicates"`
+	Invalid    int `json:"invalid"`
+}
+
+// ImportSession is a parsed file awaiting confirmation. The preview is kept
+// so the confirmed import creates exactly what the user reviewed.
+// Spreadsheet imports also keep their header and rows so the items can be
+// rebuilt when the column mapping changes.
+type ImportSession struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"-"`
+	Source     ImportSource  `json:"source"`
+	Columns    []string      `json:"columns,omitempty"`
+	Mapping    ColumnMapping `json:"mapping,omitempty"`
+	DateFormat string        `json:"date_format,omitempty"`
+	Rows       [][]string    `json:"-"`
+	Items      []ImportItem  `json:"items"`
+	Summary    ImportSummary `json:"summary"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	AppliedAt  *time.Time    `json:"applied_at,omitempty"`
+}
+
+// Spreadsheet reports whether the session was read from a CSV or XLSX file.
+func (s *ImportSession) Spreadsheet() bool {
+	return s.Source == ImportSourceCSV || s.Source == ImportSourceXLSX
+}
+
+// Summarize recomputes the session summary from its items.
+func (s *ImportSession) Summarize() {
+	summary := ImportSummary{Total: len(s.Items)}
+	for i := range s.Items {
+		switch item := &s.Items[i]; {
+		case !item.Valid():
+			summary.Invalid++
+		case item.Duplicate():
+			summary.Duplicates++
+		default:
+			summary.New++
+		}
+	}
+	s.Summary = summary
+}
+
+// ConfirmImportRequest selects which previewed items to create. By default
+// every valid item that is not a duplicate is imported.
+type ConfirmImportRequest struct {
+	Indexes           []int `json:"indexes"`
+	IncludeDuplicates bool  `json:"include_duplicates"`
+}
+
+// ImportResult reports what a confirmed import created.
+type ImportResult struct {
+	Created      int         `json:"created"`
+	Skipped      int         `json:"skipped"`
+	RecipientIDs []uuid.UUID `json:"recipient_ids"`
+}
+
+// RecipientNameKey folds a name for duplicate matching: case, accents and
+// spacing are ignored.
+func RecipientNameKey(name string) string {
+	return KeywordKey(name)
+}
+
+// SamePerson reports whether two name and birthdate pairs likely describe
+// the same person. Names must match after folding; birthdates only have to
+// agree when both are known.
+func SamePerson(nameA string, birthA *Date, nameB string, birthB *Date) bool {
+	if RecipientNameKey(nameA) != RecipientNameKey(nameB) {
+		return false
+	}
+	return birthA == nil || birthB == nil || birthA.Equal(birthB.Time)
+}
+
+var birthdaySummaryPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(.+?)['’]s?\s+(?:birthday|bday|b-day)$`),
+	regexp.MustCompile(`(?i)^(?:happy\s+)?(?:birthday|bday|b-day)\s*(?:of|:|-|–)?\s+(.+)$`),
+	regexp.MustCompile(`(?i)^(?:anivers[aá]rio|nascimento)\s*(?:de|do|da|:|-|–)?\s+(.+)$`),
+	regexp.MustCompile(`(?i)^(.+?)\s*(?:-|–|:)?\s*(?:birthday|anivers[aá]rio)$`),
+}
+
+// BirthdayName extracts the person's name from a calendar event title such
+// as "Ana's birthday" or "Aniversário de Ana". Emoji and surrounding
+// punctuation are ignored.
+func BirthdayName(summary string) (string, bool) {
+	s := strings.TrimFunc(summary, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsSymbol(r) || unicode.Is(unicode.Mn, r)
+	})
+	for _, re := range birthdaySummaryPatterns {
+		if m := re.FindStringSubmatch(s); m != nil {
+			name := strings.TrimSpace(m[1])
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Interest is one entry of the managed interest taxonomy. Slug is the
+// canonical keyword stored on recipients.
+type Interest struct {
+	Slug       string            `json:"slug"`
+	ParentSlug *string           `json:"parent,omitempty"`
+	Labels     map[string]string `json:"labels"`
+	Synonyms   []string          `json:"synonyms,omitempty"`
+}
+
+// Label returns the interest's label in locale, falling back to
+// DefaultLocale and finally to the slug.
+func (i Interest) Label(locale string) string {
+	if l, ok := i.Labels[locale]; ok {
+		return l
+	}
+	if l, ok := i.Labels[DefaultLocale]; ok {
+		return l
+	}
+	return i.Slug
+}
+
+// KeywordSuggestion is an autocomplete match for a keyword prefix.
+type KeywordSuggestion struct {
+	Slug          string  `json:"slug"`
+	Label         string  `json:"label"`
+	Category      *string `json:"category,omitempty"`
+	CategoryLabel *string `json:"category_label,omitempty"`
+}
+
+// Taxonomy resolves free-text keywords to canonical interest slugs.
+type Taxonomy struct {
+	interests []Interest
+	bySlug    map[string]Interest
+	terms     map[string]string // keyword key -> slug
+}
+
+// NewTaxonomy indexes interests by slug, synonyms and every label.
+func NewTaxonomy(interests []Interest) *Taxonomy {
+	t := &Taxonomy{
+		interests: interests,
+		bySlug:    make(map[string]Interest, len(interests)),
+		terms:     make(map[string]string),
+	}
+	for _, in := range interests {
+		t.bySlug[in.Slug] = in
+	}
+	// Slugs win over synonyms and labels so an explicit slug is never remapped.
+	for _, in := range interests {
+		for _, label := range in.Labels {
+			t.addTerm(label, in.Slug)
+		}
+		for _, syn := range in.Synonyms {
+			t.addTerm(syn, in.Slug)
+		}
+	}
+	for _, in := range interests {
+		t.terms[KeywordKey(in.Slug)] = in.Slug
+	}
+	return t
+}
+
+func (t *Taxonomy) addTerm(term, slug string) {
+	key := KeywordKey(term)
+	if _, taken := t.terms[key]; !taken && key != "" {
+		t.terms[key] = slug
+	}
+}
+
+// Canonical returns the interest slug keyword refers to. Unknown keywords are
+// kept as free text, trimmed and lowercased.
+func (t *Taxonomy) Canonical(keyword string) string {
+	key := KeywordKey(keyword)
+	if slug, ok := t.terms[key]; ok {
+		return slug
+	}
+	// Accept simple plurals ("jogos", "puzzles") of known terms.
+	if trimmed, ok := strings.CutSuffix(key, "s"); ok {
+		if slug, ok := t.terms[trimmed]; ok {
+			return slug
+		}
+	}
+	return strings.Join(strings.Fields(strings.ToLower(keyword)), " ")
+}
+
+// CanonicalizeAll maps every keyword to its canonical form, dropping
+// repeats while preserving order. Empty keywords are kept so validation can
+// report them.
+func (t *Taxonomy) CanonicalizeAll(keywords []string) []string {
+	out := make([]string, 0, len(keywords))
+	seen := make(map[string]bool, len(keywords))
+	for _, kw := range keywords {
+		c := t.Canonical(kw)
+		if c != "" && seen[c] {
+			continue
+		}
+		seen[c] = true
+		out = append(out, c)
+	}
+	return out
+}
+
+// Suggest returns up to limit interests whose slug, label or synonyms start
+// with prefix, labelled in locale. Label matches rank ahead of synonym matches.
+func (t *Taxonomy) Suggest(prefix, locale string, limit int) []KeywordSuggestion {
+	p := KeywordKey(prefix)
+	if p == "" {
+		return []KeywordSuggestion{}
+	}
+
+	type match struct {
+		interest Interest
+		rank     int
+	}
+	var matches []match
+	for _, in := range t.interests {
+		rank := -1
+		switch {
+		case wordPrefix(KeywordKey(in.Label(locale)), p):
+			rank = 0
+		case strings.HasPrefix(KeywordKey(in.Slug), p):
+			rank = 1
+		default:
+			for _, syn := range in.Synonyms {
+				if wordPrefix(KeywordKey(syn), p) {
+					rank = 2
+					break
+				}
+			}
+		}
+		if rank >= 0 {
+			matches = append(matches, match{in, rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].interest.Label(locale) < matches[j].interest.Label(locale)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	out := make([]KeywordSuggestion, 0, len(matches))
+	for _, m := range matches {
+		s := KeywordSuggestion{Slug: m.interest.Slug, Label: m.interest.Label(locale)}
+		if m.interest.ParentSlug != nil {
+			s.Category = m.interest.ParentSlug
+			if parent, ok := t.bySlug[*m.interest.ParentSlug]; ok {
+				label := parent.Label(locale)
+				s.CategoryLabel = &label
+			}
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// wordPrefix reports whether any word of key starts with prefix, or the whole
+// key does (so multi-word prefixes still match).
+func wordPrefix(key, prefix string) bool {
+	if strings.HasPrefix(key, prefix) {
+		return true
+	}
+	for _, w := range strings.Fields(key) {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeywordKey folds a keyword for matching: lowercase, accents removed, and
+// hyphens, underscores and runs of whitespace collapsed to single spaces.
+func KeywordKey(s string) string {
+	// transform.Chain keeps internal state, so build one per call.
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(stripAccents, strings.ToLower(s))
+	if err != nil {
+		folded = strings.ToLower(s)
+	}
+	folded = strings.NewReplacer("-", " ", "_", " ").Replace(folded)
+	return strings.Join(strings.Fields(folded), " ")
+}
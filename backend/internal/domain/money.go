@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Money is an amount in minor units (cents) of a currency. Every supported
+// currency has two decimal places. On the wire it is a plain decimal number,
+// so 12.5 in JSON is Money(1250).
+type Money int64
+
+// MoneyFromUnits returns n whole units of a currency.
+func MoneyFromUnits(n int64) Money {
+	return Money(n * 100)
+}
+
+var errInvalidMoney = errors.New("invalid money amount")
+
+// ParseMoney parses a decimal amount such as "12", "12.5" or "-0.99".
+// Digits past the cents are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, "/") {
+		return 0, errInvalidMoney
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, errInvalidMoney
+	}
+	r.Mul(r, big.NewRat(100, 1))
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	// Round half away from zero: compare twice the remainder with the denominator.
+	if rem.Sign() != 0 && new(big.Int).Abs(rem.Lsh(rem, 1)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(rem.Sign())))
+	}
+	if !q.IsInt64() {
+		return 0, errInvalidMoney
+	}
+	return Money(q.Int64()), nil
+}
+
+// String formats the amount with two decimals, e.g. "12.50".
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Float64 returns the amount in whole units, for spreadsheets and display.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Amounts may also be sent as
+// decimal strings.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, data)
+	}
+	*m = parsed
+	return nil
+}
+
+// ExchangeRate says one unit of Base is worth Rate units of Quote.
+type ExchangeRate struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      float64   `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks the rate against the field rules.
+func (r *ExchangeRate) Validate() error {
+	var v Validator
+	v.Check(slices.Contains(SupportedCurrencies, r.Base), "base", CodeInvalidChoice,
+		"base must be one of %s", strings.Join(SupportedCurrencies, ", "))
+	v.Check(slices.Contains(SupportedCurrencies, r.Quote), "quote", CodeInvalidChoice,
+		"quote must be one of %s", strings.Join(SupportedCurrencies, ", "))
+	v.Check(r.Base != r.Quote, "quote", CodeDuplicate, "quote must differ from base")
+	v.Check(r.Rate > 0 && !math.IsInf(r.Rate, 0), "rate", CodeOutOfRange, "rate must be greater than 0")
+	return v.Err()
+}
+
+// ExchangeRates converts money between currencies using a table of rates.
+// A pair may be given in either direction, and currencies without a direct
+// rate are converted through a third one.
+type ExchangeRates struct {
+	rates map[[2]string]float64
+}
+
+// NewExchangeRates indexes a table of rates.
+func NewExchangeRates(rates []ExchangeRate) *ExchangeRates {
+	t := &ExchangeRates{rates: make(map[[2]string]float64, len(rates))}
+	for _, r := range rates {
+		t.rates[[2]string{r.Base, r.Quote}] = r.Rate
+	}
+	return t
+}
+
+// Rate returns how many units of to one unit of from is worth.
+func (t *ExchangeRates) Rate(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	if rate, ok := t.direct(from, to); ok {
+		return rate, true
+	}
+	for _, via := range SupportedCurrencies {
+		if via == from || via == to {
+			continue
+		}
+		first, ok := t.direct(from, via)
+		if !ok {
+			continue
+		}
+		if second, ok := t.direct(via, to); ok {
+			return first * second, true
+		}
+	}
+	return 0, false
+}
+
+func (t *ExchangeRates) direct(from, to string) (float64, bool) {
+	if t == nil {
+		return 0, false
+	}
+	if rate, ok := t.rates[[2]string{from, to}]; ok {
+		return rate, true
+	}
+	if rate, ok := t.rates[[2]string{to, from}]; ok {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+// RatesTo returns the rate from each supported currency into to, leaving
+// out currencies no rate links to it.
+func (t *ExchangeRates) RatesTo(to string) map[string]float64 {
+	rates := make(map[string]float64, len(SupportedCurrencies))
+	for _, from := range SupportedCurrencies {
+		if rate, ok := t.Rate(from, to); ok {
+			rates[from] = rate
+		}
+	}
+	return rates
+}
+
+// Convert expresses m, in currency from, in currency to, rounded to the
+// nearest cent. It reports false when no rate links the two currencies.
+func (t *ExchangeRates) Convert(m Money, from, to string) (Money, bool) {
+	rate, ok := t.Rate(from, to)
+	if !ok {
+		return 0, false
+	}
+	if rate == 1 {
+		return m, true
+	}
+	return Money(math.Round(float64(m) * rate)), true
+}
+
+// validCurrency reports whether code is a supported currency.
+func validCurrency(code string) bool {
+	return slices.Contains(SupportedCurrencies, code)
+}
+
+// normalizeCurrency upper-cases a currency code from user input.
+func normalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
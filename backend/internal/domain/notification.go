@@ -0,0 +1,23 @@
+package domain
+
+// NotificationKind says what a notification is about, so notifiers can
+// pick a template or channel.
+type NotificationKind string
+
+const (
+	NotifyGroupGiftInvite  NotificationKind = "group_gift_invite"
+	NotifyGroupGiftPledge  NotificationKind = "group_gift_pledge"
+	NotifyGroupGiftFunded  NotificationKind = "group_gift_funded"
+	NotifyGroupGiftSettled NotificationKind = "group_gift_settled"
+	NotifyExchangeDrawn    NotificationKind = "exchange_drawn"
+	NotifyHouseholdInvite  NotificationKind = "household_invite"
+)
+
+// Notification is a message for one person, addressed by email whether or
+// not they have an account.
+type Notification struct {
+	Kind    NotificationKind
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// OccasionKind classifies a gifting date.
+type OccasionKind string
+
+const (
+	OccasionBirthday    OccasionKind = "birthday"
+	OccasionAnniversary OccasionKind = "anniversary"
+	OccasionWedding     OccasionKind = "wedding"
+	OccasionGraduation  OccasionKind = "graduation"
+	OccasionCustom      OccasionKind = "custom"
+)
+
+// OccasionKinds lists every accepted occasion kind.
+var OccasionKinds = []OccasionKind{
+	OccasionBirthday, OccasionAnniversary, OccasionWedding, OccasionGraduation, OccasionCustom,
+}
+
+// Occasion is a date on which the user wants to give a recipient a gift.
+// Recurring occasions repeat every year on the month and day of Date.
+type Occasion struct {
+	ID          uuid.UUID    `json:"id"`
+	RecipientID uuid.UUID    `json:"recipient_id"`
+	Kind        OccasionKind `json:"kind"`
+	Title       string       `json:"title"`
+	Date        Date         `json:"date"`
+	Recurring   bool         `json:"recurring"`
+	Budget      *Money       `json:"budget"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// CreateOccasionRequest is the payload for adding an occasion to a recipient.
+// Recurring defaults to true when omitted.
+type CreateOccasionRequest struct {
+	Kind      OccasionKind `json:"kind"`
+	Title     string       `json:"title"`
+	Date      *Date        `json:"date"`
+	Recurring *bool        `json:"recurring"`
+	Budget    *Money       `json:"budget"`
+}
+
+// UpdateOccasionRequest is the payload for updating an occasion. Set
+// ClearBudget to drop the budget override.
+type UpdateOccasionRequest struct {
+	Kind        *OccasionKind `json:"kind"`
+	Title       *string       `json:"title"`
+	Date        *Date         `json:"date"`
+	Recurring   *bool         `json:"recurring"`
+	Budget      *Money        `json:"budget"`
+	ClearBudget bool          `json:"clear_budget"`
+}
+
+// MaxOccasionTitleLength bounds occasion titles.
+const MaxOccasionTitleLength = 100
+
+// Normalize trims user input before validation.
+func (o *Occasion) Normalize() {
+	o.Kind = OccasionKind(strings.ToLower(strings.TrimSpace(string(o.Kind))))
+	o.Title = strings.TrimSpace(o.Title)
+}
+
+// Validate checks the occasion against the field rules.
+func (o *Occasion) Validate() error {
+	var v Validator
+
+	v.Check(slices.Contains(OccasionKinds, o.Kind), "kind", CodeInvalidChoice,
+		"kind must be one of birthday, anniversary, wedding, graduation, custom")
+	v.Check(o.Kind != OccasionCustom || o.Title != "", "title", CodeRequired,
+		"title is required for custom occasions")
+	v.Check(utf8.RuneCountInString(o.Title) <= MaxOccasionTitleLength, "title", CodeTooLong,
+		"title must be at most %d characters", MaxOccasionTitleLength)
+	v.Check(!o.Date.IsZero(), "date", CodeRequired, "date is required")
+	v.Check(o.Date.IsZero() || o.Date.Year() >= 1900, "date", CodeOutOfRange,
+		"date must be after 1900")
+	if o.Budget != nil {
+		v.Check(*o.Budget >= 0 && *o.Budget <= MaxRecipientBudget, "budget", CodeOutOfRange,
+			"budget must be between 0 and %s", MaxRecipientBudget)
+	}
+
+	return v.Err()
+}
+
+// NextOccurrence returns the first date on or after today the occasion falls
+// on, or nil if a one-off occasion has already passed.
+func (o *Occasion) NextOccurrence(today time.Time) *Date {
+	if o.Recurring {
+		next := o.Date.NextAnniversary(today)
+		return &next
+	}
+	if o.Date.Before(NewDate(today).Time) {
+		return nil
+	}
+	d := o.Date
+	return &d
+}
+
+// BirthdayOccasion derives a recurring birthday occasion from the recipient's
+// birthdate, or returns nil if it has none. Its ID is the zero UUID because
+// it is not stored.
+func (r *Recipient) BirthdayOccasion() *Occasion {
+	if r.Birthdate == nil {
+		return nil
+	}
+	return &Occasion{
+		RecipientID: r.ID,
+		Kind:        OccasionBirthday,
+		Date:        *r.Birthdate,
+		Recurring:   true,
+	}
+}
+
+// UpcomingOccasion is one dated entry of the upcoming feed.
+type UpcomingOccasion struct {
+	RecipientID   uuid.UUID    `json:"recipient_id"`
+	RecipientName string       `json:"recipient_name"`
+	OccasionID    *uuid.UUID   `json:"occasion_id"`
+	Kind          OccasionKind `json:"kind"`
+	Title         string       `json:"title"`
+	Date          Date         `json:"date"`
+	DaysUntil     int          `json:"days_until"`
+	// Budget is in Currency, the recipient's currency.
+	Budget   Money  `json:"budget"`
+	Currency string `json:"currency"`
+	// Holiday and Country identify the built-in holiday behind entries of
+	// kind OccasionHoliday.
+	Holiday string `json:"holiday,omitempty"`
+	Country string `json:"country,omitempty"`
+	// Turns is the age reached on a birthday or the years since the
+	// original date of another recurring occasion.
+	Turns *int `json:"turns,omitempty"`
+}
+
+// Reminder is an upcoming occasion that is exactly one of the user's
+// reminder lead times away.
+type Reminder struct {
+	UpcomingOccasion
+	LeadDays int `json:"lead_days"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults applied to users who have never saved their preferences.
+const (
+	DefaultTimezone = "UTC"
+	DefaultLocale   = "en-US"
+	DefaultCurrency = "USD"
+)
+
+// DefaultReminderLeadDays are the days before an event when reminders fire.
+var DefaultReminderLeadDays = []int{7, 1}
+
+// SupportedLocales lists the locales the clients ship translations for.
+var SupportedLocales = []string{"en-US", "pt-BR"}
+
+// SupportedCurrencies lists the ISO 4217 codes budgets can be expressed in.
+var SupportedCurrencies = []string{"BRL", "USD", "EUR"}
+
+// UserPreferences holds the per-user settings used for date and money computations.
+type UserPreferences struct {
+	UserID           uuid.UUID `json:"user_id"`
+	Timezone         string    `json:"timezone"`
+	Locale           string    `json:"locale"`
+	Currency         string    `json:"currency"`
+	ReminderLeadDays []int     `json:"reminder_lead_days"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// NewDefaultPreferences returns the preferences assumed for a user who has not set any.
+func NewDefaultPreferences(userID uuid.UUID) *UserPreferences {
+	now := time.Now()
+	return &UserPreferences{
+		UserID:           userID,
+		Timezone:         DefaultTimezone,
+		Locale:           DefaultLocale,
+		Currency:         DefaultCurrency,
+		ReminderLeadDays: append([]int(nil), DefaultReminderLeadDays...),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// Location resolves the preferred timezone, falling back to UTC if it cannot be loaded.
+func (p *UserPreferences) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Today returns midnight of the current day in the user's timezone.
+func (p *UserPreferences) Today() time.Time {
+	now := time.Now().In(p.Location())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// UpdatePreferencesRequest is the payload for updating user preferences.
+type UpdatePreferencesRequest struct {
+	Timezone         *string `json:"timezone"`
+	Locale           *string `json:"locale"`
+	Currency         *string `json:"currency"`
+	ReminderLeadDays *[]int  `json:"reminder_lead_days"`
+}
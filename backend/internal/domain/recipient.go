@@ -1,46 +1,218 @@
 package domain
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 // Recipient represents a person the user wants to buy a gift for.
 type Recipient struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Name      string    `json:"name"`
-	Age       int       `json:"age"`
-	Gender    string    `json:"gender"`
-	MinBudget float64   `json:"min_budget"`
-	MaxBudget float64   `json:"max_budget"`
-	Keywords  []string  `json:"keywords"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-}
-
-// CreateRecipientRequest is the payload for creating a recipient.
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	Name         string     `json:"name"`
+	Age          int        `json:"age"`
+	Gender       string     `json:"gender"`
+	Relationship string     `json:"relationship"`
+	Birthdate    *Date      `json:"birthdate"`
+	MinBudget    Money      `json:"min_budget"`
+	MaxBudget    Money      `json:"max_budget"`
+	Currency     string     `json:"currency"`
+	Keywords     []string   `json:"keywords"`
+	Version      int        `json:"version"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CreateRecipientRequest is the payload for creating a recipient. Currency
+// defaults to the user's preferred currency.
 type CreateRecipientRequest struct {
-	Name      string   `json:"name"`
-	Age       int      `json:"age"`
-	Gender    string   `json:"gender"`
-	MinBudget float64  `json:"min_budget"`
-	MaxBudget float64  `json:"max_budget"`
-	Keywords  []string `json:"keywords"`
+	Name         string   `json:"name"`
+	Age          int      `json:"age"`
+	Gender       string   `json:"gender"`
+	Relationship string   `json:"relationship"`
+	Birthdate    *Date    `json:"birthdate"`
+	MinBudget    Money    `json:"min_budget"`
+	MaxBudget    Money    `json:"max_budget"`
+	Currency     string   `json:"currency,omitempty"`
+	Keywords     []string `json:"keywords"`
 }
 
 // UpdateRecipientRequest is the payload for updating a recipient.
 type UpdateRecipientRequest struct {
-	Name      *string   `json:"name"`
-	Age       *int      `json:"age"`
-	Gender    *string   `json:"gender"`
-	MinBudget *float64  `json:"min_budget"`
-	MaxBudget *float64  `json:"max_budget"`
-	Keywords  *[]string `json:"keywords"`
+	Name         *string   `json:"name"`
+	Age          *int      `json:"age"`
+	Gender       *string   `json:"gender"`
+	Relationship *string   `json:"relationship"`
+	Birthdate    *Date     `json:"birthdate"`
+	MinBudget    *Money    `json:"min_budget"`
+	MaxBudget    *Money    `json:"max_budget"`
+	Currency     *string   `json:"currency"`
+	Keywords     *[]string `json:"keywords"`
+	// IfMatch holds the versions from an If-Match header; the update is
+	// rejected unless the current version is one of them.
+	IfMatch []int `json:"-"`
 }
 
 // BulkDeleteRequest is the payload for deleting multiple recipients.
 type BulkDeleteRequest struct {
 	IDs []uuid.UUID `json:"ids"`
 }
+
+// RecipientSearchResult is a recipient matched by fuzzy search with its similarity score.
+type RecipientSearchResult struct {
+	Recipient
+	Score float64 `json:"score"`
+}
+
+// RecipientSort is a field the recipient list can be ordered by.
+type RecipientSort string
+
+const (
+	RecipientSortCreatedAt    RecipientSort = "created_at"
+	RecipientSortName         RecipientSort = "name"
+	RecipientSortAge          RecipientSort = "age"
+	RecipientSortBudget       RecipientSort = "budget"
+	RecipientSortNextBirthday RecipientSort = "next_birthday"
+)
+
+// Valid reports whether s is a known sort field.
+func (s RecipientSort) Valid() bool {
+	switch s {
+	case RecipientSortCreatedAt, RecipientSortName, RecipientSortAge,
+		RecipientSortBudget, RecipientSortNextBirthday:
+		return true
+	}
+	return false
+}
+
+// RecipientCursor marks the last row of a page so the next page can resume after it.
+type RecipientCursor struct {
+	Sort RecipientSort `json:"s"`
+	Desc bool          `json:"d"`
+	Key  string        `json:"k"`
+	ID   uuid.UUID     `json:"id"`
+	// Currency is set when Key is a budget, which depends on it.
+	Currency string `json:"c,omitempty"`
+}
+
+// RecipientQuery describes a filtered, sorted page of a user's recipients.
+type RecipientQuery struct {
+	Sort   RecipientSort
+	Desc   bool
+	Cursor string
+	After  *RecipientCursor
+	Limit  int
+	Gender string
+	MinAge *int
+	MaxAge *int
+	// Currency is the currency of BudgetMin, BudgetMax and budget ordering,
+	// the user's preferred currency unless given.
+	Currency  string
+	BudgetMin *Money
+	BudgetMax *Money
+	// BudgetRates convert each recipient's budget into Currency. Recipients
+	// in a currency without a rate match no budget filter and sort as the
+	// lowest budget.
+	BudgetRates map[string]float64
+	Keywords    []string
+	GroupID     *uuid.UUID
+	// Today anchors next-birthday ordering to the user's local date.
+	Today time.Time
+}
+
+// RecipientPage is one page of the recipient list.
+type RecipientPage struct {
+	Data       []Recipient `json:"data"`
+	NextCursor *string     `json:"next_cursor"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// Limits enforced on recipient fields.
+const (
+	MaxRecipientNameLength       = 255
+	MaxRecipientAge              = 150
+	MaxRecipientBudget     Money = 99_999_999_99
+	MaxRecipientKeywords         = 20
+	MaxKeywordLength             = 50
+)
+
+// Recipient genders accepted by the API.
+const (
+	GenderFemale    = "female"
+	GenderMale      = "male"
+	GenderNonBinary = "non_binary"
+	GenderOther     = "other"
+)
+
+// Genders lists every accepted gender value.
+var Genders = []string{GenderFemale, GenderMale, GenderNonBinary, GenderOther}
+
+// Normalize trims user input and fills defaults before validation.
+func (r *Recipient) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Gender = strings.ToLower(strings.TrimSpace(r.Gender))
+	if r.Gender == "" {
+		r.Gender = GenderOther
+	}
+	r.Relationship = strings.ToLower(strings.TrimSpace(r.Relationship))
+	r.Currency = normalizeCurrency(r.Currency)
+	keywords := make([]string, 0, len(r.Keywords))
+	for _, kw := range r.Keywords {
+		keywords = append(keywords, strings.TrimSpace(kw))
+	}
+	r.Keywords = keywords
+}
+
+// Validate checks the recipient against the field rules and returns
+// ErrValidation listing every violation. today bounds the birthdate.
+func (r *Recipient) Validate(today time.Time) error {
+	var v Validator
+
+	v.Check(r.Name != "", "name", CodeRequired, "name is required")
+	v.Check(utf8.RuneCountInString(r.Name) <= MaxRecipientNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxRecipientNameLength)
+
+	v.Check(r.Age >= 0 && r.Age <= MaxRecipientAge, "age", CodeOutOfRange,
+		"age must be between 0 and %d", MaxRecipientAge)
+	v.Check(slices.Contains(Genders, r.Gender), "gender", CodeInvalidChoice,
+		"gender must be one of %s", strings.Join(Genders, ", "))
+	v.Check(r.Relationship == "" || slices.Contains(Relationships, r.Relationship), "relationship", CodeInvalidChoice,
+		"relationship must be one of %s", strings.Join(Relationships, ", "))
+
+	if r.Birthdate != nil {
+		v.Check(!r.Birthdate.After(NewDate(today).Time), "birthdate", CodeOutOfRange,
+			"birthdate cannot be in the future")
+		v.Check(r.Birthdate.Year() >= 1900, "birthdate", CodeOutOfRange,
+			"birthdate must be after 1900")
+	}
+
+	v.Check(r.MinBudget >= 0 && r.MinBudget <= MaxRecipientBudget, "min_budget", CodeOutOfRange,
+		"min_budget must be between 0 and %s", MaxRecipientBudget)
+	v.Check(r.MaxBudget >= 0 && r.MaxBudget <= MaxRecipientBudget, "max_budget", CodeOutOfRange,
+		"max_budget must be between 0 and %s", MaxRecipientBudget)
+	v.Check(r.MinBudget <= r.MaxBudget, "min_budget", CodeMinExceedsMax,
+		"min_budget cannot exceed max_budget")
+	v.Check(validCurrency(r.Currency), "currency", CodeInvalidChoice,
+		"currency must be one of %s", strings.Join(SupportedCurrencies, ", "))
+
+	v.Check(len(r.Keywords) <= MaxRecipientKeywords, "keywords", CodeTooMany,
+		"at most %d keywords are allowed", MaxRecipientKeywords)
+	seen := make(map[string]bool, len(r.Keywords))
+	for i, kw := range r.Keywords {
+		field := fmt.Sprintf("keywords[%d]", i)
+		v.Check(kw != "", field, CodeRequired, "keyword cannot be empty")
+		v.Check(utf8.RuneCountInString(kw) <= MaxKeywordLength, field, CodeTooLong,
+			"keyword must be at most %d characters", MaxKeywordLength)
+		key := strings.ToLower(kw)
+		v.Check(!seen[key], field, CodeDuplicate, "keyword %q is repeated", kw)
+		seen[key] = true
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,64 @@
+package domain
+
+// Relationships a recipient can have to the user. An empty relationship
+// means it was not specified.
+const (
+	RelationshipPartner     = "partner"
+	RelationshipSpouse      = "spouse"
+	RelationshipMother      = "mother"
+	RelationshipFather      = "father"
+	RelationshipChild       = "child"
+	RelationshipSibling     = "sibling"
+	RelationshipGrandparent = "grandparent"
+	RelationshipRelative    = "relative"
+	RelationshipBestFriend  = "best_friend"
+	RelationshipFriend      = "friend"
+	RelationshipColleague   = "colleague"
+	RelationshipBoss        = "boss"
+	RelationshipNeighbor    = "neighbor"
+	RelationshipOther       = "other"
+)
+
+// Relationships lists every accepted relationship value.
+var Relationships = []string{
+	RelationshipPartner, RelationshipSpouse, RelationshipMother, RelationshipFather,
+	RelationshipChild, RelationshipSibling, RelationshipGrandparent, RelationshipRelative,
+	RelationshipBestFriend, RelationshipFriend, RelationshipColleague, RelationshipBoss,
+	RelationshipNeighbor, RelationshipOther,
+}
+
+// DefaultCloseness applies to recipients without a known relationship.
+const DefaultCloseness = 0.5
+
+// relationshipCloseness scores how close each relationship usually is, from 0
+// (distant) to 1 (closest). Gift suggestions use it to favour dearer items
+// within the budget for close relationships and cheaper ones for distant
+// relationships.
+var relationshipCloseness = map[string]float64{
+	RelationshipPartner:     1.0,
+	RelationshipSpouse:      1.0,
+	RelationshipMother:      0.95,
+	RelationshipFather:      0.95,
+	RelationshipChild:       0.95,
+	RelationshipSibling:     0.85,
+	RelationshipBestFriend:  0.85,
+	RelationshipGrandparent: 0.8,
+	RelationshipRelative:    0.6,
+	RelationshipFriend:      0.6,
+	RelationshipColleague:   0.4,
+	RelationshipBoss:        0.35,
+	RelationshipNeighbor:    0.3,
+}
+
+// RelationshipCloseness returns the closeness weight of a relationship.
+func RelationshipCloseness(relationship string) float64 {
+	if c, ok := relationshipCloseness[relationship]; ok {
+		return c
+	}
+	return DefaultCloseness
+}
+
+// Closeness returns the closeness weight of the recipient's relationship.
+func (r *Recipient) Closeness() float64 {
+	return RelationshipCloseness(r.Relationship)
+}
@@ -0,0 +1,298 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// YearlyBudget is how much a user plans to spend on gifts in a year.
+type YearlyBudget struct {
+	UserID    uuid.UUID `json:"-"`
+	Year      int       `json:"year"`
+	Amount    Money     `json:"amount"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// YearlyBudgetRequest is the payload for setting a year's budget. Currency
+// defaults to the user's preferred currency.
+type YearlyBudgetRequest struct {
+	Amount   Money  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Years a budget or spending report may cover.
+const (
+	MinBudgetYear = 1900
+	MaxBudgetYear = 2200
+)
+
+// Validate checks the budget against the field rules.
+func (b *YearlyBudget) Validate() error {
+	var v Validator
+	v.Check(b.Year >= MinBudgetYear && b.Year <= MaxBudgetYear, "year", CodeOutOfRange,
+		"year must be between %d and %d", MinBudgetYear, MaxBudgetYear)
+	v.Check(b.Amount > 0 && b.Amount <= MaxRecipientBudget, "amount", CodeOutOfRange,
+		"amount must be greater than 0 and at most %s", MaxRecipientBudget)
+	v.Check(validCurrency(b.Currency), "currency", CodeInvalidChoice,
+		"currency must be one of %s", strings.Join(SupportedCurrencies, ", "))
+	return v.Err()
+}
+
+// GroupMembership places a recipient in a group.
+type GroupMembership struct {
+	GroupID     uuid.UUID
+	RecipientID uuid.UUID
+}
+
+// SpendingTotal sums the gifts in one slice of a spending report.
+type SpendingTotal struct {
+	Total Money `json:"total"`
+	Count int   `json:"count"`
+}
+
+func (t *SpendingTotal) add(price Money) {
+	t.Total += price
+	t.Count++
+}
+
+// RecipientSpending is what was spent on one recipient.
+type RecipientSpending struct {
+	RecipientID uuid.UUID `json:"recipient_id"`
+	Name        string    `json:"name"`
+	SpendingTotal
+}
+
+// GroupSpending is what was spent on a group's members. A recipient in
+// several groups counts toward each.
+type GroupSpending struct {
+	GroupID uuid.UUID `json:"group_id"`
+	Name    string    `json:"name"`
+	SpendingTotal
+}
+
+// MonthSpending is what was spent in one month, from 1 to 12.
+type MonthSpending struct {
+	Month int `json:"month"`
+	SpendingTotal
+}
+
+// OccasionSpending is what was spent on one kind of occasion. Gifts not
+// linked to an occasion are reported under OccasionSpendingNone.
+type OccasionSpending struct {
+	Kind string `json:"kind"`
+	SpendingTotal
+}
+
+// OccasionSpendingNone groups gifts that are not linked to an occasion.
+const OccasionSpendingNone = "none"
+
+// Warning codes in a spending report.
+const (
+	WarningOverYearlyBudget = "over_yearly_budget"
+	WarningOverGiftBudget   = "over_gift_budget"
+	WarningMissingRate      = "missing_exchange_rate"
+)
+
+// SpendingWarning flags spending above a budget. Gift warnings name the gift
+// and its recipient; Limit is the budget that was exceeded. A missing rate
+// warning names the currency that could not be converted; gifts and budgets
+// in it are left out of the report.
+type SpendingWarning struct {
+	Code        string     `json:"code"`
+	Message     string     `json:"message"`
+	RecipientID *uuid.UUID `json:"recipient_id,omitempty"`
+	GiftID      *uuid.UUID `json:"gift_id,omitempty"`
+	Currency    string     `json:"currency,omitempty"`
+	Amount      Money      `json:"amount"`
+	Limit       Money      `json:"limit"`
+}
+
+// SpendingReport breaks down a year of recorded gifts. Every amount is in
+// Currency.
+type SpendingReport struct {
+	Year     int    `json:"year"`
+	Currency string `json:"currency"`
+	// Budget and Remaining are nil when no budget is set for the year.
+	Budget      *Money              `json:"budget"`
+	Remaining   *Money              `json:"remaining"`
+	Total       Money               `json:"total"`
+	GiftCount   int                 `json:"gift_count"`
+	ByRecipient []RecipientSpending `json:"by_recipient"`
+	ByGroup     []GroupSpending     `json:"by_group"`
+	ByMonth     []MonthSpending     `json:"by_month"`
+	ByOccasion  []OccasionSpending  `json:"by_occasion"`
+	Warnings    []SpendingWarning   `json:"warnings"`
+}
+
+// SpendingInput is everything a spending report is built from. Gifts must
+// already be limited to the report's year. Rates convert amounts into
+// Currency.
+type SpendingInput struct {
+	Year        int
+	Currency    string
+	Rates       *ExchangeRates
+	Budget      *YearlyBudget
+	Gifts       []GiftRecord
+	Recipients  []Recipient
+	Occasions   []Occasion
+	Groups      []Group
+	Memberships []GroupMembership
+}
+
+// NewSpendingReport totals the gifts by recipient, group, month and occasion
+// kind, and warns when the yearly budget is exceeded or a gift cost more
+// than its occasion's budget, or else its recipient's maximum. Amounts are
+// converted into the report's currency first.
+func NewSpendingReport(in SpendingInput) *SpendingReport {
+	recipients := make(map[uuid.UUID]*Recipient, len(in.Recipients))
+	for i := range in.Recipients {
+		recipients[in.Recipients[i].ID] = &in.Recipients[i]
+	}
+	occasions := make(map[uuid.UUID]*Occasion, len(in.Occasions))
+	for i := range in.Occasions {
+		occasions[in.Occasions[i].ID] = &in.Occasions[i]
+	}
+	groupsOf := make(map[uuid.UUID][]uuid.UUID)
+	for _, m := range in.Memberships {
+		groupsOf[m.RecipientID] = append(groupsOf[m.RecipientID], m.GroupID)
+	}
+
+	report := &SpendingReport{
+		Year:        in.Year,
+		Currency:    in.Currency,
+		ByRecipient: []RecipientSpending{},
+		ByGroup:     []GroupSpending{},
+		ByMonth:     make([]MonthSpending, 12),
+		ByOccasion:  []OccasionSpending{},
+		Warnings:    []SpendingWarning{},
+	}
+	for i := range report.ByMonth {
+		report.ByMonth[i].Month = i + 1
+	}
+
+	byRecipient := make(map[uuid.UUID]*SpendingTotal)
+	byGroup := make(map[uuid.UUID]*SpendingTotal)
+	byKind := make(map[string]*SpendingTotal)
+	var unconvertible []string
+	convert := func(m Money, currency string) (Money, bool) {
+		converted, ok := in.Rates.Convert(m, currency, in.Currency)
+		if !ok && !slices.Contains(unconvertible, currency) {
+			unconvertible = append(unconvertible, currency)
+		}
+		return converted, ok
+	}
+	for _, g := range in.Gifts {
+		recipient := recipients[g.RecipientID]
+		if recipient == nil {
+			continue
+		}
+		price, ok := convert(g.Price, g.Currency)
+		if !ok {
+			continue
+		}
+		report.Total += price
+		report.GiftCount++
+		report.ByMonth[g.GivenOn.Month()-1].add(price)
+		totalFor(byRecipient, g.RecipientID).add(price)
+		for _, groupID := range groupsOf[g.RecipientID] {
+			totalFor(byGroup, groupID).add(price)
+		}
+
+		kind, limit := OccasionSpendingNone, recipient.MaxBudget
+		if g.OccasionID != nil {
+			if o := occasions[*g.OccasionID]; o != nil {
+				kind = string(o.Kind)
+				if o.Budget != nil {
+					limit = *o.Budget
+				}
+			}
+		}
+		totalFor(byKind, kind).add(price)
+
+		if limit <= 0 {
+			continue
+		}
+		// Occasion budgets are in the recipient's currency too.
+		if limit, ok = convert(limit, recipient.Currency); ok && price > limit {
+			recipientID, giftID := g.RecipientID, g.ID
+			report.Warnings = append(report.Warnings, SpendingWarning{
+				Code:        WarningOverGiftBudget,
+				Message:     fmt.Sprintf("%s for %s cost %s over its %s budget", g.Item, recipient.Name, price-limit, limit),
+				RecipientID: &recipientID,
+				GiftID:      &giftID,
+				Amount:      price,
+				Limit:       limit,
+			})
+		}
+	}
+
+	for id, total := range byRecipient {
+		report.ByRecipient = append(report.ByRecipient, RecipientSpending{
+			RecipientID: id, Name: recipients[id].Name, SpendingTotal: *total,
+		})
+	}
+	sort.Slice(report.ByRecipient, func(i, j int) bool {
+		a, b := report.ByRecipient[i], report.ByRecipient[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+
+	for _, group := range in.Groups {
+		if total, ok := byGroup[group.ID]; ok {
+			report.ByGroup = append(report.ByGroup, GroupSpending{GroupID: group.ID, Name: group.Name, SpendingTotal: *total})
+		}
+	}
+	sort.SliceStable(report.ByGroup, func(i, j int) bool { return report.ByGroup[i].Total > report.ByGroup[j].Total })
+
+	for _, kind := range OccasionKinds {
+		if total, ok := byKind[string(kind)]; ok {
+			report.ByOccasion = append(report.ByOccasion, OccasionSpending{Kind: string(kind), SpendingTotal: *total})
+		}
+	}
+	if total, ok := byKind[OccasionSpendingNone]; ok {
+		report.ByOccasion = append(report.ByOccasion, OccasionSpending{Kind: OccasionSpendingNone, SpendingTotal: *total})
+	}
+
+	if in.Budget != nil {
+		if budget, ok := convert(in.Budget.Amount, in.Budget.Currency); ok {
+			remaining := budget - report.Total
+			report.Budget, report.Remaining = &budget, &remaining
+			if remaining < 0 {
+				// The yearly warning leads the list.
+				report.Warnings = append([]SpendingWarning{{
+					Code:    WarningOverYearlyBudget,
+					Message: fmt.Sprintf("Spending in %d is %s over the %s budget", in.Year, -remaining, budget),
+					Amount:  report.Total,
+					Limit:   budget,
+				}}, report.Warnings...)
+			}
+		}
+	}
+
+	for _, currency := range unconvertible {
+		report.Warnings = append(report.Warnings, SpendingWarning{
+			Code:     WarningMissingRate,
+			Message:  fmt.Sprintf("No exchange rate from %s to %s; amounts in %s are left out", currency, in.Currency, currency),
+			Currency: currency,
+		})
+	}
+	return report
+}
+
+func totalFor[K comparable](totals map[K]*SpendingTotal, key K) *SpendingTotal {
+	if t, ok := totals[key]; ok {
+		return t
+	}
+	t := &SpendingTotal{}
+	totals[key] = t
+	return t
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ImportField is a recipient field a spreadsheet column can be mapped to.
+type ImportField string
+
+const (
+	FieldName         ImportField = "name"
+	FieldAge          ImportField = "age"
+	FieldGender       ImportField = "gender"
+	FieldRelationship ImportField = "relationship"
+	FieldBirthdate    ImportField = "birthdate"
+	FieldMinBudget    ImportField = "min_budget"
+	FieldMaxBudget    ImportField = "max_budget"
+	FieldCurrency     ImportField = "currency"
+	FieldKeywords     ImportField = "keywords"
+)
+
+// ImportFields lists every mappable field, in export column order.
+var ImportFields = []ImportField{
+	FieldName, FieldAge, FieldGender, FieldRelationship, FieldBirthdate, FieldMinBudget, FieldMaxBudget,
+	FieldCurrency, FieldKeywords,
+}
+
+// ColumnMapping assigns spreadsheet columns, by header, to recipient fields.
+type ColumnMapping map[ImportField]string
+
+// Date formats accepted for spreadsheet date columns.
+const (
+	DateFormatISO = "YYYY-MM-DD"
+	DateFormatDMY = "DD/MM/YYYY"
+	DateFormatMDY = "MM/DD/YYYY"
+)
+
+// DateFormats maps each accepted date format onto its Go layouts. Dashes
+// and dots are accepted in place of slashes.
+var DateFormats = map[string][]string{
+	DateFormatISO: {"2006-01-02", "2006/01/02", "2006.01.02"},
+	DateFormatDMY: {"2/1/2006", "2-1-2006", "2.1.2006"},
+	DateFormatMDY: {"1/2/2006", "1-2-2006", "1.2.2006"},
+}
+
+// MapColumnsRequest changes how the columns of a spreadsheet import are read.
+// An empty DateFormat keeps the current one.
+type MapColumnsRequest struct {
+	Mapping    ColumnMapping `json:"mapping"`
+	DateFormat string        `json:"date_format"`
+}
+
+// importFieldHeaders lists header spellings recognized for each field,
+// folded with KeywordKey, which also turns dashes and underscores into
+// spaces.
+var importFieldHeaders = map[ImportField][]string{
+	FieldName:         {"name", "full name", "nome", "nome completo", "recipient", "person", "contact"},
+	FieldAge:          {"age", "idade"},
+	FieldGender:       {"gender", "sex", "genero", "sexo"},
+	FieldRelationship: {"relationship", "relation", "relacao", "parentesco"},
+	FieldBirthdate:    {"birthdate", "birthday", "birth date", "date of birth", "dob", "aniversario", "data de nascimento", "nascimento"},
+	FieldMinBudget:    {"min budget", "minimum budget", "budget min", "orcamento minimo"},
+	FieldMaxBudget:    {"max budget", "maximum budget", "budget max", "budget", "orcamento", "orcamento maximo"},
+	FieldCurrency:     {"currency", "moeda"},
+	FieldKeywords:     {"keywords", "interests", "tags", "hobbies", "interesses", "palavras chave"},
+}
+
+// SuggestColumnMapping guesses the mapping from header names.
+func SuggestColumnMapping(columns []string) ColumnMapping {
+	mapping := make(ColumnMapping)
+	for _, field := range ImportFields {
+		for _, col := range columns {
+			if col != "" && slices.Contains(importFieldHeaders[field], KeywordKey(col)) && !mapping.uses(col) {
+				mapping[field] = col
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+func (m ColumnMapping) uses(column string) bool {
+	for _, col := range m {
+		if col == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that every field is known, every column exists and the
+// name is mapped.
+func (m ColumnMapping) Validate(columns []string) error {
+	var v Validator
+	v.Check(m[FieldName] != "", "mapping.name", CodeRequired, "the name field must be mapped to a column")
+	for field, col := range m {
+		key := "mapping." + string(field)
+		if !slices.Contains(ImportFields, field) {
+			v.Add(key, CodeInvalidChoice, "%s is not an importable field", field)
+			continue
+		}
+		v.Check(col == "" || slices.Contains(columns, col), key, CodeInvalidChoice,
+			"column %q is not in the file", col)
+	}
+	return v.Err()
+}
+
+// DetectDateFormat picks the format that reads the most values, preferring
+// ISO and then day-first when formats tie.
+func DetectDateFormat(values []string) string {
+	best, bestCount := DateFormatISO, 0
+	for _, format := range []string{DateFormatISO, DateFormatDMY, DateFormatMDY} {
+		count := 0
+		for _, v := range values {
+			if _, err := ParseDateFormat(strings.TrimSpace(v), format); err == nil {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = format, count
+		}
+	}
+	return best
+}
+
+// ParseDateFormat parses s in one of the DateFormats.
+func ParseDateFormat(s, format string) (Date, error) {
+	for _, layout := range DateFormats[format] {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Date{t}, nil
+		}
+	}
+	return Date{}, fmt.Errorf("%q is not a %s date", s, format)
+}
+
+// SplitKeywords splits a spreadsheet cell into keywords. Commas,
+// semicolons, pipes and line breaks all separate keywords.
+func SplitKeywords(s string) []string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|' || r == '\n' || r == '\r'
+	})
+	keywords := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			keywords = append(keywords, p)
+		}
+	}
+	return keywords
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "fmt"
+
+// Machine-readable validation codes returned to clients.
+const (
+	CodeRequired      = "required"
+	CodeTooLong       = "too_long"
+	CodeTooMany       = "too_many"
+	CodeOutOfRange    = "out_of_range"
+	CodeInvalidChoice = "invalid_choice"
+	CodeMinExceedsMax = "min_exceeds_max"
+	CodeDuplicate     = "duplicate"
+	CodeInvalidFormat = "invalid_format"
+)
+
+// FieldError describes why a single field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Validator accumulates field errors.
+type Validator struct {
+	errors []FieldError
+}
+
+// Add records an invalid field.
+func (v *Validator) Add(field, code, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// Check records an invalid field when ok is false.
+func (v *Validator) Check(ok bool, field, code, format string, args ...any) {
+	if !ok {
+		v.Add(field, code, format, args...)
+	}
+}
+
+// Err returns ErrValidation listing the invalid fields, or nil if there were none.
+func (v *Validator) Err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return ErrValidation.WithFields(v.errors)
+}
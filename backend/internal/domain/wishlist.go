@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Wishlist publishes some of a recipient's gift ideas behind an
+// unguessable slug, so friends and family can see them without an account.
+type Wishlist struct {
+	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	RecipientID uuid.UUID      `json:"recipient_id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Slug        string         `json:"slug"`
+	Items       []WishlistItem `json:"items"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// WishlistItem places a gift idea on a wishlist. Claims are deliberately
+// not part of it: the owner never learns which items were claimed.
+type WishlistItem struct {
+	ID         uuid.UUID `json:"id"`
+	WishlistID uuid.UUID `json:"wishlist_id"`
+	IdeaID     uuid.UUID `json:"idea_id"`
+	Position   int       `json:"position"`
+}
+
+// WishlistClaim marks an item as being bought by a visitor. Token lets the
+// visitor release the claim later and is only ever shown to them.
+type WishlistClaim struct {
+	ID        uuid.UUID `json:"id"`
+	ItemID    uuid.UUID `json:"item_id"`
+	Name      string    `json:"name"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PublicWishlist is what visitors see at a wishlist's public slug.
+type PublicWishlist struct {
+	Title         string               `json:"title"`
+	Description   string               `json:"description"`
+	RecipientName string               `json:"recipient_name"`
+	Items         []PublicWishlistItem `json:"items"`
+}
+
+// PublicWishlistItem is a wishlist item as shown to visitors. An idea's
+// private notes and status stay with the owner.
+type PublicWishlistItem struct {
+	ID       uuid.UUID    `json:"id"`
+	Title    string       `json:"title"`
+	URL      string       `json:"url"`
+	Price    *Money       `json:"price"`
+	Currency string       `json:"currency"`
+	Category string       `json:"category"`
+	Priority IdeaPriority `json:"priority"`
+	// Claimed is always false when the owner is the one looking.
+	Claimed bool `json:"claimed"`
+}
+
+// CreateWishlistRequest is the payload for publishing a wishlist. IdeaIDs
+// lists the recipient's ideas to show, in order.
+type CreateWishlistRequest struct {
+	RecipientID uuid.UUID   `json:"recipient_id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	IdeaIDs     []uuid.UUID `json:"idea_ids"`
+}
+
+// UpdateWishlistRequest is the payload for editing a wishlist. IdeaIDs,
+// when set, replaces the items; claims on ideas that stay are kept.
+type UpdateWishlistRequest struct {
+	Title       *string      `json:"title"`
+	Description *string      `json:"description"`
+	IdeaIDs     *[]uuid.UUID `json:"idea_ids"`
+}
+
+// ClaimWishlistItemRequest is the payload for claiming an item. Name is
+// optional and only helps the visitor recognise their own claim.
+type ClaimWishlistItemRequest struct {
+	Name string `json:"name"`
+}
+
+// Limits enforced on wishlists.
+const (
+	MaxWishlistTitleLength       = 200
+	MaxWishlistDescriptionLength = 1000
+	MaxWishlistItems             = 100
+	MaxClaimNameLength           = 100
+)
+
+// Normalize trims user input before validation.
+func (l *Wishlist) Normalize() {
+	l.Title = strings.TrimSpace(l.Title)
+	l.Description = strings.TrimSpace(l.Description)
+}
+
+// Validate checks the wishlist against the field rules.
+func (l *Wishlist) Validate() error {
+	var v Validator
+
+	v.Check(l.Title != "", "title", CodeRequired, "title is required")
+	v.Check(utf8.RuneCountInString(l.Title) <= MaxWishlistTitleLength, "title", CodeTooLong,
+		"title must be at most %d characters", MaxWishlistTitleLength)
+	v.Check(utf8.RuneCountInString(l.Description) <= MaxWishlistDescriptionLength, "description", CodeTooLong,
+		"description must be at most %d characters", MaxWishlistDescriptionLength)
+	v.Check(len(l.Items) <= MaxWishlistItems, "idea_ids", CodeTooMany,
+		"a wishlist can have at most %d items", MaxWishlistItems)
+
+	return v.Err()
+}
+
+// SetIdeas replaces the items with ideaIDs, in order. Items for ideas that
+// stay keep their IDs, so claims on them survive the edit.
+func (l *Wishlist) SetIdeas(ideaIDs []uuid.UUID) {
+	existing := make(map[uuid.UUID]uuid.UUID, len(l.Items))
+	for _, item := range l.Items {
+		existing[item.IdeaID] = item.ID
+	}
+	l.Items = make([]WishlistItem, len(ideaIDs))
+	for i, ideaID := range ideaIDs {
+		id, ok := existing[ideaID]
+		if !ok {
+			id = uuid.New()
+		}
+		l.Items[i] = WishlistItem{ID: id, WishlistID: l.ID, IdeaID: ideaID, Position: i}
+	}
+}
+
+// Item returns the item with id, if any.
+func (l *Wishlist) Item(id uuid.UUID) *WishlistItem {
+	for i := range l.Items {
+		if l.Items[i].ID == id {
+			return &l.Items[i]
+		}
+	}
+	return nil
+}
+
+// Path returns the wishlist's public URL path, relative to the API host.
+func (l *Wishlist) Path() string {
+	return "/api/public/wishlists/" + l.Slug
+}
+
+// Normalize trims user input before validation.
+func (c *WishlistClaim) Normalize() {
+	c.Name = strings.TrimSpace(c.Name)
+}
+
+// Validate checks the claim against the field rules.
+func (c *WishlistClaim) Validate() error {
+	var v Validator
+
+	v.Check(utf8.RuneCountInString(c.Name) <= MaxClaimNameLength, "name", CodeTooLong,
+		"name must be at most %d characters", MaxClaimNameLength)
+
+	return v.Err()
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs fn immediately and then on each interval tick until ctx is cancelled.
+// Errors are logged and do not stop the schedule. interval must be positive.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			log.Printf("job %s failed: %v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package ical encodes the subset of iCalendar (RFC 5545) the app needs:
+// all-day events with optional yearly recurrence and display alarms.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+// Calendar is a VCALENDAR object.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is an all-day VEVENT.
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Date        time.Time
+	Summary     string
+	Description string
+	Categories  []string
+	// RRule is the recurrence rule value, e.g. "FREQ=YEARLY". Empty for
+	// one-off events.
+	RRule string
+	// RDates are extra occurrence dates for recurrences RRULE cannot express.
+	RDates []time.Time
+	Alarms []Alarm
+}
+
+// Alarm is a VALARM that displays a message Before the start of the event.
+type Alarm struct {
+	Before      time.Duration
+	Description string
+}
+
+// Encode writes the calendar to w with CRLF line endings and folded lines.
+func (c *Calendar) Encode(w io.Writer) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	e.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", Escape(c.Name))
+	}
+	for i := range c.Events {
+		e.event(&c.Events[i])
+	}
+	e.line("END", "VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// Escape escapes a TEXT property value.
+func Escape(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) event(ev *Event) {
+	e.line("BEGIN", "VEVENT")
+	e.line("UID", ev.UID)
+	e.line("DTSTAMP", ev.Stamp.UTC().Format(dateTimeLayout))
+	e.line("DTSTART;VALUE=DATE", ev.Date.Format(dateLayout))
+	e.line("SUMMARY", Escape(ev.Summary))
+	if ev.Description != "" {
+		e.line("DESCRIPTION", Escape(ev.Description))
+	}
+	if len(ev.Categories) > 0 {
+		categories := make([]string, len(ev.Categories))
+		for i, c := range ev.Categories {
+			categories[i] = Escape(c)
+		}
+		e.line("CATEGORIES", strings.Join(categories, ","))
+	}
+	if ev.RRule != "" {
+		e.line("RRULE", ev.RRule)
+	}
+	if len(ev.RDates) > 0 {
+		dates := make([]string, len(ev.RDates))
+		for i, d := range ev.RDates {
+			dates[i] = d.Format(dateLayout)
+		}
+		e.line("RDATE;VALUE=DATE", strings.Join(dates, ","))
+	}
+	e.line("TRANSP", "TRANSPARENT")
+	for _, a := range ev.Alarms {
+		e.line("BEGIN", "VALARM")
+		e.line("ACTION", "DISPLAY")
+		e.line("TRIGGER", Duration(-a.Before))
+		e.line("DESCRIPTION", Escape(a.Description))
+		e.line("END", "VALARM")
+	}
+	e.line("END", "VEVENT")
+}
+
+// line writes one content line, folding it at 75 octets without splitting
+// UTF-8 sequences.
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	s := name + ":" + value
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.write(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = maxLineOctets - 1
+	}
+	e.write(s + "\r\n")
+}
+
+func (e *encoder) write(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
+
+// Duration formats d as an RFC 5545 DURATION value such as "-P7D" or
+// "PT9H". Only whole seconds are kept.
+func Duration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	h, m, s := d/time.Hour, (d%time.Hour)/time.Minute, (d%time.Minute)/time.Second
+
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if h > 0 || m > 0 || s > 0 || days == 0 {
+		b.WriteString("T")
+		if h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m > 0 {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+		if s > 0 || (h == 0 && m == 0) {
+			fmt.Fprintf(&b, "%dS", s)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	cal := &Calendar{
+		ProdID: "-//Test//EN",
+		Name:   "Birthdays",
+		Events: []Event{{
+			UID:     "birthday-1@test",
+			Stamp:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Date:    time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC),
+			Summary: "Ana's birthday; bring cake, please",
+			RRule:   "FREQ=YEARLY",
+			Alarms:  []Alarm{{Before: 7 * 24 * time.Hour, Description: "Soon"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, cal.Encode(&buf))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "DTSTAMP:20260102T030405Z\r\n")
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:19900315\r\n")
+	assert.Contains(t, out, `SUMMARY:Ana's birthday\; bring cake\, please`+"\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=YEARLY\r\n")
+	assert.Contains(t, out, "BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-P7D\r\n")
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	cal := &Calendar{ProdID: "-//Test//EN", Events: []Event{{
+		UID:     "long@test",
+		Summary: strings.Repeat("é", 60),
+	}}}
+
+	var buf bytes.Buffer
+	require.NoError(t, cal.Encode(&buf))
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("é", 60)+"\r\n")
+}
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, "-P7D", Duration(-7*24*time.Hour))
+	assert.Equal(t, "PT0S", Duration(0))
+	assert.Equal(t, "PT9H", Duration(9*time.Hour))
+	assert.Equal(t, "-P1DT2H30M", Duration(-(26*time.Hour + 30*time.Minute)))
+}
@@ -0,0 +1,194 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrMalformed is returned when the input is not an iCalendar stream.
+var ErrMalformed = errors.New("ical: malformed calendar")
+
+// Parse reads the VEVENTs of every VCALENDAR in r. Properties the package
+// does not model are ignored, as are VALARMs and other nested components.
+func Parse(r io.Reader) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{}
+	var (
+		inCalendar bool
+		event      *Event
+		depth      int // nesting inside the current event
+	)
+	for n, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d", ErrMalformed, n+1)
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			inCalendar = true
+		case name == "END" && strings.EqualFold(value, "VCALENDAR"):
+			inCalendar = false
+		case !inCalendar:
+			return nil, fmt.Errorf("%w: content outside VCALENDAR", ErrMalformed)
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT") && event == nil:
+			event = &Event{}
+		case name == "BEGIN" && event != nil:
+			depth++
+		case name == "END" && event != nil && depth > 0:
+			depth--
+		case name == "END" && strings.EqualFold(value, "VEVENT") && event != nil:
+			cal.Events = append(cal.Events, *event)
+			event = nil
+		case event != nil && depth == 0:
+			if err := event.set(name, params, value); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, n+1, err)
+			}
+		case event == nil && name == "PRODID":
+			cal.ProdID = value
+		case event == nil && name == "X-WR-CALNAME":
+			cal.Name = Unescape(value)
+		}
+	}
+	if inCalendar || event != nil {
+		return nil, fmt.Errorf("%w: unterminated component", ErrMalformed)
+	}
+	return cal, nil
+}
+
+func (ev *Event) set(name string, params map[string]string, value string) error {
+	switch name {
+	case "UID":
+		ev.UID = value
+	case "SUMMARY":
+		ev.Summary = Unescape(value)
+	case "DESCRIPTION":
+		ev.Description = Unescape(value)
+	case "RRULE":
+		ev.RRule = value
+	case "CATEGORIES":
+		for _, c := range splitList(value) {
+			ev.Categories = append(ev.Categories, Unescape(c))
+		}
+	case "DTSTART":
+		t, err := parseDate(params["VALUE"], value)
+		if err != nil {
+			return err
+		}
+		ev.Date = t
+	}
+	return nil
+}
+
+// parseDate reads a DATE or DATE-TIME value, keeping only the calendar date.
+func parseDate(valueType, value string) (time.Time, error) {
+	if valueType == "DATE" || len(value) == len(dateLayout) {
+		return time.Parse(dateLayout, value)
+	}
+	t, err := time.Parse("20060102T150405", strings.TrimSuffix(value, "Z"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// Unescape decodes a TEXT property value.
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// unfold joins folded content lines and drops blank ones.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// splitLine splits "NAME;PARAM=x:value" into its parts. Names and parameter
+// keys are upper-cased.
+func splitLine(line string) (string, map[string]string, string, bool) {
+	colon := -1
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+		if colon >= 0 {
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.ToUpper(strings.Trim(v, `"`))
+	}
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+// splitList splits a comma-separated TEXT list, honoring escaped commas.
+func splitList(s string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
@@ -0,0 +1,79 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Google Inc//Google Calendar 70.9054//EN\r\n" +
+	"X-WR-CALNAME:Contacts\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:19900315\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"UID:abc@google.com\r\n" +
+	"SUMMARY:Ana's birthday\\, with a very long title that the exporter has fol\r\n" +
+	" ded onto a second line\r\n" +
+	"CATEGORIES:BIRTHDAY,Family\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"DESCRIPTION:Ignored\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=America/Sao_Paulo:20260110T090000\r\n" +
+	"SUMMARY:Dentist\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	cal, err := Parse(strings.NewReader(sampleCalendar))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Contacts", cal.Name)
+	require.Len(t, cal.Events, 2)
+
+	ev := cal.Events[0]
+	assert.Equal(t, "abc@google.com", ev.UID)
+	assert.Equal(t, time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC), ev.Date)
+	assert.Equal(t, "FREQ=YEARLY", ev.RRule)
+	assert.Equal(t, "Ana's birthday, with a very long title that the exporter has folded onto a second line", ev.Summary)
+	assert.Equal(t, []string{"BIRTHDAY", "Family"}, ev.Categories)
+	assert.Empty(t, ev.Description, "VALARM properties must not leak into the event")
+
+	assert.Equal(t, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), cal.Events[1].Date)
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	in := &Calendar{ProdID: "-//Test//EN", Events: []Event{{
+		UID:     "1@test",
+		Date:    time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+		Summary: strings.Repeat("ção; ", 30),
+		RRule:   "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1",
+	}}}
+	var buf strings.Builder
+	require.NoError(t, in.Encode(&buf))
+
+	out, err := Parse(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	require.Len(t, out.Events, 1)
+	assert.Equal(t, in.Events[0].Summary, out.Events[0].Summary)
+	assert.Equal(t, in.Events[0].RRule, out.Events[0].RRule)
+	assert.Equal(t, in.Events[0].Date, out.Events[0].Date)
+}
+
+func TestParse_Malformed(t *testing.T) {
+	for _, input := range []string{
+		"not a calendar",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:x\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:yesterday\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		_, err := Parse(strings.NewReader(input))
+		assert.ErrorIs(t, err, ErrMalformed, input)
+	}
+}
@@ -5,9 +5,15 @@ import (
 	"net/http"
 )
 
-// errorResponse is the standard error payload.
-type errorResponse struct {
-	Error string `json:"error"`
+// Problem is an RFC 7807 problem details payload.
+type Problem struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Detail    string      `json:"detail,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
 }
 
 // JSON writes a JSON response with the given status code.
@@ -17,7 +23,9 @@ func JSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-// Error writes a JSON error response.
-func Error(w http.ResponseWriter, status int, message string) {
-	JSON(w, status, errorResponse{Error: message})
+// WriteProblem writes p as application/problem+json.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
 }
@@ -0,0 +1,244 @@
+package vcard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformed is returned when the input is not a vCard stream.
+var ErrMalformed = errors.New("vcard: malformed card")
+
+// Parse reads every VCARD in r. Property groups such as "item1." are
+// ignored, as are properties the package does not model.
+func Parse(r io.Reader) ([]Card, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		cards []Card
+		card  *Card
+	)
+	for n, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d", ErrMalformed, n+1)
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD") && card == nil:
+			card = &Card{}
+		case name == "END" && strings.EqualFold(value, "VCARD") && card != nil:
+			cards = append(cards, *card)
+			card = nil
+		case card == nil:
+			return nil, fmt.Errorf("%w: content outside VCARD", ErrMalformed)
+		default:
+			card.set(name, params, value)
+		}
+	}
+	if card != nil {
+		return nil, fmt.Errorf("%w: unterminated VCARD", ErrMalformed)
+	}
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("%w: no VCARD found", ErrMalformed)
+	}
+	return cards, nil
+}
+
+// set applies one property to the card. Dates that cannot be read are
+// dropped rather than failing the whole file, since address books export
+// free-text values such as "BDAY;VALUE=text:circa 1800".
+func (c *Card) set(name string, params map[string]string, value string) {
+	switch name {
+	case "UID":
+		c.UID = value
+	case "FN":
+		c.FormattedName = Unescape(value)
+	case "N":
+		parts := splitComponents(value, ';')
+		for len(parts) < 5 {
+			parts = append(parts, "")
+		}
+		c.Name = Name{
+			Family:     Unescape(parts[0]),
+			Given:      Unescape(parts[1]),
+			Additional: Unescape(parts[2]),
+			Prefix:     Unescape(parts[3]),
+			Suffix:     Unescape(parts[4]),
+		}
+	case "GENDER":
+		sex, _, _ := strings.Cut(value, ";")
+		c.Gender = strings.ToUpper(strings.TrimSpace(sex))
+	case "BDAY":
+		if d, ok := parseDate(params, value); ok {
+			c.Birthday = &d
+		}
+	case "ANNIVERSARY", "X-ANNIVERSARY":
+		if d, ok := parseDate(params, value); ok {
+			c.Anniversary = &d
+		}
+	case "CATEGORIES":
+		for _, cat := range splitComponents(value, ',') {
+			if cat = strings.TrimSpace(Unescape(cat)); cat != "" {
+				c.Categories = append(c.Categories, cat)
+			}
+		}
+	case "NOTE":
+		c.Note = Unescape(value)
+	}
+}
+
+// parseDate reads the date forms of both versions: "1990-03-15",
+// "19900315", the year-less "--0315" and "--03-15", and any of them
+// followed by a time. Apple's placeholder year counts as unknown.
+func parseDate(params map[string]string, value string) (Date, bool) {
+	if strings.EqualFold(params["VALUE"], "text") {
+		return Date{}, false
+	}
+	value, _, _ = strings.Cut(strings.TrimSpace(value), "T")
+
+	year := 0
+	rest := value
+	if !strings.HasPrefix(value, "--") {
+		if len(value) < 4 {
+			return Date{}, false
+		}
+		y, err := strconv.Atoi(value[:4])
+		if err != nil {
+			return Date{}, false
+		}
+		year, rest = y, value[4:]
+	} else {
+		rest = value[2:]
+	}
+	rest = strings.ReplaceAll(rest, "-", "")
+	if len(rest) != 4 {
+		return Date{}, false
+	}
+	month, err1 := strconv.Atoi(rest[:2])
+	day, err2 := strconv.Atoi(rest[2:])
+	if err1 != nil || err2 != nil {
+		return Date{}, false
+	}
+
+	// Validate the month and day against a leap year so February 29 is
+	// accepted when the year is unknown.
+	check := year
+	if check == 0 {
+		check = 2000
+	}
+	t := time.Date(check, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Month() != time.Month(month) || t.Day() != day {
+		return Date{}, false
+	}
+
+	if omit, ok := params["X-APPLE-OMIT-YEAR"]; ok && omit == strconv.Itoa(year) {
+		year = 0
+	}
+	return Date{Year: year, Month: time.Month(month), Day: day}, true
+}
+
+// Unescape decodes a TEXT value.
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// unfold joins folded content lines and drops blank ones.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// splitLine splits "group.NAME;PARAM=x:value" into its parts, dropping the
+// group. Names and parameter keys are upper-cased; parameter values keep
+// their case.
+func splitLine(line string) (string, map[string]string, string, bool) {
+	colon := -1
+	quoted := false
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	name := parts[0]
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		name = name[dot+1:]
+	}
+	return strings.ToUpper(name), params, value, true
+}
+
+// splitComponents splits a structured value on sep, honoring escapes.
+func splitComponents(s string, sep byte) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == sep {
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
@@ -0,0 +1,184 @@
+// Package vcard reads and writes the subset of vCard 3.0 (RFC 2426) and 4.0
+// (RFC 6350) the app needs: names, birthdays, anniversaries, gender and
+// categories.
+package vcard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Supported vCard versions.
+const (
+	Version3 = "3.0"
+	Version4 = "4.0"
+)
+
+const maxLineOctets = 75
+
+// appleOmitYear is the placeholder year Apple Contacts writes for dates
+// without a year, flagged with the X-APPLE-OMIT-YEAR parameter.
+const appleOmitYear = 1604
+
+// Card is a single VCARD.
+type Card struct {
+	UID           string
+	FormattedName string
+	Name          Name
+	// Gender is the sex component of GENDER: M, F, O, N or U.
+	Gender      string
+	Birthday    *Date
+	Anniversary *Date
+	Categories  []string
+	Note        string
+}
+
+// Name is the structured N property.
+type Name struct {
+	Family     string
+	Given      string
+	Additional string
+	Prefix     string
+	Suffix     string
+}
+
+// DisplayName returns FN, or the given and family names when FN is empty.
+func (c *Card) DisplayName() string {
+	if name := strings.TrimSpace(c.FormattedName); name != "" {
+		return name
+	}
+	parts := []string{c.Name.Prefix, c.Name.Given, c.Name.Additional, c.Name.Family, c.Name.Suffix}
+	var words []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			words = append(words, p)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Date is a calendar date whose year may be unknown.
+type Date struct {
+	Year  int // 0 when the card leaves the year out
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar date of t.
+func DateOf(t time.Time) Date {
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// HasYear reports whether the year is known.
+func (d Date) HasYear() bool {
+	return d.Year != 0
+}
+
+// Time returns the date at midnight UTC. It must only be called when the
+// year is known.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// Encode writes cards to w in the given version with CRLF line endings and
+// folded lines.
+func Encode(w io.Writer, version string, cards []Card) error {
+	if version != Version3 && version != Version4 {
+		return fmt.Errorf("vcard: unsupported version %q", version)
+	}
+	e := &encoder{w: bufio.NewWriter(w), version: version}
+	for i := range cards {
+		e.card(&cards[i])
+	}
+	return e.w.Flush()
+}
+
+// Escape encodes a TEXT value: backslashes, commas, semicolons and newlines
+// are escaped.
+func Escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+type encoder struct {
+	w       *bufio.Writer
+	version string
+}
+
+func (e *encoder) card(c *Card) {
+	e.line("BEGIN", "VCARD")
+	e.line("VERSION", e.version)
+	if c.UID != "" {
+		e.line("UID", c.UID)
+	}
+	e.line("FN", Escape(c.DisplayName()))
+	n := c.Name
+	e.line("N", strings.Join([]string{
+		Escape(n.Family), Escape(n.Given), Escape(n.Additional), Escape(n.Prefix), Escape(n.Suffix),
+	}, ";"))
+	if c.Gender != "" && e.version == Version4 {
+		e.line("GENDER", c.Gender)
+	}
+	if c.Birthday != nil {
+		e.date("BDAY", *c.Birthday)
+	}
+	if c.Anniversary != nil {
+		// vCard 3.0 has no ANNIVERSARY; X-ANNIVERSARY is what Android and
+		// most address books read instead.
+		name := "ANNIVERSARY"
+		if e.version == Version3 {
+			name = "X-ANNIVERSARY"
+		}
+		e.date(name, *c.Anniversary)
+	}
+	if len(c.Categories) > 0 {
+		categories := make([]string, len(c.Categories))
+		for i, cat := range c.Categories {
+			categories[i] = Escape(cat)
+		}
+		e.line("CATEGORIES", strings.Join(categories, ","))
+	}
+	if c.Note != "" {
+		e.line("NOTE", Escape(c.Note))
+	}
+	e.line("END", "VCARD")
+}
+
+// date writes a date property. Unknown years use the 4.0 "--MMDD" form, or
+// Apple's placeholder year in 3.0, which has no way to omit it.
+func (e *encoder) date(name string, d Date) {
+	switch {
+	case e.version == Version4 && d.HasYear():
+		e.line(name, fmt.Sprintf("%04d%02d%02d", d.Year, d.Month, d.Day))
+	case e.version == Version4:
+		e.line(name, fmt.Sprintf("--%02d%02d", d.Month, d.Day))
+	case d.HasYear():
+		e.line(name, fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day))
+	default:
+		e.line(fmt.Sprintf("%s;X-APPLE-OMIT-YEAR=%d", name, appleOmitYear),
+			fmt.Sprintf("%04d-%02d-%02d", appleOmitYear, d.Month, d.Day))
+	}
+}
+
+// line writes one content line, folding it at maxLineOctets without
+// splitting UTF-8 sequences.
+func (e *encoder) line(name, value string) {
+	s := name + ":" + value
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.w.WriteString(s[:cut])
+		e.w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1
+	}
+	e.w.WriteString(s)
+	e.w.WriteString("\r\n")
+}
@@ -0,0 +1,118 @@
+package vcard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleCards mixes an iOS 3.0 export with an Android-style 4.0 card.
+const sampleCards = "BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"PRODID:-//Apple Inc.//iPhone OS 17.0//EN\r\n" +
+	"N:Souza;Ana;Maria;;\r\n" +
+	"FN:Ana Maria Souza\r\n" +
+	"item1.TEL;type=pref:+55 11 99999-0000\r\n" +
+	"BDAY;VALUE=date:1990-03-15\r\n" +
+	"CATEGORIES:Family,Board games\\, cards\r\n" +
+	"NOTE:Allergic to nuts\\nLoves tea\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:4.0\r\n" +
+	"N:Silva;João;;;\r\n" +
+	"GENDER:M;\r\n" +
+	"BDAY:--0702\r\n" +
+	"ANNIVERSARY:20150620T000000Z\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"FN:Bob\r\n" +
+	"BDAY;X-APPLE-OMIT-YEAR=1604:1604-12-01\r\n" +
+	"X-ANNIVERSARY:2010-05-0\r\n" +
+	"END:VCARD\r\n"
+
+func TestParse(t *testing.T) {
+	cards, err := Parse(strings.NewReader(sampleCards))
+	require.NoError(t, err)
+	require.Len(t, cards, 3)
+
+	ana := cards[0]
+	assert.Equal(t, "Ana Maria Souza", ana.DisplayName())
+	assert.Equal(t, Name{Family: "Souza", Given: "Ana", Additional: "Maria"}, ana.Name)
+	assert.Equal(t, &Date{Year: 1990, Month: time.March, Day: 15}, ana.Birthday)
+	assert.Equal(t, []string{"Family", "Board games, cards"}, ana.Categories)
+	assert.Equal(t, "Allergic to nuts\nLoves tea", ana.Note)
+
+	joao := cards[1]
+	assert.Equal(t, "João Silva", joao.DisplayName(), "falls back to N without FN")
+	assert.Equal(t, "M", joao.Gender)
+	assert.Equal(t, &Date{Month: time.July, Day: 2}, joao.Birthday)
+	assert.False(t, joao.Birthday.HasYear())
+	assert.Equal(t, &Date{Year: 2015, Month: time.June, Day: 20}, joao.Anniversary)
+
+	bob := cards[2]
+	assert.Equal(t, &Date{Month: time.December, Day: 1}, bob.Birthday, "Apple's omitted year is unknown")
+	assert.Nil(t, bob.Anniversary, "unreadable dates are dropped")
+}
+
+func TestParse_Malformed(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"just some notes",
+		"BEGIN:VCARD\r\nFN:Ana\r\n",
+		"FN:Ana\r\n",
+	} {
+		_, err := Parse(strings.NewReader(in))
+		assert.ErrorIs(t, err, ErrMalformed, in)
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	cards := []Card{{
+		UID:         "urn:uuid:1",
+		Name:        Name{Family: "Souza; Filho", Given: "Ana"},
+		Gender:      "F",
+		Birthday:    &Date{Year: 2000, Month: time.February, Day: 29},
+		Anniversary: &Date{Month: time.June, Day: 20},
+		Categories:  []string{"reading", "board, games"},
+		Note:        strings.Repeat("ção ", 40),
+	}}
+
+	for _, version := range []string{Version3, Version4} {
+		var buf strings.Builder
+		require.NoError(t, Encode(&buf, version, cards))
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), maxLineOctets, version)
+		}
+
+		parsed, err := Parse(strings.NewReader(buf.String()))
+		require.NoError(t, err)
+		require.Len(t, parsed, 1)
+
+		got := parsed[0]
+		assert.Equal(t, "Ana Souza; Filho", got.FormattedName, version)
+		assert.Equal(t, cards[0].Name, got.Name, version)
+		assert.Equal(t, cards[0].Birthday, got.Birthday, version)
+		assert.Equal(t, cards[0].Anniversary, got.Anniversary, version)
+		assert.Equal(t, cards[0].Categories, got.Categories, version)
+		assert.Equal(t, cards[0].Note, got.Note, version)
+	}
+}
+
+func TestEncode_VersionSpecificProperties(t *testing.T) {
+	cards := []Card{{FormattedName: "Bob", Gender: "M", Anniversary: &Date{Year: 2015, Month: time.June, Day: 20}}}
+
+	var v3, v4 strings.Builder
+	require.NoError(t, Encode(&v3, Version3, cards))
+	require.NoError(t, Encode(&v4, Version4, cards))
+
+	assert.Contains(t, v3.String(), "\r\nX-ANNIVERSARY:2015-06-20\r\n")
+	assert.NotContains(t, v3.String(), "GENDER")
+	assert.Contains(t, v4.String(), "\r\nANNIVERSARY:20150620\r\n")
+	assert.Contains(t, v4.String(), "\r\nGENDER:M\r\n")
+
+	assert.Error(t, Encode(&v3, "2.1", cards))
+}
@@ -0,0 +1,314 @@
+// Package xlsx reads the first worksheet of an Office Open XML spreadsheet
+// as text rows and writes single-sheet workbooks. Only cell values are
+// supported; formulas are read from their cached results and styles are
+// consulted solely to recognize dates.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformed is returned when the input is not a readable workbook.
+var ErrMalformed = errors.New("xlsx: malformed workbook")
+
+// DateLayout is the format date cells are returned in.
+const DateLayout = "2006-01-02"
+
+// maxCellsPerRow bounds the column index a cell reference may name, so a
+// hostile "XFD1" reference cannot allocate huge rows.
+const maxCellsPerRow = 16384
+
+// excelEpoch is day zero of the 1900 date system, shifted for Excel's
+// fictitious 1900-02-29.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// IsWorkbook reports whether data starts like a ZIP archive, as every
+// .xlsx file does.
+func IsWorkbook(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// ReadRows returns the cell values of the first worksheet, one slice per
+// row. Empty rows are kept so row numbers match the sheet; trailing empty
+// cells are dropped.
+func ReadRows(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+	var dateStyles map[int]bool
+	if f, ok := files["xl/styles.xml"]; ok {
+		if dateStyles, err = readDateStyles(f); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrMalformed, sheetPath)
+	}
+	return readSheet(f, shared, dateStyles)
+}
+
+// firstSheetPath resolves the first sheet of the workbook through its
+// relationships, falling back to the conventional location.
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var workbook struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	wf, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: missing xl/workbook.xml", ErrMalformed)
+	}
+	if err := decodeFile(wf, &workbook); err != nil {
+		return "", err
+	}
+	rf, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok || len(workbook.Sheets) == 0 {
+		return fallback, nil
+	}
+	if err := decodeFile(rf, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// richText is a string item that is either plain (<t>) or a list of runs.
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt richText) String() string {
+	if len(rt.Runs) == 0 {
+		return rt.T
+	}
+	var b strings.Builder
+	for _, r := range rt.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+	if err := decodeFile(f, &sst); err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		strs[i] = item.String()
+	}
+	return strs, nil
+}
+
+// readDateStyles returns the cell style indexes whose number format shows
+// a date.
+func readDateStyles(f *zip.File) (map[int]bool, error) {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := decodeFile(f, &styles); err != nil {
+		return nil, err
+	}
+
+	custom := make(map[int]string, len(styles.NumFmts))
+	for _, nf := range styles.NumFmts {
+		custom[nf.ID] = nf.Code
+	}
+	dates := make(map[int]bool)
+	for i, xf := range styles.CellXfs {
+		if code, ok := custom[xf.NumFmtID]; ok {
+			dates[i] = isDateFormat(code)
+		} else {
+			dates[i] = isBuiltInDateFormat(xf.NumFmtID)
+		}
+	}
+	return dates, nil
+}
+
+func isBuiltInDateFormat(id int) bool {
+	return (id >= 14 && id <= 17) || id == 22
+}
+
+// isDateFormat reports whether a custom format code renders a date: it has
+// day, month or year tokens outside quoted literals and color or locale
+// sections.
+func isDateFormat(code string) bool {
+	inQuote, inBracket := false, false
+	for _, r := range strings.ToLower(code) {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '[':
+			inBracket = true
+		case r == ']':
+			inBracket = false
+		case inBracket:
+		case r == 'd' || r == 'm' || r == 'y':
+			return true
+		}
+	}
+	return false
+}
+
+func readSheet(f *zip.File, shared []string, dateStyles map[int]bool) ([][]string, error) {
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Style  int      `xml:"s,attr"`
+				Value  string   `xml:"v"`
+				Inline richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeFile(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		// Rows may be sparse; pad with empty rows to keep numbering.
+		for row.R > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+		var cells []string
+		for i, c := range row.Cells {
+			col := i
+			if c.Ref != "" {
+				var err error
+				if col, err = columnIndex(c.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(cells) < col {
+				cells = append(cells, "")
+			}
+
+			var value string
+			switch c.Type {
+			case "s":
+				n, err := strconv.Atoi(c.Value)
+				if err != nil || n < 0 || n >= len(shared) {
+					return nil, fmt.Errorf("%w: bad shared string in %s", ErrMalformed, c.Ref)
+				}
+				value = shared[n]
+			case "inlineStr":
+				value = c.Inline.String()
+			case "b":
+				value = map[string]string{"0": "FALSE", "1": "TRUE"}[c.Value]
+			case "", "n":
+				value = c.Value
+				if dateStyles[c.Style] {
+					if d, ok := serialDate(c.Value); ok {
+						value = d
+					}
+				}
+			default: // str (formula result), e (error)
+				value = c.Value
+			}
+			if col < len(cells) {
+				cells[col] = value
+			} else {
+				cells = append(cells, value)
+			}
+		}
+		for len(cells) > 0 && strings.TrimSpace(cells[len(cells)-1]) == "" {
+			cells = cells[:len(cells)-1]
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// columnIndex returns the zero-based column of a cell reference like "AB12".
+func columnIndex(ref string) (int, error) {
+	col := 0
+	i := 0
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A'+1)
+	}
+	if i == 0 || col > maxCellsPerRow {
+		return 0, fmt.Errorf("%w: bad cell reference %q", ErrMalformed, ref)
+	}
+	return col - 1, nil
+}
+
+// serialDate converts a 1900-system serial day number to DateLayout.
+func serialDate(v string) (string, bool) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 1 || f > 2958465 {
+		return "", false
+	}
+	return excelEpoch.AddDate(0, 0, int(math.Floor(f))).Format(DateLayout), true
+}
+
+func decodeFile(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMalformed, f.Name, err)
+	}
+	return nil
+}
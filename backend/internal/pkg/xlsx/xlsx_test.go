@@ -0,0 +1,97 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildWorkbook zips the given parts into an .xlsx file.
+func buildWorkbook(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		f.Write([]byte(content))
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestReadRows(t *testing.T) {
+	data := buildWorkbook(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="People" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId3" Target="worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>Name</t></si><si><t>Birthday</t></si>` +
+			`<si><r><t>Ana </t></r><r><t>Souza</t></r></si><si><t>Keywords</t></si></sst>`,
+		"xl/styles.xml": `<styleSheet><numFmts><numFmt numFmtId="164" formatCode="dd/mm/yyyy"/>` +
+			`<numFmt numFmtId="165" formatCode="&quot;Day&quot; 0"/></numFmts>` +
+			`<cellXfs><xf numFmtId="0"/><xf numFmtId="164"/><xf numFmtId="14"/><xf numFmtId="165"/></cellXfs></styleSheet>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>Wrong sheet</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="s"><v>3</v></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2" s="1"><v>32947</v></c><c r="C2" s="3"><v>7</v></c>` +
+			`<c r="D2" t="inlineStr"><is><t>books, games</t></is></c></row>` +
+			`<row r="4"><c r="A4" t="str"><v>Bob</v></c><c r="B4" s="2"><v>31230.75</v></c><c r="E4" t="inlineStr"><is><t> </t></is></c></row>` +
+			`</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadRows(data)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Birthday", "", "Keywords"},
+		{"Ana Souza", "1990-03-15", "7", "books, games"},
+		nil,
+		{"Bob", "1985-07-02"},
+	}, rows)
+}
+
+func TestReadRows_Malformed(t *testing.T) {
+	_, err := ReadRows([]byte("name,birthday\nAna,1990-03-15\n"))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = ReadRows(buildWorkbook(t, map[string]string{"word/document.xml": "<document/>"}))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = ReadRows(buildWorkbook(t, map[string]string{
+		"xl/workbook.xml":          `<workbook/>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>5</v></c></row></sheetData></worksheet>`,
+	}))
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, "Recipients & co", [][]any{
+		{"name", "age", "budget", "notes"},
+		{"João <Silva>", 36, 49.9, "line one\nline two"},
+		{"Ana", 0, 0.0, ""},
+	}))
+	assert.True(t, IsWorkbook(buf.Bytes()))
+
+	rows, err := ReadRows(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "age", "budget", "notes"},
+		{"João <Silva>", "36", "49.9", "line one\nline two"},
+		{"Ana", "0", "0"},
+	}, rows)
+}
+
+func TestColumnName(t *testing.T) {
+	for col, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, columnName(col))
+		index, err := columnIndex(name + "1")
+		require.NoError(t, err)
+		assert.Equal(t, col, index)
+	}
+}
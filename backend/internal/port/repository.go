@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vsssp/birthday-app/backend/internal/domain"
@@ -31,12 +32,216 @@ type RefreshTokenRepository interface {
 	DeleteExpired(ctx context.Context) error
 }
 
-// RecipientRepository defines the data access methods for recipients.
+// RecipientRepository defines the data access methods for recipients. List
+// methods taking ownerIDs cover the recipients of all of those users, so a
+// household member sees everyone's.
 type RecipientRepository interface {
 	Create(ctx context.Context, recipient *domain.Recipient) error
+	CreateMany(ctx context.Context, recipients []domain.Recipient) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Recipient, error)
-	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Recipient, error)
+	ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error)
+	ListPage(ctx context.Context, ownerIDs []uuid.UUID, q domain.RecipientQuery) ([]domain.Recipient, *domain.RecipientCursor, error)
+	Search(ctx context.Context, ownerIDs []uuid.UUID, term string, limit int) ([]domain.RecipientSearchResult, error)
 	Update(ctx context.Context, recipient *domain.Recipient) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	BulkDelete(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+	BulkDelete(ctx context.Context, ids []uuid.UUID) error
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Recipient, error)
+	ListDeleted(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error)
+	Restore(ctx context.Context, id uuid.UUID, updatedAt time.Time) (int, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PreferencesRepository defines the data access methods for user preferences.
+type PreferencesRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserPreferences, error)
+	Upsert(ctx context.Context, prefs *domain.UserPreferences) error
+}
+
+// RecipientHistoryRepository defines the data access methods for recipient versions.
+type RecipientHistoryRepository interface {
+	Create(ctx context.Context, version *domain.RecipientVersion) error
+	CreateMany(ctx context.Context, versions []domain.RecipientVersion) error
+	ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.RecipientVersion, error)
+	GetByVersion(ctx context.Context, recipientID uuid.UUID, version int) (*domain.RecipientVersion, error)
+}
+
+// KeywordRepository defines the data access methods for the interest taxonomy.
+type KeywordRepository interface {
+	ListInterests(ctx context.Context) ([]domain.Interest, error)
+}
+
+// GroupRepository defines the data access methods for recipient groups.
+type GroupRepository interface {
+	Create(ctx context.Context, group *domain.Group) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Group, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Group, error)
+	Update(ctx context.Context, group *domain.Group) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddMembers(ctx context.Context, groupID uuid.UUID, recipientIDs []uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, recipientID uuid.UUID) error
+	ReassignMembers(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+	ListMemberships(ctx context.Context, userID uuid.UUID) ([]domain.GroupMembership, error)
+}
+
+// OccasionRepository defines the data access methods for occasions.
+type OccasionRepository interface {
+	Create(ctx context.Context, occasion *domain.Occasion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Occasion, error)
+	ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.Occasion, error)
+	ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Occasion, error)
+	Update(ctx context.Context, occasion *domain.Occasion) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+}
+
+// GiftRepository defines the data access methods for gift history.
+type GiftRepository interface {
+	Create(ctx context.Context, gift *domain.GiftRecord) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.GiftRecord, error)
+	ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.GiftRecord, error)
+	ListByOwners(ctx context.Context, ownerIDs []uuid.UUID, from, to domain.Date) ([]domain.GiftRecord, error)
+	Update(ctx context.Context, gift *domain.GiftRecord) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+}
+
+// BudgetRepository defines the data access methods for yearly budgets.
+type BudgetRepository interface {
+	Upsert(ctx context.Context, budget *domain.YearlyBudget) error
+	Get(ctx context.Context, userID uuid.UUID, year int) (*domain.YearlyBudget, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.YearlyBudget, error)
+	Delete(ctx context.Context, userID uuid.UUID, year int) error
+}
+
+// ExchangeRateRepository defines the data access methods for the exchange-rate table.
+type ExchangeRateRepository interface {
+	List(ctx context.Context) ([]domain.ExchangeRate, error)
+	// ReplaceAll swaps the whole table for rates.
+	ReplaceAll(ctx context.Context, rates []domain.ExchangeRate) error
+}
+
+// IdeaRepository defines the data access methods for gift ideas.
+type IdeaRepository interface {
+	Create(ctx context.Context, idea *domain.GiftIdea) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.GiftIdea, error)
+	ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.GiftIdea, error)
+	Update(ctx context.Context, idea *domain.GiftIdea) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+}
+
+// GroupGiftRepository defines the data access methods for group gifts and
+// their contributors.
+type GroupGiftRepository interface {
+	Create(ctx context.Context, gift *domain.GroupGift) error
+	// GetByID loads a group gift with its contributors.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.GroupGift, error)
+	// ListForUser returns the group gifts a user organizes or was invited
+	// to by email, with their contributors.
+	ListForUser(ctx context.Context, userID uuid.UUID, email string) ([]domain.GroupGift, error)
+	Update(ctx context.Context, gift *domain.GroupGift) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddContributor(ctx context.Context, contributor *domain.GroupGiftContributor) error
+	UpdateContributor(ctx context.Context, contributor *domain.GroupGiftContributor) error
+	DeleteContributor(ctx context.Context, id uuid.UUID) error
+	Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+}
+
+// ExchangeRepository defines the data access methods for gift exchanges,
+// their participants and exclusions.
+type ExchangeRepository interface {
+	Create(ctx context.Context, exchange *domain.GiftExchange) error
+	// GetByID loads an exchange with its participants and exclusions.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.GiftExchange, error)
+	// ListForUser returns the exchanges a user organizes or takes part in
+	// by email, with their participants and exclusions.
+	ListForUser(ctx context.Context, userID uuid.UUID, email string) ([]domain.GiftExchange, error)
+	Update(ctx context.Context, exchange *domain.GiftExchange) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddParticipant(ctx context.Context, participant *domain.ExchangeParticipant) error
+	DeleteParticipant(ctx context.Context, id uuid.UUID) error
+	AddExclusion(ctx context.Context, exclusion *domain.ExchangeExclusion) error
+	DeleteExclusion(ctx context.Context, id uuid.UUID) error
+	// SaveAssignments stores each participant's receiver and link token.
+	SaveAssignments(ctx context.Context, participants []domain.ExchangeParticipant) error
+	// GetParticipantByToken finds the participant holding a secret link.
+	GetParticipantByToken(ctx context.Context, token string) (*domain.ExchangeParticipant, error)
+}
+
+// WishlistRepository defines the data access methods for public wishlists
+// and the claims visitors make on their items.
+type WishlistRepository interface {
+	// Create inserts a wishlist with its items.
+	Create(ctx context.Context, wishlist *domain.Wishlist) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Wishlist, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Wishlist, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Wishlist, error)
+	// Update saves a wishlist's details and replaces its items, dropping
+	// the claims on items that were removed.
+	Update(ctx context.Context, wishlist *domain.Wishlist) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+	// CreateClaim inserts a claim. An item that is already claimed yields
+	// domain.ErrAlreadyExists.
+	CreateClaim(ctx context.Context, claim *domain.WishlistClaim) error
+	GetClaimByToken(ctx context.Context, token string) (*domain.WishlistClaim, error)
+	DeleteClaim(ctx context.Context, id uuid.UUID) error
+	// ClaimedItemIDs returns which of a wishlist's items are claimed.
+	ClaimedItemIDs(ctx context.Context, wishlistID uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// HouseholdRepository defines the data access methods for households, their
+// members and pending invitations. Households are loaded with their members.
+type HouseholdRepository interface {
+	// Create inserts a household with its members.
+	Create(ctx context.Context, household *domain.Household) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error)
+	// GetByUserID returns the household userID belongs to, or nil.
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Household, error)
+	Update(ctx context.Context, household *domain.Household) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// AddMember inserts a membership. A user who already belongs to a
+	// household yields domain.ErrAlreadyExists.
+	AddMember(ctx context.Context, member *domain.HouseholdMember) error
+	UpdateMemberRole(ctx context.Context, householdID, userID uuid.UUID, role domain.HouseholdRole) error
+	RemoveMember(ctx context.Context, householdID, userID uuid.UUID) error
+	// CreateInvitation inserts an invitation. Inviting an email that already
+	// has a pending invitation to the household yields domain.ErrAlreadyExists.
+	CreateInvitation(ctx context.Context, invitation *domain.HouseholdInvitation) error
+	ListInvitations(ctx context.Context, householdID uuid.UUID) ([]domain.HouseholdInvitation, error)
+	GetInvitationByToken(ctx context.Context, token string) (*domain.HouseholdInvitation, error)
+	DeleteInvitation(ctx context.Context, id uuid.UUID) error
+}
+
+// HolidaySubscriptionRepository defines the data access methods for holiday subscriptions.
+type HolidaySubscriptionRepository interface {
+	Create(ctx context.Context, subscription *domain.HolidaySubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.HolidaySubscription, error)
+	ListByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]domain.HolidaySubscription, error)
+	ListByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.HolidaySubscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reassign(ctx context.Context, fromRecipientID, toRecipientID uuid.UUID) error
+}
+
+// CalendarFeedRepository defines the data access methods for iCalendar feed tokens.
+type CalendarFeedRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error)
+	Save(ctx context.Context, feed *domain.CalendarFeed) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// ImportRepository defines the data access methods for import previews.
+type ImportRepository interface {
+	Create(ctx context.Context, session *domain.ImportSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportSession, error)
+	UpdateItems(ctx context.Context, session *domain.ImportSession) (bool, error)
+	MarkApplied(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Transactor runs a function inside a database transaction. Repository calls
+// made with the context passed to fn take part in the transaction.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -24,11 +24,223 @@ type UserService interface {
 // RecipientService defines the business logic for recipient operations.
 type RecipientService interface {
 	Create(ctx context.Context, userID uuid.UUID, req domain.CreateRecipientRequest) (*domain.Recipient, error)
+	CreateMany(ctx context.Context, userID uuid.UUID, reqs []domain.CreateRecipientRequest) ([]domain.Recipient, error)
 	GetByID(ctx context.Context, userID, recipientID uuid.UUID) (*domain.Recipient, error)
-	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Recipient, error)
+	List(ctx context.Context, userID uuid.UUID, q domain.RecipientQuery) (*domain.RecipientPage, error)
+	Search(ctx context.Context, userID uuid.UUID, term string, limit int) ([]domain.RecipientSearchResult, error)
 	Update(ctx context.Context, userID, recipientID uuid.UUID, req domain.UpdateRecipientRequest) (*domain.Recipient, error)
 	Delete(ctx context.Context, userID, recipientID uuid.UUID) error
 	BulkDelete(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+	ListTrash(ctx context.Context, userID uuid.UUID) ([]domain.Recipient, error)
+	Restore(ctx context.Context, userID, recipientID uuid.UUID) (*domain.Recipient, error)
+	History(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.RecipientVersion, error)
+	Revert(ctx context.Context, userID, recipientID uuid.UUID, version int) (*domain.Recipient, error)
+}
+
+// PermissionService decides who may see and change a recipient and the
+// records hanging off it. A recipient's owner may do anything with it; other
+// members of the owner's household get what their role grants.
+type PermissionService interface {
+	// Recipient loads a live recipient and checks that userID holds perm on it.
+	Recipient(ctx context.Context, userID, recipientID uuid.UUID, perm domain.Permission) (*domain.Recipient, error)
+	// Authorize checks that userID holds perm on an already loaded recipient,
+	// including one in the trash.
+	Authorize(ctx context.Context, userID uuid.UUID, recipient *domain.Recipient, perm domain.Permission) error
+	// RecipientOwners returns the users whose recipients userID can see:
+	// userID itself and the other members of its household.
+	RecipientOwners(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// HouseholdService defines the business logic for households. Only the
+// owner manages members and invitations.
+type HouseholdService interface {
+	Create(ctx context.Context, userID uuid.UUID, req domain.CreateHouseholdRequest) (*domain.Household, error)
+	Get(ctx context.Context, userID uuid.UUID) (*domain.Household, error)
+	Update(ctx context.Context, userID uuid.UUID, req domain.UpdateHouseholdRequest) (*domain.Household, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+	Leave(ctx context.Context, userID uuid.UUID) error
+	Invite(ctx context.Context, userID uuid.UUID, req domain.InviteHouseholdMemberRequest) (*domain.HouseholdInvitation, error)
+	RevokeInvitation(ctx context.Context, userID, invitationID uuid.UUID) error
+	// Accept joins the household of the invitation sent with token. The
+	// invitation must be addressed to userID's email.
+	Accept(ctx context.Context, userID uuid.UUID, token string) (*domain.Household, error)
+	UpdateMember(ctx context.Context, userID, memberID uuid.UUID, req domain.UpdateHouseholdMemberRequest) (*domain.Household, error)
+	RemoveMember(ctx context.Context, userID, memberID uuid.UUID) error
+}
+
+// DuplicateService defines the business logic for finding and merging
+// duplicate recipients.
+type DuplicateService interface {
+	Find(ctx context.Context, userID uuid.UUID, limit int) ([]domain.DuplicatePair, error)
+	Merge(ctx context.Context, userID uuid.UUID, req domain.MergeRecipientsRequest) (*domain.Recipient, error)
+}
+
+// PreferencesService defines the business logic for user preferences.
+type PreferencesService interface {
+	Get(ctx context.Context, userID uuid.UUID) (*domain.UserPreferences, error)
+	Update(ctx context.Context, userID uuid.UUID, req domain.UpdatePreferencesRequest) (*domain.UserPreferences, error)
+}
+
+// KeywordService defines the business logic for the interest taxonomy.
+type KeywordService interface {
+	Suggest(ctx context.Context, userID uuid.UUID, prefix, locale string, limit int) ([]domain.KeywordSuggestion, error)
+	Canonicalize(ctx context.Context, keywords []string) ([]string, error)
+}
+
+// GroupService defines the business logic for recipient groups.
+type GroupService interface {
+	Create(ctx context.Context, userID uuid.UUID, req domain.GroupRequest) (*domain.Group, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.Group, error)
+	GetByID(ctx context.Context, userID, groupID uuid.UUID) (*domain.Group, error)
+	Update(ctx context.Context, userID, groupID uuid.UUID, req domain.GroupRequest) (*domain.Group, error)
+	Delete(ctx context.Context, userID, groupID uuid.UUID) error
+	AddMembers(ctx context.Context, userID, groupID uuid.UUID, req domain.GroupMembersRequest) (*domain.Group, error)
+	RemoveMember(ctx context.Context, userID, groupID, recipientID uuid.UUID) error
+}
+
+// OccasionService defines the business logic for recipient occasions.
+type OccasionService interface {
+	Create(ctx context.Context, userID, recipientID uuid.UUID, req domain.CreateOccasionRequest) (*domain.Occasion, error)
+	List(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.Occasion, error)
+	GetByID(ctx context.Context, userID, recipientID, occasionID uuid.UUID) (*domain.Occasion, error)
+	Update(ctx context.Context, userID, recipientID, occasionID uuid.UUID, req domain.UpdateOccasionRequest) (*domain.Occasion, error)
+	Delete(ctx context.Context, userID, recipientID, occasionID uuid.UUID) error
+}
+
+// GiftService defines the business logic for a recipient's gift history.
+type GiftService interface {
+	Create(ctx context.Context, userID, recipientID uuid.UUID, req domain.CreateGiftRequest) (*domain.GiftRecord, error)
+	List(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.GiftRecord, error)
+	GetByID(ctx context.Context, userID, recipientID, giftID uuid.UUID) (*domain.GiftRecord, error)
+	Update(ctx context.Context, userID, recipientID, giftID uuid.UUID, req domain.UpdateGiftRequest) (*domain.GiftRecord, error)
+	Delete(ctx context.Context, userID, recipientID, giftID uuid.UUID) error
+}
+
+// IdeaService defines the business logic for a recipient's gift idea board.
+type IdeaService interface {
+	Create(ctx context.Context, userID, recipientID uuid.UUID, req domain.CreateIdeaRequest) (*domain.GiftIdea, error)
+	Promote(ctx context.Context, userID, recipientID uuid.UUID, req domain.PromoteSuggestionRequest) (*domain.GiftIdea, error)
+	List(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.GiftIdea, error)
+	GetByID(ctx context.Context, userID, recipientID, ideaID uuid.UUID) (*domain.GiftIdea, error)
+	Update(ctx context.Context, userID, recipientID, ideaID uuid.UUID, req domain.UpdateIdeaRequest) (*domain.GiftIdea, error)
+	Transition(ctx context.Context, userID, recipientID, ideaID uuid.UUID, req domain.TransitionIdeaRequest) (*domain.GiftIdea, error)
+	Delete(ctx context.Context, userID, recipientID, ideaID uuid.UUID) error
+}
+
+// GroupGiftService defines the business logic for pooled gifts. The
+// organizer manages a gift; invited contributors can view it and change
+// their own pledge.
+type GroupGiftService interface {
+	Create(ctx context.Context, userID uuid.UUID, req domain.CreateGroupGiftRequest) (*domain.GroupGift, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.GroupGift, error)
+	GetByID(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGift, error)
+	Update(ctx context.Context, userID, groupGiftID uuid.UUID, req domain.UpdateGroupGiftRequest) (*domain.GroupGift, error)
+	Delete(ctx context.Context, userID, groupGiftID uuid.UUID) error
+	Invite(ctx context.Context, userID, groupGiftID uuid.UUID, req domain.InviteContributorRequest) (*domain.GroupGiftContributor, error)
+	UpdateContribution(ctx context.Context, userID, groupGiftID, contributorID uuid.UUID, req domain.UpdateContributionRequest) (*domain.GroupGiftContributor, error)
+	RemoveContributor(ctx context.Context, userID, groupGiftID, contributorID uuid.UUID) error
+	Progress(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGiftProgress, error)
+	Settle(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGift, error)
+}
+
+// ExchangeService defines the business logic for Secret Santa style gift
+// exchanges. Each participant can only ever see their own assignment.
+type ExchangeService interface {
+	Create(ctx context.Context, userID uuid.UUID, req domain.CreateExchangeRequest) (*domain.GiftExchange, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.GiftExchange, error)
+	GetByID(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, error)
+	Update(ctx context.Context, userID, exchangeID uuid.UUID, req domain.UpdateExchangeRequest) (*domain.GiftExchange, error)
+	Delete(ctx context.Context, userID, exchangeID uuid.UUID) error
+	AddParticipant(ctx context.Context, userID, exchangeID uuid.UUID, req domain.AddParticipantRequest) (*domain.ExchangeParticipant, error)
+	RemoveParticipant(ctx context.Context, userID, exchangeID, participantID uuid.UUID) error
+	AddExclusion(ctx context.Context, userID, exchangeID uuid.UUID, req domain.AddExclusionRequest) (*domain.ExchangeExclusion, error)
+	RemoveExclusion(ctx context.Context, userID, exchangeID, exclusionID uuid.UUID) error
+	Draw(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, error)
+	Verify(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.ExchangeVerification, error)
+	Assignment(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.ExchangeAssignment, error)
+	AssignmentByToken(ctx context.Context, token string) (*domain.ExchangeAssignment, error)
+}
+
+// WishlistService defines the business logic for public wishlists. Owners
+// manage them with their account; anyone with the slug can view one and
+// claim items, and claims are never shown to the owner.
+type WishlistService interface {
+	Create(ctx context.Context, userID uuid.UUID, req domain.CreateWishlistRequest) (*domain.Wishlist, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.Wishlist, error)
+	GetByID(ctx context.Context, userID, wishlistID uuid.UUID) (*domain.Wishlist, error)
+	Update(ctx context.Context, userID, wishlistID uuid.UUID, req domain.UpdateWishlistRequest) (*domain.Wishlist, error)
+	Delete(ctx context.Context, userID, wishlistID uuid.UUID) error
+	RotateSlug(ctx context.Context, userID, wishlistID uuid.UUID) (*domain.Wishlist, error)
+	// View returns the wishlist published at slug. viewerID is uuid.Nil for
+	// anonymous visitors.
+	View(ctx context.Context, slug string, viewerID uuid.UUID) (*domain.PublicWishlist, error)
+	Claim(ctx context.Context, slug string, viewerID, itemID uuid.UUID, req domain.ClaimWishlistItemRequest) (*domain.WishlistClaim, error)
+	ReleaseClaim(ctx context.Context, slug, token string) error
+}
+
+// BudgetService defines the business logic for yearly gifting budgets.
+type BudgetService interface {
+	List(ctx context.Context, userID uuid.UUID) ([]domain.YearlyBudget, error)
+	Set(ctx context.Context, userID uuid.UUID, year int, req domain.YearlyBudgetRequest) (*domain.YearlyBudget, error)
+	Delete(ctx context.Context, userID uuid.UUID, year int) error
+}
+
+// ReportService defines the business logic for spending reports.
+type ReportService interface {
+	Spending(ctx context.Context, userID uuid.UUID, year int) (*domain.SpendingReport, error)
+}
+
+// ExchangeRateService defines the business logic for currency conversion.
+type ExchangeRateService interface {
+	Rates(ctx context.Context) (*domain.ExchangeRates, error)
+	// Load replaces the rate table with the rates read from a CSV file and
+	// returns how many were loaded.
+	Load(ctx context.Context, data []byte) (int, error)
+}
+
+// SuggestionService defines the business logic for gift suggestions.
+type SuggestionService interface {
+	Suggest(ctx context.Context, userID, recipientID uuid.UUID, limit int, locale string) ([]domain.GiftSuggestion, error)
+}
+
+// HolidayService defines the business logic for the holiday calendar and
+// recipient holiday subscriptions.
+type HolidayService interface {
+	List(ctx context.Context, userID uuid.UUID, country string, year int, locale string) ([]domain.HolidayDate, error)
+	Subscribe(ctx context.Context, userID, recipientID uuid.UUID, req domain.HolidaySubscriptionRequest) (*domain.HolidaySubscription, error)
+	ListSubscriptions(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.HolidaySubscription, error)
+	Unsubscribe(ctx context.Context, userID, recipientID, subscriptionID uuid.UUID) error
+}
+
+// CalendarService defines the business logic for the iCalendar subscription feed.
+type CalendarService interface {
+	GetFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+	RotateFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+	DisableFeed(ctx context.Context, userID uuid.UUID) error
+	Render(ctx context.Context, token string) ([]byte, error)
+}
+
+// ImportService defines the business logic for previewing and confirming bulk imports.
+type ImportService interface {
+	PreviewICS(ctx context.Context, userID uuid.UUID, data []byte) (*domain.ImportSession, error)
+	PreviewVCard(ctx context.Context, userID uuid.UUID, data []byte) (*domain.ImportSession, error)
+	PreviewSpreadsheet(ctx context.Context, userID uuid.UUID, data []byte) (*domain.ImportSession, error)
+	MapColumns(ctx context.Context, userID, importID uuid.UUID, req domain.MapColumnsRequest) (*domain.ImportSession, error)
+	Get(ctx context.Context, userID, importID uuid.UUID) (*domain.ImportSession, error)
+	Confirm(ctx context.Context, userID, importID uuid.UUID, req domain.ConfirmImportRequest) (*domain.ImportResult, error)
+}
+
+// ExportService defines the business logic for exporting recipients to files.
+type ExportService interface {
+	VCard(ctx context.Context, userID uuid.UUID, version string) ([]byte, error)
+	CSV(ctx context.Context, userID uuid.UUID) ([]byte, error)
+	XLSX(ctx context.Context, userID uuid.UUID) ([]byte, error)
+}
+
+// UpcomingService defines the business logic for the upcoming feed and reminders.
+type UpcomingService interface {
+	Upcoming(ctx context.Context, userID uuid.UUID, days int) ([]domain.UpcomingOccasion, error)
+	Reminders(ctx context.Context, userID uuid.UUID) ([]domain.Reminder, error)
 }
 
 // SocialVerifier defines the interface for verifying social login tokens.
@@ -36,3 +248,10 @@ type SocialVerifier interface {
 	VerifyGoogleToken(ctx context.Context, idToken string) (email, name, sub string, err error)
 	VerifyAppleToken(ctx context.Context, identityToken string) (email, sub string, err error)
 }
+
+// Notifier delivers notifications. Delivery is best effort: implementations
+// deal with their own failures, so callers never fail because a message
+// could not be sent.
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification)
+}
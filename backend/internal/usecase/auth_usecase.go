@@ -2,7 +2,7 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,9 +13,9 @@ import (
 )
 
 var (
-	ErrEmailAlreadyExists = errors.New("email already registered")
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrEmailAlreadyExists = domain.NewError(http.StatusConflict, "email_already_exists", "Email already registered")
+	ErrInvalidCredentials = domain.NewError(http.StatusUnauthorized, "invalid_credentials", "Invalid email or password")
+	ErrInvalidToken       = domain.NewError(http.StatusUnauthorized, "invalid_token", "Invalid or expired token")
 )
 
 // AuthUseCase implements port.AuthService.
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var ErrBudgetNotFound = domain.NewError(http.StatusNotFound, "budget_not_found", "No budget is set for this year")
+
+// BudgetUseCase implements port.BudgetService.
+type BudgetUseCase struct {
+	budgetRepo   port.BudgetRepository
+	prefsService port.PreferencesService
+}
+
+// NewBudgetUseCase creates a new BudgetUseCase.
+func NewBudgetUseCase(budgetRepo port.BudgetRepository, prefsService port.PreferencesService) *BudgetUseCase {
+	return &BudgetUseCase{budgetRepo: budgetRepo, prefsService: prefsService}
+}
+
+// List returns the user's yearly budgets, latest year first.
+func (uc *BudgetUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.YearlyBudget, error) {
+	budgets, err := uc.budgetRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if budgets == nil {
+		budgets = []domain.YearlyBudget{}
+	}
+	return budgets, nil
+}
+
+// Set creates or replaces the user's budget for a year. The currency
+// defaults to the user's preferred one.
+func (uc *BudgetUseCase) Set(ctx context.Context, userID uuid.UUID, year int, req domain.YearlyBudgetRequest) (*domain.YearlyBudget, error) {
+	if req.Currency == "" {
+		prefs, err := uc.prefsService.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		req.Currency = prefs.Currency
+	}
+
+	now := time.Now()
+	budget := &domain.YearlyBudget{
+		UserID:    userID,
+		Year:      year,
+		Amount:    req.Amount,
+		Currency:  strings.ToUpper(strings.TrimSpace(req.Currency)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.budgetRepo.Upsert(ctx, budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// Delete removes the user's budget for a year.
+func (uc *BudgetUseCase) Delete(ctx context.Context, userID uuid.UUID, year int) error {
+	budget, err := uc.budgetRepo.Get(ctx, userID, year)
+	if err != nil {
+		return err
+	}
+	if budget == nil {
+		return ErrBudgetNotFound
+	}
+	return uc.budgetRepo.Delete(ctx, userID, year)
+}
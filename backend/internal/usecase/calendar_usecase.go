@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/ical"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	calendarProdID = "-//Birthday App//Calendar Feed//EN"
+	calendarName   = "Birthdays"
+	calendarDomain = "birthday-app"
+	// calendarRDateYears is how many years ahead holidays without an RRULE
+	// equivalent, such as Easter, are listed explicitly.
+	calendarRDateYears = 10
+)
+
+var ErrCalendarFeedNotFound = domain.NewError(http.StatusNotFound, "calendar_feed_not_found", "Calendar feed not found")
+
+// CalendarUseCase implements port.CalendarService.
+type CalendarUseCase struct {
+	feedRepo         port.CalendarFeedRepository
+	recipientRepo    port.RecipientRepository
+	occasionRepo     port.OccasionRepository
+	subscriptionRepo port.HolidaySubscriptionRepository
+	prefsService     port.PreferencesService
+	permissions      port.PermissionService
+}
+
+// NewCalendarUseCase creates a new CalendarUseCase.
+func NewCalendarUseCase(
+	feedRepo port.CalendarFeedRepository,
+	recipientRepo port.RecipientRepository,
+	occasionRepo port.OccasionRepository,
+	subscriptionRepo port.HolidaySubscriptionRepository,
+	prefsService port.PreferencesService,
+	permissions port.PermissionService,
+) *CalendarUseCase {
+	return &CalendarUseCase{
+		feedRepo:         feedRepo,
+		recipientRepo:    recipientRepo,
+		occasionRepo:     occasionRepo,
+		subscriptionRepo: subscriptionRepo,
+		prefsService:     prefsService,
+		permissions:      permissions,
+	}
+}
+
+// GetFeed returns the user's feed, creating it on first use.
+func (uc *CalendarUseCase) GetFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	feed, err := uc.feedRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if feed != nil {
+		return feed, nil
+	}
+	return uc.RotateFeed(ctx, userID)
+}
+
+// RotateFeed issues a new token, invalidating the previous feed URL.
+func (uc *CalendarUseCase) RotateFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	token, err := generateFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	feed := &domain.CalendarFeed{UserID: userID, Token: token, CreatedAt: time.Now()}
+	if err := uc.feedRepo.Save(ctx, feed); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// DisableFeed revokes the user's feed URL until a new one is requested.
+func (uc *CalendarUseCase) DisableFeed(ctx context.Context, userID uuid.UUID) error {
+	return uc.feedRepo.Delete(ctx, userID)
+}
+
+// Render encodes the feed identified by token as an iCalendar document with
+// one event per birthday, occasion and subscribed holiday.
+func (uc *CalendarUseCase) Render(ctx context.Context, token string) ([]byte, error) {
+	feed, err := uc.feedRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+	calendars, err := loadRecipientCalendars(ctx, uc.permissions, uc.recipientRepo, uc.occasionRepo, uc.subscriptionRepo, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cal := &ical.Calendar{ProdID: calendarProdID, Name: calendarName}
+	for i := range calendars {
+		c := &calendars[i]
+		for j := range c.occasions {
+			cal.Events = append(cal.Events, occasionEvent(&c.recipient, &c.occasions[j], prefs, now))
+		}
+		for _, s := range c.holidays {
+			if ev, ok := holidayEvent(&c.recipient, s, prefs, now); ok {
+				cal.Events = append(cal.Events, ev)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func occasionEvent(recipient *domain.Recipient, o *domain.Occasion, prefs *domain.UserPreferences, now time.Time) ical.Event {
+	uid := "occasion-" + o.ID.String()
+	if o.ID == uuid.Nil {
+		uid = "birthday-" + recipient.ID.String()
+	}
+
+	summary := occasionSummary(recipient.Name, o)
+	ev := ical.Event{
+		UID:     uid + "@" + calendarDomain,
+		Stamp:   now,
+		Date:    o.Date.Time,
+		Summary: summary,
+		Alarms:  reminderAlarms(summary, prefs.ReminderLeadDays),
+	}
+	budget := recipient.MaxBudget
+	if o.Budget != nil {
+		budget = *o.Budget
+	}
+	if budget > 0 {
+		ev.Description = fmt.Sprintf("Budget: %s %s", budget, recipient.Currency)
+	}
+	if o.Recurring {
+		ev.RRule = "FREQ=YEARLY"
+		// Keep leap-day occasions on the last day of February in other years.
+		if o.Date.Month() == time.February && o.Date.Day() == 29 {
+			ev.RRule = "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"
+		}
+	}
+	return ev
+}
+
+func holidayEvent(recipient *domain.Recipient, s domain.HolidaySubscription, prefs *domain.UserPreferences, now time.Time) (ical.Event, bool) {
+	holiday, ok := domain.LookupHoliday(s.Country, s.Holiday)
+	if !ok {
+		return ical.Event{}, false
+	}
+
+	year := prefs.Today().Year()
+	summary := recipient.Name + ": " + holiday.Label(prefs.Locale)
+	ev := ical.Event{
+		UID:     "holiday-" + s.ID.String() + "@" + calendarDomain,
+		Stamp:   now,
+		Date:    holiday.Rule.In(year).Time,
+		Summary: summary,
+		Alarms:  reminderAlarms(summary, prefs.ReminderLeadDays),
+	}
+	if rrule, ok := holiday.Rule.RRule(); ok {
+		ev.RRule = rrule
+	} else {
+		for y := year + 1; y <= year+calendarRDateYears; y++ {
+			ev.RDates = append(ev.RDates, holiday.Rule.In(y).Time)
+		}
+	}
+	return ev, true
+}
+
+func occasionSummary(name string, o *domain.Occasion) string {
+	if o.Title != "" {
+		return name + ": " + o.Title
+	}
+	return name + "'s " + string(o.Kind)
+}
+
+// reminderAlarms builds one alarm per reminder lead time.
+func reminderAlarms(summary string, leadDays []int) []ical.Alarm {
+	alarms := make([]ical.Alarm, 0, len(leadDays))
+	for _, days := range leadDays {
+		var description string
+		switch days {
+		case 0:
+			description = summary + " today"
+		case 1:
+			description = summary + " tomorrow"
+		default:
+			description = fmt.Sprintf("%s in %d days", summary, days)
+		}
+		alarms = append(alarms, ical.Alarm{
+			Before:      time.Duration(days) * 24 * time.Hour,
+			Description: description,
+		})
+	}
+	return alarms
+}
+
+// generateFeedToken creates an unguessable feed token.
+func generateFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
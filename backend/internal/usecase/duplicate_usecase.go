@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	defaultDuplicateLimit = 50
+	maxDuplicateLimit     = 100
+)
+
+// DuplicateUseCase implements port.DuplicateService.
+type DuplicateUseCase struct {
+	recipientRepo    port.RecipientRepository
+	recipientService port.RecipientService
+	occasionRepo     port.OccasionRepository
+	holidayRepo      port.HolidaySubscriptionRepository
+	groupRepo        port.GroupRepository
+	giftRepo         port.GiftRepository
+	ideaRepo         port.IdeaRepository
+	groupGiftRepo    port.GroupGiftRepository
+	wishlistRepo     port.WishlistRepository
+	tx               port.Transactor
+	permissions      port.PermissionService
+}
+
+// NewDuplicateUseCase creates a new DuplicateUseCase.
+func NewDuplicateUseCase(
+	recipientRepo port.RecipientRepository,
+	recipientService port.RecipientService,
+	occasionRepo port.OccasionRepository,
+	holidayRepo port.HolidaySubscriptionRepository,
+	groupRepo port.GroupRepository,
+	giftRepo port.GiftRepository,
+	ideaRepo port.IdeaRepository,
+	groupGiftRepo port.GroupGiftRepository,
+	wishlistRepo port.WishlistRepository,
+	tx port.Transactor,
+	permissions port.PermissionService,
+) *DuplicateUseCase {
+	return &DuplicateUseCase{
+		recipientRepo:    recipientRepo,
+		recipientService: recipientService,
+		occasionRepo:     occasionRepo,
+		holidayRepo:      holidayRepo,
+		groupRepo:        groupRepo,
+		giftRepo:         giftRepo,
+		ideaRepo:         ideaRepo,
+		groupGiftRepo:    groupGiftRepo,
+		wishlistRepo:     wishlistRepo,
+		tx:               tx,
+		permissions:      permissions,
+	}
+}
+
+// Find returns the likeliest duplicate pairs among the recipients the user
+// can see, best first.
+func (uc *DuplicateUseCase) Find(ctx context.Context, userID uuid.UUID, limit int) ([]domain.DuplicatePair, error) {
+	if limit == 0 {
+		limit = defaultDuplicateLimit
+	}
+	if limit < 1 || limit > maxDuplicateLimit {
+		return nil, ErrInvalidPageSize
+	}
+
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := uc.recipientRepo.ListByOwners(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+	pairs := domain.FindDuplicates(recipients)
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
+
+// Merge folds one recipient into another in a single transaction: the kept
+// recipient gains the other's keywords, a budget range covering both and
+// any fields it was missing, takes over its occasions, holiday
+// subscriptions, group memberships, gift history, ideas and group gifts, and
+// the other is moved to the trash.
+func (uc *DuplicateUseCase) Merge(ctx context.Context, userID uuid.UUID, req domain.MergeRecipientsRequest) (*domain.Recipient, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var merged *domain.Recipient
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		keep, err := uc.recipientService.GetByID(ctx, userID, req.KeepID)
+		if err != nil {
+			return err
+		}
+		other, err := uc.recipientService.GetByID(ctx, userID, req.MergeID)
+		if err != nil {
+			return err
+		}
+
+		combined := *keep
+		combined.Keywords = slices.Clone(keep.Keywords)
+		combined.MergeFrom(other)
+		merged, err = uc.recipientService.Update(ctx, userID, keep.ID, domain.UpdateRecipientRequest{
+			Age:          &combined.Age,
+			Gender:       &combined.Gender,
+			Relationship: &combined.Relationship,
+			Birthdate:    combined.Birthdate,
+			MinBudget:    &combined.MinBudget,
+			MaxBudget:    &combined.MaxBudget,
+			Currency:     &combined.Currency,
+			Keywords:     &combined.Keywords,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := uc.occasionRepo.Reassign(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		if err := uc.holidayRepo.Reassign(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		if err := uc.groupRepo.ReassignMembers(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		if err := uc.giftRepo.Reassign(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		if err := uc.ideaRepo.Reassign(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		if err := uc.groupGiftRepo.Reassign(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		if err := uc.wishlistRepo.Reassign(ctx, other.ID, keep.ID); err != nil {
+			return err
+		}
+		return uc.recipientService.Delete(ctx, userID, other.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// ExchangeRateUseCase implements port.ExchangeRateService.
+type ExchangeRateUseCase struct {
+	rateRepo port.ExchangeRateRepository
+	tx       port.Transactor
+}
+
+// NewExchangeRateUseCase creates a new ExchangeRateUseCase.
+func NewExchangeRateUseCase(rateRepo port.ExchangeRateRepository, tx port.Transactor) *ExchangeRateUseCase {
+	return &ExchangeRateUseCase{rateRepo: rateRepo, tx: tx}
+}
+
+// Rates returns the current rate table.
+func (uc *ExchangeRateUseCase) Rates(ctx context.Context) (*domain.ExchangeRates, error) {
+	rates, err := uc.rateRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewExchangeRates(rates), nil
+}
+
+// Load replaces the rate table with a CSV file of base,quote,rate rows,
+// such as "USD,BRL,5.42". A header row is optional. Nothing is written
+// unless every row is valid.
+func (uc *ExchangeRateUseCase) Load(ctx context.Context, data []byte) (int, error) {
+	rows, err := readCSV(data)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var rates []domain.ExchangeRate
+	seen := make(map[[2]string]int)
+	for i, row := range rows {
+		line := i + 1
+		if i == 0 && len(row) > 0 && strings.EqualFold(strings.TrimSpace(row[0]), "base") {
+			continue
+		}
+		if len(row) != 3 {
+			return 0, fmt.Errorf("line %d: expected base,quote,rate but got %d columns", line, len(row))
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(row[2]), 64)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: rate %q is not a number", line, row[2])
+		}
+		r := domain.ExchangeRate{
+			Base:      strings.ToUpper(strings.TrimSpace(row[0])),
+			Quote:     strings.ToUpper(strings.TrimSpace(row[1])),
+			Rate:      rate,
+			UpdatedAt: now,
+		}
+		if err := r.Validate(); err != nil {
+			return 0, fmt.Errorf("line %d: %s", line, fieldMessages(err))
+		}
+		pair := [2]string{r.Base, r.Quote}
+		if r.Base > r.Quote {
+			pair = [2]string{r.Quote, r.Base}
+		}
+		if first, ok := seen[pair]; ok {
+			return 0, fmt.Errorf("line %d: %s/%s is already given on line %d", line, r.Base, r.Quote, first)
+		}
+		seen[pair] = line
+		rates = append(rates, r)
+	}
+	if len(rates) == 0 {
+		return 0, errors.New("the file has no rates")
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.rateRepo.ReplaceAll(ctx, rates)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// fieldMessages joins the field messages of a validation error.
+func fieldMessages(err error) string {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || len(domainErr.Fields) == 0 {
+		return err.Error()
+	}
+	messages := make([]string, len(domainErr.Fields))
+	for i, f := range domainErr.Fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,498 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrExchangeNotFound      = domain.NewError(http.StatusNotFound, "exchange_not_found", "Gift exchange not found")
+	ErrParticipantNotFound   = domain.NewError(http.StatusNotFound, "participant_not_found", "Participant not found")
+	ErrExclusionNotFound     = domain.NewError(http.StatusNotFound, "exclusion_not_found", "Exclusion not found")
+	ErrAssignmentNotFound    = domain.NewError(http.StatusNotFound, "assignment_not_found", "Assignment not found")
+	ErrNotParticipant        = domain.NewError(http.StatusNotFound, "not_a_participant", "You are not taking part in this exchange")
+	ErrParticipantExists     = domain.NewError(http.StatusConflict, "participant_exists", "This email is already taking part")
+	ErrExclusionExists       = domain.NewError(http.StatusConflict, "exclusion_exists", "This pair is already excluded")
+	ErrExchangeDrawn         = domain.NewError(http.StatusConflict, "exchange_drawn", "Names have already been drawn")
+	ErrExchangeNotDrawn      = domain.NewError(http.StatusConflict, "exchange_not_drawn", "Names have not been drawn yet")
+	ErrNotEnoughParticipants = domain.NewError(http.StatusConflict, "not_enough_participants", "Not enough participants to draw names")
+	ErrNoValidDraw           = domain.NewError(http.StatusConflict, "no_valid_draw", "No draw satisfies the exclusions")
+)
+
+// ExchangeUseCase implements port.ExchangeService.
+type ExchangeUseCase struct {
+	exchangeRepo port.ExchangeRepository
+	userService  port.UserService
+	prefsService port.PreferencesService
+	notifier     port.Notifier
+	tx           port.Transactor
+}
+
+// NewExchangeUseCase creates a new ExchangeUseCase.
+func NewExchangeUseCase(
+	exchangeRepo port.ExchangeRepository,
+	userService port.UserService,
+	prefsService port.PreferencesService,
+	notifier port.Notifier,
+	tx port.Transactor,
+) *ExchangeUseCase {
+	return &ExchangeUseCase{
+		exchangeRepo: exchangeRepo,
+		userService:  userService,
+		prefsService: prefsService,
+		notifier:     notifier,
+		tx:           tx,
+	}
+}
+
+// Create sets up a new exchange organized by the user.
+func (uc *ExchangeUseCase) Create(ctx context.Context, userID uuid.UUID, req domain.CreateExchangeRequest) (*domain.GiftExchange, error) {
+	if req.Currency == "" {
+		prefs, err := uc.prefsService.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		req.Currency = prefs.Currency
+	}
+
+	now := time.Now()
+	exchange := &domain.GiftExchange{
+		ID:                 uuid.New(),
+		OrganizerID:        userID,
+		Name:               req.Name,
+		Budget:             req.Budget,
+		Currency:           req.Currency,
+		ExchangeOn:         req.ExchangeOn,
+		PreviousExchangeID: req.PreviousExchangeID,
+		Status:             domain.ExchangeDraft,
+		Participants:       []domain.ExchangeParticipant{},
+		Exclusions:         []domain.ExchangeExclusion{},
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := uc.validate(ctx, exchange); err != nil {
+		return nil, err
+	}
+
+	if err := uc.exchangeRepo.Create(ctx, exchange); err != nil {
+		return nil, err
+	}
+	return exchange, nil
+}
+
+// List returns the exchanges the user organizes or takes part in.
+func (uc *ExchangeUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.GiftExchange, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exchanges, err := uc.exchangeRepo.ListForUser(ctx, userID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if exchanges == nil {
+		exchanges = []domain.GiftExchange{}
+	}
+	return exchanges, nil
+}
+
+// GetByID retrieves an exchange the user organizes or takes part in.
+func (uc *ExchangeUseCase) GetByID(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, error) {
+	exchange, _, err := uc.get(ctx, userID, exchangeID)
+	return exchange, err
+}
+
+// Update modifies the provided fields of an exchange that has not been
+// drawn yet.
+func (uc *ExchangeUseCase) Update(ctx context.Context, userID, exchangeID uuid.UUID, req domain.UpdateExchangeRequest) (*domain.GiftExchange, error) {
+	exchange, err := uc.getDraft(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		exchange.Name = *req.Name
+	}
+	if req.Budget != nil {
+		exchange.Budget = req.Budget
+	}
+	if req.ClearBudget {
+		exchange.Budget = nil
+	}
+	if req.Currency != nil {
+		exchange.Currency = *req.Currency
+	}
+	if req.ExchangeOn != nil {
+		exchange.ExchangeOn = req.ExchangeOn
+	}
+	if req.PreviousExchangeID != nil {
+		exchange.PreviousExchangeID = req.PreviousExchangeID
+	}
+	if req.ClearPrevious {
+		exchange.PreviousExchangeID = nil
+	}
+	if err := uc.validate(ctx, exchange); err != nil {
+		return nil, err
+	}
+	exchange.UpdatedAt = time.Now()
+
+	if err := uc.exchangeRepo.Update(ctx, exchange); err != nil {
+		return nil, err
+	}
+	return exchange, nil
+}
+
+// Delete removes an exchange with its participants and assignments.
+func (uc *ExchangeUseCase) Delete(ctx context.Context, userID, exchangeID uuid.UUID) error {
+	if _, err := uc.getOrganized(ctx, userID, exchangeID); err != nil {
+		return err
+	}
+	return uc.exchangeRepo.Delete(ctx, exchangeID)
+}
+
+// AddParticipant adds someone to an exchange that has not been drawn yet.
+func (uc *ExchangeUseCase) AddParticipant(ctx context.Context, userID, exchangeID uuid.UUID, req domain.AddParticipantRequest) (*domain.ExchangeParticipant, error) {
+	exchange, err := uc.getDraft(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &domain.ExchangeParticipant{
+		ID:         uuid.New(),
+		ExchangeID: exchange.ID,
+		Name:       req.Name,
+		Email:      req.Email,
+		CreatedAt:  time.Now(),
+	}
+	participant.Normalize()
+	if err := participant.Validate(); err != nil {
+		return nil, err
+	}
+	if exchange.Participant(participant.Email) != nil {
+		return nil, ErrParticipantExists
+	}
+	if len(exchange.Participants) >= domain.MaxExchangeParticipants {
+		var v domain.Validator
+		v.Add("email", domain.CodeTooMany, "an exchange can have at most %d participants", domain.MaxExchangeParticipants)
+		return nil, v.Err()
+	}
+
+	if err := uc.exchangeRepo.AddParticipant(ctx, participant); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrParticipantExists
+		}
+		return nil, err
+	}
+	return participant, nil
+}
+
+// RemoveParticipant takes someone out of an exchange, along with the
+// exclusions that name them.
+func (uc *ExchangeUseCase) RemoveParticipant(ctx context.Context, userID, exchangeID, participantID uuid.UUID) error {
+	exchange, err := uc.getDraft(ctx, userID, exchangeID)
+	if err != nil {
+		return err
+	}
+	if exchange.ParticipantByID(participantID) == nil {
+		return ErrParticipantNotFound
+	}
+	return uc.exchangeRepo.DeleteParticipant(ctx, participantID)
+}
+
+// AddExclusion forbids a giver from drawing a receiver, and the reverse
+// too when the exclusion is mutual.
+func (uc *ExchangeUseCase) AddExclusion(ctx context.Context, userID, exchangeID uuid.UUID, req domain.AddExclusionRequest) (*domain.ExchangeExclusion, error) {
+	exchange, err := uc.getDraft(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var v domain.Validator
+	v.Check(exchange.ParticipantByID(req.GiverID) != nil, "giver_id", domain.CodeInvalidChoice,
+		"giver_id is not a participant")
+	v.Check(exchange.ParticipantByID(req.ReceiverID) != nil, "receiver_id", domain.CodeInvalidChoice,
+		"receiver_id is not a participant")
+	v.Check(req.GiverID != req.ReceiverID, "receiver_id", domain.CodeDuplicate,
+		"receiver_id must differ from giver_id")
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	for _, x := range exchange.Exclusions {
+		if x.GiverID == req.GiverID && x.ReceiverID == req.ReceiverID {
+			return nil, ErrExclusionExists
+		}
+	}
+
+	exclusion := &domain.ExchangeExclusion{
+		ID:         uuid.New(),
+		ExchangeID: exchange.ID,
+		GiverID:    req.GiverID,
+		ReceiverID: req.ReceiverID,
+		Mutual:     req.Mutual,
+		CreatedAt:  time.Now(),
+	}
+	if err := uc.exchangeRepo.AddExclusion(ctx, exclusion); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrExclusionExists
+		}
+		return nil, err
+	}
+	return exclusion, nil
+}
+
+// RemoveExclusion lifts an exclusion.
+func (uc *ExchangeUseCase) RemoveExclusion(ctx context.Context, userID, exchangeID, exclusionID uuid.UUID) error {
+	exchange, err := uc.getDraft(ctx, userID, exchangeID)
+	if err != nil {
+		return err
+	}
+	for _, x := range exchange.Exclusions {
+		if x.ID == exclusionID {
+			return uc.exchangeRepo.DeleteExclusion(ctx, exclusionID)
+		}
+	}
+	return ErrExclusionNotFound
+}
+
+// Draw assigns every participant a receiver from a fresh random seed,
+// avoiding the exclusions and last year's pairs, then sends each
+// participant a secret link to their own assignment. The seed and last
+// year's pairs are stored so Verify can replay the draw on its own.
+func (uc *ExchangeUseCase) Draw(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, error) {
+	var exchange *domain.GiftExchange
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		exchange, err = uc.getDraft(ctx, userID, exchangeID)
+		if err != nil {
+			return err
+		}
+		if len(exchange.Participants) < domain.MinExchangeParticipants {
+			return ErrNotEnoughParticipants.WithDetail(
+				fmt.Sprintf("at least %d participants are needed", domain.MinExchangeParticipants))
+		}
+
+		var previous *domain.GiftExchange
+		if exchange.PreviousExchangeID != nil {
+			if previous, err = uc.exchangeRepo.GetByID(ctx, *exchange.PreviousExchangeID); err != nil {
+				return err
+			}
+		}
+		exchange.PreviousPairs = exchange.RepeatedPairs(previous)
+
+		seed, err := newDrawSeed()
+		if err != nil {
+			return err
+		}
+		assignments, err := replayDraw(exchange, seed)
+		if err != nil {
+			return err
+		}
+		for i := range exchange.Participants {
+			p := &exchange.Participants[i]
+			receiverID := assignments[p.ID]
+			p.ReceiverID = &receiverID
+			if p.Token, err = newAssignmentToken(); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		exchange.Status = domain.ExchangeDrawn
+		exchange.Seed = seed
+		exchange.DrawnAt = &now
+		exchange.UpdatedAt = now
+		if err := uc.exchangeRepo.SaveAssignments(ctx, exchange.Participants); err != nil {
+			return err
+		}
+		return uc.exchangeRepo.Update(ctx, exchange)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range exchange.Participants {
+		uc.notifier.Notify(ctx, domain.Notification{
+			Kind:    domain.NotifyExchangeDrawn,
+			To:      p.Email,
+			Subject: fmt.Sprintf("Names have been drawn for %s", exchange.Name),
+			Body: fmt.Sprintf("Hi %s, names have been drawn for %q. Open %s to see who you are giving to.",
+				p.Name, exchange.Name, p.AssignmentPath()),
+		})
+	}
+	return exchange, nil
+}
+
+// Verify replays the draw from its stored seed and constraints and reports
+// whether it gives the stored assignments, without revealing them. It does
+// not depend on the previous exchange, which may since have been deleted.
+func (uc *ExchangeUseCase) Verify(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.ExchangeVerification, error) {
+	exchange, err := uc.getOrganized(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	if exchange.Status != domain.ExchangeDrawn {
+		return nil, ErrExchangeNotDrawn
+	}
+
+	verification := &domain.ExchangeVerification{ExchangeID: exchange.ID, DrawnAt: exchange.DrawnAt}
+	assignments, err := replayDraw(exchange, exchange.Seed)
+	if errors.Is(err, ErrNoValidDraw) {
+		return verification, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	verification.Matches = true
+	for _, p := range exchange.Participants {
+		if p.ReceiverID == nil || assignments[p.ID] != *p.ReceiverID {
+			verification.Matches = false
+		}
+	}
+	return verification, nil
+}
+
+// Assignment returns the signed-in participant's own assignment.
+func (uc *ExchangeUseCase) Assignment(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.ExchangeAssignment, error) {
+	exchange, user, err := uc.get(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	participant := exchange.Participant(user.Email)
+	if participant == nil {
+		return nil, ErrNotParticipant
+	}
+	assignment, ok := exchange.Assignment(participant)
+	if !ok {
+		return nil, ErrExchangeNotDrawn
+	}
+	return assignment, nil
+}
+
+// AssignmentByToken returns the assignment behind a secret link. The token
+// is the only credential, so participants need no account.
+func (uc *ExchangeUseCase) AssignmentByToken(ctx context.Context, token string) (*domain.ExchangeAssignment, error) {
+	participant, err := uc.exchangeRepo.GetParticipantByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		return nil, ErrAssignmentNotFound
+	}
+	exchange, err := uc.exchangeRepo.GetByID(ctx, participant.ExchangeID)
+	if err != nil {
+		return nil, err
+	}
+	if exchange == nil {
+		return nil, ErrAssignmentNotFound
+	}
+	assignment, ok := exchange.Assignment(participant)
+	if !ok {
+		return nil, ErrAssignmentNotFound
+	}
+	return assignment, nil
+}
+
+// replayDraw runs the draw for the exchange's participants and constraints
+// with the given seed.
+func replayDraw(exchange *domain.GiftExchange, seed int64) (map[uuid.UUID]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(exchange.Participants))
+	for i, p := range exchange.Participants {
+		ids[i] = p.ID
+	}
+	assignments, ok := domain.DrawAssignments(ids, exchange.Forbidden(), seed)
+	if !ok {
+		return nil, ErrNoValidDraw
+	}
+	return assignments, nil
+}
+
+// validate normalizes the exchange and checks the field rules, including
+// that a previous exchange is one the same user organized.
+func (uc *ExchangeUseCase) validate(ctx context.Context, exchange *domain.GiftExchange) error {
+	exchange.Normalize()
+	if err := exchange.Validate(); err != nil {
+		return err
+	}
+
+	if exchange.PreviousExchangeID != nil {
+		previous, err := uc.exchangeRepo.GetByID(ctx, *exchange.PreviousExchangeID)
+		if err != nil {
+			return err
+		}
+		if previous == nil || previous.OrganizerID != exchange.OrganizerID || previous.ID == exchange.ID {
+			var v domain.Validator
+			v.Add("previous_exchange_id", domain.CodeInvalidChoice, "previous_exchange_id is not one of your other exchanges")
+			return v.Err()
+		}
+	}
+	return nil
+}
+
+// get loads an exchange the user organizes or takes part in by email.
+// Anyone else is told it does not exist.
+func (uc *ExchangeUseCase) get(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, *domain.User, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	exchange, err := uc.exchangeRepo.GetByID(ctx, exchangeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exchange == nil || (exchange.OrganizerID != userID && exchange.Participant(user.Email) == nil) {
+		return nil, nil, ErrExchangeNotFound
+	}
+	return exchange, user, nil
+}
+
+// getOrganized is get for actions only the organizer may take.
+func (uc *ExchangeUseCase) getOrganized(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, error) {
+	exchange, _, err := uc.get(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	if exchange.OrganizerID != userID {
+		return nil, ErrNotOrganizer
+	}
+	return exchange, nil
+}
+
+// getDraft is getOrganized for changes that are only allowed before the draw.
+func (uc *ExchangeUseCase) getDraft(ctx context.Context, userID, exchangeID uuid.UUID) (*domain.GiftExchange, error) {
+	exchange, err := uc.getOrganized(ctx, userID, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	if exchange.Status == domain.ExchangeDrawn {
+		return nil, ErrExchangeDrawn
+	}
+	return exchange, nil
+}
+
+// newDrawSeed picks a random seed for a draw.
+func newDrawSeed() (int64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, fmt.Errorf("failed to generate draw seed: %w", err)
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
+
+// newAssignmentToken creates an unguessable assignment link token.
+func newAssignmentToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate assignment token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
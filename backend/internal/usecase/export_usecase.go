@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/vcard"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/xlsx"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var ErrUnsupportedVCardVersion = domain.NewError(http.StatusBadRequest, "unsupported_vcard_version", "vCard version must be 3.0 or 4.0")
+
+// ExportUseCase implements port.ExportService.
+type ExportUseCase struct {
+	recipientRepo port.RecipientRepository
+	occasionRepo  port.OccasionRepository
+	permissions   port.PermissionService
+}
+
+// NewExportUseCase creates a new ExportUseCase.
+func NewExportUseCase(recipientRepo port.RecipientRepository, occasionRepo port.OccasionRepository, permissions port.PermissionService) *ExportUseCase {
+	return &ExportUseCase{recipientRepo: recipientRepo, occasionRepo: occasionRepo, permissions: permissions}
+}
+
+// VCard writes every recipient the user can see, their household's included,
+// as a contact in the given vCard version, 3.0 when empty, sorted by name.
+// The earliest recurring anniversary or wedding occasion becomes the card's
+// anniversary.
+func (uc *ExportUseCase) VCard(ctx context.Context, userID uuid.UUID, version string) ([]byte, error) {
+	if version == "" {
+		version = vcard.Version3
+	}
+	if version != vcard.Version3 && version != vcard.Version4 {
+		return nil, ErrUnsupportedVCardVersion
+	}
+
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := uc.listSorted(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+	occasions, err := uc.occasionRepo.ListByOwners(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+	anniversaries := make(map[uuid.UUID]domain.Date)
+	for _, o := range occasions {
+		if !o.Recurring || (o.Kind != domain.OccasionAnniversary && o.Kind != domain.OccasionWedding) {
+			continue
+		}
+		if d, ok := anniversaries[o.RecipientID]; !ok || o.Date.Before(d.Time) {
+			anniversaries[o.RecipientID] = o.Date
+		}
+	}
+
+	cards := make([]vcard.Card, 0, len(recipients))
+	for i := range recipients {
+		r := &recipients[i]
+		card := vcard.Card{
+			UID:           "urn:uuid:" + r.ID.String(),
+			FormattedName: r.Name,
+			Name:          vcardName(r.Name),
+			Gender:        recipientVCardGenders[r.Gender],
+			Categories:    r.Keywords,
+		}
+		if r.Birthdate != nil {
+			d := vcard.DateOf(r.Birthdate.Time)
+			card.Birthday = &d
+		}
+		if a, ok := anniversaries[r.ID]; ok {
+			d := vcard.DateOf(a.Time)
+			card.Anniversary = &d
+		}
+		cards = append(cards, card)
+	}
+
+	var buf bytes.Buffer
+	if err := vcard.Encode(&buf, version, cards); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CSV writes every recipient the user can see as a spreadsheet row, sorted
+// by name. The header uses the import field names, so the file can be
+// imported again without remapping; keywords are comma-separated.
+func (uc *ExportUseCase) CSV(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	rows, err := uc.sheetRows(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			switch v := v.(type) {
+			case int:
+				record[i] = strconv.Itoa(v)
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', 2, 64)
+			default:
+				record[i] = escapeFormula(v.(string))
+			}
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeFormula quotes text that spreadsheet apps would run as a formula
+// when opening a CSV file. The import strips the quote again.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// XLSX writes the same rows as CSV to a single-sheet workbook, with ages
+// and budgets as numbers.
+func (uc *ExportUseCase) XLSX(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	rows, err := uc.sheetRows(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := xlsx.Write(&buf, "Recipients", rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sheetRows returns the header and one row per recipient, in
+// domain.ImportFields order.
+func (uc *ExportUseCase) sheetRows(ctx context.Context, userID uuid.UUID) ([][]any, error) {
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := uc.listSorted(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]any, len(domain.ImportFields))
+	for i, field := range domain.ImportFields {
+		header[i] = string(field)
+	}
+	rows := [][]any{header}
+	for i := range recipients {
+		r := &recipients[i]
+		birthdate := ""
+		if r.Birthdate != nil {
+			birthdate = r.Birthdate.String()
+		}
+		rows = append(rows, []any{
+			r.Name, r.Age, r.Gender, r.Relationship, birthdate, r.MinBudget.Float64(), r.MaxBudget.Float64(), r.Currency,
+			strings.Join(r.Keywords, ", "),
+		})
+	}
+	return rows, nil
+}
+
+func (uc *ExportUseCase) listSorted(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.Recipient, error) {
+	recipients, err := uc.recipientRepo.ListByOwners(ctx, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(recipients, func(a, b domain.Recipient) int {
+		return strings.Compare(domain.RecipientNameKey(a.Name), domain.RecipientNameKey(b.Name))
+	})
+	return recipients, nil
+}
+
+// recipientVCardGenders maps recipient genders onto the GENDER sex
+// component. "other" is the default for recipients created without a
+// gender, so it is not exported.
+var recipientVCardGenders = map[string]string{
+	domain.GenderFemale:    "F",
+	domain.GenderMale:      "M",
+	domain.GenderNonBinary: "O",
+}
+
+// vcardName splits a display name into the structured N property, taking
+// the last word as the family name.
+func vcardName(name string) vcard.Name {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return vcard.Name{Given: name}
+	}
+	return vcard.Name{
+		Given:  strings.Join(words[:len(words)-1], " "),
+		Family: words[len(words)-1],
+	}
+}
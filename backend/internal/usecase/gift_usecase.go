@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var ErrGiftNotFound = domain.NewError(http.StatusNotFound, "gift_not_found", "Gift not found")
+
+// GiftUseCase implements port.GiftService.
+type GiftUseCase struct {
+	giftRepo       port.GiftRepository
+	permissions    port.PermissionService
+	occasionRepo   port.OccasionRepository
+	keywordService port.KeywordService
+	prefsService   port.PreferencesService
+}
+
+// NewGiftUseCase creates a new GiftUseCase.
+func NewGiftUseCase(
+	giftRepo port.GiftRepository,
+	permissions port.PermissionService,
+	occasionRepo port.OccasionRepository,
+	keywordService port.KeywordService,
+	prefsService port.PreferencesService,
+) *GiftUseCase {
+	return &GiftUseCase{
+		giftRepo:       giftRepo,
+		permissions:    permissions,
+		occasionRepo:   occasionRepo,
+		keywordService: keywordService,
+		prefsService:   prefsService,
+	}
+}
+
+// Create records a gift given to one of the user's recipients.
+func (uc *GiftUseCase) Create(ctx context.Context, userID, recipientID uuid.UUID, req domain.CreateGiftRequest) (*domain.GiftRecord, error) {
+	recipient, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		req.Currency = recipient.Currency
+	}
+
+	now := time.Now()
+	gift := &domain.GiftRecord{
+		ID:            uuid.New(),
+		RecipientID:   recipientID,
+		OccasionID:    req.OccasionID,
+		CatalogItemID: req.CatalogItemID,
+		Item:          req.Item,
+		Category:      req.Category,
+		Price:         req.Price,
+		Currency:      req.Currency,
+		Rating:        req.Rating,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.CatalogItemID != "" {
+		item, ok := domain.LookupCatalogItem(req.CatalogItemID)
+		if !ok {
+			var v domain.Validator
+			v.Add("catalog_item_id", domain.CodeInvalidChoice, "catalog_item_id is not a catalog item")
+			return nil, v.Err()
+		}
+		prefs, err := uc.prefsService.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if gift.Item == "" {
+			gift.Item = item.Label(prefs.Locale)
+		}
+		if gift.Category == "" {
+			gift.Category = item.Category
+		}
+		if gift.Price == 0 {
+			gift.Price, gift.Currency = item.Price, domain.CatalogCurrency
+		}
+	}
+
+	today, err := uc.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	gift.GivenOn = domain.NewDate(today)
+	if req.GivenOn != nil {
+		gift.GivenOn = *req.GivenOn
+	}
+	if err := uc.validate(ctx, gift, today); err != nil {
+		return nil, err
+	}
+
+	if err := uc.giftRepo.Create(ctx, gift); err != nil {
+		return nil, err
+	}
+	return gift, nil
+}
+
+// List returns a recipient's gifts, most recently given first.
+func (uc *GiftUseCase) List(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.GiftRecord, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionView); err != nil {
+		return nil, err
+	}
+
+	gifts, err := uc.giftRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if gifts == nil {
+		gifts = []domain.GiftRecord{}
+	}
+	return gifts, nil
+}
+
+// GetByID retrieves one of a recipient's gifts.
+func (uc *GiftUseCase) GetByID(ctx context.Context, userID, recipientID, giftID uuid.UUID) (*domain.GiftRecord, error) {
+	return uc.getOwned(ctx, userID, recipientID, giftID, domain.PermissionView)
+}
+
+// Update modifies the provided fields of a gift record.
+func (uc *GiftUseCase) Update(ctx context.Context, userID, recipientID, giftID uuid.UUID, req domain.UpdateGiftRequest) (*domain.GiftRecord, error) {
+	gift, err := uc.getOwned(ctx, userID, recipientID, giftID, domain.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OccasionID != nil {
+		gift.OccasionID = req.OccasionID
+	}
+	if req.ClearOccasion {
+		gift.OccasionID = nil
+	}
+	if req.Item != nil {
+		gift.Item = *req.Item
+	}
+	if req.Category != nil {
+		gift.Category = *req.Category
+	}
+	if req.Price != nil {
+		gift.Price = *req.Price
+	}
+	if req.Currency != nil {
+		gift.Currency = *req.Currency
+	}
+	if req.GivenOn != nil {
+		gift.GivenOn = *req.GivenOn
+	}
+	if req.Rating != nil {
+		gift.Rating = req.Rating
+	}
+	if req.ClearRating {
+		gift.Rating = nil
+	}
+
+	today, err := uc.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validate(ctx, gift, today); err != nil {
+		return nil, err
+	}
+	gift.UpdatedAt = time.Now()
+
+	if err := uc.giftRepo.Update(ctx, gift); err != nil {
+		return nil, err
+	}
+	return gift, nil
+}
+
+// Delete removes a gift record.
+func (uc *GiftUseCase) Delete(ctx context.Context, userID, recipientID, giftID uuid.UUID) error {
+	if _, err := uc.getOwned(ctx, userID, recipientID, giftID, domain.PermissionEdit); err != nil {
+		return err
+	}
+	return uc.giftRepo.Delete(ctx, giftID)
+}
+
+// validate normalizes the gift, maps its category onto the interest
+// taxonomy and checks the field rules, including that its occasion belongs
+// to the same recipient.
+func (uc *GiftUseCase) validate(ctx context.Context, gift *domain.GiftRecord, today time.Time) error {
+	gift.Normalize()
+	category, err := canonicalCategory(ctx, uc.keywordService, gift.Category)
+	if err != nil {
+		return err
+	}
+	gift.Category = category
+	if err := gift.Validate(today); err != nil {
+		return err
+	}
+
+	if gift.OccasionID != nil {
+		occasion, err := uc.occasionRepo.GetByID(ctx, *gift.OccasionID)
+		if err != nil {
+			return err
+		}
+		if occasion == nil || occasion.RecipientID != gift.RecipientID {
+			var v domain.Validator
+			v.Add("occasion_id", domain.CodeInvalidChoice, "occasion_id is not one of the recipient's occasions")
+			return v.Err()
+		}
+	}
+	return nil
+}
+
+// canonicalCategory maps a gift category onto the interest taxonomy so the
+// suggestion engine can match it against catalog items.
+func canonicalCategory(ctx context.Context, keywordService port.KeywordService, category string) (string, error) {
+	if category == "" {
+		return "", nil
+	}
+	categories, err := keywordService.Canonicalize(ctx, []string{category})
+	if err != nil || len(categories) == 0 {
+		return category, err
+	}
+	return categories[0], nil
+}
+
+// getOwned loads a gift record, ensuring it belongs to the given recipient
+// and that the requesting user holds perm on the recipient.
+func (uc *GiftUseCase) getOwned(ctx context.Context, userID, recipientID, giftID uuid.UUID, perm domain.Permission) (*domain.GiftRecord, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, perm); err != nil {
+		return nil, err
+	}
+	gift, err := uc.giftRepo.GetByID(ctx, giftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift == nil || gift.RecipientID != recipientID {
+		return nil, ErrGiftNotFound
+	}
+	return gift, nil
+}
+
+// today returns the current date in the user's preferred timezone.
+func (uc *GiftUseCase) today(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return prefs.Today(), nil
+}
@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrGroupGiftNotFound   = domain.NewError(http.StatusNotFound, "group_gift_not_found", "Group gift not found")
+	ErrContributorNotFound = domain.NewError(http.StatusNotFound, "contributor_not_found", "Contributor not found")
+	ErrContributorExists   = domain.NewError(http.StatusConflict, "contributor_exists", "This email is already invited")
+	ErrGroupGiftSettled    = domain.NewError(http.StatusConflict, "group_gift_settled", "Group gift is already settled")
+	ErrNotOrganizer        = domain.NewError(http.StatusForbidden, "not_organizer", "Only the organizer can do this")
+)
+
+// GroupGiftUseCase implements port.GroupGiftService.
+type GroupGiftUseCase struct {
+	groupGiftRepo port.GroupGiftRepository
+	permissions   port.PermissionService
+	occasionRepo  port.OccasionRepository
+	userService   port.UserService
+	notifier      port.Notifier
+}
+
+// NewGroupGiftUseCase creates a new GroupGiftUseCase.
+func NewGroupGiftUseCase(
+	groupGiftRepo port.GroupGiftRepository,
+	permissions port.PermissionService,
+	occasionRepo port.OccasionRepository,
+	userService port.UserService,
+	notifier port.Notifier,
+) *GroupGiftUseCase {
+	return &GroupGiftUseCase{
+		groupGiftRepo: groupGiftRepo,
+		permissions:   permissions,
+		occasionRepo:  occasionRepo,
+		userService:   userService,
+		notifier:      notifier,
+	}
+}
+
+// Create starts a group gift for one of the user's recipients, tied to one
+// of the recipient's occasions. The user becomes its organizer.
+func (uc *GroupGiftUseCase) Create(ctx context.Context, userID uuid.UUID, req domain.CreateGroupGiftRequest) (*domain.GroupGift, error) {
+	recipient, err := uc.permissions.Recipient(ctx, userID, req.RecipientID, domain.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		req.Currency = recipient.Currency
+	}
+
+	now := time.Now()
+	gift := &domain.GroupGift{
+		ID:           uuid.New(),
+		OrganizerID:  userID,
+		RecipientID:  recipient.ID,
+		OccasionID:   req.OccasionID,
+		Title:        req.Title,
+		Description:  req.Description,
+		TargetAmount: req.TargetAmount,
+		Currency:     req.Currency,
+		Status:       domain.GroupGiftOpen,
+		Contributors: []domain.GroupGiftContributor{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	gift.Normalize()
+	if err := gift.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.checkOccasion(ctx, gift); err != nil {
+		return nil, err
+	}
+
+	if err := uc.groupGiftRepo.Create(ctx, gift); err != nil {
+		return nil, err
+	}
+	return gift, nil
+}
+
+// List returns the group gifts the user organizes or was invited to.
+func (uc *GroupGiftUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.GroupGift, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	gifts, err := uc.groupGiftRepo.ListForUser(ctx, userID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if gifts == nil {
+		gifts = []domain.GroupGift{}
+	}
+	return gifts, nil
+}
+
+// GetByID retrieves a group gift the user organizes or contributes to.
+func (uc *GroupGiftUseCase) GetByID(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGift, error) {
+	gift, _, err := uc.get(ctx, userID, groupGiftID)
+	return gift, err
+}
+
+// Update modifies the provided fields of an open group gift.
+func (uc *GroupGiftUseCase) Update(ctx context.Context, userID, groupGiftID uuid.UUID, req domain.UpdateGroupGiftRequest) (*domain.GroupGift, error) {
+	gift, _, err := uc.getOrganized(ctx, userID, groupGiftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift.Status == domain.GroupGiftSettled {
+		return nil, ErrGroupGiftSettled
+	}
+
+	if req.Title != nil {
+		gift.Title = *req.Title
+	}
+	if req.Description != nil {
+		gift.Description = *req.Description
+	}
+	if req.TargetAmount != nil {
+		gift.TargetAmount = *req.TargetAmount
+	}
+	gift.Normalize()
+	if err := gift.Validate(); err != nil {
+		return nil, err
+	}
+	gift.UpdatedAt = time.Now()
+
+	if err := uc.groupGiftRepo.Update(ctx, gift); err != nil {
+		return nil, err
+	}
+	return gift, nil
+}
+
+// Delete removes a group gift and its contributors.
+func (uc *GroupGiftUseCase) Delete(ctx context.Context, userID, groupGiftID uuid.UUID) error {
+	if _, _, err := uc.getOrganized(ctx, userID, groupGiftID); err != nil {
+		return err
+	}
+	return uc.groupGiftRepo.Delete(ctx, groupGiftID)
+}
+
+// Invite adds a contributor by email and sends them an invitation. They
+// can follow the gift once they sign in with that email.
+func (uc *GroupGiftUseCase) Invite(ctx context.Context, userID, groupGiftID uuid.UUID, req domain.InviteContributorRequest) (*domain.GroupGiftContributor, error) {
+	gift, organizer, err := uc.getOrganized(ctx, userID, groupGiftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift.Status == domain.GroupGiftSettled {
+		return nil, ErrGroupGiftSettled
+	}
+
+	now := time.Now()
+	contributor := &domain.GroupGiftContributor{
+		ID:          uuid.New(),
+		GroupGiftID: gift.ID,
+		Email:       req.Email,
+		Name:        req.Name,
+		Pledged:     req.Pledged,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	contributor.Normalize()
+	if err := contributor.Validate(); err != nil {
+		return nil, err
+	}
+	if gift.Contributor(contributor.Email) != nil {
+		return nil, ErrContributorExists
+	}
+	if len(gift.Contributors) >= domain.MaxGroupGiftContributors {
+		var v domain.Validator
+		v.Add("email", domain.CodeTooMany, "a group gift can have at most %d contributors", domain.MaxGroupGiftContributors)
+		return nil, v.Err()
+	}
+
+	if err := uc.groupGiftRepo.AddContributor(ctx, contributor); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrContributorExists
+		}
+		return nil, err
+	}
+
+	body := fmt.Sprintf("%s is collecting %s %s for %q.", displayName(organizer), gift.TargetAmount, gift.Currency, gift.Title)
+	if contributor.Pledged > 0 {
+		body += fmt.Sprintf(" Your pledge: %s %s.", contributor.Pledged, gift.Currency)
+	}
+	body += fmt.Sprintf(" Sign in with %s to follow along.", contributor.Email)
+	uc.notify(ctx, domain.NotifyGroupGiftInvite, contributor.Email,
+		fmt.Sprintf("%s invited you to chip in for %s", displayName(organizer), gift.Title), body)
+	return contributor, nil
+}
+
+// UpdateContribution changes a contributor's pledge or paid amount. A
+// contributor may change their own pledge; the organizer may change
+// anyone's and is the only one who records payments.
+func (uc *GroupGiftUseCase) UpdateContribution(ctx context.Context, userID, groupGiftID, contributorID uuid.UUID, req domain.UpdateContributionRequest) (*domain.GroupGiftContributor, error) {
+	gift, user, err := uc.get(ctx, userID, groupGiftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift.Status == domain.GroupGiftSettled {
+		return nil, ErrGroupGiftSettled
+	}
+	contributor := findContributor(gift, contributorID)
+	if contributor == nil {
+		return nil, ErrContributorNotFound
+	}
+	isOrganizer := gift.OrganizerID == userID
+	if !isOrganizer {
+		if !strings.EqualFold(contributor.Email, user.Email) {
+			return nil, ErrForbidden
+		}
+		if req.Paid != nil {
+			return nil, ErrNotOrganizer.WithDetail("only the organizer records payments")
+		}
+	}
+
+	before := gift.Progress()
+	pledged := contributor.Pledged
+	if req.Pledged != nil {
+		contributor.Pledged = *req.Pledged
+	}
+	if req.Paid != nil {
+		contributor.Paid = *req.Paid
+	}
+	if err := contributor.Validate(); err != nil {
+		return nil, err
+	}
+	contributor.UpdatedAt = time.Now()
+
+	if err := uc.groupGiftRepo.UpdateContributor(ctx, contributor); err != nil {
+		return nil, err
+	}
+
+	if !isOrganizer && contributor.Pledged != pledged {
+		if organizer, err := uc.userService.GetByID(ctx, gift.OrganizerID); err == nil {
+			uc.notify(ctx, domain.NotifyGroupGiftPledge, organizer.Email,
+				fmt.Sprintf("%s updated their pledge for %s", contributor.DisplayName(), gift.Title),
+				fmt.Sprintf("%s now pledges %s %s.", contributor.DisplayName(), contributor.Pledged, gift.Currency))
+		}
+	}
+	if after := gift.Progress(); after.Funded && !before.Funded {
+		for _, c := range gift.Contributors {
+			uc.notify(ctx, domain.NotifyGroupGiftFunded, c.Email,
+				fmt.Sprintf("%s is fully funded", gift.Title),
+				fmt.Sprintf("The %s %s target for %q has been reached. Thank you for chipping in!",
+					gift.TargetAmount, gift.Currency, gift.Title))
+		}
+	}
+	return contributor, nil
+}
+
+// RemoveContributor withdraws an invitation.
+func (uc *GroupGiftUseCase) RemoveContributor(ctx context.Context, userID, groupGiftID, contributorID uuid.UUID) error {
+	gift, _, err := uc.getOrganized(ctx, userID, groupGiftID)
+	if err != nil {
+		return err
+	}
+	if gift.Status == domain.GroupGiftSettled {
+		return ErrGroupGiftSettled
+	}
+	if findContributor(gift, contributorID) == nil {
+		return ErrContributorNotFound
+	}
+	return uc.groupGiftRepo.DeleteContributor(ctx, contributorID)
+}
+
+// Progress totals the pledges and payments against the target.
+func (uc *GroupGiftUseCase) Progress(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGiftProgress, error) {
+	gift, _, err := uc.get(ctx, userID, groupGiftID)
+	if err != nil {
+		return nil, err
+	}
+	progress := gift.Progress()
+	return &progress, nil
+}
+
+// Settle closes a group gift to further changes and tells each contributor
+// what they paid and what, if anything, they still owe.
+func (uc *GroupGiftUseCase) Settle(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGift, error) {
+	gift, _, err := uc.getOrganized(ctx, userID, groupGiftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift.Status == domain.GroupGiftSettled {
+		return nil, ErrGroupGiftSettled
+	}
+
+	now := time.Now()
+	gift.Status = domain.GroupGiftSettled
+	gift.SettledAt = &now
+	gift.UpdatedAt = now
+	if err := uc.groupGiftRepo.Update(ctx, gift); err != nil {
+		return nil, err
+	}
+
+	for _, c := range gift.Contributors {
+		body := fmt.Sprintf("The organizer has settled %q. You pledged %s %s and paid %s %s.",
+			gift.Title, c.Pledged, gift.Currency, c.Paid, gift.Currency)
+		if owed := c.Pledged - c.Paid; owed > 0 {
+			body += fmt.Sprintf(" You still owe %s %s.", owed, gift.Currency)
+		}
+		uc.notify(ctx, domain.NotifyGroupGiftSettled, c.Email, fmt.Sprintf("%s is settled", gift.Title), body)
+	}
+	return gift, nil
+}
+
+// checkOccasion requires the gift's occasion to be one of its recipient's.
+func (uc *GroupGiftUseCase) checkOccasion(ctx context.Context, gift *domain.GroupGift) error {
+	var v domain.Validator
+	if gift.OccasionID == nil {
+		v.Add("occasion_id", domain.CodeRequired, "occasion_id is required")
+		return v.Err()
+	}
+	occasion, err := uc.occasionRepo.GetByID(ctx, *gift.OccasionID)
+	if err != nil {
+		return err
+	}
+	if occasion == nil || occasion.RecipientID != gift.RecipientID {
+		v.Add("occasion_id", domain.CodeInvalidChoice, "occasion_id is not one of the recipient's occasions")
+	}
+	return v.Err()
+}
+
+// get loads a group gift the user organizes or was invited to by email.
+// Anyone else is told it does not exist.
+func (uc *GroupGiftUseCase) get(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGift, *domain.User, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	gift, err := uc.groupGiftRepo.GetByID(ctx, groupGiftID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if gift == nil || (gift.OrganizerID != userID && gift.Contributor(user.Email) == nil) {
+		return nil, nil, ErrGroupGiftNotFound
+	}
+	return gift, user, nil
+}
+
+// getOrganized is get for actions only the organizer may take.
+func (uc *GroupGiftUseCase) getOrganized(ctx context.Context, userID, groupGiftID uuid.UUID) (*domain.GroupGift, *domain.User, error) {
+	gift, user, err := uc.get(ctx, userID, groupGiftID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if gift.OrganizerID != userID {
+		return nil, nil, ErrNotOrganizer
+	}
+	return gift, user, nil
+}
+
+func (uc *GroupGiftUseCase) notify(ctx context.Context, kind domain.NotificationKind, to, subject, body string) {
+	uc.notifier.Notify(ctx, domain.Notification{Kind: kind, To: to, Subject: subject, Body: body})
+}
+
+func findContributor(gift *domain.GroupGift, contributorID uuid.UUID) *domain.GroupGiftContributor {
+	for i := range gift.Contributors {
+		if gift.Contributors[i].ID == contributorID {
+			return &gift.Contributors[i]
+		}
+	}
+	return nil
+}
+
+// displayName is the user's name, or their email when they have none.
+func displayName(user *domain.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Email
+}
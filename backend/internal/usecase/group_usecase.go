@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrGroupNotFound      = domain.NewError(http.StatusNotFound, "group_not_found", "Group not found")
+	ErrDuplicateGroupName = domain.NewError(http.StatusConflict, "duplicate_group_name", "A group with this name already exists")
+)
+
+// GroupUseCase implements port.GroupService.
+type GroupUseCase struct {
+	groupRepo   port.GroupRepository
+	permissions port.PermissionService
+}
+
+// NewGroupUseCase creates a new GroupUseCase.
+func NewGroupUseCase(groupRepo port.GroupRepository, permissions port.PermissionService) *GroupUseCase {
+	return &GroupUseCase{groupRepo: groupRepo, permissions: permissions}
+}
+
+// Create adds a new group for the authenticated user.
+func (uc *GroupUseCase) Create(ctx context.Context, userID uuid.UUID, req domain.GroupRequest) (*domain.Group, error) {
+	now := time.Now()
+	group := &domain.Group{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      req.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	group.Normalize()
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.groupRepo.Create(ctx, group); err != nil {
+		return nil, duplicateGroupName(err)
+	}
+	return group, nil
+}
+
+// List returns the authenticated user's groups.
+func (uc *GroupUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.Group, error) {
+	groups, err := uc.groupRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if groups == nil {
+		groups = []domain.Group{}
+	}
+	return groups, nil
+}
+
+// GetByID retrieves a group, ensuring it belongs to the requesting user.
+func (uc *GroupUseCase) GetByID(ctx context.Context, userID, groupID uuid.UUID) (*domain.Group, error) {
+	return uc.getOwned(ctx, userID, groupID)
+}
+
+// Update renames a group.
+func (uc *GroupUseCase) Update(ctx context.Context, userID, groupID uuid.UUID, req domain.GroupRequest) (*domain.Group, error) {
+	group, err := uc.getOwned(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = req.Name
+	group.Normalize()
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+	group.UpdatedAt = time.Now()
+
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		return nil, duplicateGroupName(err)
+	}
+	return group, nil
+}
+
+// Delete removes a group. Its recipients are kept.
+func (uc *GroupUseCase) Delete(ctx context.Context, userID, groupID uuid.UUID) error {
+	if _, err := uc.getOwned(ctx, userID, groupID); err != nil {
+		return err
+	}
+	return uc.groupRepo.Delete(ctx, groupID)
+}
+
+// AddMembers adds recipients the user can see, including household ones, to a group.
+func (uc *GroupUseCase) AddMembers(ctx context.Context, userID, groupID uuid.UUID, req domain.GroupMembersRequest) (*domain.Group, error) {
+	if _, err := uc.getOwned(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	var v domain.Validator
+	v.Check(len(req.RecipientIDs) > 0, "recipient_ids", domain.CodeRequired, "recipient_ids are required")
+	v.Check(len(req.RecipientIDs) <= domain.MaxGroupMembersPerCall, "recipient_ids", domain.CodeTooMany,
+		"at most %d recipients can be added at once", domain.MaxGroupMembersPerCall)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range req.RecipientIDs {
+		_, err := uc.permissions.Recipient(ctx, userID, id, domain.PermissionView)
+		if errors.Is(err, ErrRecipientNotFound) || errors.Is(err, ErrForbidden) {
+			return nil, ErrRecipientNotFound.WithDetail("recipient " + id.String() + " not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.groupRepo.AddMembers(ctx, groupID, req.RecipientIDs); err != nil {
+		return nil, err
+	}
+	return uc.groupRepo.GetByID(ctx, groupID)
+}
+
+// RemoveMember takes a recipient out of a group.
+func (uc *GroupUseCase) RemoveMember(ctx context.Context, userID, groupID, recipientID uuid.UUID) error {
+	if _, err := uc.getOwned(ctx, userID, groupID); err != nil {
+		return err
+	}
+	return uc.groupRepo.RemoveMember(ctx, groupID, recipientID)
+}
+
+func (uc *GroupUseCase) getOwned(ctx context.Context, userID, groupID uuid.UUID) (*domain.Group, error) {
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return group, nil
+}
+
+// duplicateGroupName turns a repository uniqueness failure into ErrDuplicateGroupName.
+func duplicateGroupName(err error) error {
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return ErrDuplicateGroupName
+	}
+	return err
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	minHolidayYear = 1900
+	maxHolidayYear = 2200
+)
+
+var (
+	ErrUnsupportedCountry          = domain.NewError(http.StatusBadRequest, "unsupported_country", "No holiday calendar for this country")
+	ErrInvalidHolidayYear          = domain.NewError(http.StatusBadRequest, "invalid_holiday_year", "Year must be between 1900 and 2200")
+	ErrHolidaySubscriptionNotFound = domain.NewError(http.StatusNotFound, "holiday_subscription_not_found", "Holiday subscription not found")
+	ErrAlreadySubscribed           = domain.NewError(http.StatusConflict, "already_subscribed", "Recipient is already subscribed to this holiday")
+)
+
+// HolidayUseCase implements port.HolidayService on top of the built-in
+// holiday dataset.
+type HolidayUseCase struct {
+	subscriptionRepo port.HolidaySubscriptionRepository
+	permissions      port.PermissionService
+	prefsService     port.PreferencesService
+}
+
+// NewHolidayUseCase creates a new HolidayUseCase.
+func NewHolidayUseCase(subscriptionRepo port.HolidaySubscriptionRepository, permissions port.PermissionService, prefsService port.PreferencesService) *HolidayUseCase {
+	return &HolidayUseCase{subscriptionRepo: subscriptionRepo, permissions: permissions, prefsService: prefsService}
+}
+
+// List resolves a country's holidays to dates in year, sorted by date. Empty
+// arguments fall back to the user's locale, its country and the current year.
+func (uc *HolidayUseCase) List(ctx context.Context, userID uuid.UUID, country string, year int, locale string) ([]domain.HolidayDate, error) {
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if locale == "" {
+		locale = prefs.Locale
+	}
+	if country == "" {
+		country = domain.CountryForLocale(locale)
+	}
+	country = strings.ToUpper(country)
+	if !slices.Contains(domain.HolidayCountries, country) {
+		return nil, ErrUnsupportedCountry
+	}
+	if year == 0 {
+		year = prefs.Today().Year()
+	}
+	if year < minHolidayYear || year > maxHolidayYear {
+		return nil, ErrInvalidHolidayYear
+	}
+
+	holidays := domain.HolidaysFor(country)
+	dates := make([]domain.HolidayDate, 0, len(holidays))
+	for _, h := range holidays {
+		dates = append(dates, domain.HolidayDate{
+			Slug:    h.Slug,
+			Country: h.Country,
+			Name:    h.Label(locale),
+			Date:    h.Rule.In(year),
+			Rule:    h.Rule.String(),
+		})
+	}
+	slices.SortStableFunc(dates, func(a, b domain.HolidayDate) int {
+		return a.Date.Compare(b.Date.Time)
+	})
+	return dates, nil
+}
+
+// Subscribe puts a holiday on a recipient's calendar.
+func (uc *HolidayUseCase) Subscribe(ctx context.Context, userID, recipientID uuid.UUID, req domain.HolidaySubscriptionRequest) (*domain.HolidaySubscription, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	country := req.Country
+	if strings.TrimSpace(country) == "" {
+		prefs, err := uc.prefsService.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		country = domain.CountryForLocale(prefs.Locale)
+	}
+
+	subscription := &domain.HolidaySubscription{
+		ID:          uuid.New(),
+		RecipientID: recipientID,
+		Country:     country,
+		Holiday:     req.Holiday,
+		Budget:      req.Budget,
+		CreatedAt:   time.Now(),
+	}
+	subscription.Normalize()
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.subscriptionRepo.Create(ctx, subscription); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrAlreadySubscribed
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions returns the holidays a recipient is subscribed to.
+func (uc *HolidayUseCase) ListSubscriptions(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.HolidaySubscription, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionView); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := uc.subscriptionRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if subscriptions == nil {
+		subscriptions = []domain.HolidaySubscription{}
+	}
+	return subscriptions, nil
+}
+
+// Unsubscribe takes a holiday off a recipient's calendar.
+func (uc *HolidayUseCase) Unsubscribe(ctx context.Context, userID, recipientID, subscriptionID uuid.UUID) error {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit); err != nil {
+		return err
+	}
+
+	subscription, err := uc.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if subscription == nil || subscription.RecipientID != recipientID {
+		return ErrHolidaySubscriptionNotFound
+	}
+	return uc.subscriptionRepo.Delete(ctx, subscriptionID)
+}
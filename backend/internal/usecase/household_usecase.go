@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrHouseholdNotFound       = domain.NewError(http.StatusNotFound, "household_not_found", "You are not in a household")
+	ErrAlreadyInHousehold      = domain.NewError(http.StatusConflict, "already_in_household", "Already a member of a household")
+	ErrNotHouseholdOwner       = domain.NewError(http.StatusForbidden, "not_household_owner", "Only the household owner can do this")
+	ErrHouseholdMemberNotFound = domain.NewError(http.StatusNotFound, "household_member_not_found", "Household member not found")
+	ErrHouseholdFull           = domain.NewError(http.StatusConflict, "household_full", "The household has no room for more members")
+	ErrOwnerCannotLeave        = domain.NewError(http.StatusConflict, "owner_cannot_leave", "The owner must hand the household over or delete it")
+	ErrInvitationNotFound      = domain.NewError(http.StatusNotFound, "invitation_not_found", "Invitation not found")
+	ErrInvitationExists        = domain.NewError(http.StatusConflict, "invitation_exists", "This email has already been invited or is a member")
+	ErrInvitationEmailMismatch = domain.NewError(http.StatusForbidden, "invitation_email_mismatch", "This invitation was sent to a different email address")
+)
+
+// HouseholdUseCase implements port.HouseholdService.
+type HouseholdUseCase struct {
+	householdRepo port.HouseholdRepository
+	userService   port.UserService
+	notifier      port.Notifier
+	tx            port.Transactor
+}
+
+// NewHouseholdUseCase creates a new HouseholdUseCase.
+func NewHouseholdUseCase(
+	householdRepo port.HouseholdRepository,
+	userService port.UserService,
+	notifier port.Notifier,
+	tx port.Transactor,
+) *HouseholdUseCase {
+	return &HouseholdUseCase{
+		householdRepo: householdRepo,
+		userService:   userService,
+		notifier:      notifier,
+		tx:            tx,
+	}
+}
+
+// Create starts a household with the user as its owner.
+func (uc *HouseholdUseCase) Create(ctx context.Context, userID uuid.UUID, req domain.CreateHouseholdRequest) (*domain.Household, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	household := &domain.Household{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	household.Normalize()
+	if err := household.Validate(); err != nil {
+		return nil, err
+	}
+	household.Members = []domain.HouseholdMember{{
+		HouseholdID: household.ID,
+		UserID:      userID,
+		Email:       user.Email,
+		Name:        user.Name,
+		Role:        domain.HouseholdOwner,
+		JoinedAt:    now,
+	}}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.householdRepo.Create(ctx, household)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrAlreadyInHousehold
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+// Get returns the user's household. The owner also sees pending invitations.
+func (uc *HouseholdUseCase) Get(ctx context.Context, userID uuid.UUID) (*domain.Household, error) {
+	household, err := uc.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if household.Member(userID).Role == domain.HouseholdOwner {
+		if err := uc.loadInvitations(ctx, household); err != nil {
+			return nil, err
+		}
+	}
+	return household, nil
+}
+
+// Update renames the household.
+func (uc *HouseholdUseCase) Update(ctx context.Context, userID uuid.UUID, req domain.UpdateHouseholdRequest) (*domain.Household, error) {
+	household, err := uc.getOwned(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		household.Name = *req.Name
+	}
+	household.Normalize()
+	if err := household.Validate(); err != nil {
+		return nil, err
+	}
+	household.UpdatedAt = time.Now()
+
+	if err := uc.householdRepo.Update(ctx, household); err != nil {
+		return nil, err
+	}
+	if err := uc.loadInvitations(ctx, household); err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+// Delete dissolves the household. Every member keeps their own recipients.
+func (uc *HouseholdUseCase) Delete(ctx context.Context, userID uuid.UUID) error {
+	household, err := uc.getOwned(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return uc.householdRepo.Delete(ctx, household.ID)
+}
+
+// Leave takes the user out of their household. Their recipients are no
+// longer shared and they lose access to the other members'.
+func (uc *HouseholdUseCase) Leave(ctx context.Context, userID uuid.UUID) error {
+	household, err := uc.get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if household.Member(userID).Role == domain.HouseholdOwner {
+		return ErrOwnerCannotLeave
+	}
+	return uc.householdRepo.RemoveMember(ctx, household.ID, userID)
+}
+
+// Invite asks someone by email to join the household and sends them a
+// link to accept with.
+func (uc *HouseholdUseCase) Invite(ctx context.Context, userID uuid.UUID, req domain.InviteHouseholdMemberRequest) (*domain.HouseholdInvitation, error) {
+	household, err := uc.getOwned(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &domain.HouseholdInvitation{
+		ID:          uuid.New(),
+		HouseholdID: household.ID,
+		Email:       req.Email,
+		Role:        req.Role,
+		InvitedBy:   userID,
+		CreatedAt:   time.Now(),
+	}
+	invitation.Normalize()
+	if err := invitation.Validate(); err != nil {
+		return nil, err
+	}
+	for _, m := range household.Members {
+		if strings.EqualFold(m.Email, invitation.Email) {
+			return nil, ErrInvitationExists
+		}
+	}
+	if len(household.Members) >= domain.MaxHouseholdMembers {
+		return nil, ErrHouseholdFull
+	}
+	pending, err := uc.householdRepo.ListInvitations(ctx, household.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) >= domain.MaxHouseholdInvitations {
+		var v domain.Validator
+		v.Add("email", domain.CodeTooMany, "a household can have at most %d pending invitations", domain.MaxHouseholdInvitations)
+		return nil, v.Err()
+	}
+
+	if invitation.Token, err = newInvitationToken(); err != nil {
+		return nil, err
+	}
+	if err := uc.householdRepo.CreateInvitation(ctx, invitation); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrInvitationExists
+		}
+		return nil, err
+	}
+
+	owner := household.Member(userID)
+	inviter := owner.Name
+	if inviter == "" {
+		inviter = owner.Email
+	}
+	uc.notifier.Notify(ctx, domain.Notification{
+		Kind:    domain.NotifyHouseholdInvite,
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("%s invited you to join %s", inviter, household.Name),
+		Body: fmt.Sprintf("%s would like to share their gift recipients with you as a household %s. Sign in with %s and open %s to accept.",
+			inviter, invitation.Role, invitation.Email, invitation.AcceptPath()),
+	})
+	return invitation, nil
+}
+
+// RevokeInvitation withdraws a pending invitation.
+func (uc *HouseholdUseCase) RevokeInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	household, err := uc.getOwned(ctx, userID)
+	if err != nil {
+		return err
+	}
+	invitations, err := uc.householdRepo.ListInvitations(ctx, household.ID)
+	if err != nil {
+		return err
+	}
+	for _, inv := range invitations {
+		if inv.ID == invitationID {
+			return uc.householdRepo.DeleteInvitation(ctx, invitationID)
+		}
+	}
+	return ErrInvitationNotFound
+}
+
+// Accept joins the household behind an invitation token with the role it
+// offers. The invitation is used up.
+func (uc *HouseholdUseCase) Accept(ctx context.Context, userID uuid.UUID, token string) (*domain.Household, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var household *domain.Household
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		invitation, err := uc.householdRepo.GetInvitationByToken(ctx, token)
+		if err != nil {
+			return err
+		}
+		if invitation == nil {
+			return ErrInvitationNotFound
+		}
+		if !strings.EqualFold(invitation.Email, user.Email) {
+			return ErrInvitationEmailMismatch
+		}
+		current, err := uc.householdRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			return ErrAlreadyInHousehold
+		}
+		household, err = uc.householdRepo.GetByID(ctx, invitation.HouseholdID)
+		if err != nil {
+			return err
+		}
+		if household == nil {
+			return ErrInvitationNotFound
+		}
+		if len(household.Members) >= domain.MaxHouseholdMembers {
+			return ErrHouseholdFull
+		}
+
+		member := domain.HouseholdMember{
+			HouseholdID: household.ID,
+			UserID:      userID,
+			Email:       user.Email,
+			Name:        user.Name,
+			Role:        invitation.Role,
+			JoinedAt:    time.Now(),
+		}
+		if err := uc.householdRepo.AddMember(ctx, &member); err != nil {
+			if errors.Is(err, domain.ErrAlreadyExists) {
+				return ErrAlreadyInHousehold
+			}
+			return err
+		}
+		household.Members = append(household.Members, member)
+		return uc.householdRepo.DeleteInvitation(ctx, invitation.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+// UpdateMember changes a member's role. Making them the owner hands the
+// household over; the previous owner stays on as an editor.
+func (uc *HouseholdUseCase) UpdateMember(ctx context.Context, userID, memberID uuid.UUID, req domain.UpdateHouseholdMemberRequest) (*domain.Household, error) {
+	household, err := uc.getOwned(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	member := household.Member(memberID)
+	if member == nil {
+		return nil, ErrHouseholdMemberNotFound
+	}
+
+	var v domain.Validator
+	v.Check(req.Role.Valid(), "role", domain.CodeInvalidChoice, "role must be one of owner, editor, viewer")
+	v.Check(memberID != userID || req.Role == domain.HouseholdOwner, "role", domain.CodeInvalidChoice,
+		"the owner cannot change their own role; make another member the owner instead")
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if member.Role == req.Role {
+		return uc.Get(ctx, userID)
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if req.Role == domain.HouseholdOwner {
+			if err := uc.householdRepo.UpdateMemberRole(ctx, household.ID, userID, domain.HouseholdEditor); err != nil {
+				return err
+			}
+		}
+		return uc.householdRepo.UpdateMemberRole(ctx, household.ID, memberID, req.Role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.Get(ctx, userID)
+}
+
+// RemoveMember takes another member out of the household.
+func (uc *HouseholdUseCase) RemoveMember(ctx context.Context, userID, memberID uuid.UUID) error {
+	household, err := uc.getOwned(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if memberID == userID {
+		return ErrOwnerCannotLeave
+	}
+	if household.Member(memberID) == nil {
+		return ErrHouseholdMemberNotFound
+	}
+	return uc.householdRepo.RemoveMember(ctx, household.ID, memberID)
+}
+
+// get loads the user's household.
+func (uc *HouseholdUseCase) get(ctx context.Context, userID uuid.UUID) (*domain.Household, error) {
+	household, err := uc.householdRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if household == nil {
+		return nil, ErrHouseholdNotFound
+	}
+	return household, nil
+}
+
+// getOwned is get for actions only the owner may take.
+func (uc *HouseholdUseCase) getOwned(ctx context.Context, userID uuid.UUID) (*domain.Household, error) {
+	household, err := uc.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if household.Member(userID).Role != domain.HouseholdOwner {
+		return nil, ErrNotHouseholdOwner
+	}
+	return household, nil
+}
+
+func (uc *HouseholdUseCase) loadInvitations(ctx context.Context, household *domain.Household) error {
+	invitations, err := uc.householdRepo.ListInvitations(ctx, household.ID)
+	if err != nil {
+		return err
+	}
+	if invitations == nil {
+		invitations = []domain.HouseholdInvitation{}
+	}
+	household.Invitations = invitations
+	return nil
+}
+
+// newInvitationToken creates an unguessable household invitation token.
+func newInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
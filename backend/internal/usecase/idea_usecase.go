@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrIdeaNotFound          = domain.NewError(http.StatusNotFound, "idea_not_found", "Gift idea not found")
+	ErrIdeaExists            = domain.NewError(http.StatusConflict, "idea_exists", "This suggestion is already on the idea board")
+	ErrInvalidIdeaTransition = domain.NewError(http.StatusConflict, "invalid_status_transition", "Gift idea cannot move to this status")
+)
+
+// IdeaUseCase implements port.IdeaService.
+type IdeaUseCase struct {
+	ideaRepo       port.IdeaRepository
+	permissions    port.PermissionService
+	giftService    port.GiftService
+	keywordService port.KeywordService
+	prefsService   port.PreferencesService
+	tx             port.Transactor
+}
+
+// NewIdeaUseCase creates a new IdeaUseCase.
+func NewIdeaUseCase(
+	ideaRepo port.IdeaRepository,
+	permissions port.PermissionService,
+	giftService port.GiftService,
+	keywordService port.KeywordService,
+	prefsService port.PreferencesService,
+	tx port.Transactor,
+) *IdeaUseCase {
+	return &IdeaUseCase{
+		ideaRepo:       ideaRepo,
+		permissions:    permissions,
+		giftService:    giftService,
+		keywordService: keywordService,
+		prefsService:   prefsService,
+		tx:             tx,
+	}
+}
+
+// Create saves a gift idea on a recipient's board.
+func (uc *IdeaUseCase) Create(ctx context.Context, userID, recipientID uuid.UUID, req domain.CreateIdeaRequest) (*domain.GiftIdea, error) {
+	recipient, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		req.Currency = recipient.Currency
+	}
+
+	now := time.Now()
+	idea := &domain.GiftIdea{
+		ID:          uuid.New(),
+		RecipientID: recipientID,
+		Title:       req.Title,
+		URL:         req.URL,
+		Price:       req.Price,
+		Currency:    req.Currency,
+		Notes:       req.Notes,
+		Category:    req.Category,
+		Priority:    req.Priority,
+		Status:      domain.IdeaStatusIdea,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.validate(ctx, idea); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ideaRepo.Create(ctx, idea); err != nil {
+		return nil, err
+	}
+	return idea, nil
+}
+
+// Promote saves a catalog suggestion as an idea, titled in the user's
+// preferred locale and priced in the catalog's currency. A suggestion can only be on the board once until it is
+// given.
+func (uc *IdeaUseCase) Promote(ctx context.Context, userID, recipientID uuid.UUID, req domain.PromoteSuggestionRequest) (*domain.GiftIdea, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit); err != nil {
+		return nil, err
+	}
+	item, ok := domain.LookupCatalogItem(req.ItemID)
+	if !ok {
+		var v domain.Validator
+		v.Check(req.ItemID != "", "item_id", domain.CodeRequired, "item_id is required")
+		v.Check(req.ItemID == "", "item_id", domain.CodeInvalidChoice, "item_id is not a catalog item")
+		return nil, v.Err()
+	}
+
+	ideas, err := uc.ideaRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	for _, idea := range ideas {
+		if idea.CatalogItemID == item.ID && idea.Status != domain.IdeaStatusGiven {
+			return nil, ErrIdeaExists
+		}
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	price := item.Price
+	idea := &domain.GiftIdea{
+		ID:            uuid.New(),
+		RecipientID:   recipientID,
+		CatalogItemID: item.ID,
+		Title:         item.Label(prefs.Locale),
+		Price:         &price,
+		Currency:      domain.CatalogCurrency,
+		Notes:         req.Notes,
+		Category:      item.Category,
+		Priority:      req.Priority,
+		Status:        domain.IdeaStatusIdea,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := uc.validate(ctx, idea); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ideaRepo.Create(ctx, idea); err != nil {
+		return nil, err
+	}
+	return idea, nil
+}
+
+// List returns a recipient's ideas, highest priority first.
+func (uc *IdeaUseCase) List(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.GiftIdea, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionView); err != nil {
+		return nil, err
+	}
+
+	ideas, err := uc.ideaRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if ideas == nil {
+		ideas = []domain.GiftIdea{}
+	}
+	return ideas, nil
+}
+
+// GetByID retrieves one of a recipient's ideas.
+func (uc *IdeaUseCase) GetByID(ctx context.Context, userID, recipientID, ideaID uuid.UUID) (*domain.GiftIdea, error) {
+	return uc.getOwned(ctx, userID, recipientID, ideaID, domain.PermissionView)
+}
+
+// Update modifies the provided fields of an idea.
+func (uc *IdeaUseCase) Update(ctx context.Context, userID, recipientID, ideaID uuid.UUID, req domain.UpdateIdeaRequest) (*domain.GiftIdea, error) {
+	idea, err := uc.getOwned(ctx, userID, recipientID, ideaID, domain.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		idea.Title = *req.Title
+	}
+	if req.URL != nil {
+		idea.URL = *req.URL
+	}
+	if req.Price != nil {
+		idea.Price = req.Price
+	}
+	if req.ClearPrice {
+		idea.Price = nil
+	}
+	if req.Currency != nil {
+		idea.Currency = *req.Currency
+	}
+	if req.Notes != nil {
+		idea.Notes = *req.Notes
+	}
+	if req.Category != nil {
+		idea.Category = *req.Category
+	}
+	if req.Priority != nil {
+		idea.Priority = *req.Priority
+	}
+	if err := uc.validate(ctx, idea); err != nil {
+		return nil, err
+	}
+	idea.UpdatedAt = time.Now()
+
+	if err := uc.ideaRepo.Update(ctx, idea); err != nil {
+		return nil, err
+	}
+	return idea, nil
+}
+
+// Transition moves an idea along the status workflow. Reaching given
+// records the idea in the recipient's gift history, in the same
+// transaction.
+func (uc *IdeaUseCase) Transition(ctx context.Context, userID, recipientID, ideaID uuid.UUID, req domain.TransitionIdeaRequest) (*domain.GiftIdea, error) {
+	var idea *domain.GiftIdea
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		idea, err = uc.getOwned(ctx, userID, recipientID, ideaID, domain.PermissionEdit)
+		if err != nil {
+			return err
+		}
+		if !idea.Status.CanTransition(req.Status) {
+			return ErrInvalidIdeaTransition.WithDetail(fmt.Sprintf("cannot move from %s to %q", idea.Status, req.Status))
+		}
+
+		if req.Status == domain.IdeaStatusGiven {
+			var price domain.Money
+			if idea.Price != nil {
+				price = *idea.Price
+			}
+			gift, err := uc.giftService.Create(ctx, userID, recipientID, domain.CreateGiftRequest{
+				OccasionID:    req.OccasionID,
+				CatalogItemID: idea.CatalogItemID,
+				Item:          idea.Title,
+				Category:      idea.Category,
+				Price:         price,
+				Currency:      idea.Currency,
+				GivenOn:       req.GivenOn,
+				Rating:        req.Rating,
+			})
+			if err != nil {
+				return err
+			}
+			idea.GiftID = &gift.ID
+		}
+		idea.Status = req.Status
+		idea.UpdatedAt = time.Now()
+		return uc.ideaRepo.Update(ctx, idea)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idea, nil
+}
+
+// Delete removes an idea. A gift record it produced stays in the history.
+func (uc *IdeaUseCase) Delete(ctx context.Context, userID, recipientID, ideaID uuid.UUID) error {
+	if _, err := uc.getOwned(ctx, userID, recipientID, ideaID, domain.PermissionEdit); err != nil {
+		return err
+	}
+	return uc.ideaRepo.Delete(ctx, ideaID)
+}
+
+// validate normalizes the idea, maps its category onto the interest
+// taxonomy and checks the field rules.
+func (uc *IdeaUseCase) validate(ctx context.Context, idea *domain.GiftIdea) error {
+	idea.Normalize()
+	category, err := canonicalCategory(ctx, uc.keywordService, idea.Category)
+	if err != nil {
+		return err
+	}
+	idea.Category = category
+	return idea.Validate()
+}
+
+// getOwned loads an idea, ensuring it belongs to the given recipient and
+// that the requesting user holds perm on the recipient.
+func (uc *IdeaUseCase) getOwned(ctx context.Context, userID, recipientID, ideaID uuid.UUID, perm domain.Permission) (*domain.GiftIdea, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, perm); err != nil {
+		return nil, err
+	}
+	idea, err := uc.ideaRepo.GetByID(ctx, ideaID)
+	if err != nil {
+		return nil, err
+	}
+	if idea == nil || idea.RecipientID != recipientID {
+		return nil, ErrIdeaNotFound
+	}
+	return idea, nil
+}
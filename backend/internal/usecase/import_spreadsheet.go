@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/xlsx"
+)
+
+var ErrImportNotSpreadsheet = domain.NewError(http.StatusConflict, "import_not_spreadsheet", "Only spreadsheet imports have a column mapping")
+
+// PreviewSpreadsheet reads recipients from a CSV or XLSX file whose first
+// row holds the column headers. Columns are mapped to fields by header name
+// until MapColumns says otherwise.
+func (uc *ImportUseCase) PreviewSpreadsheet(ctx context.Context, userID uuid.UUID, data []byte) (*domain.ImportSession, error) {
+	var (
+		rows   [][]string
+		source domain.ImportSource
+		err    error
+	)
+	if xlsx.IsWorkbook(data) {
+		source = domain.ImportSourceXLSX
+		rows, err = xlsx.ReadRows(data)
+	} else {
+		source = domain.ImportSourceCSV
+		rows, err = readCSV(data)
+	}
+	if err != nil {
+		return nil, ErrInvalidImportFile.WithDetail(err.Error())
+	}
+	if len(rows) == 0 || isBlankRow(rows[0]) {
+		return nil, ErrInvalidImportFile.WithDetail("the first row must hold the column headers")
+	}
+
+	session := newImportSession(userID, source)
+	session.Columns = headerNames(rows[0])
+	session.Rows = rows[1:]
+	session.Mapping = domain.SuggestColumnMapping(session.Columns)
+	session.DateFormat = detectDateFormat(session)
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.preview(ctx, session, sheetCandidates(session, prefs.Today()), prefs)
+}
+
+// MapColumns rebuilds the items of a spreadsheet import with a new column
+// mapping and date format. Without a date format, it is detected again when
+// the birthdate column changes.
+func (uc *ImportUseCase) MapColumns(ctx context.Context, userID, importID uuid.UUID, req domain.MapColumnsRequest) (*domain.ImportSession, error) {
+	session, err := uc.getOwned(ctx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Spreadsheet() {
+		return nil, ErrImportNotSpreadsheet
+	}
+	if session.AppliedAt != nil {
+		return nil, ErrImportApplied
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrImportExpired
+	}
+
+	if req.DateFormat != "" {
+		var v domain.Validator
+		_, ok := domain.DateFormats[req.DateFormat]
+		v.Check(ok, "date_format", domain.CodeInvalidChoice, "date_format must be one of %s, %s, %s",
+			domain.DateFormatISO, domain.DateFormatDMY, domain.DateFormatMDY)
+		if err := v.Err(); err != nil {
+			return nil, err
+		}
+		session.DateFormat = req.DateFormat
+	}
+	if err := req.Mapping.Validate(session.Columns); err != nil {
+		return nil, err
+	}
+	redetect := req.DateFormat == "" && req.Mapping[domain.FieldBirthdate] != session.Mapping[domain.FieldBirthdate]
+	session.Mapping = req.Mapping
+	if redetect {
+		session.DateFormat = detectDateFormat(session)
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.buildItems(ctx, userID, sheetCandidates(session, prefs.Today()), prefs)
+	if err != nil {
+		return nil, err
+	}
+	session.Items = items
+	session.Summarize()
+
+	updated, err := uc.importRepo.UpdateItems(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrImportApplied
+	}
+	return session, nil
+}
+
+// sheetCandidates reads one candidate per non-blank row using the session's
+// mapping. Cells that cannot be read are reported as item errors.
+func sheetCandidates(session *domain.ImportSession, today time.Time) []importCandidate {
+	cols := make(map[domain.ImportField]int, len(session.Mapping))
+	for field, column := range session.Mapping {
+		if col := columnPosition(session.Columns, column); col >= 0 {
+			cols[field] = col
+		}
+	}
+	value := func(row []string, field domain.ImportField) string {
+		col, ok := cols[field]
+		if !ok {
+			return ""
+		}
+		v := strings.TrimSpace(cell(row, col))
+		// Undo the quote exports put before formula-like text.
+		if len(v) > 1 && v[0] == '\'' && strings.ContainsRune("=+-@", rune(v[1])) {
+			v = v[1:]
+		}
+		return v
+	}
+
+	var candidates []importCandidate
+	for i, row := range session.Rows {
+		if isBlankRow(row) {
+			continue
+		}
+		// Rows are numbered as in the file, below the header row.
+		c := importCandidate{row: i + 2}
+		req := &c.request
+		req.Name = value(row, domain.FieldName)
+		req.Gender = spreadsheetGender(value(row, domain.FieldGender))
+		if rel := value(row, domain.FieldRelationship); rel != "" {
+			req.Relationship = strings.ReplaceAll(domain.KeywordKey(rel), " ", "_")
+		}
+		req.Currency = value(row, domain.FieldCurrency)
+		req.Keywords = domain.SplitKeywords(value(row, domain.FieldKeywords))
+
+		if v := value(row, domain.FieldBirthdate); v != "" {
+			if d, err := domain.ParseDateFormat(v, session.DateFormat); err == nil {
+				c.setBirthdate(d, today)
+			} else {
+				c.fail(domain.FieldBirthdate, "birthdate %q is not a %s date", v, session.DateFormat)
+			}
+		}
+		if v := value(row, domain.FieldAge); v != "" {
+			if age, err := strconv.ParseFloat(v, 64); err == nil && age == float64(int(age)) {
+				req.Age = int(age)
+			} else {
+				c.fail(domain.FieldAge, "age %q is not a whole number", v)
+			}
+		}
+		if v := value(row, domain.FieldMinBudget); v != "" {
+			if amount, ok := parseAmount(v); ok {
+				req.MinBudget = amount
+			} else {
+				c.fail(domain.FieldMinBudget, "min_budget %q is not an amount", v)
+			}
+		}
+		if v := value(row, domain.FieldMaxBudget); v != "" {
+			if amount, ok := parseAmount(v); ok {
+				req.MaxBudget = amount
+			} else {
+				c.fail(domain.FieldMaxBudget, "max_budget %q is not an amount", v)
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// detectDateFormat guesses the date format from the mapped birthdate column.
+func detectDateFormat(session *domain.ImportSession) string {
+	var dates []string
+	if col := columnPosition(session.Columns, session.Mapping[domain.FieldBirthdate]); col >= 0 {
+		for _, row := range session.Rows {
+			dates = append(dates, cell(row, col))
+		}
+	}
+	return domain.DetectDateFormat(dates)
+}
+
+func (c *importCandidate) fail(field domain.ImportField, format string, args ...any) {
+	c.errors = append(c.errors, domain.FieldError{
+		Field:   string(field),
+		Code:    domain.CodeInvalidFormat,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// spreadsheetGenders maps common gender spellings, folded with KeywordKey,
+// onto recipient genders. Anything else is left for validation to reject.
+var spreadsheetGenders = map[string]string{
+	"f": domain.GenderFemale, "woman": domain.GenderFemale, "feminino": domain.GenderFemale, "mulher": domain.GenderFemale,
+	"m": domain.GenderMale, "man": domain.GenderMale, "masculino": domain.GenderMale, "homem": domain.GenderMale,
+	"nb": domain.GenderNonBinary, "non binary": domain.GenderNonBinary, "nonbinary": domain.GenderNonBinary, "nao binario": domain.GenderNonBinary,
+	"o": domain.GenderOther, "outro": domain.GenderOther,
+}
+
+func spreadsheetGender(v string) string {
+	if gender, ok := spreadsheetGenders[domain.KeywordKey(v)]; ok {
+		return gender
+	}
+	return strings.ReplaceAll(domain.KeywordKey(v), " ", "_")
+}
+
+// parseAmount reads a money amount, ignoring currency symbols and accepting
+// a decimal comma such as "R$ 150,00".
+func parseAmount(v string) (domain.Money, bool) {
+	v = strings.TrimFunc(v, func(r rune) bool {
+		return !(r >= '0' && r <= '9') && r != '-'
+	})
+	v = strings.ReplaceAll(v, " ", "")
+	if strings.Contains(v, ",") {
+		if strings.Contains(v, ".") {
+			// Whichever separator comes last is the decimal one.
+			if strings.LastIndex(v, ",") > strings.LastIndex(v, ".") {
+				v = strings.ReplaceAll(v, ".", "")
+			} else {
+				v = strings.ReplaceAll(v, ",", "")
+			}
+		}
+		v = strings.ReplaceAll(v, ",", ".")
+	}
+	amount, err := domain.ParseMoney(v)
+	return amount, err == nil
+}
+
+// readCSV parses a UTF-8 CSV file, detecting whether fields are separated
+// by commas, semicolons or tabs from the header line.
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("CSV files must be UTF-8 encoded")
+	}
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	comma := ','
+	best := bytes.Count(header, []byte(","))
+	for _, sep := range []rune{';', '\t'} {
+		if n := bytes.Count(header, []byte(string(sep))); n > best {
+			comma, best = sep, n
+		}
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// headerNames trims the header cells, names blank ones after their position
+// and numbers repeated ones, so every column has a distinct name.
+func headerNames(header []string) []string {
+	names := make([]string, len(header))
+	seen := make(map[string]int, len(header))
+	for i, h := range header {
+		name := strings.TrimSpace(h)
+		if name == "" {
+			name = fmt.Sprintf("Column %d", i+1)
+		}
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name = fmt.Sprintf("%s (%d)", name, n)
+		}
+		names[i] = name
+	}
+	return names
+}
+
+func columnPosition(columns []string, name string) int {
+	for i, col := range columns {
+		if name != "" && col == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func cell(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+func isBlankRow(row []string) bool {
+	for _, c := range row {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
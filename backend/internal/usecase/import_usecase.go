@@ -0,0 +1,450 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/ical"
+	"github.com/vsssp/birthday-app/backend/internal/pkg/vcard"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrImportNotFound     = domain.NewError(http.StatusNotFound, "import_not_found", "Import not found")
+	ErrImportExpired      = domain.NewError(http.StatusGone, "import_expired", "Import preview has expired")
+	ErrImportApplied      = domain.NewError(http.StatusConflict, "import_already_applied", "Import has already been applied")
+	ErrInvalidImportFile  = domain.NewError(http.StatusBadRequest, "invalid_import_file", "File could not be parsed")
+	ErrTooManyImportItems = domain.NewError(http.StatusRequestEntityTooLarge, "too_many_import_items", "Imports are limited to 1000 recipients")
+)
+
+// ImportUseCase implements port.ImportService. Files are parsed into a
+// stored preview first; confirming it creates the recipients in one
+// transaction.
+type ImportUseCase struct {
+	importRepo       port.ImportRepository
+	recipientRepo    port.RecipientRepository
+	recipientService port.RecipientService
+	occasionService  port.OccasionService
+	keywordService   port.KeywordService
+	tx               port.Transactor
+	prefsService     port.PreferencesService
+	permissions      port.PermissionService
+}
+
+// NewImportUseCase creates a new ImportUseCase.
+func NewImportUseCase(
+	importRepo port.ImportRepository,
+	recipientRepo port.RecipientRepository,
+	recipientService port.RecipientService,
+	occasionService port.OccasionService,
+	keywordService port.KeywordService,
+	tx port.Transactor,
+	prefsService port.PreferencesService,
+	permissions port.PermissionService,
+) *ImportUseCase {
+	return &ImportUseCase{
+		importRepo:       importRepo,
+		recipientRepo:    recipientRepo,
+		recipientService: recipientService,
+		occasionService:  occasionService,
+		keywordService:   keywordService,
+		tx:               tx,
+		prefsService:     prefsService,
+		permissions:      permissions,
+	}
+}
+
+// importCandidate is a recipient read from a file, before validation.
+type importCandidate struct {
+	row       int
+	request   domain.CreateRecipientRequest
+	occasions []domain.CreateOccasionRequest
+	warnings  []string
+	// errors are values the file held that could not be read.
+	errors []domain.FieldError
+}
+
+// PreviewICS detects yearly birthday events in an iCalendar file and stores
+// the recipients they would create.
+func (uc *ImportUseCase) PreviewICS(ctx context.Context, userID uuid.UUID, data []byte) (*domain.ImportSession, error) {
+	cal, err := ical.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImportFile.WithDetail(err.Error())
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := prefs.Today()
+
+	var candidates []importCandidate
+	for _, ev := range cal.Events {
+		name, ok := birthdayEventName(ev)
+		if !ok {
+			continue
+		}
+
+		c := importCandidate{request: domain.CreateRecipientRequest{Name: name}}
+		// Calendars store birthdays with an unknown year under placeholder
+		// years such as 1604, which recipients cannot hold.
+		if ev.Date.Year() >= 1900 {
+			c.setBirthdate(domain.NewDate(ev.Date), today)
+		} else {
+			c.warnings = append(c.warnings, domain.WarningBirthYearUnknown)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return uc.preview(ctx, newImportSession(userID, domain.ImportSourceICS), candidates, prefs)
+}
+
+// PreviewVCard reads contacts from a vCard 3.0 or 4.0 file and stores the
+// recipients they would create. BDAY becomes the birthdate, ANNIVERSARY a
+// yearly anniversary occasion and CATEGORIES the keywords.
+func (uc *ImportUseCase) PreviewVCard(ctx context.Context, userID uuid.UUID, data []byte) (*domain.ImportSession, error) {
+	cards, err := vcard.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImportFile.WithDetail(err.Error())
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := prefs.Today()
+
+	candidates := make([]importCandidate, 0, len(cards))
+	for i := range cards {
+		card := &cards[i]
+		c := importCandidate{request: domain.CreateRecipientRequest{
+			Name:     card.DisplayName(),
+			Gender:   vcardGenders[card.Gender],
+			Keywords: card.Categories,
+		}}
+
+		if b := card.Birthday; b != nil {
+			if b.HasYear() && b.Year >= 1900 {
+				c.setBirthdate(domain.NewDate(b.Time()), today)
+			} else {
+				c.warnings = append(c.warnings, domain.WarningBirthYearUnknown)
+			}
+		}
+		if a := card.Anniversary; a != nil {
+			if a.HasYear() && a.Year >= 1900 {
+				date := domain.NewDate(a.Time())
+				c.occasions = append(c.occasions, domain.CreateOccasionRequest{
+					Kind: domain.OccasionAnniversary,
+					Date: &date,
+				})
+			} else {
+				c.warnings = append(c.warnings, domain.WarningAnniversaryYearUnknown)
+			}
+		}
+		candidates = append(candidates, c)
+	}
+
+	return uc.preview(ctx, newImportSession(userID, domain.ImportSourceVCard), candidates, prefs)
+}
+
+// vcardGenders maps the GENDER sex component onto recipient genders. None
+// and unknown are left empty so the recipient default applies.
+var vcardGenders = map[string]string{
+	"F": domain.GenderFemale,
+	"M": domain.GenderMale,
+	"O": domain.GenderOther,
+}
+
+// setBirthdate sets the birthdate and, unless it lies in the future, the
+// age it implies.
+func (c *importCandidate) setBirthdate(birthdate domain.Date, today time.Time) {
+	c.request.Birthdate = &birthdate
+	if !birthdate.After(domain.NewDate(today).Time) {
+		c.request.Age = birthdate.AgeOn(today)
+	}
+}
+
+// birthdayEventName returns the person an event celebrates if it is a yearly
+// birthday, recognized by its title or a BIRTHDAY category.
+func birthdayEventName(ev ical.Event) (string, bool) {
+	if !strings.Contains(strings.ToUpper(ev.RRule), "FREQ=YEARLY") {
+		return "", false
+	}
+	if name, ok := domain.BirthdayName(ev.Summary); ok {
+		return name, true
+	}
+	for _, c := range ev.Categories {
+		if strings.EqualFold(c, "birthday") || strings.EqualFold(c, "birthdays") {
+			name := strings.TrimSpace(ev.Summary)
+			return name, name != ""
+		}
+	}
+	return "", false
+}
+
+// newImportSession starts an unsaved session that expires after
+// domain.ImportSessionTTL.
+func newImportSession(userID uuid.UUID, source domain.ImportSource) *domain.ImportSession {
+	now := time.Now()
+	return &domain.ImportSession{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Source:    source,
+		CreatedAt: now,
+		ExpiresAt: now.Add(domain.ImportSessionTTL),
+	}
+}
+
+// preview builds the session items from candidates and stores the session.
+func (uc *ImportUseCase) preview(ctx context.Context, session *domain.ImportSession, candidates []importCandidate, prefs *domain.UserPreferences) (*domain.ImportSession, error) {
+	if len(candidates) > domain.MaxImportItems {
+		return nil, ErrTooManyImportItems
+	}
+
+	items, err := uc.buildItems(ctx, session.UserID, candidates, prefs)
+	if err != nil {
+		return nil, err
+	}
+	session.Items = items
+	session.Summarize()
+
+	if err := uc.importRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// buildItems validates candidates, normalized the same way confirming will
+// create them, and flags likely duplicates of the recipients the user can
+// see, their household's included, and of earlier candidates.
+func (uc *ImportUseCase) buildItems(ctx context.Context, userID uuid.UUID, candidates []importCandidate, prefs *domain.UserPreferences) ([]domain.ImportItem, error) {
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := uc.recipientRepo.ListByOwners(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.ImportItem, 0, len(candidates))
+	for i, c := range candidates {
+		item := domain.ImportItem{
+			Index:     i,
+			Row:       c.row,
+			Recipient: c.request,
+			Occasions: c.occasions,
+			Warnings:  c.warnings,
+			Errors:    c.errors,
+		}
+
+		recipient := recipientFromRequest(userID, item.Recipient, prefs.Currency)
+		if err := normalizeRecipient(ctx, uc.keywordService, recipient); err != nil {
+			return nil, err
+		}
+		item.Recipient.Name = recipient.Name
+		item.Recipient.Keywords = recipient.Keywords
+		if err := recipient.Validate(prefs.Today()); err != nil {
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) {
+				return nil, err
+			}
+			item.Errors = append(item.Errors, domainErr.Fields...)
+		}
+
+		for _, r := range existing {
+			if domain.SamePerson(r.Name, r.Birthdate, recipient.Name, recipient.Birthdate) {
+				id := r.ID
+				item.DuplicateOf = &id
+				break
+			}
+		}
+		if item.DuplicateOf != nil || uc.repeatsEarlierItem(items, &item) {
+			item.Warnings = append(item.Warnings, domain.WarningDuplicate)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (uc *ImportUseCase) repeatsEarlierItem(items []domain.ImportItem, item *domain.ImportItem) bool {
+	for i := range items {
+		earlier := &items[i].Recipient
+		if domain.SamePerson(earlier.Name, earlier.Birthdate, item.Recipient.Name, item.Recipient.Birthdate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns a stored import preview.
+func (uc *ImportUseCase) Get(ctx context.Context, userID, importID uuid.UUID) (*domain.ImportSession, error) {
+	return uc.getOwned(ctx, userID, importID)
+}
+
+// Confirm creates the selected recipients of a preview in bulk. Either
+// every selected item is imported or none is.
+func (uc *ImportUseCase) Confirm(ctx context.Context, userID, importID uuid.UUID, req domain.ConfirmImportRequest) (*domain.ImportResult, error) {
+	session, err := uc.getOwned(ctx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	if session.AppliedAt != nil {
+		return nil, ErrImportApplied
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrImportExpired
+	}
+
+	selected, err := selectImportItems(session, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{RecipientIDs: []uuid.UUID{}}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		applied, err := uc.importRepo.MarkApplied(ctx, session.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return ErrImportApplied
+		}
+
+		var items []*domain.ImportItem
+		var reqs []domain.CreateRecipientRequest
+		for i := range session.Items {
+			item := &session.Items[i]
+			if !selected[item.Index] {
+				result.Skipped++
+				continue
+			}
+			items = append(items, item)
+			reqs = append(reqs, item.Recipient)
+		}
+
+		recipients, err := uc.recipientService.CreateMany(ctx, userID, reqs)
+		if err != nil {
+			return reindexRejectedItems(err, items)
+		}
+		for i, item := range items {
+			for _, occasion := range item.Occasions {
+				if _, err := uc.occasionService.Create(ctx, userID, recipients[i].ID, occasion); err != nil {
+					return importItemRejected(item, err)
+				}
+			}
+			result.RecipientIDs = append(result.RecipientIDs, recipients[i].ID)
+		}
+		result.Created = len(recipients)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// reindexRejectedItems rewrites the "recipients[i]" fields of a bulk create
+// failure to the "items[index]" of the import.
+func reindexRejectedItems(err error, items []*domain.ImportItem) error {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || len(domainErr.Fields) == 0 {
+		return err
+	}
+	fields := make([]domain.FieldError, len(domainErr.Fields))
+	for i, f := range domainErr.Fields {
+		var pos int
+		var rest string
+		if n, _ := fmt.Sscanf(f.Field, "recipients[%d]", &pos); n == 1 && pos >= 0 && pos < len(items) {
+			_, rest, _ = strings.Cut(f.Field, "]")
+			f.Field = fmt.Sprintf("items[%d]%s", items[pos].Index, rest)
+		}
+		fields[i] = f
+	}
+	return domainErr.WithFields(fields)
+}
+
+// importItemRejected names the failing item in domain errors.
+func importItemRejected(item *domain.ImportItem, err error) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.WithDetail(fmt.Sprintf("import item %d was rejected", item.Index))
+	}
+	return err
+}
+
+// selectImportItems resolves which items to create. Explicit indexes are
+// imported as given, duplicates included; otherwise every valid item is,
+// skipping duplicates unless IncludeDuplicates is set.
+func selectImportItems(session *domain.ImportSession, req domain.ConfirmImportRequest) (map[int]bool, error) {
+	selected := make(map[int]bool, len(session.Items))
+	if req.Indexes == nil {
+		for i := range session.Items {
+			item := &session.Items[i]
+			selected[item.Index] = item.Valid() && (req.IncludeDuplicates || !item.Duplicate())
+		}
+		return selected, nil
+	}
+
+	var v domain.Validator
+	for i, index := range req.Indexes {
+		field := fmt.Sprintf("indexes[%d]", i)
+		inRange := index >= 0 && index < len(session.Items)
+		v.Check(inRange, field, domain.CodeOutOfRange, "index %d is not in this import", index)
+		if inRange {
+			v.Check(session.Items[index].Valid(), field, domain.CodeInvalidChoice, "item %d is not valid", index)
+			selected[index] = true
+		}
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// PurgeExpired deletes import previews that were never confirmed in time.
+func (uc *ImportUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	return uc.importRepo.DeleteExpired(ctx, time.Now())
+}
+
+func (uc *ImportUseCase) getOwned(ctx context.Context, userID, importID uuid.UUID) (*domain.ImportSession, error) {
+	session, err := uc.importRepo.GetByID(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrImportNotFound
+	}
+	if session.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return session, nil
+}
+
+// recipientFromRequest builds the recipient a create request describes,
+// without normalizing or validating it. currency applies when the request
+// names none.
+func recipientFromRequest(userID uuid.UUID, req domain.CreateRecipientRequest, currency string) *domain.Recipient {
+	if req.Currency != "" {
+		currency = req.Currency
+	}
+	return &domain.Recipient{
+		UserID:       userID,
+		Name:         req.Name,
+		Age:          req.Age,
+		Gender:       req.Gender,
+		Relationship: req.Relationship,
+		Birthdate:    req.Birthdate,
+		MinBudget:    req.MinBudget,
+		MaxBudget:    req.MaxBudget,
+		Currency:     currency,
+		Keywords:     req.Keywords,
+	}
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	defaultKeywordSuggestLimit = 10
+	maxKeywordSuggestLimit     = 50
+	taxonomyCacheTTL           = 5 * time.Minute
+)
+
+var (
+	ErrInvalidKeywordPrefix = domain.NewError(http.StatusBadRequest, "invalid_keyword_prefix", "Prefix must be at most 50 characters")
+	ErrInvalidSuggestLimit  = domain.NewError(http.StatusBadRequest, "invalid_suggest_limit", "Limit must be between 1 and 50")
+)
+
+// KeywordUseCase implements port.KeywordService. The taxonomy changes rarely,
+// so it is cached in memory and reloaded after taxonomyCacheTTL.
+type KeywordUseCase struct {
+	keywordRepo  port.KeywordRepository
+	prefsService port.PreferencesService
+
+	mu       sync.Mutex
+	taxonomy *domain.Taxonomy
+	loadedAt time.Time
+}
+
+// NewKeywordUseCase creates a new KeywordUseCase.
+func NewKeywordUseCase(keywordRepo port.KeywordRepository, prefsService port.PreferencesService) *KeywordUseCase {
+	return &KeywordUseCase{keywordRepo: keywordRepo, prefsService: prefsService}
+}
+
+// Suggest returns interests matching prefix for autocomplete. Labels use
+// locale, or the user's preferred locale when locale is empty.
+func (uc *KeywordUseCase) Suggest(ctx context.Context, userID uuid.UUID, prefix, locale string, limit int) ([]domain.KeywordSuggestion, error) {
+	if utf8.RuneCountInString(prefix) > domain.MaxKeywordLength {
+		return nil, ErrInvalidKeywordPrefix
+	}
+	if limit == 0 {
+		limit = defaultKeywordSuggestLimit
+	}
+	if limit < 1 || limit > maxKeywordSuggestLimit {
+		return nil, ErrInvalidSuggestLimit
+	}
+	if locale == "" {
+		prefs, err := uc.prefsService.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		locale = prefs.Locale
+	}
+
+	taxonomy, err := uc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return taxonomy.Suggest(prefix, locale, limit), nil
+}
+
+// Canonicalize maps keywords onto taxonomy slugs, merging synonyms.
+func (uc *KeywordUseCase) Canonicalize(ctx context.Context, keywords []string) ([]string, error) {
+	if len(keywords) == 0 {
+		return keywords, nil
+	}
+	taxonomy, err := uc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return taxonomy.CanonicalizeAll(keywords), nil
+}
+
+func (uc *KeywordUseCase) load(ctx context.Context) (*domain.Taxonomy, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.taxonomy != nil && time.Since(uc.loadedAt) < taxonomyCacheTTL {
+		return uc.taxonomy, nil
+	}
+	interests, err := uc.keywordRepo.ListInterests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uc.taxonomy = domain.NewTaxonomy(interests)
+	uc.loadedAt = time.Now()
+	return uc.taxonomy, nil
+}
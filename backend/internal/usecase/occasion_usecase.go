@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var ErrOccasionNotFound = domain.NewError(http.StatusNotFound, "occasion_not_found", "Occasion not found")
+
+// OccasionUseCase implements port.OccasionService.
+type OccasionUseCase struct {
+	occasionRepo port.OccasionRepository
+	permissions  port.PermissionService
+}
+
+// NewOccasionUseCase creates a new OccasionUseCase.
+func NewOccasionUseCase(occasionRepo port.OccasionRepository, permissions port.PermissionService) *OccasionUseCase {
+	return &OccasionUseCase{occasionRepo: occasionRepo, permissions: permissions}
+}
+
+// Create adds an occasion to one of the user's recipients.
+func (uc *OccasionUseCase) Create(ctx context.Context, userID, recipientID uuid.UUID, req domain.CreateOccasionRequest) (*domain.Occasion, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	occasion := &domain.Occasion{
+		ID:          uuid.New(),
+		RecipientID: recipientID,
+		Kind:        req.Kind,
+		Title:       req.Title,
+		Recurring:   true,
+		Budget:      req.Budget,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if req.Date != nil {
+		occasion.Date = *req.Date
+	}
+	if req.Recurring != nil {
+		occasion.Recurring = *req.Recurring
+	}
+	occasion.Normalize()
+	if err := occasion.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.occasionRepo.Create(ctx, occasion); err != nil {
+		return nil, err
+	}
+	return occasion, nil
+}
+
+// List returns a recipient's occasions ordered by date.
+func (uc *OccasionUseCase) List(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.Occasion, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionView); err != nil {
+		return nil, err
+	}
+
+	occasions, err := uc.occasionRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if occasions == nil {
+		occasions = []domain.Occasion{}
+	}
+	return occasions, nil
+}
+
+// GetByID retrieves one of a recipient's occasions.
+func (uc *OccasionUseCase) GetByID(ctx context.Context, userID, recipientID, occasionID uuid.UUID) (*domain.Occasion, error) {
+	return uc.getOwned(ctx, userID, recipientID, occasionID, domain.PermissionView)
+}
+
+// Update modifies the provided fields of an occasion.
+func (uc *OccasionUseCase) Update(ctx context.Context, userID, recipientID, occasionID uuid.UUID, req domain.UpdateOccasionRequest) (*domain.Occasion, error) {
+	occasion, err := uc.getOwned(ctx, userID, recipientID, occasionID, domain.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Kind != nil {
+		occasion.Kind = *req.Kind
+	}
+	if req.Title != nil {
+		occasion.Title = *req.Title
+	}
+	if req.Date != nil {
+		occasion.Date = *req.Date
+	}
+	if req.Recurring != nil {
+		occasion.Recurring = *req.Recurring
+	}
+	if req.Budget != nil {
+		occasion.Budget = req.Budget
+	}
+	if req.ClearBudget {
+		occasion.Budget = nil
+	}
+	occasion.Normalize()
+	if err := occasion.Validate(); err != nil {
+		return nil, err
+	}
+	occasion.UpdatedAt = time.Now()
+
+	if err := uc.occasionRepo.Update(ctx, occasion); err != nil {
+		return nil, err
+	}
+	return occasion, nil
+}
+
+// Delete removes an occasion.
+func (uc *OccasionUseCase) Delete(ctx context.Context, userID, recipientID, occasionID uuid.UUID) error {
+	if _, err := uc.getOwned(ctx, userID, recipientID, occasionID, domain.PermissionEdit); err != nil {
+		return err
+	}
+	return uc.occasionRepo.Delete(ctx, occasionID)
+}
+
+// getOwned loads an occasion, ensuring it belongs to the given recipient and
+// that the requesting user holds perm on the recipient.
+func (uc *OccasionUseCase) getOwned(ctx context.Context, userID, recipientID, occasionID uuid.UUID, perm domain.Permission) (*domain.Occasion, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, recipientID, perm); err != nil {
+		return nil, err
+	}
+	occasion, err := uc.occasionRepo.GetByID(ctx, occasionID)
+	if err != nil {
+		return nil, err
+	}
+	if occasion == nil || occasion.RecipientID != recipientID {
+		return nil, ErrOccasionNotFound
+	}
+	return occasion, nil
+}
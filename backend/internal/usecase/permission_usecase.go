@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+// PermissionUseCase implements port.PermissionService.
+type PermissionUseCase struct {
+	recipientRepo port.RecipientRepository
+	householdRepo port.HouseholdRepository
+}
+
+// NewPermissionUseCase creates a new PermissionUseCase.
+func NewPermissionUseCase(recipientRepo port.RecipientRepository, householdRepo port.HouseholdRepository) *PermissionUseCase {
+	return &PermissionUseCase{recipientRepo: recipientRepo, householdRepo: householdRepo}
+}
+
+// Recipient loads a live recipient and checks that userID holds perm on it.
+func (uc *PermissionUseCase) Recipient(ctx context.Context, userID, recipientID uuid.UUID, perm domain.Permission) (*domain.Recipient, error) {
+	recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if recipient == nil {
+		return nil, ErrRecipientNotFound
+	}
+	if err := uc.Authorize(ctx, userID, recipient, perm); err != nil {
+		return nil, err
+	}
+	return recipient, nil
+}
+
+// Authorize checks that userID holds perm on recipient. Owners may do
+// anything; a household member gets what their role grants on the
+// recipients of the other members.
+func (uc *PermissionUseCase) Authorize(ctx context.Context, userID uuid.UUID, recipient *domain.Recipient, perm domain.Permission) error {
+	if recipient.UserID == userID {
+		return nil
+	}
+	household, err := uc.householdRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if household == nil || household.Member(recipient.UserID) == nil {
+		return ErrForbidden
+	}
+	if member := household.Member(userID); member == nil || !member.Role.Grants(perm) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// RecipientOwners returns userID followed by the other members of its household.
+func (uc *PermissionUseCase) RecipientOwners(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	household, err := uc.householdRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owners := []uuid.UUID{userID}
+	if household == nil {
+		return owners, nil
+	}
+	for _, id := range household.MemberIDs() {
+		if id != userID {
+			owners = append(owners, id)
+		}
+	}
+	return owners, nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	maxReminderLeadTimes = 5
+	maxReminderLeadDays  = 365
+)
+
+var (
+	ErrInvalidTimezone     = domain.NewError(http.StatusBadRequest, "invalid_timezone", "Timezone must be a valid IANA time zone")
+	ErrUnsupportedLocale   = domain.NewError(http.StatusBadRequest, "unsupported_locale", "Unsupported locale")
+	ErrUnsupportedCurrency = domain.NewError(http.StatusBadRequest, "unsupported_currency", "Unsupported currency")
+	ErrInvalidReminderDays = domain.NewError(http.StatusBadRequest, "invalid_reminder_days", "Reminder lead days must be unique values between 0 and 365, at most 5")
+)
+
+// PreferencesUseCase implements port.PreferencesService.
+type PreferencesUseCase struct {
+	prefsRepo port.PreferencesRepository
+}
+
+// NewPreferencesUseCase creates a new PreferencesUseCase.
+func NewPreferencesUseCase(prefsRepo port.PreferencesRepository) *PreferencesUseCase {
+	return &PreferencesUseCase{prefsRepo: prefsRepo}
+}
+
+// Get returns the user's preferences, or the defaults if none were saved.
+func (uc *PreferencesUseCase) Get(ctx context.Context, userID uuid.UUID) (*domain.UserPreferences, error) {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs == nil {
+		return domain.NewDefaultPreferences(userID), nil
+	}
+	return prefs, nil
+}
+
+// Update validates and stores the provided preference fields.
+func (uc *PreferencesUseCase) Update(ctx context.Context, userID uuid.UUID, req domain.UpdatePreferencesRequest) (*domain.UserPreferences, error) {
+	prefs, err := uc.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Timezone != nil {
+		if !isValidTimezone(*req.Timezone) {
+			return nil, ErrInvalidTimezone
+		}
+		prefs.Timezone = *req.Timezone
+	}
+	if req.Locale != nil {
+		if !slices.Contains(domain.SupportedLocales, *req.Locale) {
+			return nil, ErrUnsupportedLocale
+		}
+		prefs.Locale = *req.Locale
+	}
+	if req.Currency != nil {
+		currency := strings.ToUpper(*req.Currency)
+		if !slices.Contains(domain.SupportedCurrencies, currency) {
+			return nil, ErrUnsupportedCurrency
+		}
+		prefs.Currency = currency
+	}
+	if req.ReminderLeadDays != nil {
+		days, ok := normalizeReminderDays(*req.ReminderLeadDays)
+		if !ok {
+			return nil, ErrInvalidReminderDays
+		}
+		prefs.ReminderLeadDays = days
+	}
+	prefs.UpdatedAt = time.Now()
+
+	if err := uc.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// isValidTimezone reports whether name is an IANA zone. time.LoadLocation also
+// accepts "" and "Local", which depend on the server and are rejected here.
+func isValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// normalizeReminderDays validates the lead times and sorts them furthest first.
+func normalizeReminderDays(days []int) ([]int, bool) {
+	if len(days) > maxReminderLeadTimes {
+		return nil, false
+	}
+	sorted := slices.Clone(days)
+	slices.SortFunc(sorted, func(a, b int) int { return b - a })
+	for i, d := range sorted {
+		if d < 0 || d > maxReminderLeadDays {
+			return nil, false
+		}
+		if i > 0 && sorted[i-1] == d {
+			return nil, false
+		}
+	}
+	if sorted == nil {
+		sorted = []int{}
+	}
+	return sorted, true
+}
@@ -2,73 +2,418 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/vsssp/birthday-app/backend/internal/domain"
 	"github.com/vsssp/birthday-app/backend/internal/port"
 )
 
+const (
+	defaultRecipientPageSize = 20
+	maxRecipientPageSize     = 100
+	defaultSearchLimit       = 20
+	maxSearchTermLength      = 100
+)
+
 var (
-	ErrRecipientNotFound = errors.New("recipient not found")
-	ErrForbidden         = errors.New("access denied")
+	ErrRecipientNotFound  = domain.NewError(http.StatusNotFound, "recipient_not_found", "Recipient not found")
+	ErrForbidden          = domain.NewError(http.StatusForbidden, "forbidden", "Access denied")
+	ErrInvalidCursor      = domain.NewError(http.StatusBadRequest, "invalid_cursor", "Invalid or mismatched cursor")
+	ErrInvalidSort        = domain.NewError(http.StatusBadRequest, "invalid_sort", "Unsupported sort field")
+	ErrInvalidPageSize    = domain.NewError(http.StatusBadRequest, "invalid_page_size", "Limit must be between 1 and 100")
+	ErrInvalidSearchTerm  = domain.NewError(http.StatusBadRequest, "invalid_search_term", "Search term must be between 1 and 100 characters")
+	ErrVersionNotFound    = domain.NewError(http.StatusNotFound, "version_not_found", "Recipient version not found")
+	ErrPreconditionFailed = domain.NewError(http.StatusPreconditionFailed, "precondition_failed", "Recipient has been modified since it was fetched")
 )
 
 // RecipientUseCase implements port.RecipientService.
 type RecipientUseCase struct {
-	recipientRepo port.RecipientRepository
+	recipientRepo  port.RecipientRepository
+	historyRepo    port.RecipientHistoryRepository
+	tx             port.Transactor
+	prefsService   port.PreferencesService
+	keywordService port.KeywordService
+	permissions    port.PermissionService
+	rateService    port.ExchangeRateService
 }
 
 // NewRecipientUseCase creates a new RecipientUseCase.
-func NewRecipientUseCase(recipientRepo port.RecipientRepository) *RecipientUseCase {
-	return &RecipientUseCase{recipientRepo: recipientRepo}
+func NewRecipientUseCase(
+	recipientRepo port.RecipientRepository,
+	historyRepo port.RecipientHistoryRepository,
+	tx port.Transactor,
+	prefsService port.PreferencesService,
+	keywordService port.KeywordService,
+	permissions port.PermissionService,
+	rateService port.ExchangeRateService,
+) *RecipientUseCase {
+	return &RecipientUseCase{
+		recipientRepo:  recipientRepo,
+		historyRepo:    historyRepo,
+		tx:             tx,
+		prefsService:   prefsService,
+		keywordService: keywordService,
+		permissions:    permissions,
+		rateService:    rateService,
+	}
 }
 
 // Create adds a new recipient for the authenticated user.
 func (uc *RecipientUseCase) Create(ctx context.Context, userID uuid.UUID, req domain.CreateRecipientRequest) (*domain.Recipient, error) {
 	now := time.Now()
 	recipient := &domain.Recipient{
-		ID:        uuid.New(),
-		UserID:    userID,
-		Name:      req.Name,
-		Age:       req.Age,
-		Gender:    req.Gender,
-		MinBudget: req.MinBudget,
-		MaxBudget: req.MaxBudget,
-		Keywords:  req.Keywords,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:           uuid.New(),
+		UserID:       userID,
+		Name:         req.Name,
+		Age:          req.Age,
+		Gender:       req.Gender,
+		Relationship: req.Relationship,
+		Birthdate:    req.Birthdate,
+		MinBudget:    req.MinBudget,
+		MaxBudget:    req.MaxBudget,
+		Currency:     req.Currency,
+		Keywords:     req.Keywords,
+		Version:      1,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 
-	if recipient.Keywords == nil {
-		recipient.Keywords = []string{}
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.Currency == "" {
+		recipient.Currency = prefs.Currency
+	}
+	if err := uc.normalize(ctx, recipient); err != nil {
+		return nil, err
+	}
+	if err := recipient.Validate(prefs.Today()); err != nil {
+		return nil, err
 	}
 
-	if err := uc.recipientRepo.Create(ctx, recipient); err != nil {
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.recipientRepo.Create(ctx, recipient); err != nil {
+			return err
+		}
+		return uc.recordVersion(ctx, userID, domain.ChangeActionCreate, nil, recipient)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return recipient, nil
 }
 
-// GetByID retrieves a recipient, ensuring it belongs to the requesting user.
+// CreateMany adds recipients in bulk, validating all of them before writing
+// any. Field errors are reported as "recipients[i].field".
+func (uc *RecipientUseCase) CreateMany(ctx context.Context, userID uuid.UUID, reqs []domain.CreateRecipientRequest) ([]domain.Recipient, error) {
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := prefs.Today()
+
+	now := time.Now()
+	recipients := make([]domain.Recipient, len(reqs))
+	var fields []domain.FieldError
+	for i, req := range reqs {
+		recipient := recipientFromRequest(userID, req, prefs.Currency)
+		recipient.ID = uuid.New()
+		recipient.Version = 1
+		recipient.CreatedAt = now
+		recipient.UpdatedAt = now
+		if err := uc.normalize(ctx, recipient); err != nil {
+			return nil, err
+		}
+		if err := recipient.Validate(today); err != nil {
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) {
+				return nil, err
+			}
+			for _, f := range domainErr.Fields {
+				f.Field = fmt.Sprintf("recipients[%d].%s", i, f.Field)
+				fields = append(fields, f)
+			}
+		}
+		recipients[i] = *recipient
+	}
+	if len(fields) > 0 {
+		return nil, domain.ErrValidation.WithFields(fields)
+	}
+	if len(recipients) == 0 {
+		return recipients, nil
+	}
+
+	versions := make([]domain.RecipientVersion, len(recipients))
+	for i := range recipients {
+		versions[i] = domain.RecipientVersion{
+			ID:          uuid.New(),
+			RecipientID: recipients[i].ID,
+			Action:      domain.ChangeActionCreate,
+			ChangedBy:   userID,
+			Snapshot:    recipients[i],
+			Changes:     domain.DiffRecipients(nil, &recipients[i]),
+			CreatedAt:   now,
+		}
+	}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.recipientRepo.CreateMany(ctx, recipients); err != nil {
+			return err
+		}
+		return uc.historyRepo.CreateMany(ctx, versions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// GetByID retrieves a recipient the requesting user may view.
 func (uc *RecipientUseCase) GetByID(ctx context.Context, userID, recipientID uuid.UUID) (*domain.Recipient, error) {
-	recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	return uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionView)
+}
+
+// List returns one page of the recipients the authenticated user can see,
+// including those shared by their household. Next-birthday ordering is
+// anchored to the current date in the user's timezone.
+func (uc *RecipientUseCase) List(ctx context.Context, userID uuid.UUID, q domain.RecipientQuery) (*domain.RecipientPage, error) {
+	if q.Sort == "" {
+		q.Sort = domain.RecipientSortCreatedAt
+		q.Desc = true
+	}
+	if !q.Sort.Valid() {
+		return nil, ErrInvalidSort
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultRecipientPageSize
+	}
+	if q.Limit < 1 || q.Limit > maxRecipientPageSize {
+		return nil, ErrInvalidPageSize
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if recipient == nil {
-		return nil, ErrRecipientNotFound
+	q.Today = prefs.Today()
+	if q.Currency == "" {
+		q.Currency = prefs.Currency
+	}
+	// Budgets are in each recipient's own currency, so they are converted
+	// into the query's currency before comparing or ordering them.
+	budgeted := q.Sort == domain.RecipientSortBudget
+	if budgeted || q.BudgetMin != nil || q.BudgetMax != nil {
+		rates, err := uc.rateService.Rates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		q.BudgetRates = rates.RatesTo(q.Currency)
+	}
+	cursorCurrency := ""
+	if budgeted {
+		cursorCurrency = q.Currency
+	}
+
+	if q.Cursor != "" {
+		after, err := decodeRecipientCursor(q.Cursor)
+		if err != nil || after.Sort != q.Sort || after.Desc != q.Desc || after.Currency != cursorCurrency {
+			return nil, ErrInvalidCursor
+		}
+		q.After = after
+	}
+
+	// Filter on canonical slugs so "Games" finds recipients tagged "gaming".
+	q.Keywords, err = uc.keywordService.Canonicalize(ctx, q.Keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, next, err := uc.recipientRepo.ListPage(ctx, owners, q)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.RecipientPage{Data: recipients}
+	if page.Data == nil {
+		page.Data = []domain.Recipient{}
+	}
+	if next != nil {
+		next.Currency = cursorCurrency
+		cursor := encodeRecipientCursor(next)
+		page.NextCursor = &cursor
+		page.HasMore = true
+	}
+	return page, nil
+}
+
+// Search performs a typo- and accent-tolerant search over the recipients
+// the user can see.
+func (uc *RecipientUseCase) Search(ctx context.Context, userID uuid.UUID, term string, limit int) ([]domain.RecipientSearchResult, error) {
+	term = strings.TrimSpace(term)
+	if term == "" || utf8.RuneCountInString(term) > maxSearchTermLength {
+		return nil, ErrInvalidSearchTerm
+	}
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 1 || limit > maxRecipientPageSize {
+		return nil, ErrInvalidPageSize
+	}
+
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := uc.recipientRepo.Search(ctx, owners, term, limit)
+	if err != nil {
+		return nil, err
 	}
-	if recipient.UserID != userID {
-		return nil, ErrForbidden
+	if results == nil {
+		results = []domain.RecipientSearchResult{}
+	}
+	return results, nil
+}
+
+// Update modifies a recipient's fields. When req.IfMatch is set the update
+// only proceeds if the stored version is one of the listed versions.
+func (uc *RecipientUseCase) Update(ctx context.Context, userID, recipientID uuid.UUID, req domain.UpdateRecipientRequest) (*domain.Recipient, error) {
+	today, err := uc.today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipient *domain.Recipient
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		recipient, err = uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit)
+		if err != nil {
+			return err
+		}
+		if len(req.IfMatch) > 0 && !slices.Contains(req.IfMatch, recipient.Version) {
+			return ErrPreconditionFailed
+		}
+		before := *recipient
+
+		if req.Name != nil {
+			recipient.Name = *req.Name
+		}
+		if req.Age != nil {
+			recipient.Age = *req.Age
+		}
+		if req.Gender != nil {
+			recipient.Gender = *req.Gender
+		}
+		if req.Relationship != nil {
+			recipient.Relationship = *req.Relationship
+		}
+		if req.Birthdate != nil {
+			recipient.Birthdate = req.Birthdate
+		}
+		if req.MinBudget != nil {
+			recipient.MinBudget = *req.MinBudget
+		}
+		if req.MaxBudget != nil {
+			recipient.MaxBudget = *req.MaxBudget
+		}
+		if req.Currency != nil {
+			recipient.Currency = *req.Currency
+		}
+		if req.Keywords != nil {
+			recipient.Keywords = *req.Keywords
+		}
+		if err := uc.normalize(ctx, recipient); err != nil {
+			return err
+		}
+		if err := recipient.Validate(today); err != nil {
+			return err
+		}
+		recipient.UpdatedAt = time.Now()
+
+		err = uc.recipientRepo.Update(ctx, recipient)
+		// Another writer committed after the If-Match check: the version the
+		// client holds is stale all the same.
+		if errors.Is(err, domain.ErrVersionConflict) && len(req.IfMatch) > 0 {
+			return ErrPreconditionFailed
+		}
+		if err != nil {
+			return err
+		}
+		return uc.recordVersion(ctx, userID, domain.ChangeActionUpdate, &before, recipient)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return recipient, nil
 }
 
-// ListByUserID returns all recipients for the authenticated user.
-func (uc *RecipientUseCase) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Recipient, error) {
-	recipients, err := uc.recipientRepo.ListByUserID(ctx, userID)
+// Delete moves a recipient the requesting user may edit to the trash.
+func (uc *RecipientUseCase) Delete(ctx context.Context, userID, recipientID uuid.UUID) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		recipient, err := uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit)
+		if err != nil {
+			return err
+		}
+		if err := uc.recipientRepo.Delete(ctx, recipientID); err != nil {
+			return err
+		}
+		return uc.recordVersion(ctx, userID, domain.ChangeActionDelete, recipient, recipient)
+	})
+}
+
+// BulkDelete moves multiple recipients the authenticated user may edit to
+// the trash. IDs that do not exist or that the user may not edit are skipped.
+func (uc *RecipientUseCase) BulkDelete(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var deleted []*domain.Recipient
+		var deletedIDs []uuid.UUID
+		for _, id := range ids {
+			recipient, err := uc.permissions.Recipient(ctx, userID, id, domain.PermissionEdit)
+			if errors.Is(err, ErrRecipientNotFound) || errors.Is(err, ErrForbidden) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			deleted = append(deleted, recipient)
+			deletedIDs = append(deletedIDs, id)
+		}
+		if len(deletedIDs) == 0 {
+			return nil
+		}
+
+		if err := uc.recipientRepo.BulkDelete(ctx, deletedIDs); err != nil {
+			return err
+		}
+		for _, recipient := range deleted {
+			if err := uc.recordVersion(ctx, userID, domain.ChangeActionDelete, recipient, recipient); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListTrash returns the deleted recipients the authenticated user can see
+// that have not been purged yet.
+func (uc *RecipientUseCase) ListTrash(ctx context.Context, userID uuid.UUID) ([]domain.Recipient, error) {
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := uc.recipientRepo.ListDeleted(ctx, owners)
 	if err != nil {
 		return nil, err
 	}
@@ -78,61 +423,153 @@ func (uc *RecipientUseCase) ListByUserID(ctx context.Context, userID uuid.UUID)
 	return recipients, nil
 }
 
-// Update modifies a recipient's fields.
-func (uc *RecipientUseCase) Update(ctx context.Context, userID, recipientID uuid.UUID, req domain.UpdateRecipientRequest) (*domain.Recipient, error) {
+// Restore takes a recipient the requesting user may edit out of the trash.
+func (uc *RecipientUseCase) Restore(ctx context.Context, userID, recipientID uuid.UUID) (*domain.Recipient, error) {
+	var recipient *domain.Recipient
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		recipient, err = uc.recipientRepo.GetDeletedByID(ctx, recipientID)
+		if err != nil {
+			return err
+		}
+		if recipient == nil {
+			return ErrRecipientNotFound
+		}
+		if err := uc.permissions.Authorize(ctx, userID, recipient, domain.PermissionEdit); err != nil {
+			return err
+		}
+
+		recipient.DeletedAt = nil
+		recipient.UpdatedAt = time.Now()
+		if recipient.Version, err = uc.recipientRepo.Restore(ctx, recipientID, recipient.UpdatedAt); err != nil {
+			return err
+		}
+		return uc.recordVersion(ctx, userID, domain.ChangeActionRestore, recipient, recipient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recipient, nil
+}
+
+// History returns every recorded version of a recipient, newest first.
+// Trashed recipients keep their history until they are purged.
+func (uc *RecipientUseCase) History(ctx context.Context, userID, recipientID uuid.UUID) ([]domain.RecipientVersion, error) {
 	recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+	if err == nil && recipient == nil {
+		recipient, err = uc.recipientRepo.GetDeletedByID(ctx, recipientID)
+	}
 	if err != nil {
 		return nil, err
 	}
 	if recipient == nil {
 		return nil, ErrRecipientNotFound
 	}
-	if recipient.UserID != userID {
-		return nil, ErrForbidden
+	if err := uc.permissions.Authorize(ctx, userID, recipient, domain.PermissionView); err != nil {
+		return nil, err
 	}
 
-	if req.Name != nil {
-		recipient.Name = *req.Name
-	}
-	if req.Age != nil {
-		recipient.Age = *req.Age
-	}
-	if req.Gender != nil {
-		recipient.Gender = *req.Gender
-	}
-	if req.MinBudget != nil {
-		recipient.MinBudget = *req.MinBudget
-	}
-	if req.MaxBudget != nil {
-		recipient.MaxBudget = *req.MaxBudget
+	versions, err := uc.historyRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
 	}
-	if req.Keywords != nil {
-		recipient.Keywords = *req.Keywords
+	if versions == nil {
+		versions = []domain.RecipientVersion{}
 	}
-	recipient.UpdatedAt = time.Now()
+	return versions, nil
+}
+
+// Revert restores a recipient's fields to those captured in an earlier version.
+// The revert itself is recorded as a new version.
+func (uc *RecipientUseCase) Revert(ctx context.Context, userID, recipientID uuid.UUID, version int) (*domain.Recipient, error) {
+	var recipient *domain.Recipient
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		recipient, err = uc.permissions.Recipient(ctx, userID, recipientID, domain.PermissionEdit)
+		if err != nil {
+			return err
+		}
+
+		target, err := uc.historyRepo.GetByVersion(ctx, recipientID, version)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return ErrVersionNotFound
+		}
+
+		before := *recipient
+		recipient.ApplySnapshot(target.Snapshot)
+		recipient.UpdatedAt = time.Now()
 
-	if err := uc.recipientRepo.Update(ctx, recipient); err != nil {
+		if err := uc.recipientRepo.Update(ctx, recipient); err != nil {
+			return err
+		}
+		return uc.recordVersion(ctx, userID, domain.ChangeActionRevert, &before, recipient)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return recipient, nil
 }
 
-// Delete removes a recipient, ensuring it belongs to the requesting user.
-func (uc *RecipientUseCase) Delete(ctx context.Context, userID, recipientID uuid.UUID) error {
-	recipient, err := uc.recipientRepo.GetByID(ctx, recipientID)
+// PurgeTrash permanently deletes recipients that have been in the trash longer than retention.
+func (uc *RecipientUseCase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	return uc.recipientRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+}
+
+// today returns the current date in the user's preferred timezone.
+func (uc *RecipientUseCase) today(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	prefs, err := uc.prefsService.Get(ctx, userID)
 	if err != nil {
-		return err
-	}
-	if recipient == nil {
-		return ErrRecipientNotFound
+		return time.Time{}, err
 	}
-	if recipient.UserID != userID {
-		return ErrForbidden
+	return prefs.Today(), nil
+}
+
+// normalize cleans user input and maps keywords onto the interest taxonomy.
+func (uc *RecipientUseCase) normalize(ctx context.Context, r *domain.Recipient) error {
+	return normalizeRecipient(ctx, uc.keywordService, r)
+}
+
+// normalizeRecipient trims a recipient's fields and maps its keywords onto
+// the taxonomy, as every write does before validation.
+func normalizeRecipient(ctx context.Context, keywordService port.KeywordService, r *domain.Recipient) error {
+	r.Normalize()
+	keywords, err := keywordService.Canonicalize(ctx, r.Keywords)
+	if err != nil {
+		return err
 	}
-	return uc.recipientRepo.Delete(ctx, recipientID)
+	r.Keywords = keywords
+	return nil
 }
 
-// BulkDelete removes multiple recipients belonging to the authenticated user.
-func (uc *RecipientUseCase) BulkDelete(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
-	return uc.recipientRepo.BulkDelete(ctx, userID, ids)
+// recordVersion appends a snapshot of after to the recipient's history.
+func (uc *RecipientUseCase) recordVersion(ctx context.Context, userID uuid.UUID, action domain.ChangeAction, before, after *domain.Recipient) error {
+	return uc.historyRepo.Create(ctx, &domain.RecipientVersion{
+		ID:          uuid.New(),
+		RecipientID: after.ID,
+		Action:      action,
+		ChangedBy:   userID,
+		Snapshot:    *after,
+		Changes:     domain.DiffRecipients(before, after),
+		CreatedAt:   time.Now(),
+	})
+}
+
+func encodeRecipientCursor(c *domain.RecipientCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeRecipientCursor(s string) (*domain.RecipientCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c domain.RecipientCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
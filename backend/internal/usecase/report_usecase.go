@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var ErrInvalidReportYear = domain.NewError(http.StatusBadRequest, "invalid_report_year", "Year must be between 1900 and 2200")
+
+// ReportUseCase implements port.ReportService.
+type ReportUseCase struct {
+	giftRepo      port.GiftRepository
+	recipientRepo port.RecipientRepository
+	occasionRepo  port.OccasionRepository
+	groupRepo     port.GroupRepository
+	budgetRepo    port.BudgetRepository
+	prefsService  port.PreferencesService
+	rateService   port.ExchangeRateService
+	permissions   port.PermissionService
+}
+
+// NewReportUseCase creates a new ReportUseCase.
+func NewReportUseCase(
+	giftRepo port.GiftRepository,
+	recipientRepo port.RecipientRepository,
+	occasionRepo port.OccasionRepository,
+	groupRepo port.GroupRepository,
+	budgetRepo port.BudgetRepository,
+	prefsService port.PreferencesService,
+	rateService port.ExchangeRateService,
+	permissions port.PermissionService,
+) *ReportUseCase {
+	return &ReportUseCase{
+		giftRepo:      giftRepo,
+		recipientRepo: recipientRepo,
+		occasionRepo:  occasionRepo,
+		groupRepo:     groupRepo,
+		budgetRepo:    budgetRepo,
+		prefsService:  prefsService,
+		rateService:   rateService,
+		permissions:   permissions,
+	}
+}
+
+// Spending reports what was spent on gifts given in year to the recipients
+// the user can see, which includes their household's. The year defaults to
+// the current one in the user's timezone, and amounts are converted into the
+// user's preferred currency.
+func (uc *ReportUseCase) Spending(ctx context.Context, userID uuid.UUID, year int) (*domain.SpendingReport, error) {
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if year == 0 {
+		year = prefs.Today().Year()
+	}
+	if year < domain.MinBudgetYear || year > domain.MaxBudgetYear {
+		return nil, ErrInvalidReportYear
+	}
+
+	in := domain.SpendingInput{Year: year, Currency: prefs.Currency}
+	from := domain.NewDate(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+	to := domain.NewDate(time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC))
+	owners, err := uc.permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if in.Gifts, err = uc.giftRepo.ListByOwners(ctx, owners, from, to); err != nil {
+		return nil, err
+	}
+	if in.Recipients, err = uc.recipientRepo.ListByOwners(ctx, owners); err != nil {
+		return nil, err
+	}
+	if in.Occasions, err = uc.occasionRepo.ListByOwners(ctx, owners); err != nil {
+		return nil, err
+	}
+	if in.Groups, err = uc.groupRepo.ListByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if in.Memberships, err = uc.groupRepo.ListMemberships(ctx, userID); err != nil {
+		return nil, err
+	}
+	if in.Budget, err = uc.budgetRepo.Get(ctx, userID, year); err != nil {
+		return nil, err
+	}
+	if in.Rates, err = uc.rateService.Rates(ctx); err != nil {
+		return nil, err
+	}
+	return domain.NewSpendingReport(in), nil
+}
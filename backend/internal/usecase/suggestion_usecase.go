@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	defaultGiftSuggestLimit = 10
+	maxGiftSuggestLimit     = 50
+)
+
+// SuggestionUseCase implements port.SuggestionService.
+type SuggestionUseCase struct {
+	recipientService port.RecipientService
+	giftRepo         port.GiftRepository
+	prefsService     port.PreferencesService
+	rateService      port.ExchangeRateService
+}
+
+// NewSuggestionUseCase creates a new SuggestionUseCase.
+func NewSuggestionUseCase(
+	recipientService port.RecipientService,
+	giftRepo port.GiftRepository,
+	prefsService port.PreferencesService,
+	rateService port.ExchangeRateService,
+) *SuggestionUseCase {
+	return &SuggestionUseCase{
+		recipientService: recipientService,
+		giftRepo:         giftRepo,
+		prefsService:     prefsService,
+		rateService:      rateService,
+	}
+}
+
+// Suggest ranks catalog gifts for a recipient using their interests, budget,
+// relationship and gift history. Titles use locale, or the user's preferred
+// locale when locale is empty, and prices are converted into the user's
+// preferred currency.
+func (uc *SuggestionUseCase) Suggest(ctx context.Context, userID, recipientID uuid.UUID, limit int, locale string) ([]domain.GiftSuggestion, error) {
+	if limit == 0 {
+		limit = defaultGiftSuggestLimit
+	}
+	if limit < 1 || limit > maxGiftSuggestLimit {
+		return nil, ErrInvalidSuggestLimit
+	}
+
+	recipient, err := uc.recipientService.GetByID(ctx, userID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if locale == "" {
+		locale = prefs.Locale
+	}
+	rates, err := uc.rateService.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.giftRepo.ListByRecipientID(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SuggestGifts(recipient, recipient.Closeness(), history, locale, prefs.Currency, rates, limit), nil
+}
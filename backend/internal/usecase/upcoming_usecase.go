@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+const (
+	defaultUpcomingDays = 30
+	maxUpcomingDays     = 366
+)
+
+var ErrInvalidUpcomingDays = domain.NewError(http.StatusBadRequest, "invalid_upcoming_days", "Days must be between 1 and 366")
+
+// UpcomingUseCase implements port.UpcomingService. It merges each recipient's
+// stored occasions and holiday subscriptions with the birthday implied by
+// their birthdate.
+type UpcomingUseCase struct {
+	recipientRepo    port.RecipientRepository
+	occasionRepo     port.OccasionRepository
+	subscriptionRepo port.HolidaySubscriptionRepository
+	prefsService     port.PreferencesService
+	permissions      port.PermissionService
+}
+
+// NewUpcomingUseCase creates a new UpcomingUseCase.
+func NewUpcomingUseCase(
+	recipientRepo port.RecipientRepository,
+	occasionRepo port.OccasionRepository,
+	subscriptionRepo port.HolidaySubscriptionRepository,
+	prefsService port.PreferencesService,
+	permissions port.PermissionService,
+) *UpcomingUseCase {
+	return &UpcomingUseCase{
+		recipientRepo:    recipientRepo,
+		occasionRepo:     occasionRepo,
+		subscriptionRepo: subscriptionRepo,
+		prefsService:     prefsService,
+		permissions:      permissions,
+	}
+}
+
+// Upcoming returns the occasions falling within the next days days, counted
+// from today in the user's timezone, soonest first.
+func (uc *UpcomingUseCase) Upcoming(ctx context.Context, userID uuid.UUID, days int) ([]domain.UpcomingOccasion, error) {
+	if days == 0 {
+		days = defaultUpcomingDays
+	}
+	if days < 1 || days > maxUpcomingDays {
+		return nil, ErrInvalidUpcomingDays
+	}
+
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := uc.upcoming(ctx, userID, prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := []domain.UpcomingOccasion{}
+	for _, u := range all {
+		if u.DaysUntil < days {
+			feed = append(feed, u)
+		}
+	}
+	return feed, nil
+}
+
+// Reminders returns the occasions that are exactly one of the user's
+// reminder lead times away today.
+func (uc *UpcomingUseCase) Reminders(ctx context.Context, userID uuid.UUID) ([]domain.Reminder, error) {
+	prefs, err := uc.prefsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := uc.upcoming(ctx, userID, prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := []domain.Reminder{}
+	for _, u := range all {
+		if slices.Contains(prefs.ReminderLeadDays, u.DaysUntil) {
+			reminders = append(reminders, domain.Reminder{UpcomingOccasion: u, LeadDays: u.DaysUntil})
+		}
+	}
+	return reminders, nil
+}
+
+// upcoming computes the next occurrence of every occasion and subscribed
+// holiday the user has, sorted by date and then recipient name.
+func (uc *UpcomingUseCase) upcoming(ctx context.Context, userID uuid.UUID, prefs *domain.UserPreferences) ([]domain.UpcomingOccasion, error) {
+	today := prefs.Today()
+
+	calendars, err := loadRecipientCalendars(ctx, uc.permissions, uc.recipientRepo, uc.occasionRepo, uc.subscriptionRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var feed []domain.UpcomingOccasion
+	for i := range calendars {
+		c := &calendars[i]
+		for j := range c.occasions {
+			if u := upcomingOccasion(&c.recipient, &c.occasions[j], today); u != nil {
+				feed = append(feed, *u)
+			}
+		}
+		for _, s := range c.holidays {
+			if u := upcomingHoliday(&c.recipient, s, prefs.Locale, today); u != nil {
+				feed = append(feed, *u)
+			}
+		}
+	}
+
+	slices.SortStableFunc(feed, func(a, b domain.UpcomingOccasion) int {
+		if c := a.Date.Compare(b.Date.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.RecipientName), strings.ToLower(b.RecipientName))
+	})
+	return feed, nil
+}
+
+// recipientCalendar is everything a recipient celebrates.
+type recipientCalendar struct {
+	recipient domain.Recipient
+	occasions []domain.Occasion
+	holidays  []domain.HolidaySubscription
+}
+
+// loadRecipientCalendars gathers the stored occasions and holiday
+// subscriptions of each recipient the user can see, their household's
+// included. A recipient's birthdate is added as a birthday occasion unless
+// one has been stored for them.
+func loadRecipientCalendars(
+	ctx context.Context,
+	permissions port.PermissionService,
+	recipientRepo port.RecipientRepository,
+	occasionRepo port.OccasionRepository,
+	subscriptionRepo port.HolidaySubscriptionRepository,
+	userID uuid.UUID,
+) ([]recipientCalendar, error) {
+	owners, err := permissions.RecipientOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := recipientRepo.ListByOwners(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+	occasions, err := occasionRepo.ListByOwners(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := subscriptionRepo.ListByOwners(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	byRecipient := make(map[uuid.UUID][]domain.Occasion, len(recipients))
+	for _, o := range occasions {
+		byRecipient[o.RecipientID] = append(byRecipient[o.RecipientID], o)
+	}
+	holidays := make(map[uuid.UUID][]domain.HolidaySubscription, len(recipients))
+	for _, s := range subscriptions {
+		holidays[s.RecipientID] = append(holidays[s.RecipientID], s)
+	}
+
+	calendars := make([]recipientCalendar, 0, len(recipients))
+	for _, recipient := range recipients {
+		stored := byRecipient[recipient.ID]
+		hasBirthday := slices.ContainsFunc(stored, func(o domain.Occasion) bool {
+			return o.Kind == domain.OccasionBirthday
+		})
+		if birthday := recipient.BirthdayOccasion(); birthday != nil && !hasBirthday {
+			stored = append(stored, *birthday)
+		}
+		calendars = append(calendars, recipientCalendar{
+			recipient: recipient,
+			occasions: stored,
+			holidays:  holidays[recipient.ID],
+		})
+	}
+	return calendars, nil
+}
+
+func upcomingOccasion(recipient *domain.Recipient, o *domain.Occasion, today time.Time) *domain.UpcomingOccasion {
+	next := o.NextOccurrence(today)
+	if next == nil {
+		return nil
+	}
+
+	u := &domain.UpcomingOccasion{
+		RecipientID:   recipient.ID,
+		RecipientName: recipient.Name,
+		Kind:          o.Kind,
+		Title:         o.Title,
+		Date:          *next,
+		DaysUntil:     next.DaysUntil(today),
+		Budget:        recipient.MaxBudget,
+		Currency:      recipient.Currency,
+	}
+	if o.ID != uuid.Nil {
+		id := o.ID
+		u.OccasionID = &id
+	}
+	if o.Budget != nil {
+		u.Budget = *o.Budget
+	}
+	if o.Recurring {
+		turns := next.Year() - o.Date.Year()
+		u.Turns = &turns
+	}
+	return u
+}
+
+func upcomingHoliday(recipient *domain.Recipient, s domain.HolidaySubscription, locale string, today time.Time) *domain.UpcomingOccasion {
+	holiday, ok := domain.LookupHoliday(s.Country, s.Holiday)
+	if !ok {
+		return nil
+	}
+
+	next := holiday.NextOccurrence(today)
+	u := &domain.UpcomingOccasion{
+		RecipientID:   recipient.ID,
+		RecipientName: recipient.Name,
+		Kind:          domain.OccasionHoliday,
+		Title:         holiday.Label(locale),
+		Date:          next,
+		DaysUntil:     next.DaysUntil(today),
+		Budget:        recipient.MaxBudget,
+		Currency:      recipient.Currency,
+		Holiday:       holiday.Slug,
+		Country:       holiday.Country,
+	}
+	if s.Budget != nil {
+		u.Budget = *s.Budget
+	}
+	return u
+}
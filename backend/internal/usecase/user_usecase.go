@@ -2,14 +2,14 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/vsssp/birthday-app/backend/internal/domain"
 	"github.com/vsssp/birthday-app/backend/internal/port"
 )
 
-var ErrUserNotFound = errors.New("user not found")
+var ErrUserNotFound = domain.NewError(http.StatusNotFound, "user_not_found", "User not found")
 
 // UserUseCase implements port.UserService.
 type UserUseCase struct {
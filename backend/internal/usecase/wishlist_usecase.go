@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vsssp/birthday-app/backend/internal/domain"
+	"github.com/vsssp/birthday-app/backend/internal/port"
+)
+
+var (
+	ErrWishlistNotFound     = domain.NewError(http.StatusNotFound, "wishlist_not_found", "Wishlist not found")
+	ErrWishlistItemNotFound = domain.NewError(http.StatusNotFound, "wishlist_item_not_found", "Wishlist item not found")
+	ErrClaimNotFound        = domain.NewError(http.StatusNotFound, "claim_not_found", "Claim not found")
+	ErrItemClaimed          = domain.NewError(http.StatusConflict, "item_claimed", "Someone is already buying this item")
+	ErrOwnWishlist          = domain.NewError(http.StatusForbidden, "own_wishlist", "You cannot claim items on your own wishlist")
+)
+
+// WishlistUseCase implements port.WishlistService.
+type WishlistUseCase struct {
+	wishlistRepo  port.WishlistRepository
+	recipientRepo port.RecipientRepository
+	permissions   port.PermissionService
+	ideaRepo      port.IdeaRepository
+	tx            port.Transactor
+}
+
+// NewWishlistUseCase creates a new WishlistUseCase.
+func NewWishlistUseCase(
+	wishlistRepo port.WishlistRepository,
+	recipientRepo port.RecipientRepository,
+	permissions port.PermissionService,
+	ideaRepo port.IdeaRepository,
+	tx port.Transactor,
+) *WishlistUseCase {
+	return &WishlistUseCase{
+		wishlistRepo:  wishlistRepo,
+		recipientRepo: recipientRepo,
+		permissions:   permissions,
+		ideaRepo:      ideaRepo,
+		tx:            tx,
+	}
+}
+
+// Create publishes a wishlist of a recipient's ideas under a fresh slug.
+func (uc *WishlistUseCase) Create(ctx context.Context, userID uuid.UUID, req domain.CreateWishlistRequest) (*domain.Wishlist, error) {
+	if _, err := uc.permissions.Recipient(ctx, userID, req.RecipientID, domain.PermissionEdit); err != nil {
+		return nil, err
+	}
+	slug, err := newWishlistSlug()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	wishlist := &domain.Wishlist{
+		ID:          uuid.New(),
+		UserID:      userID,
+		RecipientID: req.RecipientID,
+		Title:       req.Title,
+		Description: req.Description,
+		Slug:        slug,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	wishlist.SetIdeas(req.IdeaIDs)
+	if err := uc.validate(ctx, wishlist, req.IdeaIDs); err != nil {
+		return nil, err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.wishlistRepo.Create(ctx, wishlist)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+// List returns the user's wishlists.
+func (uc *WishlistUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.Wishlist, error) {
+	wishlists, err := uc.wishlistRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wishlists == nil {
+		wishlists = []domain.Wishlist{}
+	}
+	return wishlists, nil
+}
+
+// GetByID retrieves one of the user's wishlists.
+func (uc *WishlistUseCase) GetByID(ctx context.Context, userID, wishlistID uuid.UUID) (*domain.Wishlist, error) {
+	return uc.getOwned(ctx, userID, wishlistID)
+}
+
+// Update modifies the provided fields of a wishlist.
+func (uc *WishlistUseCase) Update(ctx context.Context, userID, wishlistID uuid.UUID, req domain.UpdateWishlistRequest) (*domain.Wishlist, error) {
+	wishlist, err := uc.getOwned(ctx, userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		wishlist.Title = *req.Title
+	}
+	if req.Description != nil {
+		wishlist.Description = *req.Description
+	}
+	var ideaIDs []uuid.UUID
+	if req.IdeaIDs != nil {
+		ideaIDs = *req.IdeaIDs
+		wishlist.SetIdeas(ideaIDs)
+	}
+	if err := uc.validate(ctx, wishlist, ideaIDs); err != nil {
+		return nil, err
+	}
+	wishlist.UpdatedAt = time.Now()
+
+	if err := uc.save(ctx, wishlist); err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+// Delete unpublishes a wishlist. Its ideas stay on the recipient's board.
+func (uc *WishlistUseCase) Delete(ctx context.Context, userID, wishlistID uuid.UUID) error {
+	if _, err := uc.getOwned(ctx, userID, wishlistID); err != nil {
+		return err
+	}
+	return uc.wishlistRepo.Delete(ctx, wishlistID)
+}
+
+// RotateSlug moves a wishlist to a new slug; the old link stops working.
+// Claims are kept.
+func (uc *WishlistUseCase) RotateSlug(ctx context.Context, userID, wishlistID uuid.UUID) (*domain.Wishlist, error) {
+	wishlist, err := uc.getOwned(ctx, userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist.Slug, err = newWishlistSlug(); err != nil {
+		return nil, err
+	}
+	wishlist.UpdatedAt = time.Now()
+
+	if err := uc.save(ctx, wishlist); err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+// View returns the wishlist published at slug. Ideas that were given or
+// deleted drop out, and claims are hidden when the owner is looking.
+func (uc *WishlistUseCase) View(ctx context.Context, slug string, viewerID uuid.UUID) (*domain.PublicWishlist, error) {
+	wishlist, recipient, ideas, err := uc.published(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	claimed := map[uuid.UUID]bool{}
+	if viewerID != wishlist.UserID {
+		if claimed, err = uc.wishlistRepo.ClaimedItemIDs(ctx, wishlist.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	view := &domain.PublicWishlist{
+		Title:         wishlist.Title,
+		Description:   wishlist.Description,
+		RecipientName: recipient.Name,
+		Items:         []domain.PublicWishlistItem{},
+	}
+	for _, item := range wishlist.Items {
+		idea, ok := ideas[item.IdeaID]
+		if !ok {
+			continue
+		}
+		view.Items = append(view.Items, domain.PublicWishlistItem{
+			ID:       item.ID,
+			Title:    idea.Title,
+			URL:      idea.URL,
+			Price:    idea.Price,
+			Currency: idea.Currency,
+			Category: idea.Category,
+			Priority: idea.Priority,
+			Claimed:  claimed[item.ID],
+		})
+	}
+	return view, nil
+}
+
+// Claim marks an item as being bought by the visitor. The returned claim
+// carries the token needed to release it again.
+func (uc *WishlistUseCase) Claim(ctx context.Context, slug string, viewerID, itemID uuid.UUID, req domain.ClaimWishlistItemRequest) (*domain.WishlistClaim, error) {
+	wishlist, _, ideas, err := uc.published(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if viewerID == wishlist.UserID {
+		return nil, ErrOwnWishlist
+	}
+	item := wishlist.Item(itemID)
+	if item == nil {
+		return nil, ErrWishlistItemNotFound
+	}
+	if _, ok := ideas[item.IdeaID]; !ok {
+		return nil, ErrWishlistItemNotFound
+	}
+
+	claim := &domain.WishlistClaim{
+		ID:        uuid.New(),
+		ItemID:    item.ID,
+		Name:      req.Name,
+		CreatedAt: time.Now(),
+	}
+	claim.Normalize()
+	if err := claim.Validate(); err != nil {
+		return nil, err
+	}
+	if claim.Token, err = newClaimToken(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.wishlistRepo.CreateClaim(ctx, claim); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrItemClaimed
+		}
+		return nil, err
+	}
+	return claim, nil
+}
+
+// ReleaseClaim withdraws the claim holding token on the wishlist at slug.
+func (uc *WishlistUseCase) ReleaseClaim(ctx context.Context, slug, token string) error {
+	wishlist, err := uc.wishlistRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if wishlist == nil {
+		return ErrWishlistNotFound
+	}
+	claim, err := uc.wishlistRepo.GetClaimByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if claim == nil || wishlist.Item(claim.ItemID) == nil {
+		return ErrClaimNotFound
+	}
+	return uc.wishlistRepo.DeleteClaim(ctx, claim.ID)
+}
+
+// save updates a wishlist and its items together.
+func (uc *WishlistUseCase) save(ctx context.Context, wishlist *domain.Wishlist) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.wishlistRepo.Update(ctx, wishlist)
+	})
+}
+
+// validate normalizes the wishlist and checks the field rules, including
+// that every idea is on the recipient's board and listed once. ideaIDs is
+// nil when the items are unchanged.
+func (uc *WishlistUseCase) validate(ctx context.Context, wishlist *domain.Wishlist, ideaIDs []uuid.UUID) error {
+	wishlist.Normalize()
+	if err := wishlist.Validate(); err != nil {
+		return err
+	}
+	if len(ideaIDs) == 0 {
+		return nil
+	}
+
+	ideas, err := uc.ideaRepo.ListByRecipientID(ctx, wishlist.RecipientID)
+	if err != nil {
+		return err
+	}
+	known := make(map[uuid.UUID]bool, len(ideas))
+	for _, idea := range ideas {
+		known[idea.ID] = true
+	}
+	var v domain.Validator
+	seen := make(map[uuid.UUID]bool, len(ideaIDs))
+	for _, id := range ideaIDs {
+		if !known[id] {
+			v.Add("idea_ids", domain.CodeInvalidChoice, "idea %s is not on the recipient's idea board", id)
+			break
+		}
+		if seen[id] {
+			v.Add("idea_ids", domain.CodeDuplicate, "idea %s is listed more than once", id)
+			break
+		}
+		seen[id] = true
+	}
+	return v.Err()
+}
+
+// published loads the wishlist at slug with its recipient and the ideas
+// visitors may see, keyed by ID. Wishlists of trashed recipients are gone.
+func (uc *WishlistUseCase) published(ctx context.Context, slug string) (*domain.Wishlist, *domain.Recipient, map[uuid.UUID]domain.GiftIdea, error) {
+	wishlist, err := uc.wishlistRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if wishlist == nil {
+		return nil, nil, nil, ErrWishlistNotFound
+	}
+	recipient, err := uc.recipientRepo.GetByID(ctx, wishlist.RecipientID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if recipient == nil {
+		return nil, nil, nil, ErrWishlistNotFound
+	}
+
+	ideas, err := uc.ideaRepo.ListByRecipientID(ctx, wishlist.RecipientID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	visible := make(map[uuid.UUID]domain.GiftIdea, len(ideas))
+	for _, idea := range ideas {
+		if idea.Status != domain.IdeaStatusGiven {
+			visible[idea.ID] = idea
+		}
+	}
+	return wishlist, recipient, visible, nil
+}
+
+// getOwned loads a wishlist, ensuring it belongs to the requesting user.
+func (uc *WishlistUseCase) getOwned(ctx context.Context, userID, wishlistID uuid.UUID) (*domain.Wishlist, error) {
+	wishlist, err := uc.wishlistRepo.GetByID(ctx, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, ErrWishlistNotFound
+	}
+	if wishlist.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return wishlist, nil
+}
+
+// newWishlistSlug creates an unguessable public wishlist slug.
+func newWishlistSlug() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate wishlist slug: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newClaimToken creates an unguessable claim release token.
+func newClaimToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate claim token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
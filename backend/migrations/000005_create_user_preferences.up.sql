@@ -0,0 +1,9 @@
+CREATE TABLE user_preferences (
+    user_id            UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone           VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    locale             VARCHAR(10) NOT NULL DEFAULT 'en-US',
+    currency           CHAR(3) NOT NULL DEFAULT 'USD',
+    reminder_lead_days INT[] NOT NULL DEFAULT '{7,1}',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
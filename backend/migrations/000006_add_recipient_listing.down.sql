@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_recipients_keywords;
+DROP INDEX IF EXISTS idx_recipients_user_created_at;
+DROP INDEX IF EXISTS idx_recipients_user_name;
+ALTER TABLE recipients DROP COLUMN IF EXISTS birthdate;
@@ -0,0 +1,5 @@
+ALTER TABLE recipients ADD COLUMN birthdate DATE;
+
+CREATE INDEX idx_recipients_user_name ON recipients(user_id, lower(name), id);
+CREATE INDEX idx_recipients_user_created_at ON recipients(user_id, created_at, id);
+CREATE INDEX idx_recipients_keywords ON recipients USING GIN (keywords);
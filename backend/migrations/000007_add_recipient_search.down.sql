@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_recipients_search_trgm;
+DROP FUNCTION IF EXISTS recipient_search_text(text, text[]);
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
+DROP EXTENSION IF EXISTS unaccent;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE, so it cannot back an index directly. Pinning the
+-- dictionary makes the wrapper safe to declare IMMUTABLE.
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+CREATE OR REPLACE FUNCTION recipient_search_text(name text, keywords text[]) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT lower(immutable_unaccent(name || ' ' || array_to_string(keywords, ' '))) $$;
+
+CREATE INDEX idx_recipients_search_trgm ON recipients
+    USING GIN (recipient_search_text(name, keywords) gin_trgm_ops);
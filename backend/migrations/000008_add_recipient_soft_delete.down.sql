@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_recipients_deleted_at;
+ALTER TABLE recipients DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE recipients ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_recipients_deleted_at ON recipients(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
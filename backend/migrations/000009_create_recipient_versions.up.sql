@@ -0,0 +1,12 @@
+CREATE TABLE recipient_versions (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient_id UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    version      INT NOT NULL,
+    action       VARCHAR(20) NOT NULL,
+    changed_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    snapshot     JSONB NOT NULL,
+    changes      JSONB NOT NULL DEFAULT '[]',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(recipient_id, version)
+);
@@ -0,0 +1 @@
+ALTER TABLE recipients ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Keywords rewritten to canonical slugs by the up migration are left as is.
+DROP TABLE IF EXISTS interest_synonyms;
+DROP TABLE IF EXISTS interest_labels;
+DROP TABLE IF EXISTS interests;
@@ -0,0 +1,304 @@
+CREATE TABLE interests (
+    slug        VARCHAR(50) PRIMARY KEY,
+    parent_slug VARCHAR(50) REFERENCES interests(slug) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE interest_labels (
+    slug   VARCHAR(50) NOT NULL REFERENCES interests(slug) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    label  VARCHAR(100) NOT NULL,
+    PRIMARY KEY (slug, locale)
+);
+
+-- term holds the folded form (lowercase, unaccented, single spaces) that the
+-- application matches keywords against.
+CREATE TABLE interest_synonyms (
+    term VARCHAR(50) PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL REFERENCES interests(slug) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_interests_parent ON interests(parent_slug);
+CREATE INDEX idx_interest_synonyms_slug ON interest_synonyms(slug);
+
+INSERT INTO interests (slug, parent_slug) VALUES
+    ('entertainment', NULL),
+    ('sports', NULL),
+    ('arts', NULL),
+    ('food-drink', NULL),
+    ('technology', NULL),
+    ('outdoors', NULL),
+    ('lifestyle', NULL),
+    ('learning', NULL),
+    ('gaming', 'entertainment'),
+    ('board-games', 'entertainment'),
+    ('movies', 'entertainment'),
+    ('tv-series', 'entertainment'),
+    ('music', 'entertainment'),
+    ('anime', 'entertainment'),
+    ('football', 'sports'),
+    ('running', 'sports'),
+    ('fitness', 'sports'),
+    ('yoga', 'sports'),
+    ('cycling', 'sports'),
+    ('painting', 'arts'),
+    ('photography', 'arts'),
+    ('crafts', 'arts'),
+    ('cooking', 'food-drink'),
+    ('coffee', 'food-drink'),
+    ('wine', 'food-drink'),
+    ('beer', 'food-drink'),
+    ('gadgets', 'technology'),
+    ('programming', 'technology'),
+    ('geek', 'technology'),
+    ('travel', 'outdoors'),
+    ('hiking', 'outdoors'),
+    ('camping', 'outdoors'),
+    ('gardening', 'outdoors'),
+    ('fashion', 'lifestyle'),
+    ('beauty', 'lifestyle'),
+    ('pets', 'lifestyle'),
+    ('wellness', 'lifestyle'),
+    ('reading', 'learning'),
+    ('languages', 'learning');
+
+INSERT INTO interest_labels (slug, locale, label) VALUES
+    ('entertainment', 'en-US', 'Entertainment'),
+    ('entertainment', 'pt-BR', 'Entretenimento'),
+    ('sports', 'en-US', 'Sports'),
+    ('sports', 'pt-BR', 'Esportes'),
+    ('arts', 'en-US', 'Arts'),
+    ('arts', 'pt-BR', 'Artes'),
+    ('food-drink', 'en-US', 'Food & drink'),
+    ('food-drink', 'pt-BR', 'Comida e bebida'),
+    ('technology', 'en-US', 'Technology'),
+    ('technology', 'pt-BR', 'Tecnologia'),
+    ('outdoors', 'en-US', 'Outdoors'),
+    ('outdoors', 'pt-BR', 'Ar livre'),
+    ('lifestyle', 'en-US', 'Lifestyle'),
+    ('lifestyle', 'pt-BR', 'Estilo de vida'),
+    ('learning', 'en-US', 'Learning'),
+    ('learning', 'pt-BR', 'Aprendizado'),
+    ('gaming', 'en-US', 'Video games'),
+    ('gaming', 'pt-BR', 'Videogames'),
+    ('board-games', 'en-US', 'Board games'),
+    ('board-games', 'pt-BR', 'Jogos de tabuleiro'),
+    ('movies', 'en-US', 'Movies'),
+    ('movies', 'pt-BR', 'Filmes'),
+    ('tv-series', 'en-US', 'TV series'),
+    ('tv-series', 'pt-BR', 'Séries'),
+    ('music', 'en-US', 'Music'),
+    ('music', 'pt-BR', 'Música'),
+    ('anime', 'en-US', 'Anime & manga'),
+    ('anime', 'pt-BR', 'Anime e mangá'),
+    ('football', 'en-US', 'Football'),
+    ('football', 'pt-BR', 'Futebol'),
+    ('running', 'en-US', 'Running'),
+    ('running', 'pt-BR', 'Corrida'),
+    ('fitness', 'en-US', 'Fitness'),
+    ('fitness', 'pt-BR', 'Academia'),
+    ('yoga', 'en-US', 'Yoga'),
+    ('yoga', 'pt-BR', 'Ioga'),
+    ('cycling', 'en-US', 'Cycling'),
+    ('cycling', 'pt-BR', 'Ciclismo'),
+    ('painting', 'en-US', 'Painting & drawing'),
+    ('painting', 'pt-BR', 'Pintura e desenho'),
+    ('photography', 'en-US', 'Photography'),
+    ('photography', 'pt-BR', 'Fotografia'),
+    ('crafts', 'en-US', 'Crafts'),
+    ('crafts', 'pt-BR', 'Artesanato'),
+    ('cooking', 'en-US', 'Cooking'),
+    ('cooking', 'pt-BR', 'Culinária'),
+    ('coffee', 'en-US', 'Coffee'),
+    ('coffee', 'pt-BR', 'Café'),
+    ('wine', 'en-US', 'Wine'),
+    ('wine', 'pt-BR', 'Vinho'),
+    ('beer', 'en-US', 'Beer'),
+    ('beer', 'pt-BR', 'Cerveja'),
+    ('gadgets', 'en-US', 'Gadgets'),
+    ('gadgets', 'pt-BR', 'Gadgets'),
+    ('programming', 'en-US', 'Programming'),
+    ('programming', 'pt-BR', 'Programação'),
+    ('geek', 'en-US', 'Geek culture'),
+    ('geek', 'pt-BR', 'Cultura geek'),
+    ('travel', 'en-US', 'Travel'),
+    ('travel', 'pt-BR', 'Viagens'),
+    ('hiking', 'en-US', 'Hiking'),
+    ('hiking', 'pt-BR', 'Trilhas'),
+    ('camping', 'en-US', 'Camping'),
+    ('camping', 'pt-BR', 'Acampamento'),
+    ('gardening', 'en-US', 'Gardening'),
+    ('gardening', 'pt-BR', 'Jardinagem'),
+    ('fashion', 'en-US', 'Fashion'),
+    ('fashion', 'pt-BR', 'Moda'),
+    ('beauty', 'en-US', 'Beauty'),
+    ('beauty', 'pt-BR', 'Beleza'),
+    ('pets', 'en-US', 'Pets'),
+    ('pets', 'pt-BR', 'Animais de estimação'),
+    ('wellness', 'en-US', 'Wellness'),
+    ('wellness', 'pt-BR', 'Bem-estar'),
+    ('reading', 'en-US', 'Reading'),
+    ('reading', 'pt-BR', 'Leitura'),
+    ('languages', 'en-US', 'Languages'),
+    ('languages', 'pt-BR', 'Idiomas');
+
+INSERT INTO interest_synonyms (term, slug) VALUES
+    ('entretenimento', 'entertainment'),
+    ('fun', 'entertainment'),
+    ('sport', 'sports'),
+    ('esporte', 'sports'),
+    ('esportes', 'sports'),
+    ('art', 'arts'),
+    ('arte', 'arts'),
+    ('food', 'food-drink'),
+    ('comida', 'food-drink'),
+    ('gastronomy', 'food-drink'),
+    ('gastronomia', 'food-drink'),
+    ('tech', 'technology'),
+    ('tecnologia', 'technology'),
+    ('outdoor', 'outdoors'),
+    ('nature', 'outdoors'),
+    ('natureza', 'outdoors'),
+    ('estilo de vida', 'lifestyle'),
+    ('education', 'learning'),
+    ('educacao', 'learning'),
+    ('estudos', 'learning'),
+    ('games', 'gaming'),
+    ('game', 'gaming'),
+    ('videogame', 'gaming'),
+    ('gamer', 'gaming'),
+    ('jogos', 'gaming'),
+    ('jogo', 'gaming'),
+    ('jogos eletronicos', 'gaming'),
+    ('videogames', 'gaming'),
+    ('boardgames', 'board-games'),
+    ('boardgame', 'board-games'),
+    ('tabletop', 'board-games'),
+    ('tabuleiro', 'board-games'),
+    ('films', 'movies'),
+    ('film', 'movies'),
+    ('cinema', 'movies'),
+    ('filmes', 'movies'),
+    ('filme', 'movies'),
+    ('series', 'tv-series'),
+    ('tv shows', 'tv-series'),
+    ('tv', 'tv-series'),
+    ('seriados', 'tv-series'),
+    ('musica', 'music'),
+    ('songs', 'music'),
+    ('musicas', 'music'),
+    ('manga', 'anime'),
+    ('otaku', 'anime'),
+    ('soccer', 'football'),
+    ('futebol', 'football'),
+    ('corrida', 'running'),
+    ('jogging', 'running'),
+    ('marathon', 'running'),
+    ('maratona', 'running'),
+    ('gym', 'fitness'),
+    ('academia', 'fitness'),
+    ('workout', 'fitness'),
+    ('musculacao', 'fitness'),
+    ('ioga', 'yoga'),
+    ('bike', 'cycling'),
+    ('bicycle', 'cycling'),
+    ('ciclismo', 'cycling'),
+    ('bicicleta', 'cycling'),
+    ('pintura', 'painting'),
+    ('drawing', 'painting'),
+    ('desenho', 'painting'),
+    ('photos', 'photography'),
+    ('photo', 'photography'),
+    ('fotografia', 'photography'),
+    ('foto', 'photography'),
+    ('camera', 'photography'),
+    ('diy', 'crafts'),
+    ('artesanato', 'crafts'),
+    ('handmade', 'crafts'),
+    ('feito a mao', 'crafts'),
+    ('culinaria', 'cooking'),
+    ('cozinhar', 'cooking'),
+    ('cozinha', 'cooking'),
+    ('chef', 'cooking'),
+    ('baking', 'cooking'),
+    ('cafe', 'coffee'),
+    ('vinho', 'wine'),
+    ('vinhos', 'wine'),
+    ('cerveja', 'beer'),
+    ('cervejas', 'beer'),
+    ('craft beer', 'beer'),
+    ('electronics', 'gadgets'),
+    ('eletronicos', 'gadgets'),
+    ('coding', 'programming'),
+    ('code', 'programming'),
+    ('programacao', 'programming'),
+    ('developer', 'programming'),
+    ('dev', 'programming'),
+    ('nerd', 'geek'),
+    ('nerds', 'geek'),
+    ('geeks', 'geek'),
+    ('traveling', 'travel'),
+    ('travelling', 'travel'),
+    ('viagem', 'travel'),
+    ('viajar', 'travel'),
+    ('trips', 'travel'),
+    ('trekking', 'hiking'),
+    ('trilha', 'hiking'),
+    ('trilhas', 'hiking'),
+    ('acampar', 'camping'),
+    ('acampamento', 'camping'),
+    ('plants', 'gardening'),
+    ('plantas', 'gardening'),
+    ('jardinagem', 'gardening'),
+    ('garden', 'gardening'),
+    ('jardim', 'gardening'),
+    ('moda', 'fashion'),
+    ('clothes', 'fashion'),
+    ('roupas', 'fashion'),
+    ('style', 'fashion'),
+    ('makeup', 'beauty'),
+    ('maquiagem', 'beauty'),
+    ('skincare', 'beauty'),
+    ('beleza', 'beauty'),
+    ('pet', 'pets'),
+    ('dogs', 'pets'),
+    ('dog', 'pets'),
+    ('cats', 'pets'),
+    ('cat', 'pets'),
+    ('cachorro', 'pets'),
+    ('cachorros', 'pets'),
+    ('gato', 'pets'),
+    ('gatos', 'pets'),
+    ('self care', 'wellness'),
+    ('meditation', 'wellness'),
+    ('meditacao', 'wellness'),
+    ('bem estar', 'wellness'),
+    ('books', 'reading'),
+    ('book', 'reading'),
+    ('livros', 'reading'),
+    ('livro', 'reading'),
+    ('leitura', 'reading'),
+    ('idiomas', 'languages'),
+    ('linguas', 'languages'),
+    ('language', 'languages');
+
+-- Map existing free-text keywords onto the taxonomy so filters match
+-- regardless of how a keyword was originally spelled.
+UPDATE recipients r SET keywords = ARRAY(
+    SELECT k.keyword
+    FROM (
+        SELECT COALESCE(i.slug, l.slug, s.slug, lower(btrim(t.kw))) AS keyword, MIN(t.ord) AS ord
+        FROM unnest(r.keywords) WITH ORDINALITY AS t(kw, ord)
+        LEFT JOIN interests i ON i.slug = replace(lower(immutable_unaccent(btrim(t.kw))), ' ', '-')
+        LEFT JOIN LATERAL (
+            SELECT il.slug FROM interest_labels il
+            WHERE lower(immutable_unaccent(il.label)) = lower(immutable_unaccent(btrim(t.kw)))
+            LIMIT 1
+        ) l ON TRUE
+        LEFT JOIN interest_synonyms s ON s.term = lower(immutable_unaccent(btrim(t.kw)))
+        WHERE btrim(t.kw) <> ''
+        GROUP BY 1
+    ) k
+    ORDER BY k.ord
+)
+WHERE cardinality(r.keywords) > 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS recipient_group_members;
+DROP TABLE IF EXISTS recipient_groups;
+ALTER TABLE recipients DROP COLUMN IF EXISTS relationship;
@@ -0,0 +1,21 @@
+ALTER TABLE recipients ADD COLUMN relationship VARCHAR(30) NOT NULL DEFAULT '';
+
+CREATE TABLE recipient_groups (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_recipient_groups_user_name ON recipient_groups(user_id, lower(name));
+
+CREATE TABLE recipient_group_members (
+    group_id     UUID NOT NULL REFERENCES recipient_groups(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (group_id, recipient_id)
+);
+
+CREATE INDEX idx_recipient_group_members_recipient ON recipient_group_members(recipient_id);
@@ -0,0 +1,13 @@
+CREATE TABLE occasions (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient_id UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    kind         VARCHAR(20) NOT NULL,
+    title        VARCHAR(100) NOT NULL DEFAULT '',
+    date         DATE NOT NULL,
+    recurring    BOOLEAN NOT NULL DEFAULT TRUE,
+    budget       DECIMAL(10, 2),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_occasions_recipient_id ON occasions(recipient_id);
@@ -0,0 +1,9 @@
+CREATE TABLE holiday_subscriptions (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient_id UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    country      CHAR(2) NOT NULL,
+    holiday      VARCHAR(50) NOT NULL,
+    budget       DECIMAL(10, 2),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (recipient_id, country, holiday)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE import_sessions (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source     VARCHAR(20) NOT NULL,
+    items      JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    applied_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_import_sessions_expires_at ON import_sessions(expires_at);
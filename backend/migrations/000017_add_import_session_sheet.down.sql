@@ -0,0 +1,5 @@
+ALTER TABLE import_sessions
+    DROP COLUMN IF EXISTS rows,
+    DROP COLUMN IF EXISTS date_format,
+    DROP COLUMN IF EXISTS mapping,
+    DROP COLUMN IF EXISTS columns;
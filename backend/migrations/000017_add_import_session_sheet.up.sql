@@ -0,0 +1,5 @@
+ALTER TABLE import_sessions
+    ADD COLUMN columns     JSONB,
+    ADD COLUMN mapping     JSONB,
+    ADD COLUMN date_format VARCHAR(10),
+    ADD COLUMN rows        JSONB;
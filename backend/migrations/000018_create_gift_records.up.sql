@@ -0,0 +1,15 @@
+CREATE TABLE gift_records (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient_id    UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    occasion_id     UUID REFERENCES occasions(id) ON DELETE SET NULL,
+    catalog_item_id VARCHAR(100) NOT NULL DEFAULT '',
+    item            VARCHAR(200) NOT NULL,
+    category        VARCHAR(50) NOT NULL DEFAULT '',
+    price           DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    given_on        DATE NOT NULL,
+    rating          SMALLINT CHECK (rating BETWEEN 1 AND 5),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_gift_records_recipient_id ON gift_records(recipient_id, given_on DESC);
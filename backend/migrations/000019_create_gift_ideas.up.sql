@@ -0,0 +1,17 @@
+CREATE TABLE gift_ideas (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient_id    UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    catalog_item_id VARCHAR(100) NOT NULL DEFAULT '',
+    title           VARCHAR(200) NOT NULL,
+    url             VARCHAR(2048) NOT NULL DEFAULT '',
+    price           DECIMAL(10, 2),
+    notes           VARCHAR(1000) NOT NULL DEFAULT '',
+    category        VARCHAR(50) NOT NULL DEFAULT '',
+    priority        VARCHAR(10) NOT NULL DEFAULT 'medium',
+    status          VARCHAR(20) NOT NULL DEFAULT 'idea',
+    gift_id         UUID REFERENCES gift_records(id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_gift_ideas_recipient_id ON gift_ideas(recipient_id);
@@ -0,0 +1,29 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE yearly_budgets
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN amount TYPE DECIMAL(10, 2) USING amount / 100.0;
+
+ALTER TABLE gift_ideas
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 100.0;
+
+ALTER TABLE gift_records
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN price DROP DEFAULT;
+ALTER TABLE gift_records ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 100.0;
+ALTER TABLE gift_records ALTER COLUMN price SET DEFAULT 0;
+
+ALTER TABLE holiday_subscriptions ALTER COLUMN budget TYPE DECIMAL(10, 2) USING budget / 100.0;
+ALTER TABLE occasions ALTER COLUMN budget TYPE DECIMAL(10, 2) USING budget / 100.0;
+
+ALTER TABLE recipients
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN min_budget DROP DEFAULT,
+    ALTER COLUMN max_budget DROP DEFAULT;
+ALTER TABLE recipients
+    ALTER COLUMN min_budget TYPE DECIMAL(10, 2) USING min_budget / 100.0,
+    ALTER COLUMN max_budget TYPE DECIMAL(10, 2) USING max_budget / 100.0;
+ALTER TABLE recipients
+    ALTER COLUMN min_budget SET DEFAULT 0,
+    ALTER COLUMN max_budget SET DEFAULT 0;
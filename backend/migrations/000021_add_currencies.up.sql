@@ -0,0 +1,46 @@
+-- Money is stored in minor units (cents) of the row's currency.
+ALTER TABLE recipients
+    ALTER COLUMN min_budget DROP DEFAULT,
+    ALTER COLUMN max_budget DROP DEFAULT;
+ALTER TABLE recipients
+    ALTER COLUMN min_budget TYPE BIGINT USING ROUND(min_budget * 100)::BIGINT,
+    ALTER COLUMN max_budget TYPE BIGINT USING ROUND(max_budget * 100)::BIGINT;
+ALTER TABLE recipients
+    ALTER COLUMN min_budget SET DEFAULT 0,
+    ALTER COLUMN max_budget SET DEFAULT 0,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+UPDATE recipients r SET currency = p.currency
+FROM user_preferences p WHERE p.user_id = r.user_id;
+
+ALTER TABLE occasions ALTER COLUMN budget TYPE BIGINT USING ROUND(budget * 100)::BIGINT;
+ALTER TABLE holiday_subscriptions ALTER COLUMN budget TYPE BIGINT USING ROUND(budget * 100)::BIGINT;
+
+ALTER TABLE gift_records ALTER COLUMN price DROP DEFAULT;
+ALTER TABLE gift_records ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+ALTER TABLE gift_records
+    ALTER COLUMN price SET DEFAULT 0,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+UPDATE gift_records g SET currency = r.currency
+FROM recipients r WHERE r.id = g.recipient_id;
+
+ALTER TABLE gift_ideas
+    ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+UPDATE gift_ideas i SET currency = r.currency
+FROM recipients r WHERE r.id = i.recipient_id;
+
+ALTER TABLE yearly_budgets
+    ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+UPDATE yearly_budgets b SET currency = p.currency
+FROM user_preferences p WHERE p.user_id = b.user_id;
+
+-- One unit of base is worth rate units of quote. The table is replaced
+-- wholesale by the rates loader.
+CREATE TABLE exchange_rates (
+    base       CHAR(3) NOT NULL,
+    quote      CHAR(3) NOT NULL,
+    rate       NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (base, quote)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS group_gift_contributors;
+DROP TABLE IF EXISTS group_gifts;
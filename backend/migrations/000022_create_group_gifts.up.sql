@@ -0,0 +1,32 @@
+CREATE TABLE group_gifts (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id  UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id  UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    occasion_id   UUID REFERENCES occasions(id) ON DELETE SET NULL,
+    title         VARCHAR(200) NOT NULL,
+    description   VARCHAR(1000) NOT NULL DEFAULT '',
+    target_amount BIGINT NOT NULL CHECK (target_amount > 0),
+    currency      CHAR(3) NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'open',
+    settled_at    TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_group_gifts_organizer_id ON group_gifts(organizer_id);
+CREATE INDEX idx_group_gifts_recipient_id ON group_gifts(recipient_id);
+
+-- Contributors are invited by email, lower-cased, whether or not they have an account.
+CREATE TABLE group_gift_contributors (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    group_gift_id UUID NOT NULL REFERENCES group_gifts(id) ON DELETE CASCADE,
+    email         VARCHAR(255) NOT NULL,
+    name          VARCHAR(100) NOT NULL DEFAULT '',
+    pledged       BIGINT NOT NULL DEFAULT 0 CHECK (pledged >= 0),
+    paid          BIGINT NOT NULL DEFAULT 0 CHECK (paid >= 0),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (group_gift_id, email)
+);
+
+CREATE INDEX idx_group_gift_contributors_email ON group_gift_contributors(email);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS exchange_exclusions;
+DROP TABLE IF EXISTS exchange_participants;
+DROP TABLE IF EXISTS gift_exchanges;
@@ -0,0 +1,43 @@
+CREATE TABLE gift_exchanges (
+    id                   UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name                 VARCHAR(100) NOT NULL,
+    budget               BIGINT CHECK (budget >= 0),
+    currency             CHAR(3) NOT NULL,
+    exchange_on          DATE,
+    previous_exchange_id UUID REFERENCES gift_exchanges(id) ON DELETE SET NULL,
+    status               VARCHAR(20) NOT NULL DEFAULT 'draft',
+    -- seed replays the draw; it is never sent to clients.
+    seed                 BIGINT NOT NULL DEFAULT 0,
+    drawn_at             TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_gift_exchanges_organizer_id ON gift_exchanges(organizer_id);
+
+-- Participants are identified by email, lower-cased, whether or not they have an account.
+-- token is the secret link to their assignment, set by the draw.
+CREATE TABLE exchange_participants (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    exchange_id UUID NOT NULL REFERENCES gift_exchanges(id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    email       VARCHAR(255) NOT NULL,
+    token       VARCHAR(64) UNIQUE,
+    receiver_id UUID REFERENCES exchange_participants(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (exchange_id, email)
+);
+
+CREATE INDEX idx_exchange_participants_email ON exchange_participants(email);
+
+CREATE TABLE exchange_exclusions (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    exchange_id UUID NOT NULL REFERENCES gift_exchanges(id) ON DELETE CASCADE,
+    giver_id    UUID NOT NULL REFERENCES exchange_participants(id) ON DELETE CASCADE,
+    receiver_id UUID NOT NULL REFERENCES exchange_participants(id) ON DELETE CASCADE,
+    mutual      BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (exchange_id, giver_id, receiver_id),
+    CHECK (giver_id <> receiver_id)
+);
@@ -0,0 +1,31 @@
+CREATE TABLE wishlists (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES recipients(id) ON DELETE CASCADE,
+    title        VARCHAR(200) NOT NULL,
+    description  VARCHAR(1000) NOT NULL DEFAULT '',
+    slug         VARCHAR(64) NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_wishlists_user_id ON wishlists(user_id);
+CREATE INDEX idx_wishlists_recipient_id ON wishlists(recipient_id);
+
+CREATE TABLE wishlist_items (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    wishlist_id UUID NOT NULL REFERENCES wishlists(id) ON DELETE CASCADE,
+    idea_id     UUID NOT NULL REFERENCES gift_ideas(id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (wishlist_id, idea_id)
+);
+
+-- At most one claim per item. Claims are made by anonymous visitors and
+-- released with their secret token; they are never shown to the owner.
+CREATE TABLE wishlist_claims (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id    UUID NOT NULL UNIQUE REFERENCES wishlist_items(id) ON DELETE CASCADE,
+    name       VARCHAR(100) NOT NULL DEFAULT '',
+    token      VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,30 @@
+CREATE TABLE households (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A user belongs to at most one household; its members share their
+-- recipients according to their role.
+CREATE TABLE household_members (
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    role         VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    joined_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (household_id, user_id)
+);
+
+-- Exactly one owner per household.
+CREATE UNIQUE INDEX idx_household_members_owner ON household_members(household_id) WHERE role = 'owner';
+
+CREATE TABLE household_invitations (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    email        VARCHAR(255) NOT NULL,
+    role         VARCHAR(20) NOT NULL CHECK (role IN ('editor', 'viewer')),
+    token        VARCHAR(64) NOT NULL UNIQUE,
+    invited_by   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (household_id, email)
+);
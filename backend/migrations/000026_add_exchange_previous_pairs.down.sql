@@ -0,0 +1 @@
+ALTER TABLE gift_exchanges DROP COLUMN IF EXISTS previous_pairs;
@@ -0,0 +1,21 @@
+-- previous_pairs are the giver/receiver participant ids the draw kept from
+-- repeating the previous exchange, stored with the seed so the draw still
+-- replays once the previous exchange is deleted. Like seed, never sent to clients.
+ALTER TABLE gift_exchanges ADD COLUMN previous_pairs JSONB NOT NULL DEFAULT '[]';
+
+-- Backfill drawn exchanges whose previous exchange still exists, matching
+-- last year's pairs onto this year's participants by email.
+UPDATE gift_exchanges e
+SET previous_pairs = pairs.value
+FROM (
+    SELECT e.id,
+           jsonb_agg(jsonb_build_object('giver_id', giver.id, 'receiver_id', receiver.id)) AS value
+    FROM gift_exchanges e
+    JOIN exchange_participants last_giver ON last_giver.exchange_id = e.previous_exchange_id
+    JOIN exchange_participants last_receiver ON last_receiver.id = last_giver.receiver_id
+    JOIN exchange_participants giver ON giver.exchange_id = e.id AND giver.email = last_giver.email
+    JOIN exchange_participants receiver ON receiver.exchange_id = e.id AND receiver.email = last_receiver.email
+    WHERE e.drawn_at IS NOT NULL
+    GROUP BY e.id
+) pairs
+WHERE e.id = pairs.id;
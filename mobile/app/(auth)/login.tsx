@@ -14,6 +14,7 @@ import { Link } from "expo-router";
 import { useAuthStore } from "../../stores/authStore";
 import { GoogleSignInButton } from "../../components/auth/GoogleSignInButton";
 import { AppleSignInButton } from "../../components/auth/AppleSignInButton";
+import { problemMessage } from "../../services/api";
 
 export default function LoginScreen() {
   const [email, setEmail] = useState("");
@@ -32,7 +33,7 @@ export default function LoginScreen() {
     } catch (error: any) {
       Alert.alert(
         "Login Failed",
-        error.response?.data?.error || "Something went wrong"
+        problemMessage(error, "Something went wrong")
       );
     } finally {
       setLoading(false);
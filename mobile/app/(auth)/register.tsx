@@ -14,6 +14,7 @@ import { Link } from "expo-router";
 import { useAuthStore } from "../../stores/authStore";
 import { GoogleSignInButton } from "../../components/auth/GoogleSignInButton";
 import { AppleSignInButton } from "../../components/auth/AppleSignInButton";
+import { problemMessage } from "../../services/api";
 
 export default function RegisterScreen() {
   const [name, setName] = useState("");
@@ -37,7 +38,7 @@ export default function RegisterScreen() {
     } catch (error: any) {
       Alert.alert(
         "Registration Failed",
-        error.response?.data?.error || "Something went wrong"
+        problemMessage(error, "Something went wrong")
       );
     } finally {
       setLoading(false);
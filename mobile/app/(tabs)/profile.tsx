@@ -1,5 +1,8 @@
 import { View, Text, TouchableOpacity, StyleSheet, Alert } from "react-native";
+import * as Linking from "expo-linking";
 import { useAuthStore } from "../../stores/authStore";
+import { calendarService } from "../../services/calendarService";
+import { problemMessage } from "../../services/api";
 
 export default function ProfileScreen() {
   const { user, logout } = useAuthStore();
@@ -15,6 +18,36 @@ export default function ProfileScreen() {
     ]);
   };
 
+  const handleSubscribeCalendar = async () => {
+    try {
+      const feed = await calendarService.getFeed();
+      await Linking.openURL(calendarService.subscribeUrl(feed));
+    } catch (error) {
+      Alert.alert("Error", problemMessage(error, "Could not open the calendar feed"));
+    }
+  };
+
+  const handleResetCalendar = () => {
+    Alert.alert(
+      "Reset calendar link",
+      "Calendars subscribed with the current link will stop updating.",
+      [
+        { text: "Cancel", style: "cancel" },
+        {
+          text: "Reset",
+          style: "destructive",
+          onPress: async () => {
+            try {
+              await calendarService.rotateFeed();
+            } catch (error) {
+              Alert.alert("Error", problemMessage(error, "Could not reset the calendar link"));
+            }
+          },
+        },
+      ]
+    );
+  };
+
   return (
     <View style={styles.container}>
       <View style={styles.card}>
@@ -27,6 +60,13 @@ export default function ProfileScreen() {
         <Text style={styles.email}>{user?.email || ""}</Text>
       </View>
 
+      <TouchableOpacity onPress={handleSubscribeCalendar} style={styles.calendarButton}>
+        <Text style={styles.calendarText}>Add birthdays to my calendar</Text>
+      </TouchableOpacity>
+      <TouchableOpacity onPress={handleResetCalendar} style={styles.linkButton}>
+        <Text style={styles.linkText}>Reset calendar link</Text>
+      </TouchableOpacity>
+
       <TouchableOpacity onPress={handleLogout} style={styles.logoutButton}>
         <Text style={styles.logoutText}>Logout</Text>
       </TouchableOpacity>
@@ -76,6 +116,26 @@ const styles = StyleSheet.create({
     fontSize: 14,
     color: "#6B7280",
   },
+  calendarButton: {
+    backgroundColor: "#EDE9FE",
+    padding: 16,
+    borderRadius: 12,
+    alignItems: "center",
+  },
+  calendarText: {
+    color: "#7C3AED",
+    fontSize: 16,
+    fontWeight: "600",
+  },
+  linkButton: {
+    padding: 12,
+    alignItems: "center",
+    marginBottom: 12,
+  },
+  linkText: {
+    color: "#6B7280",
+    fontSize: 14,
+  },
   logoutButton: {
     backgroundColor: "#FEE2E2",
     padding: 16,
@@ -7,6 +7,7 @@ import {
   StyleSheet,
   RefreshControl,
   Alert,
+  ActivityIndicator,
 } from "react-native";
 import { useRouter } from "expo-router";
 import { Ionicons } from "@expo/vector-icons";
@@ -15,8 +16,15 @@ import { Recipient } from "../../types/recipient";
 
 export default function RecipientsScreen() {
   const router = useRouter();
-  const { recipients, isLoading, fetchRecipients, deleteRecipient, bulkDeleteRecipients } =
-    useRecipientStore();
+  const {
+    recipients,
+    isLoading,
+    isLoadingMore,
+    fetchRecipients,
+    fetchMoreRecipients,
+    deleteRecipient,
+    bulkDeleteRecipients,
+  } = useRecipientStore();
   const [selectedIds, setSelectedIds] = useState<string[]>([]);
   const [selectionMode, setSelectionMode] = useState(false);
 
@@ -159,6 +167,13 @@ export default function RecipientsScreen() {
         refreshControl={
           <RefreshControl refreshing={isLoading} onRefresh={onRefresh} />
         }
+        onEndReached={fetchMoreRecipients}
+        onEndReachedThreshold={0.5}
+        ListFooterComponent={
+          isLoadingMore ? (
+            <ActivityIndicator style={styles.footerLoader} color="#7C3AED" />
+          ) : null
+        }
         contentContainerStyle={[
           styles.list,
           recipients.length === 0 && styles.emptyList,
@@ -300,6 +315,9 @@ const styles = StyleSheet.create({
   deleteButton: {
     padding: 8,
   },
+  footerLoader: {
+    marginVertical: 16,
+  },
   empty: {
     alignItems: "center",
   },
@@ -16,24 +16,25 @@ import { Ionicons } from "@expo/vector-icons";
 import { useRecipientStore } from "../../stores/recipientStore";
 import { recipientService } from "../../services/recipientService";
 import { Recipient } from "../../types/recipient";
+import { problemMessage } from "../../services/api";
+import { useKeywordSuggestions } from "../../hooks/useKeywordSuggestions";
 
 const GENDER_OPTIONS = ["male", "female", "other"];
 
 const SUGGESTED_KEYWORDS = [
-  "nerd",
-  "geek",
-  "sports",
+  "gaming",
+  "reading",
   "music",
   "cooking",
-  "reading",
-  "gaming",
+  "sports",
   "travel",
   "fitness",
-  "tech",
+  "technology",
+  "geek",
   "fashion",
-  "art",
+  "arts",
   "movies",
-  "outdoor",
+  "outdoors",
   "pets",
 ];
 
@@ -50,6 +51,7 @@ export default function RecipientDetailScreen() {
   const [maxBudget, setMaxBudget] = useState("");
   const [keywords, setKeywords] = useState<string[]>([]);
   const [customKeyword, setCustomKeyword] = useState("");
+  const keywordSuggestions = useKeywordSuggestions(customKeyword);
   const [loading, setLoading] = useState(true);
   const [saving, setSaving] = useState(false);
 
@@ -87,6 +89,11 @@ export default function RecipientDetailScreen() {
     setKeywords(keywords.filter((k) => k !== kw));
   };
 
+  // While typing, offer taxonomy matches; otherwise fall back to the defaults.
+  const suggestions = customKeyword.trim()
+    ? keywordSuggestions.map((s) => ({ value: s.slug, label: s.label }))
+    : SUGGESTED_KEYWORDS.map((kw) => ({ value: kw, label: kw }));
+
   const handleSave = async () => {
     if (!name.trim()) {
       Alert.alert("Error", "Name is required");
@@ -104,7 +111,7 @@ export default function RecipientDetailScreen() {
       });
       router.back();
     } catch (error: any) {
-      Alert.alert("Error", error.response?.data?.error || "Failed to update");
+      Alert.alert("Error", problemMessage(error, "Failed to update"));
     } finally {
       setSaving(false);
     }
@@ -259,14 +266,14 @@ export default function RecipientDetailScreen() {
             </View>
 
             <View style={styles.suggestedTags}>
-              {SUGGESTED_KEYWORDS.filter((kw) => !keywords.includes(kw)).map(
-                (kw) => (
+              {suggestions.filter((s) => !keywords.includes(s.value)).map(
+                (s) => (
                   <TouchableOpacity
-                    key={kw}
+                    key={s.value}
                     style={styles.suggestedTag}
-                    onPress={() => addKeyword(kw)}
+                    onPress={() => addKeyword(s.value)}
                   >
-                    <Text style={styles.suggestedTagText}>{kw}</Text>
+                    <Text style={styles.suggestedTagText}>{s.label}</Text>
                     <Ionicons name="add" size={14} color="#6B7280" />
                   </TouchableOpacity>
                 )
@@ -0,0 +1,37 @@
+import { useEffect, useState } from "react";
+import { keywordService } from "../services/keywordService";
+import { KeywordSuggestion } from "../types/keyword";
+
+const DEBOUNCE_MS = 200;
+
+// Fetches taxonomy matches for the keyword being typed, debounced.
+export function useKeywordSuggestions(prefix: string): KeywordSuggestion[] {
+  const [suggestions, setSuggestions] = useState<KeywordSuggestion[]>([]);
+
+  useEffect(() => {
+    const term = prefix.trim();
+    if (!term) {
+      setSuggestions([]);
+      return;
+    }
+
+    let cancelled = false;
+    const timer = setTimeout(() => {
+      keywordService
+        .suggest(term)
+        .then((result) => {
+          if (!cancelled) setSuggestions(result);
+        })
+        .catch(() => {
+          if (!cancelled) setSuggestions([]);
+        });
+    }, DEBOUNCE_MS);
+
+    return () => {
+      cancelled = true;
+      clearTimeout(timer);
+    };
+  }, [prefix]);
+
+  return suggestions;
+}
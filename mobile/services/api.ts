@@ -77,4 +77,21 @@ api.interceptors.response.use(
   }
 );
 
+// ProblemDetails is the RFC 7807 body the API returns for every error.
+export interface ProblemDetails {
+  type: string;
+  title: string;
+  status: number;
+  detail?: string;
+  instance?: string;
+  request_id?: string;
+  errors?: Array<{ field: string; code: string; message: string }>;
+}
+
+// problemMessage picks the most specific human-readable message from an API error.
+export function problemMessage(error: unknown, fallback: string): string {
+  const problem = (error as AxiosError<ProblemDetails>)?.response?.data;
+  return problem?.errors?.[0]?.message || problem?.detail || problem?.title || fallback;
+}
+
 export default api;
@@ -0,0 +1,23 @@
+import api from "./api";
+import { API_URL } from "../constants/config";
+import { CalendarFeed } from "../types/calendar";
+
+export const calendarService = {
+  getFeed: async (): Promise<CalendarFeed> => {
+    const { data } = await api.get<CalendarFeed>("/api/me/calendar");
+    return data;
+  },
+
+  rotateFeed: async (): Promise<CalendarFeed> => {
+    const { data } = await api.post<CalendarFeed>("/api/me/calendar/rotate");
+    return data;
+  },
+
+  disableFeed: async (): Promise<void> => {
+    await api.delete("/api/me/calendar");
+  },
+
+  // webcal:// links open the system calendar's subscribe flow.
+  subscribeUrl: (feed: CalendarFeed): string =>
+    API_URL.replace(/^https?:/, "webcal:") + feed.path,
+};
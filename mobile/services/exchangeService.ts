@@ -0,0 +1,76 @@
+import api from "./api";
+import {
+  AddExclusionRequest,
+  AddParticipantRequest,
+  CreateExchangeRequest,
+  ExchangeAssignment,
+  ExchangeExclusion,
+  ExchangeParticipant,
+  ExchangeVerification,
+  GiftExchange,
+  UpdateExchangeRequest,
+} from "../types/exchange";
+
+export const exchangeService = {
+  list: async (): Promise<GiftExchange[]> => {
+    const { data } = await api.get<GiftExchange[]>("/api/exchanges");
+    return data;
+  },
+
+  get: async (id: string): Promise<GiftExchange> => {
+    const { data } = await api.get<GiftExchange>(`/api/exchanges/${id}`);
+    return data;
+  },
+
+  create: async (payload: CreateExchangeRequest): Promise<GiftExchange> => {
+    const { data } = await api.post<GiftExchange>("/api/exchanges", payload);
+    return data;
+  },
+
+  update: async (id: string, payload: UpdateExchangeRequest): Promise<GiftExchange> => {
+    const { data } = await api.put<GiftExchange>(`/api/exchanges/${id}`, payload);
+    return data;
+  },
+
+  delete: async (id: string): Promise<void> => {
+    await api.delete(`/api/exchanges/${id}`);
+  },
+
+  addParticipant: async (id: string, payload: AddParticipantRequest): Promise<ExchangeParticipant> => {
+    const { data } = await api.post<ExchangeParticipant>(`/api/exchanges/${id}/participants`, payload);
+    return data;
+  },
+
+  removeParticipant: async (id: string, participantId: string): Promise<void> => {
+    await api.delete(`/api/exchanges/${id}/participants/${participantId}`);
+  },
+
+  addExclusion: async (id: string, payload: AddExclusionRequest): Promise<ExchangeExclusion> => {
+    const { data } = await api.post<ExchangeExclusion>(`/api/exchanges/${id}/exclusions`, payload);
+    return data;
+  },
+
+  removeExclusion: async (id: string, exclusionId: string): Promise<void> => {
+    await api.delete(`/api/exchanges/${id}/exclusions/${exclusionId}`);
+  },
+
+  draw: async (id: string): Promise<GiftExchange> => {
+    const { data } = await api.post<GiftExchange>(`/api/exchanges/${id}/draw`);
+    return data;
+  },
+
+  verify: async (id: string): Promise<ExchangeVerification> => {
+    const { data } = await api.post<ExchangeVerification>(`/api/exchanges/${id}/verify`);
+    return data;
+  },
+
+  myAssignment: async (id: string): Promise<ExchangeAssignment> => {
+    const { data } = await api.get<ExchangeAssignment>(`/api/exchanges/${id}/assignment`);
+    return data;
+  },
+
+  assignmentByToken: async (token: string): Promise<ExchangeAssignment> => {
+    const { data } = await api.get<ExchangeAssignment>(`/api/exchange-assignments/${token}`);
+    return data;
+  },
+};
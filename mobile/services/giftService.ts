@@ -0,0 +1,30 @@
+import api from "./api";
+import { CreateGiftRequest, GiftRecord, GiftSuggestion, UpdateGiftRequest } from "../types/gift";
+
+export const giftService = {
+  list: async (recipientId: string): Promise<GiftRecord[]> => {
+    const { data } = await api.get<GiftRecord[]>(`/api/recipients/${recipientId}/gifts`);
+    return data;
+  },
+
+  create: async (recipientId: string, payload: CreateGiftRequest): Promise<GiftRecord> => {
+    const { data } = await api.post<GiftRecord>(`/api/recipients/${recipientId}/gifts`, payload);
+    return data;
+  },
+
+  update: async (recipientId: string, id: string, payload: UpdateGiftRequest): Promise<GiftRecord> => {
+    const { data } = await api.put<GiftRecord>(`/api/recipients/${recipientId}/gifts/${id}`, payload);
+    return data;
+  },
+
+  delete: async (recipientId: string, id: string): Promise<void> => {
+    await api.delete(`/api/recipients/${recipientId}/gifts/${id}`);
+  },
+
+  suggestions: async (recipientId: string, limit?: number, locale?: string): Promise<GiftSuggestion[]> => {
+    const { data } = await api.get<GiftSuggestion[]>(`/api/recipients/${recipientId}/suggestions`, {
+      params: { limit, locale },
+    });
+    return data;
+  },
+};
@@ -0,0 +1,64 @@
+import api from "./api";
+import {
+  CreateGroupGiftRequest,
+  GroupGift,
+  GroupGiftContributor,
+  GroupGiftProgress,
+  InviteContributorRequest,
+  UpdateContributionRequest,
+  UpdateGroupGiftRequest,
+} from "../types/groupGift";
+
+export const groupGiftService = {
+  list: async (): Promise<GroupGift[]> => {
+    const { data } = await api.get<GroupGift[]>("/api/group-gifts");
+    return data;
+  },
+
+  get: async (id: string): Promise<GroupGift> => {
+    const { data } = await api.get<GroupGift>(`/api/group-gifts/${id}`);
+    return data;
+  },
+
+  create: async (payload: CreateGroupGiftRequest): Promise<GroupGift> => {
+    const { data } = await api.post<GroupGift>("/api/group-gifts", payload);
+    return data;
+  },
+
+  update: async (id: string, payload: UpdateGroupGiftRequest): Promise<GroupGift> => {
+    const { data } = await api.put<GroupGift>(`/api/group-gifts/${id}`, payload);
+    return data;
+  },
+
+  delete: async (id: string): Promise<void> => {
+    await api.delete(`/api/group-gifts/${id}`);
+  },
+
+  progress: async (id: string): Promise<GroupGiftProgress> => {
+    const { data } = await api.get<GroupGiftProgress>(`/api/group-gifts/${id}/progress`);
+    return data;
+  },
+
+  settle: async (id: string): Promise<GroupGift> => {
+    const { data } = await api.post<GroupGift>(`/api/group-gifts/${id}/settle`);
+    return data;
+  },
+
+  invite: async (id: string, payload: InviteContributorRequest): Promise<GroupGiftContributor> => {
+    const { data } = await api.post<GroupGiftContributor>(`/api/group-gifts/${id}/contributors`, payload);
+    return data;
+  },
+
+  updateContribution: async (
+    id: string,
+    contributorId: string,
+    payload: UpdateContributionRequest,
+  ): Promise<GroupGiftContributor> => {
+    const { data } = await api.put<GroupGiftContributor>(`/api/group-gifts/${id}/contributors/${contributorId}`, payload);
+    return data;
+  },
+
+  removeContributor: async (id: string, contributorId: string): Promise<void> => {
+    await api.delete(`/api/group-gifts/${id}/contributors/${contributorId}`);
+  },
+};
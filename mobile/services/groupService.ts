@@ -0,0 +1,34 @@
+import api from "./api";
+import { Group } from "../types/recipient";
+
+export const groupService = {
+  list: async (): Promise<Group[]> => {
+    const { data } = await api.get<Group[]>("/api/groups");
+    return data;
+  },
+
+  create: async (name: string): Promise<Group> => {
+    const { data } = await api.post<Group>("/api/groups", { name });
+    return data;
+  },
+
+  rename: async (id: string, name: string): Promise<Group> => {
+    const { data } = await api.put<Group>(`/api/groups/${id}`, { name });
+    return data;
+  },
+
+  delete: async (id: string): Promise<void> => {
+    await api.delete(`/api/groups/${id}`);
+  },
+
+  addMembers: async (id: string, recipientIds: string[]): Promise<Group> => {
+    const { data } = await api.post<Group>(`/api/groups/${id}/members`, {
+      recipient_ids: recipientIds,
+    });
+    return data;
+  },
+
+  removeMember: async (id: string, recipientId: string): Promise<void> => {
+    await api.delete(`/api/groups/${id}/members/${recipientId}`);
+  },
+};
@@ -0,0 +1,29 @@
+import api from "./api";
+import { HolidayDate, HolidaySubscription, HolidaySubscriptionRequest } from "../types/holiday";
+
+export interface HolidayParams {
+  country?: string;
+  year?: number;
+  locale?: string;
+}
+
+export const holidayService = {
+  list: async (params: HolidayParams = {}): Promise<HolidayDate[]> => {
+    const { data } = await api.get<HolidayDate[]>("/api/holidays", { params });
+    return data;
+  },
+
+  subscriptions: async (recipientId: string): Promise<HolidaySubscription[]> => {
+    const { data } = await api.get<HolidaySubscription[]>(`/api/recipients/${recipientId}/holidays`);
+    return data;
+  },
+
+  subscribe: async (recipientId: string, payload: HolidaySubscriptionRequest): Promise<HolidaySubscription> => {
+    const { data } = await api.post<HolidaySubscription>(`/api/recipients/${recipientId}/holidays`, payload);
+    return data;
+  },
+
+  unsubscribe: async (recipientId: string, id: string): Promise<void> => {
+    await api.delete(`/api/recipients/${recipientId}/holidays/${id}`);
+  },
+};
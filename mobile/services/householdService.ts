@@ -0,0 +1,57 @@
+import api from "./api";
+import {
+  CreateHouseholdRequest,
+  Household,
+  HouseholdInvitation,
+  InviteHouseholdMemberRequest,
+  UpdateHouseholdMemberRequest,
+  UpdateHouseholdRequest,
+} from "../types/household";
+
+export const householdService = {
+  get: async (): Promise<Household> => {
+    const { data } = await api.get<Household>("/api/household");
+    return data;
+  },
+
+  create: async (payload: CreateHouseholdRequest): Promise<Household> => {
+    const { data } = await api.post<Household>("/api/household", payload);
+    return data;
+  },
+
+  update: async (payload: UpdateHouseholdRequest): Promise<Household> => {
+    const { data } = await api.put<Household>("/api/household", payload);
+    return data;
+  },
+
+  delete: async (): Promise<void> => {
+    await api.delete("/api/household");
+  },
+
+  leave: async (): Promise<void> => {
+    await api.post("/api/household/leave");
+  },
+
+  invite: async (payload: InviteHouseholdMemberRequest): Promise<HouseholdInvitation> => {
+    const { data } = await api.post<HouseholdInvitation>("/api/household/invitations", payload);
+    return data;
+  },
+
+  revokeInvitation: async (id: string): Promise<void> => {
+    await api.delete(`/api/household/invitations/${id}`);
+  },
+
+  accept: async (token: string): Promise<Household> => {
+    const { data } = await api.post<Household>(`/api/household-invitations/${token}/accept`);
+    return data;
+  },
+
+  updateMember: async (userId: string, payload: UpdateHouseholdMemberRequest): Promise<Household> => {
+    const { data } = await api.put<Household>(`/api/household/members/${userId}`, payload);
+    return data;
+  },
+
+  removeMember: async (userId: string): Promise<void> => {
+    await api.delete(`/api/household/members/${userId}`);
+  },
+};
@@ -0,0 +1,39 @@
+import api from "./api";
+import {
+  CreateIdeaRequest,
+  GiftIdea,
+  PromoteSuggestionRequest,
+  TransitionIdeaRequest,
+  UpdateIdeaRequest,
+} from "../types/idea";
+
+export const ideaService = {
+  list: async (recipientId: string): Promise<GiftIdea[]> => {
+    const { data } = await api.get<GiftIdea[]>(`/api/recipients/${recipientId}/ideas`);
+    return data;
+  },
+
+  create: async (recipientId: string, payload: CreateIdeaRequest): Promise<GiftIdea> => {
+    const { data } = await api.post<GiftIdea>(`/api/recipients/${recipientId}/ideas`, payload);
+    return data;
+  },
+
+  promote: async (recipientId: string, payload: PromoteSuggestionRequest): Promise<GiftIdea> => {
+    const { data } = await api.post<GiftIdea>(`/api/recipients/${recipientId}/ideas/promote`, payload);
+    return data;
+  },
+
+  update: async (recipientId: string, id: string, payload: UpdateIdeaRequest): Promise<GiftIdea> => {
+    const { data } = await api.put<GiftIdea>(`/api/recipients/${recipientId}/ideas/${id}`, payload);
+    return data;
+  },
+
+  move: async (recipientId: string, id: string, payload: TransitionIdeaRequest): Promise<GiftIdea> => {
+    const { data } = await api.post<GiftIdea>(`/api/recipients/${recipientId}/ideas/${id}/status`, payload);
+    return data;
+  },
+
+  delete: async (recipientId: string, id: string): Promise<void> => {
+    await api.delete(`/api/recipients/${recipientId}/ideas/${id}`);
+  },
+};
@@ -0,0 +1,87 @@
+import api from "./api";
+import {
+  ConfirmImportRequest,
+  ImportResult,
+  ImportSession,
+  MapColumnsRequest,
+  VCardVersion,
+} from "../types/import";
+
+// A picked document, as returned by expo-document-picker.
+export interface ImportFile {
+  uri: string;
+  name: string;
+  mimeType?: string;
+}
+
+export const importService = {
+  // Uploading a file only builds a preview; nothing is created until it is confirmed.
+  previewIcs: (file: ImportFile): Promise<ImportSession> =>
+    upload("/api/import/ics", file, "text/calendar"),
+
+  previewVcard: (file: ImportFile): Promise<ImportSession> =>
+    upload("/api/import/vcard", file, "text/vcard"),
+
+  // Accepts .csv and .xlsx files; columns are mapped from the header row and can be changed with mapColumns.
+  previewSpreadsheet: (file: ImportFile): Promise<ImportSession> =>
+    upload("/api/import/spreadsheet", file, "text/csv"),
+
+  mapColumns: async (id: string, req: MapColumnsRequest): Promise<ImportSession> => {
+    const { data } = await api.put<ImportSession>(`/api/import/${id}/mapping`, req);
+    return data;
+  },
+
+  get: async (id: string): Promise<ImportSession> => {
+    const { data } = await api.get<ImportSession>(`/api/import/${id}`);
+    return data;
+  },
+
+  confirm: async (id: string, req: ConfirmImportRequest = {}): Promise<ImportResult> => {
+    const { data } = await api.post<ImportResult>(`/api/import/${id}/confirm`, req);
+    return data;
+  },
+
+  // Returns the .vcf text, ready to be written to a file and shared.
+  exportVcard: async (version: VCardVersion = "3.0"): Promise<string> => {
+    const { data } = await api.get<string>("/api/export/recipients.vcf", {
+      params: { version },
+      responseType: "text",
+    });
+    return data;
+  },
+
+  exportCsv: async (): Promise<string> => {
+    const { data } = await api.get<string>("/api/export/recipients.csv", { responseType: "text" });
+    return data;
+  },
+
+  // Returns the workbook as base64, the encoding expo-file-system writes binary files with.
+  exportXlsx: async (): Promise<string> => {
+    const { data } = await api.get<ArrayBuffer>("/api/export/recipients.xlsx", {
+      responseType: "arraybuffer",
+    });
+    return arrayBufferToBase64(data);
+  },
+};
+
+async function upload(path: string, file: ImportFile, defaultType: string): Promise<ImportSession> {
+  const form = new FormData();
+  form.append("file", {
+    uri: file.uri,
+    name: file.name,
+    type: file.mimeType ?? defaultType,
+  } as unknown as Blob);
+  const { data } = await api.post<ImportSession>(path, form, {
+    headers: { "Content-Type": "multipart/form-data" },
+  });
+  return data;
+}
+
+function arrayBufferToBase64(buffer: ArrayBuffer): string {
+  const bytes = new Uint8Array(buffer);
+  let binary = "";
+  for (let i = 0; i < bytes.length; i++) {
+    binary += String.fromCharCode(bytes[i]);
+  }
+  return btoa(binary);
+}
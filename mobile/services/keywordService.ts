@@ -0,0 +1,11 @@
+import api from "./api";
+import { KeywordSuggestion } from "../types/keyword";
+
+export const keywordService = {
+  suggest: async (prefix: string, limit = 10): Promise<KeywordSuggestion[]> => {
+    const { data } = await api.get<KeywordSuggestion[]>("/api/keywords", {
+      params: { prefix, limit },
+    });
+    return data;
+  },
+};
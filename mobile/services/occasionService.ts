@@ -0,0 +1,39 @@
+import api from "./api";
+import {
+  CreateOccasionRequest,
+  Occasion,
+  Reminder,
+  UpcomingOccasion,
+  UpdateOccasionRequest,
+} from "../types/occasion";
+
+export const occasionService = {
+  list: async (recipientId: string): Promise<Occasion[]> => {
+    const { data } = await api.get<Occasion[]>(`/api/recipients/${recipientId}/occasions`);
+    return data;
+  },
+
+  create: async (recipientId: string, payload: CreateOccasionRequest): Promise<Occasion> => {
+    const { data } = await api.post<Occasion>(`/api/recipients/${recipientId}/occasions`, payload);
+    return data;
+  },
+
+  update: async (recipientId: string, id: string, payload: UpdateOccasionRequest): Promise<Occasion> => {
+    const { data } = await api.put<Occasion>(`/api/recipients/${recipientId}/occasions/${id}`, payload);
+    return data;
+  },
+
+  delete: async (recipientId: string, id: string): Promise<void> => {
+    await api.delete(`/api/recipients/${recipientId}/occasions/${id}`);
+  },
+
+  upcoming: async (days?: number): Promise<UpcomingOccasion[]> => {
+    const { data } = await api.get<UpcomingOccasion[]>("/api/upcoming", { params: { days } });
+    return data;
+  },
+
+  reminders: async (): Promise<Reminder[]> => {
+    const { data } = await api.get<Reminder[]>("/api/reminders");
+    return data;
+  },
+};
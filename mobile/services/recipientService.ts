@@ -3,6 +3,10 @@ import {
   Recipient,
   CreateRecipientRequest,
   UpdateRecipientRequest,
+  RecipientListParams,
+  RecipientPage,
+  DuplicatePair,
+  MergeRecipientsRequest,
 } from "../types/recipient";
 
 export const recipientService = {
@@ -14,8 +18,10 @@ export const recipientService = {
     return recipient;
   },
 
-  list: async (): Promise<Recipient[]> => {
-    const { data } = await api.get<Recipient[]>("/api/recipients");
+  list: async (params: RecipientListParams = {}): Promise<RecipientPage> => {
+    const { data } = await api.get<RecipientPage>("/api/recipients", {
+      params,
+    });
     return data;
   },
 
@@ -42,4 +48,18 @@ export const recipientService = {
   bulkDelete: async (ids: string[]): Promise<void> => {
     await api.delete("/api/recipients", { data: { ids } });
   },
+
+  findDuplicates: async (limit?: number): Promise<DuplicatePair[]> => {
+    const { data } = await api.get<DuplicatePair[]>(
+      "/api/recipients/duplicates",
+      { params: { limit } }
+    );
+    return data;
+  },
+
+  // The recipient named by merge_id is moved to the trash; the merged recipient is returned.
+  merge: async (req: MergeRecipientsRequest): Promise<Recipient> => {
+    const { data } = await api.post<Recipient>("/api/recipients/merge", req);
+    return data;
+  },
 };
@@ -0,0 +1,23 @@
+import api from "./api";
+import { SpendingReport, YearlyBudget, YearlyBudgetRequest } from "../types/report";
+
+export const reportService = {
+  budgets: async (): Promise<YearlyBudget[]> => {
+    const { data } = await api.get<YearlyBudget[]>("/api/budgets");
+    return data;
+  },
+
+  setBudget: async (year: number, req: YearlyBudgetRequest): Promise<YearlyBudget> => {
+    const { data } = await api.put<YearlyBudget>(`/api/budgets/${year}`, req);
+    return data;
+  },
+
+  deleteBudget: async (year: number): Promise<void> => {
+    await api.delete(`/api/budgets/${year}`);
+  },
+
+  spending: async (year?: number): Promise<SpendingReport> => {
+    const { data } = await api.get<SpendingReport>("/api/reports/spending", { params: { year } });
+    return data;
+  },
+};
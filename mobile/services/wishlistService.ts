@@ -0,0 +1,54 @@
+import api from "./api";
+import {
+  ClaimWishlistItemRequest,
+  CreateWishlistRequest,
+  PublicWishlist,
+  UpdateWishlistRequest,
+  Wishlist,
+  WishlistClaim,
+} from "../types/wishlist";
+
+export const wishlistService = {
+  list: async (): Promise<Wishlist[]> => {
+    const { data } = await api.get<Wishlist[]>("/api/wishlists");
+    return data;
+  },
+
+  get: async (id: string): Promise<Wishlist> => {
+    const { data } = await api.get<Wishlist>(`/api/wishlists/${id}`);
+    return data;
+  },
+
+  create: async (payload: CreateWishlistRequest): Promise<Wishlist> => {
+    const { data } = await api.post<Wishlist>("/api/wishlists", payload);
+    return data;
+  },
+
+  update: async (id: string, payload: UpdateWishlistRequest): Promise<Wishlist> => {
+    const { data } = await api.put<Wishlist>(`/api/wishlists/${id}`, payload);
+    return data;
+  },
+
+  delete: async (id: string): Promise<void> => {
+    await api.delete(`/api/wishlists/${id}`);
+  },
+
+  rotateSlug: async (id: string): Promise<Wishlist> => {
+    const { data } = await api.post<Wishlist>(`/api/wishlists/${id}/rotate`);
+    return data;
+  },
+
+  viewPublic: async (slug: string): Promise<PublicWishlist> => {
+    const { data } = await api.get<PublicWishlist>(`/api/public/wishlists/${slug}`);
+    return data;
+  },
+
+  claim: async (slug: string, itemId: string, payload: ClaimWishlistItemRequest = {}): Promise<WishlistClaim> => {
+    const { data } = await api.post<WishlistClaim>(`/api/public/wishlists/${slug}/items/${itemId}/claim`, payload);
+    return data;
+  },
+
+  releaseClaim: async (slug: string, token: string): Promise<void> => {
+    await api.delete(`/api/public/wishlists/${slug}/claims/${token}`);
+  },
+};
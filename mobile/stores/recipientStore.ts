@@ -5,28 +5,54 @@ import { recipientService } from "../services/recipientService";
 interface RecipientStore {
   recipients: Recipient[];
   isLoading: boolean;
+  isLoadingMore: boolean;
+  nextCursor: string | null;
 
   fetchRecipients: () => Promise<void>;
+  fetchMoreRecipients: () => Promise<void>;
   createRecipient: (data: CreateRecipientRequest) => Promise<Recipient>;
   updateRecipient: (id: string, data: UpdateRecipientRequest) => Promise<Recipient>;
   deleteRecipient: (id: string) => Promise<void>;
   bulkDeleteRecipients: (ids: string[]) => Promise<void>;
 }
 
+const PAGE_SIZE = 20;
+
 export const useRecipientStore = create<RecipientStore>((set, get) => ({
   recipients: [],
   isLoading: false,
+  isLoadingMore: false,
+  nextCursor: null,
 
   fetchRecipients: async () => {
     set({ isLoading: true });
     try {
-      const recipients = await recipientService.list();
-      set({ recipients });
+      const page = await recipientService.list({ limit: PAGE_SIZE });
+      set({ recipients: page.data, nextCursor: page.next_cursor });
     } finally {
       set({ isLoading: false });
     }
   },
 
+  fetchMoreRecipients: async () => {
+    const { nextCursor, isLoading, isLoadingMore } = get();
+    if (!nextCursor || isLoading || isLoadingMore) return;
+
+    set({ isLoadingMore: true });
+    try {
+      const page = await recipientService.list({
+        limit: PAGE_SIZE,
+        cursor: nextCursor,
+      });
+      set({
+        recipients: [...get().recipients, ...page.data],
+        nextCursor: page.next_cursor,
+      });
+    } finally {
+      set({ isLoadingMore: false });
+    }
+  },
+
   createRecipient: async (data) => {
     const recipient = await recipientService.create(data);
     set({ recipients: [recipient, ...get().recipients] });
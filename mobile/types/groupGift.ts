@@ -0,0 +1,69 @@
+export type GroupGiftStatus = "open" | "settled";
+
+export interface GroupGiftContributor {
+  id: string;
+  group_gift_id: string;
+  email: string;
+  name: string;
+  pledged: number;
+  paid: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface GroupGift {
+  id: string;
+  organizer_id: string;
+  recipient_id: string;
+  occasion_id: string | null;
+  title: string;
+  description: string;
+  target_amount: number;
+  currency: string;
+  status: GroupGiftStatus;
+  settled_at: string | null;
+  contributors: GroupGiftContributor[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateGroupGiftRequest {
+  recipient_id: string;
+  occasion_id: string;
+  title: string;
+  description?: string;
+  target_amount: number;
+  currency?: string;
+}
+
+export interface UpdateGroupGiftRequest {
+  title?: string;
+  description?: string;
+  target_amount?: number;
+}
+
+export interface InviteContributorRequest {
+  email: string;
+  name?: string;
+  pledged?: number;
+}
+
+export interface UpdateContributionRequest {
+  pledged?: number;
+  paid?: number;
+}
+
+export interface GroupGiftProgress {
+  group_gift_id: string;
+  status: GroupGiftStatus;
+  currency: string;
+  target_amount: number;
+  pledged: number;
+  paid: number;
+  remaining: number;
+  pledged_percent: number;
+  paid_percent: number;
+  contributors: number;
+  paid_contributors: number;
+  funded: boolean;
+}
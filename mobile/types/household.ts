@@ -0,0 +1,45 @@
+export type HouseholdRole = "owner" | "editor" | "viewer";
+
+export interface HouseholdMember {
+  household_id: string;
+  user_id: string;
+  email: string;
+  name: string;
+  role: HouseholdRole;
+  joined_at: string;
+}
+
+export interface HouseholdInvitation {
+  id: string;
+  household_id: string;
+  email: string;
+  role: HouseholdRole;
+  invited_by: string;
+  created_at: string;
+}
+
+export interface Household {
+  id: string;
+  name: string;
+  members: HouseholdMember[];
+  invitations?: HouseholdInvitation[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateHouseholdRequest {
+  name: string;
+}
+
+export interface UpdateHouseholdRequest {
+  name?: string;
+}
+
+export interface InviteHouseholdMemberRequest {
+  email: string;
+  role?: Exclude<HouseholdRole, "owner">;
+}
+
+export interface UpdateHouseholdMemberRequest {
+  role: HouseholdRole;
+}
@@ -0,0 +1,71 @@
+import { CreateOccasionRequest } from "./occasion";
+import { CreateRecipientRequest } from "./recipient";
+
+export type ImportSource = "ics" | "vcard" | "csv" | "xlsx";
+
+export type ImportWarning = "birth_year_unknown" | "anniversary_year_unknown" | "duplicate";
+
+export interface ImportItem {
+  index: number;
+  // Spreadsheet row the item was read from, counting the header as row 1.
+  row?: number;
+  recipient: CreateRecipientRequest;
+  occasions?: CreateOccasionRequest[];
+  duplicate_of?: string;
+  warnings?: ImportWarning[];
+  errors?: Array<{ field: string; code: string; message: string }>;
+}
+
+export interface ImportSummary {
+  total: number;
+  new: number;
+  duplicates: number;
+  invalid: number;
+}
+
+export interface ImportSession {
+  id: string;
+  source: ImportSource;
+  columns?: string[];
+  mapping?: ColumnMapping;
+  date_format?: DateFormat;
+  items: ImportItem[];
+  summary: ImportSummary;
+  created_at: string;
+  expires_at: string;
+  applied_at?: string;
+}
+
+export interface ConfirmImportRequest {
+  indexes?: number[];
+  include_duplicates?: boolean;
+}
+
+export interface ImportResult {
+  created: number;
+  skipped: number;
+  recipient_ids: string[];
+}
+
+export type VCardVersion = "3.0" | "4.0";
+
+export type ImportField =
+  | "name"
+  | "age"
+  | "gender"
+  | "relationship"
+  | "birthdate"
+  | "min_budget"
+  | "max_budget"
+  | "currency"
+  | "keywords";
+
+// Maps each recipient field to a spreadsheet column header.
+export type ColumnMapping = Partial<Record<ImportField, string>>;
+
+export type DateFormat = "YYYY-MM-DD" | "DD/MM/YYYY" | "MM/DD/YYYY";
+
+export interface MapColumnsRequest {
+  mapping: ColumnMapping;
+  date_format?: DateFormat;
+}
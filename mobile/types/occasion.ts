@@ -0,0 +1,49 @@
+export type OccasionKind = "birthday" | "anniversary" | "wedding" | "graduation" | "custom";
+
+export interface Occasion {
+  id: string;
+  recipient_id: string;
+  kind: OccasionKind;
+  title: string;
+  date: string;
+  recurring: boolean;
+  budget: number | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateOccasionRequest {
+  kind: OccasionKind;
+  title?: string;
+  date: string;
+  recurring?: boolean;
+  budget?: number;
+}
+
+export interface UpdateOccasionRequest {
+  kind?: OccasionKind;
+  title?: string;
+  date?: string;
+  recurring?: boolean;
+  budget?: number;
+  clear_budget?: boolean;
+}
+
+export interface UpcomingOccasion {
+  recipient_id: string;
+  recipient_name: string;
+  occasion_id: string | null;
+  kind: OccasionKind | "holiday";
+  title: string;
+  date: string;
+  days_until: number;
+  budget: number;
+  currency: string;
+  holiday?: string;
+  country?: string;
+  turns?: number;
+}
+
+export interface Reminder extends UpcomingOccasion {
+  lead_days: number;
+}
@@ -0,0 +1,62 @@
+export interface YearlyBudget {
+  year: number;
+  amount: number;
+  currency: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface YearlyBudgetRequest {
+  amount: number;
+  // Defaults to the user"s preferred currency.
+  currency?: string;
+}
+
+export interface SpendingTotal {
+  total: number;
+  count: number;
+}
+
+export interface RecipientSpending extends SpendingTotal {
+  recipient_id: string;
+  name: string;
+}
+
+export interface GroupSpending extends SpendingTotal {
+  group_id: string;
+  name: string;
+}
+
+export interface MonthSpending extends SpendingTotal {
+  month: number;
+}
+
+export interface OccasionSpending extends SpendingTotal {
+  kind: string;
+}
+
+export type SpendingWarningCode = "over_yearly_budget" | "over_gift_budget" | "missing_exchange_rate";
+
+export interface SpendingWarning {
+  code: SpendingWarningCode;
+  message: string;
+  recipient_id?: string;
+  gift_id?: string;
+  currency?: string;
+  amount: number;
+  limit: number;
+}
+
+export interface SpendingReport {
+  year: number;
+  currency: string;
+  budget: number | null;
+  remaining: number | null;
+  total: number;
+  gift_count: number;
+  by_recipient: RecipientSpending[];
+  by_group: GroupSpending[];
+  by_month: MonthSpending[];
+  by_occasion: OccasionSpending[];
+  warnings: SpendingWarning[];
+}
@@ -0,0 +1,63 @@
+import { IdeaPriority } from "./idea";
+
+export interface WishlistItem {
+  id: string;
+  wishlist_id: string;
+  idea_id: string;
+  position: number;
+}
+
+export interface Wishlist {
+  id: string;
+  user_id: string;
+  recipient_id: string;
+  title: string;
+  description: string;
+  slug: string;
+  items: WishlistItem[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateWishlistRequest {
+  recipient_id: string;
+  title: string;
+  description?: string;
+  idea_ids?: string[];
+}
+
+export interface UpdateWishlistRequest {
+  title?: string;
+  description?: string;
+  idea_ids?: string[];
+}
+
+export interface PublicWishlistItem {
+  id: string;
+  title: string;
+  url: string;
+  price: number | null;
+  currency: string;
+  category: string;
+  priority: IdeaPriority;
+  claimed: boolean;
+}
+
+export interface PublicWishlist {
+  title: string;
+  description: string;
+  recipient_name: string;
+  items: PublicWishlistItem[];
+}
+
+export interface ClaimWishlistItemRequest {
+  name?: string;
+}
+
+export interface WishlistClaim {
+  id: string;
+  item_id: string;
+  name: string;
+  token: string;
+  created_at: string;
+}
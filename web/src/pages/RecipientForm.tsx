@@ -1,14 +1,20 @@
 import { useMemo, useState, type FormEvent } from 'react';
 import { useNavigate, useParams } from 'react-router-dom';
 import { useRecipientStore } from '../stores/recipientStore';
+import { useKeywordSuggestions } from '../hooks/useKeywordSuggestions';
 import Toast from '../components/Toast';
 import Loading from '../components/Loading';
 import styles from './RecipientForm.module.css';
 
+const RELATIONSHIP_OPTIONS = [
+  'partner', 'spouse', 'mother', 'father', 'child', 'sibling', 'grandparent',
+  'relative', 'best_friend', 'friend', 'colleague', 'boss', 'neighbor', 'other',
+];
+
 const SUGGESTED_KEYWORDS = [
-  'nerd', 'geek', 'sports', 'music', 'cooking', 'reading',
-  'gaming', 'travel', 'fitness', 'tech', 'fashion', 'art',
-  'movies', 'outdoor', 'pets',
+  'gaming', 'reading', 'music', 'cooking', 'sports', 'travel',
+  'fitness', 'technology', 'geek', 'fashion', 'arts', 'movies',
+  'outdoors', 'pets',
 ];
 
 export default function RecipientForm() {
@@ -27,11 +33,18 @@ export default function RecipientForm() {
   const [name, setName] = useState(existing?.name ?? '');
   const [age, setAge] = useState(existing?.age ? String(existing.age) : '');
   const [gender, setGender] = useState(existing?.gender ?? '');
+  const [relationship, setRelationship] = useState(existing?.relationship ?? '');
   const [minBudget, setMinBudget] = useState(existing?.min_budget ? String(existing.min_budget) : '');
   const [maxBudget, setMaxBudget] = useState(existing?.max_budget ? String(existing.max_budget) : '');
   const [keywords, setKeywords] = useState<string[]>(existing?.keywords ?? []);
   const [keywordInput, setKeywordInput] = useState('');
   const [error, setError] = useState('');
+  const keywordSuggestions = useKeywordSuggestions(keywordInput);
+
+  // While typing, offer taxonomy matches; otherwise fall back to the defaults.
+  const suggestions = keywordInput.trim()
+    ? keywordSuggestions.map((s) => ({ value: s.slug, label: s.label }))
+    : SUGGESTED_KEYWORDS.map((kw) => ({ value: kw, label: kw }));
 
   const addKeyword = (kw?: string) => {
     const value = (kw || keywordInput).trim().toLowerCase();
@@ -58,6 +71,7 @@ export default function RecipientForm() {
       name: name.trim(),
       age: age ? parseInt(age) : 0,
       gender: gender || 'other',
+      relationship,
       min_budget: minBudget ? parseFloat(minBudget) : 0,
       max_budget: maxBudget ? parseFloat(maxBudget) : 0,
       keywords,
@@ -145,6 +159,22 @@ export default function RecipientForm() {
           </div>
         </div>
 
+        <div className={styles.formGroup}>
+          <label htmlFor="relationship">Relationship</label>
+          <select
+            id="relationship"
+            value={relationship}
+            onChange={(e) => setRelationship(e.target.value)}
+          >
+            <option value="">Not specified</option>
+            {RELATIONSHIP_OPTIONS.map((rel) => (
+              <option key={rel} value={rel}>
+                {rel.charAt(0).toUpperCase() + rel.slice(1).replace('_', ' ')}
+              </option>
+            ))}
+          </select>
+        </div>
+
         <div className={styles.formGroup}>
           <label>Budget Range</label>
           <div className={styles.row}>
@@ -211,14 +241,14 @@ export default function RecipientForm() {
           <div className={styles.suggested}>
             <span className={styles.suggestedLabel}>Suggestions:</span>
             <div className={styles.suggestedList}>
-              {SUGGESTED_KEYWORDS.filter((kw) => !keywords.includes(kw)).map((kw) => (
+              {suggestions.filter((s) => !keywords.includes(s.value)).map((s) => (
                 <button
-                  key={kw}
+                  key={s.value}
                   type="button"
                   className={styles.chipSuggested}
-                  onClick={() => addKeyword(kw)}
+                  onClick={() => addKeyword(s.value)}
                 >
-                  {kw}
+                  {s.label}
                 </button>
               ))}
             </div>
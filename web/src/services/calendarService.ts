@@ -0,0 +1,22 @@
+import api from './api';
+import type { CalendarFeed } from '../types/calendar';
+
+export async function getCalendarFeed(): Promise<CalendarFeed> {
+  const res = await api.get<CalendarFeed>('/api/me/calendar');
+  return res.data;
+}
+
+export async function rotateCalendarFeed(): Promise<CalendarFeed> {
+  const res = await api.post<CalendarFeed>('/api/me/calendar/rotate');
+  return res.data;
+}
+
+export async function disableCalendarFeed(): Promise<void> {
+  await api.delete('/api/me/calendar');
+}
+
+// webcal:// links open the system calendar's subscribe flow.
+export function calendarSubscribeUrl(feed: CalendarFeed): string {
+  const base = api.defaults.baseURL ?? window.location.origin;
+  return base.replace(/^https?:/, 'webcal:') + feed.path;
+}
@@ -0,0 +1,74 @@
+import api from './api';
+import type {
+  AddExclusionRequest,
+  AddParticipantRequest,
+  CreateExchangeRequest,
+  ExchangeAssignment,
+  ExchangeExclusion,
+  ExchangeParticipant,
+  ExchangeVerification,
+  GiftExchange,
+  UpdateExchangeRequest,
+} from '../types/exchange';
+
+export async function listExchanges(): Promise<GiftExchange[]> {
+  const res = await api.get<GiftExchange[]>('/api/exchanges');
+  return res.data;
+}
+
+export async function getExchange(id: string): Promise<GiftExchange> {
+  const res = await api.get<GiftExchange>(`/api/exchanges/${id}`);
+  return res.data;
+}
+
+export async function createExchange(data: CreateExchangeRequest): Promise<GiftExchange> {
+  const res = await api.post<GiftExchange>('/api/exchanges', data);
+  return res.data;
+}
+
+export async function updateExchange(id: string, data: UpdateExchangeRequest): Promise<GiftExchange> {
+  const res = await api.put<GiftExchange>(`/api/exchanges/${id}`, data);
+  return res.data;
+}
+
+export async function deleteExchange(id: string): Promise<void> {
+  await api.delete(`/api/exchanges/${id}`);
+}
+
+export async function addParticipant(id: string, data: AddParticipantRequest): Promise<ExchangeParticipant> {
+  const res = await api.post<ExchangeParticipant>(`/api/exchanges/${id}/participants`, data);
+  return res.data;
+}
+
+export async function removeParticipant(id: string, participantId: string): Promise<void> {
+  await api.delete(`/api/exchanges/${id}/participants/${participantId}`);
+}
+
+export async function addExclusion(id: string, data: AddExclusionRequest): Promise<ExchangeExclusion> {
+  const res = await api.post<ExchangeExclusion>(`/api/exchanges/${id}/exclusions`, data);
+  return res.data;
+}
+
+export async function removeExclusion(id: string, exclusionId: string): Promise<void> {
+  await api.delete(`/api/exchanges/${id}/exclusions/${exclusionId}`);
+}
+
+export async function drawExchange(id: string): Promise<GiftExchange> {
+  const res = await api.post<GiftExchange>(`/api/exchanges/${id}/draw`);
+  return res.data;
+}
+
+export async function verifyExchange(id: string): Promise<ExchangeVerification> {
+  const res = await api.post<ExchangeVerification>(`/api/exchanges/${id}/verify`);
+  return res.data;
+}
+
+export async function getMyAssignment(id: string): Promise<ExchangeAssignment> {
+  const res = await api.get<ExchangeAssignment>(`/api/exchanges/${id}/assignment`);
+  return res.data;
+}
+
+export async function getAssignmentByToken(token: string): Promise<ExchangeAssignment> {
+  const res = await api.get<ExchangeAssignment>(`/api/exchange-assignments/${token}`);
+  return res.data;
+}
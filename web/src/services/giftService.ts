@@ -0,0 +1,28 @@
+import api from './api';
+import type { CreateGiftRequest, GiftRecord, GiftSuggestion, UpdateGiftRequest } from '../types/gift';
+
+export async function listGifts(recipientId: string): Promise<GiftRecord[]> {
+  const res = await api.get<GiftRecord[]>(`/api/recipients/${recipientId}/gifts`);
+  return res.data;
+}
+
+export async function createGift(recipientId: string, data: CreateGiftRequest): Promise<GiftRecord> {
+  const res = await api.post<GiftRecord>(`/api/recipients/${recipientId}/gifts`, data);
+  return res.data;
+}
+
+export async function updateGift(recipientId: string, id: string, data: UpdateGiftRequest): Promise<GiftRecord> {
+  const res = await api.put<GiftRecord>(`/api/recipients/${recipientId}/gifts/${id}`, data);
+  return res.data;
+}
+
+export async function deleteGift(recipientId: string, id: string): Promise<void> {
+  await api.delete(`/api/recipients/${recipientId}/gifts/${id}`);
+}
+
+export async function getSuggestions(recipientId: string, limit?: number, locale?: string): Promise<GiftSuggestion[]> {
+  const res = await api.get<GiftSuggestion[]>(`/api/recipients/${recipientId}/suggestions`, {
+    params: { limit, locale },
+  });
+  return res.data;
+}
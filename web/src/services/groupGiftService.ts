@@ -0,0 +1,62 @@
+import api from './api';
+import type {
+  CreateGroupGiftRequest,
+  GroupGift,
+  GroupGiftContributor,
+  GroupGiftProgress,
+  InviteContributorRequest,
+  UpdateContributionRequest,
+  UpdateGroupGiftRequest,
+} from '../types/groupGift';
+
+export async function listGroupGifts(): Promise<GroupGift[]> {
+  const res = await api.get<GroupGift[]>('/api/group-gifts');
+  return res.data;
+}
+
+export async function getGroupGift(id: string): Promise<GroupGift> {
+  const res = await api.get<GroupGift>(`/api/group-gifts/${id}`);
+  return res.data;
+}
+
+export async function createGroupGift(data: CreateGroupGiftRequest): Promise<GroupGift> {
+  const res = await api.post<GroupGift>('/api/group-gifts', data);
+  return res.data;
+}
+
+export async function updateGroupGift(id: string, data: UpdateGroupGiftRequest): Promise<GroupGift> {
+  const res = await api.put<GroupGift>(`/api/group-gifts/${id}`, data);
+  return res.data;
+}
+
+export async function deleteGroupGift(id: string): Promise<void> {
+  await api.delete(`/api/group-gifts/${id}`);
+}
+
+export async function getGroupGiftProgress(id: string): Promise<GroupGiftProgress> {
+  const res = await api.get<GroupGiftProgress>(`/api/group-gifts/${id}/progress`);
+  return res.data;
+}
+
+export async function settleGroupGift(id: string): Promise<GroupGift> {
+  const res = await api.post<GroupGift>(`/api/group-gifts/${id}/settle`);
+  return res.data;
+}
+
+export async function inviteContributor(id: string, data: InviteContributorRequest): Promise<GroupGiftContributor> {
+  const res = await api.post<GroupGiftContributor>(`/api/group-gifts/${id}/contributors`, data);
+  return res.data;
+}
+
+export async function updateContribution(
+  id: string,
+  contributorId: string,
+  data: UpdateContributionRequest,
+): Promise<GroupGiftContributor> {
+  const res = await api.put<GroupGiftContributor>(`/api/group-gifts/${id}/contributors/${contributorId}`, data);
+  return res.data;
+}
+
+export async function removeContributor(id: string, contributorId: string): Promise<void> {
+  await api.delete(`/api/group-gifts/${id}/contributors/${contributorId}`);
+}
@@ -0,0 +1,30 @@
+import api from './api';
+import type { Group } from '../types/recipient';
+
+export async function listGroups(): Promise<Group[]> {
+  const res = await api.get<Group[]>('/api/groups');
+  return res.data;
+}
+
+export async function createGroup(name: string): Promise<Group> {
+  const res = await api.post<Group>('/api/groups', { name });
+  return res.data;
+}
+
+export async function renameGroup(id: string, name: string): Promise<Group> {
+  const res = await api.put<Group>(`/api/groups/${id}`, { name });
+  return res.data;
+}
+
+export async function deleteGroup(id: string): Promise<void> {
+  await api.delete(`/api/groups/${id}`);
+}
+
+export async function addGroupMembers(id: string, recipientIds: string[]): Promise<Group> {
+  const res = await api.post<Group>(`/api/groups/${id}/members`, { recipient_ids: recipientIds });
+  return res.data;
+}
+
+export async function removeGroupMember(id: string, recipientId: string): Promise<void> {
+  await api.delete(`/api/groups/${id}/members/${recipientId}`);
+}
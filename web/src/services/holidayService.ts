@@ -0,0 +1,30 @@
+import api from './api';
+import type { HolidayDate, HolidaySubscription, HolidaySubscriptionRequest } from '../types/holiday';
+
+export interface HolidayParams {
+  country?: string;
+  year?: number;
+  locale?: string;
+}
+
+export async function listHolidays(params: HolidayParams = {}): Promise<HolidayDate[]> {
+  const res = await api.get<HolidayDate[]>('/api/holidays', { params });
+  return res.data;
+}
+
+export async function listHolidaySubscriptions(recipientId: string): Promise<HolidaySubscription[]> {
+  const res = await api.get<HolidaySubscription[]>(`/api/recipients/${recipientId}/holidays`);
+  return res.data;
+}
+
+export async function subscribeHoliday(
+  recipientId: string,
+  data: HolidaySubscriptionRequest,
+): Promise<HolidaySubscription> {
+  const res = await api.post<HolidaySubscription>(`/api/recipients/${recipientId}/holidays`, data);
+  return res.data;
+}
+
+export async function unsubscribeHoliday(recipientId: string, id: string): Promise<void> {
+  await api.delete(`/api/recipients/${recipientId}/holidays/${id}`);
+}
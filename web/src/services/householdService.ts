@@ -0,0 +1,55 @@
+import api from './api';
+import type {
+  CreateHouseholdRequest,
+  Household,
+  HouseholdInvitation,
+  InviteHouseholdMemberRequest,
+  UpdateHouseholdMemberRequest,
+  UpdateHouseholdRequest,
+} from '../types/household';
+
+export async function getHousehold(): Promise<Household> {
+  const res = await api.get<Household>('/api/household');
+  return res.data;
+}
+
+export async function createHousehold(data: CreateHouseholdRequest): Promise<Household> {
+  const res = await api.post<Household>('/api/household', data);
+  return res.data;
+}
+
+export async function updateHousehold(data: UpdateHouseholdRequest): Promise<Household> {
+  const res = await api.put<Household>('/api/household', data);
+  return res.data;
+}
+
+export async function deleteHousehold(): Promise<void> {
+  await api.delete('/api/household');
+}
+
+export async function leaveHousehold(): Promise<void> {
+  await api.post('/api/household/leave');
+}
+
+export async function inviteHouseholdMember(data: InviteHouseholdMemberRequest): Promise<HouseholdInvitation> {
+  const res = await api.post<HouseholdInvitation>('/api/household/invitations', data);
+  return res.data;
+}
+
+export async function revokeHouseholdInvitation(id: string): Promise<void> {
+  await api.delete(`/api/household/invitations/${id}`);
+}
+
+export async function acceptHouseholdInvitation(token: string): Promise<Household> {
+  const res = await api.post<Household>(`/api/household-invitations/${token}/accept`);
+  return res.data;
+}
+
+export async function updateHouseholdMember(userId: string, data: UpdateHouseholdMemberRequest): Promise<Household> {
+  const res = await api.put<Household>(`/api/household/members/${userId}`, data);
+  return res.data;
+}
+
+export async function removeHouseholdMember(userId: string): Promise<void> {
+  await api.delete(`/api/household/members/${userId}`);
+}
@@ -0,0 +1,37 @@
+import api from './api';
+import type {
+  CreateIdeaRequest,
+  GiftIdea,
+  PromoteSuggestionRequest,
+  TransitionIdeaRequest,
+  UpdateIdeaRequest,
+} from '../types/idea';
+
+export async function listIdeas(recipientId: string): Promise<GiftIdea[]> {
+  const res = await api.get<GiftIdea[]>(`/api/recipients/${recipientId}/ideas`);
+  return res.data;
+}
+
+export async function createIdea(recipientId: string, data: CreateIdeaRequest): Promise<GiftIdea> {
+  const res = await api.post<GiftIdea>(`/api/recipients/${recipientId}/ideas`, data);
+  return res.data;
+}
+
+export async function promoteSuggestion(recipientId: string, data: PromoteSuggestionRequest): Promise<GiftIdea> {
+  const res = await api.post<GiftIdea>(`/api/recipients/${recipientId}/ideas/promote`, data);
+  return res.data;
+}
+
+export async function updateIdea(recipientId: string, id: string, data: UpdateIdeaRequest): Promise<GiftIdea> {
+  const res = await api.put<GiftIdea>(`/api/recipients/${recipientId}/ideas/${id}`, data);
+  return res.data;
+}
+
+export async function moveIdea(recipientId: string, id: string, data: TransitionIdeaRequest): Promise<GiftIdea> {
+  const res = await api.post<GiftIdea>(`/api/recipients/${recipientId}/ideas/${id}/status`, data);
+  return res.data;
+}
+
+export async function deleteIdea(recipientId: string, id: string): Promise<void> {
+  await api.delete(`/api/recipients/${recipientId}/ideas/${id}`);
+}
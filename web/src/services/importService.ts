@@ -0,0 +1,64 @@
+import api from './api';
+import type {
+  ConfirmImportRequest,
+  ImportResult,
+  ImportSession,
+  MapColumnsRequest,
+  VCardVersion,
+} from '../types/import';
+
+// Uploading a file only builds a preview; nothing is created until it is confirmed.
+export async function previewIcsImport(file: File): Promise<ImportSession> {
+  const form = new FormData();
+  form.append('file', file);
+  const res = await api.post<ImportSession>('/api/import/ics', form);
+  return res.data;
+}
+
+export async function previewVcardImport(file: File): Promise<ImportSession> {
+  const form = new FormData();
+  form.append('file', file);
+  const res = await api.post<ImportSession>('/api/import/vcard', form);
+  return res.data;
+}
+
+// Accepts .csv and .xlsx files; columns are mapped from the header row and can be changed with mapImportColumns.
+export async function previewSpreadsheetImport(file: File): Promise<ImportSession> {
+  const form = new FormData();
+  form.append('file', file);
+  const res = await api.post<ImportSession>('/api/import/spreadsheet', form);
+  return res.data;
+}
+
+export async function mapImportColumns(id: string, req: MapColumnsRequest): Promise<ImportSession> {
+  const res = await api.put<ImportSession>(`/api/import/${id}/mapping`, req);
+  return res.data;
+}
+
+export async function getImport(id: string): Promise<ImportSession> {
+  const res = await api.get<ImportSession>(`/api/import/${id}`);
+  return res.data;
+}
+
+export async function confirmImport(id: string, req: ConfirmImportRequest = {}): Promise<ImportResult> {
+  const res = await api.post<ImportResult>(`/api/import/${id}/confirm`, req);
+  return res.data;
+}
+
+export async function exportVcard(version: VCardVersion = '3.0'): Promise<Blob> {
+  const res = await api.get<Blob>('/api/export/recipients.vcf', {
+    params: { version },
+    responseType: 'blob',
+  });
+  return res.data;
+}
+
+export async function exportCsv(): Promise<Blob> {
+  const res = await api.get<Blob>('/api/export/recipients.csv', { responseType: 'blob' });
+  return res.data;
+}
+
+export async function exportXlsx(): Promise<Blob> {
+  const res = await api.get<Blob>('/api/export/recipients.xlsx', { responseType: 'blob' });
+  return res.data;
+}
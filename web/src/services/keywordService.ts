@@ -0,0 +1,7 @@
+import api from './api';
+import type { KeywordSuggestion } from '../types/keyword';
+
+export async function suggestKeywords(prefix: string, limit = 10): Promise<KeywordSuggestion[]> {
+  const res = await api.get<KeywordSuggestion[]>('/api/keywords', { params: { prefix, limit } });
+  return res.data;
+}
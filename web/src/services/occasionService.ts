@@ -0,0 +1,41 @@
+import api from './api';
+import type {
+  CreateOccasionRequest,
+  Occasion,
+  Reminder,
+  UpcomingOccasion,
+  UpdateOccasionRequest,
+} from '../types/occasion';
+
+export async function listOccasions(recipientId: string): Promise<Occasion[]> {
+  const res = await api.get<Occasion[]>(`/api/recipients/${recipientId}/occasions`);
+  return res.data;
+}
+
+export async function createOccasion(recipientId: string, data: CreateOccasionRequest): Promise<Occasion> {
+  const res = await api.post<Occasion>(`/api/recipients/${recipientId}/occasions`, data);
+  return res.data;
+}
+
+export async function updateOccasion(
+  recipientId: string,
+  id: string,
+  data: UpdateOccasionRequest,
+): Promise<Occasion> {
+  const res = await api.put<Occasion>(`/api/recipients/${recipientId}/occasions/${id}`, data);
+  return res.data;
+}
+
+export async function deleteOccasion(recipientId: string, id: string): Promise<void> {
+  await api.delete(`/api/recipients/${recipientId}/occasions/${id}`);
+}
+
+export async function getUpcoming(days?: number): Promise<UpcomingOccasion[]> {
+  const res = await api.get<UpcomingOccasion[]>('/api/upcoming', { params: { days } });
+  return res.data;
+}
+
+export async function getReminders(): Promise<Reminder[]> {
+  const res = await api.get<Reminder[]>('/api/reminders');
+  return res.data;
+}
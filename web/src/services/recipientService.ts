@@ -1,11 +1,30 @@
 import api from './api';
-import type { Recipient, CreateRecipientRequest, UpdateRecipientRequest } from '../types/recipient';
+import type {
+  Recipient,
+  CreateRecipientRequest,
+  UpdateRecipientRequest,
+  RecipientListParams,
+  RecipientPage,
+  DuplicatePair,
+  MergeRecipientsRequest,
+} from '../types/recipient';
 
-export async function listRecipients(): Promise<Recipient[]> {
-  const res = await api.get<Recipient[]>('/api/recipients');
+export async function listRecipientsPage(params: RecipientListParams = {}): Promise<RecipientPage> {
+  const res = await api.get<RecipientPage>('/api/recipients', { params });
   return res.data;
 }
 
+export async function listRecipients(): Promise<Recipient[]> {
+  const recipients: Recipient[] = [];
+  let cursor: string | undefined;
+  do {
+    const page = await listRecipientsPage({ limit: 100, cursor });
+    recipients.push(...page.data);
+    cursor = page.next_cursor ?? undefined;
+  } while (cursor);
+  return recipients;
+}
+
 export async function getRecipient(id: string): Promise<Recipient> {
   const res = await api.get<Recipient>(`/api/recipients/${id}`);
   return res.data;
@@ -28,3 +47,14 @@ export async function deleteRecipient(id: string): Promise<void> {
 export async function bulkDeleteRecipients(ids: string[]): Promise<void> {
   await api.delete('/api/recipients', { data: { ids } });
 }
+
+export async function findDuplicateRecipients(limit?: number): Promise<DuplicatePair[]> {
+  const res = await api.get<DuplicatePair[]>('/api/recipients/duplicates', { params: { limit } });
+  return res.data;
+}
+
+// The recipient named by merge_id is moved to the trash; the merged recipient is returned.
+export async function mergeRecipients(req: MergeRecipientsRequest): Promise<Recipient> {
+  const res = await api.post<Recipient>('/api/recipients/merge', req);
+  return res.data;
+}
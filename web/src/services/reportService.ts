@@ -0,0 +1,21 @@
+import api from './api';
+import type { SpendingReport, YearlyBudget, YearlyBudgetRequest } from '../types/report';
+
+export async function listBudgets(): Promise<YearlyBudget[]> {
+  const res = await api.get<YearlyBudget[]>('/api/budgets');
+  return res.data;
+}
+
+export async function setBudget(year: number, req: YearlyBudgetRequest): Promise<YearlyBudget> {
+  const res = await api.put<YearlyBudget>(`/api/budgets/${year}`, req);
+  return res.data;
+}
+
+export async function deleteBudget(year: number): Promise<void> {
+  await api.delete(`/api/budgets/${year}`);
+}
+
+export async function getSpendingReport(year?: number): Promise<SpendingReport> {
+  const res = await api.get<SpendingReport>('/api/reports/spending', { params: { year } });
+  return res.data;
+}
@@ -0,0 +1,56 @@
+import api from './api';
+import type {
+  ClaimWishlistItemRequest,
+  CreateWishlistRequest,
+  PublicWishlist,
+  UpdateWishlistRequest,
+  Wishlist,
+  WishlistClaim,
+} from '../types/wishlist';
+
+export async function listWishlists(): Promise<Wishlist[]> {
+  const res = await api.get<Wishlist[]>('/api/wishlists');
+  return res.data;
+}
+
+export async function getWishlist(id: string): Promise<Wishlist> {
+  const res = await api.get<Wishlist>(`/api/wishlists/${id}`);
+  return res.data;
+}
+
+export async function createWishlist(data: CreateWishlistRequest): Promise<Wishlist> {
+  const res = await api.post<Wishlist>('/api/wishlists', data);
+  return res.data;
+}
+
+export async function updateWishlist(id: string, data: UpdateWishlistRequest): Promise<Wishlist> {
+  const res = await api.put<Wishlist>(`/api/wishlists/${id}`, data);
+  return res.data;
+}
+
+export async function deleteWishlist(id: string): Promise<void> {
+  await api.delete(`/api/wishlists/${id}`);
+}
+
+export async function rotateWishlistSlug(id: string): Promise<Wishlist> {
+  const res = await api.post<Wishlist>(`/api/wishlists/${id}/rotate`);
+  return res.data;
+}
+
+export async function getPublicWishlist(slug: string): Promise<PublicWishlist> {
+  const res = await api.get<PublicWishlist>(`/api/public/wishlists/${slug}`);
+  return res.data;
+}
+
+export async function claimWishlistItem(
+  slug: string,
+  itemId: string,
+  data: ClaimWishlistItemRequest = {},
+): Promise<WishlistClaim> {
+  const res = await api.post<WishlistClaim>(`/api/public/wishlists/${slug}/items/${itemId}/claim`, data);
+  return res.data;
+}
+
+export async function releaseWishlistClaim(slug: string, token: string): Promise<void> {
+  await api.delete(`/api/public/wishlists/${slug}/claims/${token}`);
+}
@@ -0,0 +1,5 @@
+export interface CalendarFeed {
+  token: string;
+  path: string;
+  created_at: string;
+}
@@ -0,0 +1,79 @@
+export type ExchangeStatus = 'draft' | 'drawn';
+
+export interface ExchangeParticipant {
+  id: string;
+  exchange_id: string;
+  name: string;
+  email: string;
+  created_at: string;
+}
+
+export interface ExchangeExclusion {
+  id: string;
+  exchange_id: string;
+  giver_id: string;
+  receiver_id: string;
+  mutual: boolean;
+  created_at: string;
+}
+
+export interface GiftExchange {
+  id: string;
+  organizer_id: string;
+  name: string;
+  budget: number | null;
+  currency: string;
+  exchange_on: string | null;
+  previous_exchange_id: string | null;
+  status: ExchangeStatus;
+  drawn_at: string | null;
+  participants: ExchangeParticipant[];
+  exclusions: ExchangeExclusion[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateExchangeRequest {
+  name: string;
+  budget?: number;
+  currency?: string;
+  exchange_on?: string;
+  previous_exchange_id?: string;
+}
+
+export interface UpdateExchangeRequest {
+  name?: string;
+  budget?: number;
+  clear_budget?: boolean;
+  currency?: string;
+  exchange_on?: string;
+  previous_exchange_id?: string;
+  clear_previous?: boolean;
+}
+
+export interface AddParticipantRequest {
+  name: string;
+  email: string;
+}
+
+export interface AddExclusionRequest {
+  giver_id: string;
+  receiver_id: string;
+  mutual?: boolean;
+}
+
+export interface ExchangeAssignment {
+  exchange_id: string;
+  exchange_name: string;
+  exchange_on: string | null;
+  budget: number | null;
+  currency: string;
+  giver_name: string;
+  receiver_name: string;
+}
+
+export interface ExchangeVerification {
+  exchange_id: string;
+  drawn_at: string | null;
+  matches: boolean;
+}
@@ -0,0 +1,51 @@
+export interface GiftRecord {
+  id: string;
+  recipient_id: string;
+  occasion_id: string | null;
+  catalog_item_id?: string;
+  item: string;
+  category: string;
+  price: number;
+  currency: string;
+  given_on: string;
+  rating: number | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateGiftRequest {
+  occasion_id?: string;
+  catalog_item_id?: string;
+  item?: string;
+  category?: string;
+  price?: number;
+  // Defaults to the recipient's currency, or the catalog's for catalog items.
+  currency?: string;
+  given_on?: string;
+  rating?: number;
+}
+
+export interface UpdateGiftRequest {
+  occasion_id?: string;
+  clear_occasion?: boolean;
+  item?: string;
+  category?: string;
+  price?: number;
+  currency?: string;
+  given_on?: string;
+  rating?: number;
+  clear_rating?: boolean;
+}
+
+export type SuggestionReason = 'matches_interest' | 'within_budget' | 'liked_category' | 'category_given_before';
+
+export interface GiftSuggestion {
+  item_id: string;
+  title: string;
+  category: string;
+  // In the user's preferred currency when a rate is available.
+  price: number;
+  currency: string;
+  score: number;
+  reasons: SuggestionReason[];
+}
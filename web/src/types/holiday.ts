@@ -0,0 +1,22 @@
+export interface HolidayDate {
+  slug: string;
+  country: string;
+  name: string;
+  date: string;
+  rule: string;
+}
+
+export interface HolidaySubscription {
+  id: string;
+  recipient_id: string;
+  country: string;
+  holiday: string;
+  budget: number | null;
+  created_at: string;
+}
+
+export interface HolidaySubscriptionRequest {
+  country?: string;
+  holiday: string;
+  budget?: number;
+}
@@ -0,0 +1,54 @@
+export type IdeaStatus = 'idea' | 'planned' | 'purchased' | 'wrapped' | 'given';
+
+export type IdeaPriority = 'low' | 'medium' | 'high';
+
+export interface GiftIdea {
+  id: string;
+  recipient_id: string;
+  catalog_item_id?: string;
+  title: string;
+  url: string;
+  price: number | null;
+  currency: string;
+  notes: string;
+  category: string;
+  priority: IdeaPriority;
+  status: IdeaStatus;
+  gift_id: string | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateIdeaRequest {
+  title: string;
+  url?: string;
+  price?: number;
+  currency?: string;
+  notes?: string;
+  category?: string;
+  priority?: IdeaPriority;
+}
+
+export interface UpdateIdeaRequest {
+  title?: string;
+  url?: string;
+  price?: number;
+  currency?: string;
+  clear_price?: boolean;
+  notes?: string;
+  category?: string;
+  priority?: IdeaPriority;
+}
+
+export interface TransitionIdeaRequest {
+  status: IdeaStatus;
+  occasion_id?: string;
+  given_on?: string;
+  rating?: number;
+}
+
+export interface PromoteSuggestionRequest {
+  item_id: string;
+  notes?: string;
+  priority?: IdeaPriority;
+}
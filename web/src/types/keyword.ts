@@ -0,0 +1,6 @@
+export interface KeywordSuggestion {
+  slug: string;
+  label: string;
+  category?: string;
+  category_label?: string;
+}
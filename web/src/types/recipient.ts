@@ -4,8 +4,11 @@ export interface Recipient {
   name: string;
   age: number;
   gender: string;
+  relationship: string;
+  birthdate: string | null;
   min_budget: number;
   max_budget: number;
+  currency: string;
   keywords: string[];
   created_at: string;
   updated_at: string;
@@ -15,8 +18,12 @@ export interface CreateRecipientRequest {
   name: string;
   age: number;
   gender: string;
+  relationship?: string;
+  birthdate?: string | null;
   min_budget: number;
   max_budget: number;
+  // Defaults to the user's preferred currency.
+  currency?: string;
   keywords: string[];
 }
 
@@ -24,7 +31,55 @@ export interface UpdateRecipientRequest {
   name?: string;
   age?: number;
   gender?: string;
+  relationship?: string;
+  birthdate?: string | null;
   min_budget?: number;
   max_budget?: number;
+  currency?: string;
   keywords?: string[];
 }
+
+export type RecipientSort = 'created_at' | 'name' | 'age' | 'budget' | 'next_birthday';
+
+export interface RecipientListParams {
+  sort?: RecipientSort;
+  order?: 'asc' | 'desc';
+  cursor?: string;
+  limit?: number;
+  gender?: string;
+  min_age?: number;
+  max_age?: number;
+  budget_min?: number;
+  budget_max?: number;
+  keywords?: string;
+  group?: string;
+}
+
+export interface RecipientPage {
+  data: Recipient[];
+  next_cursor: string | null;
+  has_more: boolean;
+}
+
+export interface Group {
+  id: string;
+  user_id: string;
+  name: string;
+  member_count: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export type DuplicateReason = 'same_name' | 'similar_name' | 'same_birthdate' | 'shared_keywords';
+
+// Two recipients that likely describe the same person; the older one comes first.
+export interface DuplicatePair {
+  recipients: [Recipient, Recipient];
+  score: number;
+  reasons: DuplicateReason[];
+}
+
+export interface MergeRecipientsRequest {
+  keep_id: string;
+  merge_id: string;
+}